@@ -0,0 +1,190 @@
+// Package analyzer exposes go-critic checkers as go/analysis analyzers,
+// so they can be run by go vet -vettool, unitchecker and other
+// analysis-based drivers.
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-critic/go-critic/lint"
+	"golang.org/x/tools/go/analysis"
+)
+
+// Analyzers returns an analyzer for every checker that can be
+// created with lint.NewChecker. Slice is sorted by checker names.
+//
+// Checkers of ProgramScope rules are not included, as analyzers
+// have no access to the packages that import the analyzed one.
+func Analyzers() []*analysis.Analyzer {
+	var analyzers []*analysis.Analyzer
+	for _, info := range lint.CheckersInfo() {
+		if !info.ProgramScope {
+			analyzers = append(analyzers, newAnalyzer(info))
+		}
+	}
+	return analyzers
+}
+
+// New returns an analyzer for the checker with the specified name.
+// Returns nil if there is no such checker or it's a ProgramScope checker.
+func New(name string) *analysis.Analyzer {
+	for _, info := range lint.CheckersInfo() {
+		if info.Name == name && !info.ProgramScope {
+			return newAnalyzer(info)
+		}
+	}
+	return nil
+}
+
+func newAnalyzer(info lint.CheckerInfo) *analysis.Analyzer {
+	a := &analysis.Analyzer{
+		Name: info.Name,
+		Doc:  analyzerDoc(info),
+	}
+	r := &runner{name: info.Name}
+	a.Flags.StringVar(&r.minConfidence, "minConfidence", "low",
+		`minimal confidence level of reported warnings: low, medium or high`)
+	a.Flags.StringVar(&r.minSeverity, "minSeverity", "info",
+		`minimal severity level of reported warnings: info, warning or error`)
+	a.Flags.Var(&r.params, "param",
+		`checker parameter in name=value form, can be repeated`)
+	a.Flags.BoolVar(&r.checkGenerated, "checkGenerated", false,
+		`whether to check machine-generated files`)
+	a.Flags.BoolVar(&r.skipTests, "skipTests", false,
+		`whether to skip _test.go files`)
+	a.Run = r.run
+	return a
+}
+
+// analyzerDoc returns checker documentation in analysis.Analyzer.Doc format:
+// the first line is a summary, followed by the details.
+func analyzerDoc(info lint.CheckerInfo) string {
+	parts := []string{info.Summary}
+	if info.Details != "" {
+		parts = append(parts, info.Details)
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// runner runs the checker for analysis.Pass.
+type runner struct {
+	name string
+
+	// Analyzer flags:
+
+	minConfidence  string
+	minSeverity    string
+	params         paramsFlag
+	checkGenerated bool
+	skipTests      bool
+}
+
+func (r *runner) run(pass *analysis.Pass) (interface{}, error) {
+	ctx := lint.NewContext(pass.Fset, pass.TypesSizes)
+	ctx.SetPackageInfo(pass.TypesInfo, pass.Pkg)
+	if v := pass.Pkg.GoVersion(); v != "" {
+		if err := ctx.SetGoVersion(v); err != nil {
+			return nil, err
+		}
+	}
+	minConfidence, err := lint.ParseConfidence(r.minConfidence)
+	if err != nil {
+		return nil, fmt.Errorf("-minConfidence: %v", err)
+	}
+	ctx.SetMinConfidence(minConfidence)
+	minSeverity, err := lint.ParseSeverity(r.minSeverity)
+	if err != nil {
+		return nil, fmt.Errorf("-minSeverity: %v", err)
+	}
+	ctx.SetMinSeverity(minSeverity)
+	for _, p := range r.params {
+		if err := ctx.SetCheckerParam(r.name, p.name, p.value); err != nil {
+			return nil, fmt.Errorf("-param: %v", err)
+		}
+	}
+
+	var rule *lint.Rule
+	for _, x := range lint.RuleList() {
+		if x.Name() == r.name {
+			rule = x
+		}
+	}
+	c := lint.NewChecker(rule, ctx)
+	var files []*ast.File
+	for _, f := range pass.Files {
+		if !r.checkGenerated && lint.IsGenerated(f) {
+			continue
+		}
+		if r.skipTests && lint.IsTestFile(pass.Fset, f) {
+			continue
+		}
+		files = append(files, f)
+	}
+	if rule.PackageScope {
+		for _, warnings := range c.CheckPackage(files) {
+			for _, w := range warnings {
+				pass.Report(newDiagnostic(r.name, w))
+			}
+		}
+		return nil, nil
+	}
+	for _, f := range files {
+		ctx.SetFileInfo(filepath.Base(pass.Fset.Position(f.Pos()).Filename))
+		for _, w := range c.Check(f) {
+			pass.Report(newDiagnostic(r.name, w))
+		}
+	}
+	return nil, nil
+}
+
+// newDiagnostic converts checker warning to the analysis diagnostic.
+// Warning ID is used as a diagnostic category.
+func newDiagnostic(checker string, w lint.Warning) analysis.Diagnostic {
+	d := analysis.Diagnostic{
+		Pos:      w.Pos,
+		End:      w.End,
+		Category: lint.WarningID(checker, w.Code),
+		Message:  w.Text,
+	}
+	if len(w.Fix) != 0 {
+		fix := analysis.SuggestedFix{Message: "apply " + checker + " suggestion"}
+		for _, edit := range w.Fix {
+			fix.TextEdits = append(fix.TextEdits, analysis.TextEdit{
+				Pos:     edit.Pos,
+				End:     edit.End,
+				NewText: []byte(edit.NewText),
+			})
+		}
+		d.SuggestedFixes = []analysis.SuggestedFix{fix}
+	}
+	return d
+}
+
+// checkerParam is a parsed -param flag value.
+type checkerParam struct {
+	name  string
+	value string
+}
+
+// paramsFlag implements flag.Value for repeated -param flag.
+type paramsFlag []checkerParam
+
+func (f *paramsFlag) String() string {
+	parts := make([]string, len(*f))
+	for i, p := range *f {
+		parts[i] = p.name + "=" + p.value
+	}
+	return strings.Join(parts, " ")
+}
+
+func (f *paramsFlag) Set(s string) error {
+	eq := strings.Index(s, "=")
+	if eq == -1 {
+		return fmt.Errorf("%q: expected name=value", s)
+	}
+	*f = append(*f, checkerParam{name: s[:eq], value: s[eq+1:]})
+	return nil
+}
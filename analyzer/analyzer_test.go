@@ -0,0 +1,178 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"reflect"
+	"runtime"
+	"strconv"
+	"testing"
+
+	"github.com/go-critic/go-critic/lint"
+	"golang.org/x/tools/go/analysis"
+)
+
+func TestAnalyzers(t *testing.T) {
+	analyzers := Analyzers()
+	want := 0
+	for _, rule := range lint.RuleList() {
+		if !rule.ProgramScope {
+			want++
+		}
+	}
+	if len(analyzers) != want {
+		t.Errorf("have %d analyzers, want %d", len(analyzers), want)
+	}
+	if err := analysis.Validate(analyzers); err != nil {
+		t.Errorf("validate: %v", err)
+	}
+	if New("noSuchChecker") != nil {
+		t.Errorf("expected nil analyzer for unknown checker")
+	}
+	if New("unusedExported") != nil {
+		t.Errorf("expected nil analyzer for program scope checker")
+	}
+}
+
+func TestRun(t *testing.T) {
+	const src = `package p
+
+import "errors"
+
+func f(a, b int, err error) error {
+	_ = a == a
+	_ = b == b //nolint:gocritic
+	return errors.Join(err)
+}
+`
+	tests := []struct {
+		analyzer string
+		flags    map[string]string
+		want     []string
+		fixes    []string
+	}{
+		{
+			analyzer: "dupSubExpr",
+			want:     []string{"6: suspicious identical LHS and RHS for `==` operator"},
+			fixes:    []string{""},
+		},
+		{
+			analyzer: "errorsJoinSingle",
+			want:     []string{"8: errors.Join with a single error is redundant"},
+			fixes:    []string{"err"},
+		},
+		{
+			// Go version of the package is used.
+			analyzer: "errorsJoinSingle",
+			flags:    map[string]string{"goVersion": "go1.19"},
+		},
+		{
+			analyzer: "dupSubExpr",
+			flags:    map[string]string{"minConfidence": "high"},
+			want:     []string{"6: suspicious identical LHS and RHS for `==` operator"},
+			fixes:    []string{""},
+		},
+	}
+
+	for _, test := range tests {
+		a := New(test.analyzer)
+		goVersion := ""
+		for name, value := range test.flags {
+			if name == "goVersion" {
+				goVersion = value
+				continue
+			}
+			if err := a.Flags.Set(name, value); err != nil {
+				t.Fatalf("set flag: %v", err)
+			}
+		}
+
+		var have, fixes []string
+		pass := newPass(t, src, goVersion)
+		pass.Analyzer = a
+		pass.Report = func(d analysis.Diagnostic) {
+			line := pass.Fset.Position(d.Pos).Line
+			have = append(have, strconv.Itoa(line)+": "+d.Message)
+			if d.Category != test.analyzer {
+				t.Errorf("%s: have %q category", test.analyzer, d.Category)
+			}
+			fix := ""
+			for _, f := range d.SuggestedFixes {
+				for _, edit := range f.TextEdits {
+					fix += string(edit.NewText)
+				}
+			}
+			fixes = append(fixes, fix)
+		}
+		if _, err := a.Run(pass); err != nil {
+			t.Fatalf("%s: run: %v", test.analyzer, err)
+		}
+		if !reflect.DeepEqual(have, test.want) || !reflect.DeepEqual(fixes, test.fixes) {
+			t.Errorf("%s %v:\nhave: %q %q\nwant: %q %q",
+				test.analyzer, test.flags, have, fixes, test.want, test.fixes)
+		}
+	}
+}
+
+func TestRunGenerated(t *testing.T) {
+	const src = `// Code generated by hand. DO NOT EDIT.
+
+package p
+
+func f(a int) bool { return a == a }
+`
+	for _, checkGenerated := range []bool{false, true} {
+		a := New("dupSubExpr")
+		if err := a.Flags.Set("checkGenerated", strconv.FormatBool(checkGenerated)); err != nil {
+			t.Fatalf("set flag: %v", err)
+		}
+		reported := 0
+		pass := newPass(t, src, "")
+		pass.Analyzer = a
+		pass.Report = func(d analysis.Diagnostic) { reported++ }
+		if _, err := a.Run(pass); err != nil {
+			t.Fatalf("run: %v", err)
+		}
+		want := 0
+		if checkGenerated {
+			want = 1
+		}
+		if reported != want {
+			t.Errorf("checkGenerated=%v: have %d warnings, want %d", checkGenerated, reported, want)
+		}
+	}
+}
+
+func newPass(t *testing.T, src, goVersion string) *analysis.Pass {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	info := &types.Info{
+		Types:      make(map[ast.Expr]types.TypeAndValue),
+		Defs:       make(map[*ast.Ident]types.Object),
+		Uses:       make(map[*ast.Ident]types.Object),
+		Implicits:  make(map[ast.Node]types.Object),
+		Selections: make(map[*ast.SelectorExpr]*types.Selection),
+		Scopes:     make(map[ast.Node]*types.Scope),
+	}
+	conf := types.Config{
+		Importer:  importer.Default(),
+		GoVersion: goVersion,
+	}
+	pkg, err := conf.Check("p", fset, []*ast.File{f}, info)
+	if err != nil {
+		t.Fatalf("typecheck: %v", err)
+	}
+	return &analysis.Pass{
+		Fset:       fset,
+		Files:      []*ast.File{f},
+		Pkg:        pkg,
+		TypesInfo:  info,
+		TypesSizes: types.SizesFor("gc", runtime.GOARCH),
+	}
+}
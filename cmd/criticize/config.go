@@ -0,0 +1,214 @@
+package criticize
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/go-critic/go-critic/lint"
+)
+
+// config is a parsed -config file.
+//
+// Example:
+//
+//	{
+//		"enable": ["emptySelect"],
+//		"disable": ["appendAssign"],
+//		"enable-tags": ["performance"],
+//		"disable-tags": ["opinionated"],
+//		"params": {"goGenerateTool": {"strategy": "path"}, "emptySelect": {"skipMain": true}},
+//		"severity-overrides": {"appendAssign": "info", "dupSubExpr": "error"},
+//		"confidence-overrides": {"floatSumLoop": "medium"},
+//		"include": ["*.go"],
+//		"exclude": ["vendor/", "*.pb.go"],
+//		"group-by": "checker",
+//		"exit-policy": {
+//			"fail-severity": "error",
+//			"ignore-experimental": true,
+//			"fail-on": ["dupSubExpr"],
+//			"budgets": {"unslice": 10}
+//		}
+//	}
+type config struct {
+	// Enable and Disable are added to -enable and -disable lists.
+	Enable  []string `json:"enable"`
+	Disable []string `json:"disable"`
+
+	// EnableTags and DisableTags are added to -enableTags
+	// and -disableTags lists.
+	EnableTags  []string `json:"enable-tags"`
+	DisableTags []string `json:"disable-tags"`
+
+	// Params maps checker name to its parameter values.
+	// Values can be strings, numbers or bools.
+	Params map[string]map[string]paramValue `json:"params"`
+
+	// SeverityOverrides maps checker name to severity
+	// level of all its warnings.
+	SeverityOverrides map[string]string `json:"severity-overrides"`
+
+	// ConfidenceOverrides maps checker name to confidence
+	// level of all its warnings.
+	ConfidenceOverrides map[string]string `json:"confidence-overrides"`
+
+	// Include and Exclude are added to -include and -exclude lists.
+	Include []string `json:"include"`
+	Exclude []string `json:"exclude"`
+
+	// GroupBy is used when -groupBy is not specified.
+	GroupBy string `json:"group-by"`
+
+	// ExitPolicy describes which warnings fail the run.
+	// Flags take precedence over it.
+	ExitPolicy exitPolicyConfig `json:"exit-policy"`
+}
+
+// exitPolicyConfig is a lint.ExitPolicy description, see -failSeverity,
+// -ignoreExperimental, -failOn and -budgets flags.
+type exitPolicyConfig struct {
+	FailSeverity       string         `json:"fail-severity"`
+	IgnoreExperimental bool           `json:"ignore-experimental"`
+	FailOn             []string       `json:"fail-on"`
+	Budgets            map[string]int `json:"budgets"`
+}
+
+// apply sets cfg settings for p.
+func (cfg *exitPolicyConfig) apply(p *lint.ExitPolicy) error {
+	if cfg.FailSeverity != "" {
+		sev, err := lint.ParseSeverity(cfg.FailSeverity)
+		if err != nil {
+			return fmt.Errorf("fail-severity: %v", err)
+		}
+		p.FailSeverity = sev
+	}
+	p.IgnoreExperimental = cfg.IgnoreExperimental
+	p.FailOn = append(p.FailOn, cfg.FailOn...)
+	if len(cfg.Budgets) != 0 {
+		p.Budgets = make(map[string]int, len(cfg.Budgets))
+		for name, n := range cfg.Budgets {
+			p.Budgets[name] = n
+		}
+	}
+	return nil
+}
+
+// paramValue is a checker parameter value in its string form.
+type paramValue string
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (v *paramValue) UnmarshalJSON(data []byte) error {
+	var x interface{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&x); err != nil {
+		return err
+	}
+	switch x.(type) {
+	case string, bool, json.Number:
+		*v = paramValue(fmt.Sprint(x))
+		return nil
+	default:
+		return fmt.Errorf("param value should be a string, number or bool, found %s", data)
+	}
+}
+
+// loadRules registers user-defined rules from filename.
+func loadRules(filename string) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = lint.LoadRules(filename, f)
+	return err
+}
+
+func loadConfig(filename string) (*config, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var cfg config
+	dec := json.NewDecoder(f)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("%s: %v", filename, err)
+	}
+	return &cfg, nil
+}
+
+// applyFilter adds config checkers selection to filter.
+// Unknown checker names and tags are reported by lint.SelectRules.
+func (cfg *config) applyFilter(filter *lint.RuleFilter) {
+	filter.Enable = append(filter.Enable, cfg.Enable...)
+	filter.Disable = append(filter.Disable, cfg.Disable...)
+	filter.EnableTags = append(filter.EnableTags, cfg.EnableTags...)
+	filter.DisableTags = append(filter.DisableTags, cfg.DisableTags...)
+}
+
+// applyPathFilter adds config files selection to filter.
+func (cfg *config) applyPathFilter(filter *lint.PathFilter) {
+	filter.Include = append(filter.Include, cfg.Include...)
+	filter.Exclude = append(filter.Exclude, cfg.Exclude...)
+}
+
+// apply sets config params and overrides for ctx.
+// Returns an error for unknown checker names, params and levels
+// and for invalid param values.
+func (cfg *config) apply(ctx *lint.Context) error {
+	for _, checker := range sortedCheckers(cfg.Params) {
+		params := cfg.Params[checker]
+		names := make([]string, 0, len(params))
+		for name := range params {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if err := ctx.SetCheckerParam(checker, name, string(params[name])); err != nil {
+				return fmt.Errorf("params: %v", err)
+			}
+		}
+	}
+	for _, checker := range sortedKeys(cfg.SeverityOverrides) {
+		sev, err := lint.ParseSeverity(cfg.SeverityOverrides[checker])
+		if err != nil {
+			return fmt.Errorf("severity-overrides: %s: %v", checker, err)
+		}
+		if err := ctx.SetSeverityOverride(checker, sev); err != nil {
+			return fmt.Errorf("severity-overrides: %v", err)
+		}
+	}
+	for _, checker := range sortedKeys(cfg.ConfidenceOverrides) {
+		conf, err := lint.ParseConfidence(cfg.ConfidenceOverrides[checker])
+		if err != nil {
+			return fmt.Errorf("confidence-overrides: %s: %v", checker, err)
+		}
+		if err := ctx.SetConfidenceOverride(checker, conf); err != nil {
+			return fmt.Errorf("confidence-overrides: %v", err)
+		}
+	}
+	return nil
+}
+
+func sortedCheckers(m map[string]map[string]paramValue) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -1,25 +1,30 @@
 package criticize
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"flag"
+	"fmt"
 	"go/ast"
 	"go/build"
 	"go/parser"
+	"go/token"
 	"go/types"
+	"io"
+	"io/ioutil"
 	"log"
 	"os"
 	"path/filepath"
-	"regexp"
+	"plugin"
 	"runtime"
+	"sort"
+	"strconv"
 	"strings"
-	"sync"
 
 	"github.com/go-critic/go-critic/lint"
 	"golang.org/x/tools/go/loader"
 )
 
-var generatedFileCommentRE = regexp.MustCompile("Code generated .* DO NOT EDIT.")
-
 type linter struct {
 	ctx *lint.Context
 
@@ -27,17 +32,75 @@ type linter struct {
 
 	checkers []*lint.Checker
 
-	foundIssues bool // True if there any checker reported an issue
+	// cache is nil unless -cacheFile or -cacheDir is specified.
+	cache *lint.Cache
+
+	// config is nil unless -config is specified.
+	config *config
+
+	// baseline is nil unless -baseline is specified.
+	baseline *lint.Baseline
+
+	// diff is nil unless -diff is specified.
+	diff *lint.DiffFilter
+
+	// pathFilter selects files to be loaded, see -include and -exclude.
+	pathFilter lint.PathFilter
+
+	// foundIssues is true if there are unused suppression comments.
+	// Warnings fail the run according to exitPolicy.
+	foundIssues bool
+
+	// exitPolicy is built from -fail* and -budgets flags and config.
+	exitPolicy lint.ExitPolicy
+
+	// reporter is nil for the text -output format.
+	reporter lint.Reporter
+
+	// reports are collected for all -output formats and
+	// written in the -groupBy order after all packages are checked.
+	reports  []lint.Report
+	grouping lint.ReportGrouping
+
+	// explainLevel is a parsed -explain value.
+	explainLevel lint.ExplainLevel
+
+	// fixable maps file name to its warnings that have fixes.
+	// Collected for -fix.
+	fixable map[string][]lint.Warning
 
 	// Command line flags:
 
-	withOpinionated    bool
-	withExperimental   bool
 	checkGenerated     bool
+	skipTests          bool
 	shorterErrLocation bool
+	goVersion          string
+	checkerParams      paramsFlag
+	cacheFile          string
+	cacheDir           string
+	baselineFile       string
+	updateBaseline     bool
+	diffFile           string
+	workers            int
+	minConfidence      string
+	minSeverity        string
+	explain            string
+	groupBy            string
+	failSeverity       string
+	ignoreExperimental bool
+	failOn             string
+	budgets            string
+	configFile         string
+	rulesFiles         string
+	plugins            string
+	output             string
+	fix                bool
+	reportUnused       bool
+	stats              bool
+	wholeProgram       bool
 
 	packages        []string
-	enabledCheckers []string
+	rules           []*lint.Rule
 	failureExitCode int
 }
 
@@ -47,10 +110,20 @@ func Main() {
 	parseArgv(&l)
 	l.LoadProgram()
 	l.InitCheckers()
+	l.LoadCache()
+	l.LoadBaseline()
+	l.LoadDiff()
 
 	for _, pkgPath := range l.packages {
 		l.CheckPackage(pkgPath)
 	}
+	l.CheckProgram()
+
+	l.SaveCache()
+	l.SaveBaseline()
+	l.WriteReports()
+	l.ApplyFixes()
+	l.WriteStats()
 
 	os.Exit(l.ExitCode())
 }
@@ -66,6 +139,8 @@ func blame(format string, args ...interface{}) {
 func parseArgv(l *linter) {
 	const enableAll = "all"
 
+	var filter lint.RuleFilter
+
 	flag.Usage = func() {
 		log.Printf("usage: [flags] package...")
 		flag.PrintDefaults()
@@ -74,17 +149,77 @@ func parseArgv(l *linter) {
 	enable := flag.String("enable", enableAll,
 		`comma-separated list of enabled checkers`)
 	disable := flag.String("disable", "",
-		`comma-separated list of disabled checkers`)
-	flag.BoolVar(&l.withExperimental, `withExperimental`, false,
+		`comma-separated list of disabled checkers, takes precedence over enabled checkers`)
+	enableTags := flag.String("enableTags", "",
+		`comma-separated list of tags which checkers should be enabled`)
+	disableTags := flag.String("disableTags", "",
+		`comma-separated list of tags which checkers should be disabled, takes precedence over enabled checkers`)
+	flag.BoolVar(&filter.DisableAll, "disableAll", false,
+		`start with empty checkers set, to be filled by -enable and -enableTags`)
+	flag.BoolVar(&filter.WithExperimental, `withExperimental`, false,
 		`only for -enable=all, include experimental checks`)
-	flag.BoolVar(&l.withOpinionated, `withOpinionated`, false,
+	flag.BoolVar(&filter.WithOpinionated, `withOpinionated`, false,
 		`only for -enable=all, include very opinionated checks`)
 	flag.IntVar(&l.failureExitCode, "failcode", 1,
 		`exit code to be used when lint issues are found`)
 	flag.BoolVar(&l.checkGenerated, "checkGenerated", false,
 		`whether to check machine-generated files`)
+	flag.BoolVar(&l.skipTests, "skipTests", false,
+		`whether to skip _test.go files`)
 	flag.BoolVar(&l.shorterErrLocation, "shorterErrLocation", true,
 		`whether to replace error location prefix with $GOROOT and $GOPATH`)
+	flag.StringVar(&l.goVersion, "goVersion", "",
+		`Go version checked code targets, like "1.21"; latest if empty`)
+	flag.StringVar(&l.minConfidence, "minConfidence", "low",
+		`minimal confidence level of reported warnings: low, medium or high`)
+	flag.StringVar(&l.minSeverity, "minSeverity", "info",
+		`minimal severity level of reported warnings: info, warning or error`)
+	flag.StringVar(&l.explain, "explain", "none",
+		`checker documentation attached to every warning: none, link or full; full adds the rationale and before/after example`)
+	flag.StringVar(&l.groupBy, "groupBy", "",
+		`warnings order: file (by file, position, checker) or checker (by checker, file, position); default is file`)
+	flag.StringVar(&l.failSeverity, "failSeverity", "",
+		`minimal severity of warnings that fail the run: info, warning or error; default is warning`)
+	flag.BoolVar(&l.ignoreExperimental, "ignoreExperimental", false,
+		`whether warnings of experimental checkers don't fail the run, unless listed in -failOn or -budgets`)
+	flag.StringVar(&l.failOn, "failOn", "",
+		`comma-separated list of checkers which every warning fails the run, regardless of severity`)
+	flag.StringVar(&l.budgets, "budgets", "",
+		`comma-separated list of checker=N pairs; the run fails only if checker reports more than N warnings`)
+	flag.StringVar(&l.cacheFile, "cacheFile", "",
+		`file to keep checkers results between runs, so unchanged files are not re-checked`)
+	flag.StringVar(&l.cacheDir, "cacheDir", "",
+		`like -cacheFile, but results are stored in a directory that can be shared by several projects`)
+	flag.StringVar(&l.baselineFile, "baseline", "",
+		`file with known warnings that are not reported`)
+	flag.BoolVar(&l.updateBaseline, "updateBaseline", false,
+		`write all warnings to the -baseline file instead of reporting them`)
+	flag.StringVar(&l.diffFile, "diff", "",
+		`unified diff file, like git diff output; only warnings on added or modified lines are reported; "-" reads stdin`)
+	flag.StringVar(&l.configFile, "config", "",
+		`JSON file with enabled checkers, their params and severity and confidence overrides`)
+	flag.StringVar(&l.rulesFiles, "rules", "",
+		`comma-separated list of JSON files with user-defined pattern rules`)
+	flag.StringVar(&l.plugins, "plugins", "",
+		`comma-separated list of Go plugins that register additional checkers`)
+	flag.StringVar(&l.output, "output", "text",
+		`output format: text, json, sarif, checkstyle, junit, github or rdjson; json, sarif and rdjson outputs include suggested fixes`)
+	flag.BoolVar(&l.fix, "fix", false,
+		`apply suggested fixes to the source files in place`)
+	flag.BoolVar(&l.reportUnused, "reportUnusedSuppressions", false,
+		`report suppression comments that don't silence any warning`)
+	flag.IntVar(&l.workers, "workers", runtime.GOMAXPROCS(0),
+		`number of checkers that run concurrently`)
+	flag.BoolVar(&l.stats, "stats", false,
+		`print per-checker time, visits and warnings table to stderr; results restored from cache are not counted`)
+	flag.BoolVar(&l.wholeProgram, "wholeProgram", false,
+		`run checkers that analyze all specified packages together, like unusedExported`)
+	include := flag.String("include", "",
+		`comma-separated list of glob patterns of files to be checked, like "*.go,cmd/"; all files if empty`)
+	exclude := flag.String("exclude", "",
+		`comma-separated list of glob patterns of files and packages that are not loaded, like "vendor/,*.pb.go"`)
+	flag.Var(&l.checkerParams, "param",
+		`checker parameter in checker.name=value form, can be repeated`)
 
 	flag.Parse()
 
@@ -93,54 +228,125 @@ func parseArgv(l *linter) {
 	if len(l.packages) == 0 {
 		blame("no packages specified\n")
 	}
-	if *enable != enableAll && l.withExperimental {
+	if *enable != enableAll && filter.WithExperimental {
 		blame("-withExperimental used with -enable=%q", *enable)
 	}
-	if *enable != enableAll && l.withOpinionated {
+	if *enable != enableAll && filter.WithOpinionated {
 		blame("-withOpinionated used with -enable=%q", *enable)
 	}
 
 	switch *enable {
 	case enableAll:
-		for _, rule := range lint.RuleList() {
-			if rule.Experimental && !l.withExperimental {
-				continue
-			}
-			if rule.VeryOpinionated && !l.withOpinionated {
-				continue
-			}
-			l.enabledCheckers = append(l.enabledCheckers, rule.Name())
-		}
+		// Use default checkers set, unless -disableAll is given.
 	case "":
-		// Empty slice. Semantically "disable-all".
+		// Semantically "disable-all".
 		// Can be used to run all pipelines without actual checkers.
-		l.enabledCheckers = []string{}
+		filter.DisableAll = true
 	default:
 		// Comma-separated list of names.
-		l.enabledCheckers = strings.Split(*enable, ",")
+		filter.DisableAll = true
+		filter.Enable = strings.Split(*enable, ",")
 	}
 
 	switch *disable {
 	case "all":
-		l.enabledCheckers = l.enabledCheckers[:0]
+		filter.DisableTags = lint.TagList()
 	case "":
 		// nothing to disable, skip
 	default:
-		disabled := strings.Split(*disable, ",")
-		filtred := l.enabledCheckers[:0]
-
-		for _, e := range l.enabledCheckers {
-			found := false
-			for _, d := range disabled {
-				if e == d {
-					found = true
-				}
+		filter.Disable = strings.Split(*disable, ",")
+	}
+
+	if *enableTags != "" {
+		filter.EnableTags = strings.Split(*enableTags, ",")
+	}
+	if *disableTags != "" {
+		filter.DisableTags = append(filter.DisableTags, strings.Split(*disableTags, ",")...)
+	}
+
+	// Plugins and user-defined rules are loaded before the filter
+	// is applied, so their checkers can be selected by name.
+	if l.plugins != "" {
+		for _, filename := range strings.Split(l.plugins, ",") {
+			if _, err := plugin.Open(filename); err != nil {
+				log.Fatalf("-plugins: %v", err)
 			}
-			if !found {
-				filtred = append(filtred, e)
+		}
+	}
+	if l.rulesFiles != "" {
+		for _, filename := range strings.Split(l.rulesFiles, ",") {
+			if err := loadRules(filename); err != nil {
+				log.Fatalf("-rules: %v", err)
 			}
 		}
-		l.enabledCheckers = filtred
+	}
+
+	if l.configFile != "" {
+		cfg, err := loadConfig(l.configFile)
+		if err != nil {
+			log.Fatalf("-config: %v", err)
+		}
+		cfg.applyFilter(&filter)
+		cfg.applyPathFilter(&l.pathFilter)
+		l.config = cfg
+	}
+	if *include != "" {
+		l.pathFilter.Include = append(l.pathFilter.Include, strings.Split(*include, ",")...)
+	}
+	if *exclude != "" {
+		l.pathFilter.Exclude = append(l.pathFilter.Exclude, strings.Split(*exclude, ",")...)
+	}
+	if err := l.pathFilter.Validate(); err != nil {
+		blame("-include or -exclude: %v", err)
+	}
+
+	rules, err := lint.SelectRules(filter)
+	if err != nil {
+		blame("%v", err)
+	}
+	l.rules = rules
+
+	if l.updateBaseline && l.baselineFile == "" {
+		blame("-updateBaseline requires -baseline file")
+	}
+	if l.updateBaseline && l.diffFile != "" {
+		blame("-updateBaseline can't be used with -diff")
+	}
+	if l.cacheFile != "" && l.cacheDir != "" {
+		blame("-cacheFile can't be used with -cacheDir")
+	}
+	if l.reportUnused && (l.cacheFile != "" || l.cacheDir != "") {
+		blame("-reportUnusedSuppressions can't be used with -cacheFile or -cacheDir")
+	}
+
+	if err := l.initExitPolicy(); err != nil {
+		blame("%v", err)
+	}
+
+	explainLevel, err := lint.ParseExplainLevel(l.explain)
+	if err != nil {
+		blame("-explain: %v", err)
+	}
+	l.explainLevel = explainLevel
+
+	groupBy := l.groupBy
+	if groupBy == "" && l.config != nil {
+		groupBy = l.config.GroupBy
+	}
+	if groupBy != "" {
+		grouping, err := lint.ParseReportGrouping(groupBy)
+		if err != nil {
+			blame("-groupBy: %v", err)
+		}
+		l.grouping = grouping
+	}
+
+	if l.output != "text" {
+		reporter, err := lint.NewReporter(l.output)
+		if err != nil {
+			blame("-output: %v", err)
+		}
+		l.reporter = reporter
 	}
 }
 
@@ -151,6 +357,7 @@ func (l *linter) LoadProgram() {
 	}
 
 	conf := loader.Config{
+		Build:      l.filterPackages(),
 		ParserMode: parser.ParseComments,
 		TypeChecker: types.Config{
 			Sizes: sizes,
@@ -167,43 +374,273 @@ func (l *linter) LoadProgram() {
 
 	l.prog = prog
 	l.ctx = lint.NewContext(prog.Fset, sizes)
+	if l.goVersion != "" {
+		if err := l.ctx.SetGoVersion(l.goVersion); err != nil {
+			log.Fatalf("-goVersion: %v", err)
+		}
+	}
+	minConfidence, err := lint.ParseConfidence(l.minConfidence)
+	if err != nil {
+		log.Fatalf("-minConfidence: %v", err)
+	}
+	l.ctx.SetMinConfidence(minConfidence)
+	minSeverity, err := lint.ParseSeverity(l.minSeverity)
+	if err != nil {
+		log.Fatalf("-minSeverity: %v", err)
+	}
+	l.ctx.SetMinSeverity(minSeverity)
+	l.ctx.SetCollectStats(l.stats)
+	// Params from the command line take precedence over config params.
+	if l.config != nil {
+		if err := l.config.apply(l.ctx); err != nil {
+			log.Fatalf("-config: %v", err)
+		}
+	}
+	for _, p := range l.checkerParams {
+		if err := l.ctx.SetCheckerParam(p.checker, p.name, p.value); err != nil {
+			log.Fatalf("-param: %v", err)
+		}
+	}
 }
 
-func (l *linter) InitCheckers() {
-	requested := make(map[string]bool)
-	available := lint.RuleList()
+// filterPackages removes packages excluded by -exclude from the
+// packages list and returns build context that hides files of the
+// remaining packages that don't match -include and -exclude,
+// so they are never parsed or type-checked.
+// Packages that have no files left are removed too.
+//
+// Dependencies are loaded unfiltered, as they are needed for type checking.
+func (l *linter) filterPackages() *build.Context {
+	ctxt := build.Default
+	if l.pathFilter.Empty() {
+		return &ctxt
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		log.Fatalf("resolve packages: %v", err)
+	}
 
-	if l.enabledCheckers == nil {
-		// Fill default checkers set.
-		for _, rule := range available {
-			if rule.Experimental && !l.withExperimental {
-				continue
+	dirs := make(map[string]bool)
+	var packages []string
+	for _, pkgPath := range l.packages {
+		if !l.pathFilter.MatchPackage(pkgPath) {
+			continue
+		}
+		pkg, err := ctxt.Import(pkgPath, wd, build.FindOnly)
+		if err != nil {
+			log.Fatalf("resolve packages: %v", err)
+		}
+		dirs[pkg.Dir] = true
+		packages = append(packages, pkgPath)
+	}
+
+	ctxt.ReadDir = func(dir string) ([]os.FileInfo, error) {
+		list, err := ioutil.ReadDir(dir)
+		if err != nil || !dirs[dir] {
+			return list, err
+		}
+		filtered := list[:0]
+		for _, info := range list {
+			if info.IsDir() || l.pathFilter.MatchFile(filepath.Join(dir, info.Name())) {
+				filtered = append(filtered, info)
 			}
-			if rule.VeryOpinionated && !l.withOpinionated {
+		}
+		return filtered, nil
+	}
+
+	l.packages = l.packages[:0]
+	for _, pkgPath := range packages {
+		if _, err := ctxt.Import(pkgPath, wd, 0); err != nil {
+			if _, ok := err.(*build.NoGoError); ok {
 				continue
 			}
-			requested[rule.Name()] = true
 		}
-	} else {
-		for _, name := range l.enabledCheckers {
-			requested[name] = true
+		l.packages = append(l.packages, pkgPath)
+	}
+	if len(l.packages) == 0 {
+		log.Fatalf("all packages are excluded by -include and -exclude")
+	}
+	return &ctxt
+}
+
+func (l *linter) InitCheckers() {
+	for _, rule := range l.rules {
+		l.checkers = append(l.checkers, lint.NewChecker(rule, l.ctx))
+	}
+}
+
+// LoadCache reads the results of the previous run from the cache file
+// or opens the cache directory.
+// Missing cache file is not an error, empty cache is used instead.
+func (l *linter) LoadCache() {
+	pluginsID, err := l.pluginsID()
+	if err != nil {
+		log.Fatalf("-plugins: %v", err)
+	}
+	if l.cacheDir != "" {
+		id, err := executableID()
+		if err != nil {
+			log.Fatalf("-cacheDir: %v", err)
+		}
+		l.cache, err = lint.OpenCacheDir(l.cacheDir, id+pluginsID)
+		if err != nil {
+			log.Fatalf("-cacheDir: %v", err)
 		}
+		return
+	}
+	if l.cacheFile == "" {
+		return
+	}
+	// Plugin checkers are not a part of the executable,
+	// so their results are invalidated by the plugins hash.
+	defer func() { l.cache.SetToolID(pluginsID) }()
+	f, err := os.Open(l.cacheFile)
+	if os.IsNotExist(err) {
+		l.cache = lint.NewCache()
+		return
+	}
+	if err != nil {
+		log.Fatalf("-cacheFile: %v", err)
+	}
+	defer f.Close()
+	l.cache, err = lint.LoadCache(f)
+	if err != nil {
+		log.Printf("-cacheFile: %v, using empty cache", err)
+		l.cache = lint.NewCache()
 	}
+}
 
-	for _, rule := range available {
-		if !requested[rule.Name()] {
-			continue
+// pluginsID returns a hash of the -plugins files contents.
+// Returns an empty string if no plugins are loaded.
+func (l *linter) pluginsID() (string, error) {
+	if l.plugins == "" {
+		return "", nil
+	}
+	h := sha256.New()
+	for _, filename := range strings.Split(l.plugins, ",") {
+		data, err := ioutil.ReadFile(filename)
+		if err != nil {
+			return "", err
 		}
-		l.checkers = append(l.checkers, lint.NewChecker(rule, l.ctx))
-		delete(requested, rule.Name())
+		fmt.Fprintf(h, "%s\x00%d\x00", filename, len(data))
+		h.Write(data)
 	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
 
-	if len(requested) != 0 {
-		for name := range requested {
-			log.Printf("%s: checker not found", name)
+// SaveCache writes the results of the current run to the cache file.
+// Cache directory entries are written during the run, so
+// the directory is only trimmed.
+func (l *linter) SaveCache() {
+	if l.cache == nil {
+		return
+	}
+	if l.cacheDir != "" {
+		if err := l.cache.Trim(); err != nil {
+			log.Printf("-cacheDir: %v", err)
 		}
-		log.Fatalf("exiting due to initialization failure")
+		return
 	}
+	f, err := os.Create(l.cacheFile)
+	if err != nil {
+		log.Fatalf("-cacheFile: %v", err)
+	}
+	defer f.Close()
+	if err := l.cache.Save(f); err != nil {
+		log.Fatalf("-cacheFile: %v", err)
+	}
+}
+
+// executableID returns a hash of the running executable.
+// It changes every time checkers are changed, so results of
+// the different linter versions are not mixed in the cache directory.
+func executableID() (string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	f, err := os.Open(exe)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// LoadBaseline reads known warnings from the baseline file.
+// For -updateBaseline, an empty baseline is used instead.
+func (l *linter) LoadBaseline() {
+	if l.baselineFile == "" {
+		return
+	}
+	if l.updateBaseline {
+		l.baseline = lint.NewBaseline()
+		return
+	}
+	f, err := os.Open(l.baselineFile)
+	if err != nil {
+		log.Fatalf("-baseline: %v", err)
+	}
+	defer f.Close()
+	l.baseline, err = lint.LoadBaseline(f)
+	if err != nil {
+		log.Fatalf("-baseline: %v", err)
+	}
+}
+
+// SaveBaseline writes collected warnings to the baseline file for -updateBaseline.
+func (l *linter) SaveBaseline() {
+	if !l.updateBaseline {
+		return
+	}
+	f, err := os.Create(l.baselineFile)
+	if err != nil {
+		log.Fatalf("-baseline: %v", err)
+	}
+	defer f.Close()
+	if err := l.baseline.Save(f); err != nil {
+		log.Fatalf("-baseline: %v", err)
+	}
+}
+
+// LoadDiff reads the -diff file.
+func (l *linter) LoadDiff() {
+	if l.diffFile == "" {
+		return
+	}
+	var r io.Reader = os.Stdin
+	if l.diffFile != "-" {
+		f, err := os.Open(l.diffFile)
+		if err != nil {
+			log.Fatalf("-diff: %v", err)
+		}
+		defer f.Close()
+		r = f
+	}
+	diff, err := lint.ParseDiff(r)
+	if err != nil {
+		log.Fatalf("-diff: %v", err)
+	}
+	l.diff = diff
+}
+
+// baselineName returns a name that identifies file in the baseline.
+// Paths are relative to the working directory, so baseline
+// does not depend on the project location.
+func baselineName(filename string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return filename
+	}
+	rel, err := filepath.Rel(wd, filename)
+	if err != nil {
+		return filename
+	}
+	return filepath.ToSlash(rel)
 }
 
 func (l *linter) CheckPackage(pkgPath string) {
@@ -213,66 +650,318 @@ func (l *linter) CheckPackage(pkgPath string) {
 	}
 
 	l.ctx.SetPackageInfo(&pkgInfo.Info, pkgInfo.Pkg)
+	var files []*ast.File
+	srcs := make(map[*ast.File][]byte)
 	for _, f := range pkgInfo.Files {
-		if l.checkGenerated || !isGenerated(f) {
-			l.ctx.SetFileInfo(l.getFilename(f))
-			l.checkFile(f)
+		if !l.checksFile(f) {
+			continue
+		}
+		files = append(files, f)
+		if l.cache != nil || l.baseline != nil || l.reporter != nil {
+			srcs[f] = l.readSource(f)
+		}
+	}
+
+	runner := lint.Runner{
+		Workers: l.workers,
+		Cache:   l.cache,
+		Source:  func(f *ast.File) []byte { return srcs[f] },
+	}
+	results, err := runner.Run(l.checkers, files)
+	if err != nil {
+		log.Fatalf("%s: error: %v", pkgPath, err)
+	}
+	for _, r := range results {
+		l.handleWarning(r.Checker, r.File, srcs[r.File], r.Warning)
+	}
+	// Suppressions are reported after the program checkers are run.
+	if l.wholeProgram {
+		return
+	}
+	for _, f := range files {
+		l.checkSuppressions(f)
+	}
+}
+
+// CheckProgram runs checkers of ProgramScope rules over all
+// specified packages and their external tests for -wholeProgram.
+func (l *linter) CheckProgram() {
+	if !l.wholeProgram {
+		return
+	}
+	var pkgs []*lint.Package
+	for _, pkgInfo := range l.prog.InitialPackages() {
+		pkgs = append(pkgs, &lint.Package{Pkg: pkgInfo.Pkg, Info: &pkgInfo.Info, Files: pkgInfo.Files})
+	}
+
+	srcs := make(map[*ast.File][]byte)
+	for _, c := range l.checkers {
+		if !c.Rule.ProgramScope {
+			continue
+		}
+		for _, r := range c.CheckProgram(pkgs) {
+			if !l.checksFile(r.File) {
+				continue
+			}
+			if _, ok := srcs[r.File]; !ok && (l.baseline != nil || l.reporter != nil) {
+				srcs[r.File] = l.readSource(r.File)
+			}
+			l.handleWarning(r.Checker, r.File, srcs[r.File], r.Warning)
 		}
 	}
+	for _, pkg := range pkgs {
+		for _, f := range pkg.Files {
+			if l.checksFile(f) {
+				l.checkSuppressions(f)
+			}
+		}
+	}
+}
+
+// checksFile reports whether f should be checked according to
+// -checkGenerated and -skipTests flags.
+func (l *linter) checksFile(f *ast.File) bool {
+	if !l.checkGenerated && lint.IsGenerated(f) {
+		return false
+	}
+	return !l.skipTests || !lint.IsTestFile(l.ctx.FileSet(), f)
+}
+
+// readSource returns f source code.
+func (l *linter) readSource(f *ast.File) []byte {
+	src, err := ioutil.ReadFile(l.ctx.FileSet().Position(f.Pos()).Filename)
+	if err != nil {
+		log.Fatalf("read source: %v", err)
+	}
+	return src
 }
 
-func isGenerated(f *ast.File) bool {
-	return len(f.Comments) != 0 && generatedFileCommentRE.MatchString(f.Comments[0].Text())
+// checkSuppressions reports unused suppression comments of f
+// for -reportUnusedSuppressions.
+func (l *linter) checkSuppressions(f *ast.File) {
+	if !l.reportUnused {
+		return
+	}
+	for _, c := range l.ctx.UnusedSuppressions(f) {
+		l.foundIssues = true
+		loc := l.ctx.FileSet().Position(c.Pos()).String()
+		if l.shorterErrLocation {
+			loc = shortenLocation(loc)
+		}
+		log.Printf("%s: unused suppression comment: %s\n", loc, c.Text)
+	}
 }
 
-func (l *linter) getFilename(f *ast.File) string {
-	// see https://github.com/golang/go/issues/24498
-	return filepath.Base(l.prog.Fset.Position(f.Pos()).Filename)
+// initExitPolicy builds exitPolicy from the config and flags.
+// Flags take precedence over the config.
+func (l *linter) initExitPolicy() error {
+	p := &l.exitPolicy
+	if l.config != nil {
+		if err := l.config.ExitPolicy.apply(p); err != nil {
+			return fmt.Errorf("-config: exit-policy: %v", err)
+		}
+	}
+	if l.failSeverity != "" {
+		sev, err := lint.ParseSeverity(l.failSeverity)
+		if err != nil {
+			return fmt.Errorf("-failSeverity: %v", err)
+		}
+		p.FailSeverity = sev
+	}
+	p.IgnoreExperimental = p.IgnoreExperimental || l.ignoreExperimental
+	if l.failOn != "" {
+		p.FailOn = append(p.FailOn, strings.Split(l.failOn, ",")...)
+	}
+	if l.budgets != "" {
+		if p.Budgets == nil {
+			p.Budgets = make(map[string]int)
+		}
+		for _, pair := range strings.Split(l.budgets, ",") {
+			eq := strings.Index(pair, "=")
+			if eq == -1 {
+				return fmt.Errorf("-budgets: %q: expected checker=N", pair)
+			}
+			n, err := strconv.Atoi(pair[eq+1:])
+			if err != nil {
+				return fmt.Errorf("-budgets: %q: %v", pair, err)
+			}
+			p.Budgets[pair[:eq]] = n
+		}
+	}
+	if err := p.Validate(); err != nil {
+		return fmt.Errorf("exit policy: %v", err)
+	}
+	return nil
 }
 
 // ExitCode returns status code that should be used as an argument to os.Exit.
+// Checkers that fail the run are printed, unless the default policy is used.
 func (l *linter) ExitCode() int {
-	if l.foundIssues {
+	violations := l.exitPolicy.Evaluate(l.reports)
+	if !l.exitPolicy.Empty() {
+		for _, v := range violations {
+			log.Printf("exit policy: %s", v)
+		}
+	}
+	if l.foundIssues || len(violations) != 0 {
 		return l.failureExitCode
 	}
 	return 0
 }
 
-func (l *linter) checkFile(f *ast.File) {
-	var wg sync.WaitGroup
-	wg.Add(len(l.checkers))
-	for _, c := range l.checkers {
-		// All checkers are expected to use *lint.Context
-		// as read-only structure, so no copying is required.
-		go func(c *lint.Checker) {
-			defer func() {
-				wg.Done()
-				// Checker signals unexpected error with panic(error).
-				r := recover()
-				if r == nil {
-					return // There were no panic
-				}
-				if err, ok := r.(error); ok {
-					log.Printf("%s: error: %v\n", c.Rule, err)
-					panic(err)
-				} else {
-					// Some other kind of run-time panic.
-					// Undo the recover and resume panic.
-					panic(r)
-				}
-			}()
-
-			for _, warn := range c.Check(f) {
-				l.foundIssues = true
-				loc := l.ctx.FileSet().Position(warn.Node.Pos()).String()
-				if l.shorterErrLocation {
-					loc = shortenLocation(loc)
-				}
-				log.Printf("%s: %s: %v\n", loc, c.Rule, warn.Text)
-			}
-		}(c)
+// handleWarning reports warning w of the checker c for the file f.
+// src is f source code, it's only read when cache, -baseline or non-text -output is used.
+func (l *linter) handleWarning(c *lint.Checker, f *ast.File, src []byte, warn lint.Warning) {
+	if l.diff != nil {
+		pos := l.ctx.FileSet().Position(warn.Pos)
+		if !l.diff.Match(pos.Filename, pos.Line) {
+			return
+		}
+	}
+	if l.baseline != nil {
+		name := baselineName(l.ctx.FileSet().Position(f.Pos()).Filename)
+		if l.updateBaseline {
+			l.baseline.Add(l.ctx.FileSet(), name, src, c.Rule.Name(), warn)
+			return
+		}
+		if l.baseline.Match(l.ctx.FileSet(), name, src, c.Rule.Name(), warn) {
+			return
+		}
+	}
+	if l.fix && len(warn.Fix) != 0 {
+		l.addFixable(f, warn)
+	}
+	r := lint.NewReport(l.ctx.FileSet(), c.Rule.Name(), warn)
+	if l.reporter != nil {
+		r.Fingerprint = lint.Fingerprint(l.ctx.FileSet(), src, c.Rule.Name(), warn)
+	}
+	r.Explanation = lint.ExplainChecker(c.Rule.Name(), l.explainLevel)
+	l.reports = append(l.reports, r)
+}
+
+// indentExplanation indents every line of the -explain text,
+// so it's visually attached to the warning it follows.
+func indentExplanation(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = "    " + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (l *linter) addFixable(f *ast.File, w lint.Warning) {
+	filename := l.ctx.FileSet().Position(f.Pos()).Filename
+	if l.fixable == nil {
+		l.fixable = make(map[string][]lint.Warning)
+	}
+	l.fixable[filename] = append(l.fixable[filename], w)
+}
+
+// ApplyFixes rewrites source files with collected fixes for -fix.
+func (l *linter) ApplyFixes() {
+	filenames := make([]string, 0, len(l.fixable))
+	for filename := range l.fixable {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+
+	for _, filename := range filenames {
+		warnings := l.fixable[filename]
+		// Make fixes selection independent of checkers execution order.
+		sort.SliceStable(warnings, func(i, j int) bool {
+			return warnings[i].Pos < warnings[j].Pos
+		})
+		src, err := ioutil.ReadFile(filename)
+		if err != nil {
+			log.Fatalf("-fix: %v", err)
+		}
+		fixed, applied, err := lint.ApplyFixes(l.ctx.FileSet(), src, warnings)
+		if err != nil {
+			log.Printf("-fix: %s: %v", filename, err)
+			continue
+		}
+		if err := ioutil.WriteFile(filename, fixed, 0644); err != nil {
+			log.Fatalf("-fix: %v", err)
+		}
+		log.Printf("%s: applied %d of %d fixes", filename, applied, len(warnings))
+	}
+}
+
+// WriteStats prints checkers stats table for -stats.
+func (l *linter) WriteStats() {
+	if !l.stats {
+		return
+	}
+	stats := make([]lint.CheckerStats, len(l.checkers))
+	for i, c := range l.checkers {
+		stats[i] = c.Stats()
+	}
+	if err := lint.WriteStats(os.Stderr, stats); err != nil {
+		log.Fatalf("write stats: %v", err)
+	}
+}
+
+// WriteReports prints collected reports in the -output format.
+// Reports are sorted according to -groupBy.
+func (l *linter) WriteReports() {
+	lint.SortReports(l.reports, l.grouping)
+	if l.reporter == nil {
+		for _, r := range l.reports {
+			printReport(r, l.shorterErrLocation)
+		}
+		return
+	}
+	if err := l.reporter.Write(os.Stdout, l.reports); err != nil {
+		log.Fatalf("write reports: %v", err)
+	}
+}
+
+// printReport prints r in the text -output format.
+func printReport(r lint.Report, shorterErrLocation bool) {
+	pos := token.Position{Filename: r.Pos.Filename, Line: r.Pos.Line, Column: r.Pos.Column}
+	loc := pos.String()
+	if shorterErrLocation {
+		loc = shortenLocation(loc)
+	}
+	if r.Explanation != "" {
+		log.Printf("%s: %s: %v\n%s\n", loc, r.Checker, r.Text, indentExplanation(r.Explanation))
+		return
+	}
+	log.Printf("%s: %s: %v\n", loc, r.Checker, r.Text)
+}
+
+// checkerParam is a parsed -param flag value.
+type checkerParam struct {
+	checker string
+	name    string
+	value   string
+}
+
+// paramsFlag implements flag.Value for repeated -param flag.
+type paramsFlag []checkerParam
+
+func (f *paramsFlag) String() string {
+	parts := make([]string, len(*f))
+	for i, p := range *f {
+		parts[i] = p.checker + "." + p.name + "=" + p.value
+	}
+	return strings.Join(parts, " ")
+}
+
+func (f *paramsFlag) Set(s string) error {
+	eq := strings.Index(s, "=")
+	dot := strings.Index(s, ".")
+	if eq == -1 || dot == -1 || dot > eq {
+		return fmt.Errorf("%q: expected checker.name=value", s)
 	}
-	wg.Wait()
+	*f = append(*f, checkerParam{
+		checker: s[:dot],
+		name:    s[dot+1 : eq],
+		value:   s[eq+1:],
+	})
+	return nil
 }
 
 func shortenLocation(loc string) string {
@@ -11,6 +11,8 @@ import (
 	"regexp"
 	"runtime"
 	"strings"
+
+	"github.com/go-critic/go-critic/lint"
 )
 
 func packagePath() []string {
@@ -34,10 +36,43 @@ func Main() {
 		`forwarded to linter "as is"`)
 	disable := flag.String("disable", "",
 		`forwarded to linter "as is"`)
+	enableTags := flag.String("enableTags", "",
+		`forwarded to linter "as is"`)
+	disableTags := flag.String("disableTags", "",
+		`forwarded to linter "as is"`)
+	disableAll := flag.Bool("disableAll", false,
+		`forwarded to linter "as is"`)
 	exclude := flag.String("exclude", "testdata/|vendor/|builtin/",
 		`regexp used to skip package names`)
+	includeGlobs := flag.String("includeGlobs", "",
+		`forwarded to linter as -include; files that don't match are also skipped during the walk`)
+	excludeGlobs := flag.String("excludeGlobs", "",
+		`forwarded to linter as -exclude; matching directories are also skipped during the walk`)
 	checkGenerated := flag.Bool("checkGenerated", false, `forwarded to linter "as is"`)
+	skipTests := flag.Bool("skipTests", false, `forwarded to linter "as is"`)
 	shorterErrLocation := flag.Bool("shorterErrLocation", true, `forwarded to linter "as is"`)
+	goVersion := flag.String("goVersion", "", `forwarded to linter "as is"`)
+	minConfidence := flag.String("minConfidence", "low", `forwarded to linter "as is"`)
+	minSeverity := flag.String("minSeverity", "info", `forwarded to linter "as is"`)
+	explain := flag.String("explain", "none", `forwarded to linter "as is"`)
+	groupBy := flag.String("groupBy", "", `forwarded to linter "as is"`)
+	failSeverity := flag.String("failSeverity", "", `forwarded to linter "as is"`)
+	ignoreExperimental := flag.Bool("ignoreExperimental", false, `forwarded to linter "as is"`)
+	failOn := flag.String("failOn", "", `forwarded to linter "as is"`)
+	budgets := flag.String("budgets", "", `forwarded to linter "as is"`)
+	config := flag.String("config", "", `forwarded to linter "as is"`)
+	rules := flag.String("rules", "", `forwarded to linter "as is"`)
+	plugins := flag.String("plugins", "", `forwarded to linter "as is"`)
+	output := flag.String("output", "text", `forwarded to linter "as is"`)
+	fix := flag.Bool("fix", false, `forwarded to linter "as is"`)
+	reportUnused := flag.Bool("reportUnusedSuppressions", false, `forwarded to linter "as is"`)
+	baseline := flag.String("baseline", "", `forwarded to linter "as is"`)
+	updateBaseline := flag.Bool("updateBaseline", false, `forwarded to linter "as is"`)
+	diff := flag.String("diff", "", `forwarded to linter "as is"`)
+	stats := flag.Bool("stats", false, `forwarded to linter "as is"`)
+	wholeProgram := flag.Bool("wholeProgram", false, `forwarded to linter "as is"`)
+	var params []string
+	flag.Var((*stringsFlag)(&params), "param", `forwarded to linter "as is"`)
 
 	flag.Parse()
 
@@ -62,6 +97,17 @@ func Main() {
 		log.Fatalf("bad -exclude pattern: %v", err)
 	}
 
+	var pathFilter lint.PathFilter
+	if *includeGlobs != "" {
+		pathFilter.Include = strings.Split(*includeGlobs, ",")
+	}
+	if *excludeGlobs != "" {
+		pathFilter.Exclude = strings.Split(*excludeGlobs, ",")
+	}
+	if err := pathFilter.Validate(); err != nil {
+		log.Fatalf("bad -includeGlobs or -excludeGlobs pattern: %v", err)
+	}
+
 	packages := map[string]bool{}
 
 	err = filepath.Walk(srcRoot, func(path string, info os.FileInfo, e error) error {
@@ -71,7 +117,13 @@ func Main() {
 			}
 			log.Printf("walk error: %v", e)
 		}
-		if info.IsDir() || !strings.HasSuffix(path, ".go") || excludeRE.MatchString(path) {
+		if info.IsDir() {
+			if path != srcRoot && !pathFilter.MatchPackage(filepath.ToSlash(path)) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || excludeRE.MatchString(path) || !pathFilter.MatchFile(path) {
 			return nil
 		}
 
@@ -91,8 +143,37 @@ func Main() {
 		"check-package",
 		"-enable", *enable,
 		"-disable", *disable,
+		"-enableTags", *enableTags,
+		"-disableTags", *disableTags,
+		"-disableAll=" + fmt.Sprint(*disableAll),
+		"-include=" + *includeGlobs,
+		"-exclude=" + *excludeGlobs,
 		"-checkGenerated=" + fmt.Sprint(*checkGenerated),
+		"-skipTests=" + fmt.Sprint(*skipTests),
 		"-shorterErrLocation=" + fmt.Sprint(*shorterErrLocation),
+		"-goVersion=" + *goVersion,
+		"-minConfidence=" + *minConfidence,
+		"-minSeverity=" + *minSeverity,
+		"-explain=" + *explain,
+		"-groupBy=" + *groupBy,
+		"-failSeverity=" + *failSeverity,
+		"-ignoreExperimental=" + fmt.Sprint(*ignoreExperimental),
+		"-failOn=" + *failOn,
+		"-budgets=" + *budgets,
+		"-config=" + *config,
+		"-rules=" + *rules,
+		"-plugins=" + *plugins,
+		"-output=" + *output,
+		"-fix=" + fmt.Sprint(*fix),
+		"-reportUnusedSuppressions=" + fmt.Sprint(*reportUnused),
+		"-baseline=" + *baseline,
+		"-updateBaseline=" + fmt.Sprint(*updateBaseline),
+		"-diff=" + *diff,
+		"-stats=" + fmt.Sprint(*stats),
+		"-wholeProgram=" + fmt.Sprint(*wholeProgram),
+	}
+	for _, p := range params {
+		args = append(args, "-param", p)
 	}
 	for p := range packages {
 		args = append(args, p)
@@ -100,9 +181,20 @@ func Main() {
 
 	/* #nosec */
 	cmd := exec.Command("gocritic", args...)
+	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	if err := cmd.Run(); err != nil {
 		log.Fatalf("lint error: %v", err)
 	}
 }
+
+// stringsFlag implements flag.Value for repeated string flags.
+type stringsFlag []string
+
+func (f *stringsFlag) String() string { return strings.Join(*f, " ") }
+
+func (f *stringsFlag) Set(s string) error {
+	*f = append(*f, s)
+	return nil
+}
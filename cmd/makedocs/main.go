@@ -2,7 +2,6 @@ package main
 
 import (
 	"bytes"
-	"errors"
 	"fmt"
 	"go/format"
 	"go/parser"
@@ -10,6 +9,7 @@ import (
 	"io/ioutil"
 	"log"
 	"os"
+	"sort"
 	"strings"
 	"text/template"
 
@@ -32,6 +32,7 @@ type checker struct {
 	SyntaxOnly       bool
 	Experimental     bool
 	VeryOpinionated  bool
+	Performance      bool
 }
 
 var checkers []checker
@@ -49,6 +50,7 @@ func main() {
 		log.Fatal(err)
 	}
 
+	docs := make(map[string]string)
 	for _, r := range lint.RuleList() {
 		log.Printf("parsing %s\n", r.Name())
 
@@ -63,10 +65,12 @@ func main() {
 			SyntaxOnly:      r.SyntaxOnly,
 			Experimental:    r.Experimental,
 			VeryOpinionated: r.VeryOpinionated,
+			Performance:     r.Performance,
 		}
 		for _, comment := range f.Comments {
 			if strings.HasPrefix(comment.Text(), "!") {
 				parseComment(comment.Text(), &c)
+				docs[c.Name] = comment.Text()
 				break
 			}
 		}
@@ -85,27 +89,59 @@ func main() {
 	if err := ioutil.WriteFile(docsPath+"overview.md", buf.Bytes(), 0600); err != nil {
 		log.Fatal(err)
 	}
+	if err := writeDocsTable(docs); err != nil {
+		log.Fatal(err)
+	}
 }
 
-func parseComment(text string, c *checker) {
-	lines := strings.Split(text, "\n")
-	index := 0
-	stages := []func(l []string, i *int, c *checker) error{
-		parseShortDesc,
-		parseDesc,
-		parseBefore,
-		parseAfter,
-		parseNote,
+// writeDocsTable generates lint package source file that makes
+// checkers documentation available at run time.
+func writeDocsTable(docs map[string]string) error {
+	names := make([]string, 0, len(docs))
+	for name := range docs {
+		names = append(names, name)
 	}
-	for _, st := range stages {
-		err := st(lines, &index, c)
-		if err != nil {
-			log.Println(err)
-		}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by cmd/makedocs; DO NOT EDIT.\n\n")
+	buf.WriteString("package lint\n\n")
+	buf.WriteString("// checkerDocs maps checker name to its documentation comment text.\n")
+	buf.WriteString("var checkerDocs = map[string]string{\n")
+	for _, name := range names {
+		fmt.Fprintf(&buf, "%q: %q,\n", name, docs[name])
+	}
+	buf.WriteString("}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(checkersPath+"checker_docs.go", src, 0600)
+}
+
+func parseComment(text string, c *checker) {
+	doc, err := lint.ParseCheckerDoc(text)
+	if err != nil {
+		log.Printf("%s: %v", c.Name, err)
 	}
+	c.ShortDescription = doc.Summary + "\n\n"
+	c.Description = withNewline(doc.Details)
+	c.Before = withNewline(doc.Before)
+	c.After = withNewline(doc.After)
+	c.Note = withNewline(doc.Note)
 	validateSnippets(c)
 }
 
+// withNewline returns s with trailing newline added.
+// Empty strings are returned unchanged.
+func withNewline(s string) string {
+	if s == "" {
+		return s
+	}
+	return s + "\n"
+}
+
 func validateSnippets(c *checker) {
 	if want := gofmt(c.Before); want != c.Before {
 		log.Printf("%s @Before formatting mismatch:\nhave: %s\nwant: %s",
@@ -124,65 +160,3 @@ func gofmt(code string) string {
 	}
 	return string(out)
 }
-
-func parseShortDesc(lines []string, index *int, c *checker) error {
-	c.ShortDescription = strings.TrimSpace(lines[0][1:]) + "\n\n"
-	*index += 2 // skip empty line
-	return nil
-}
-
-func parseDesc(lines []string, index *int, c *checker) error {
-	if len(lines) <= *index {
-		return errors.New("parseDesc: no description provided")
-	}
-	if strings.HasPrefix(lines[*index], "@") { // if no description
-		return nil
-	}
-	for *index < len(lines) && len(lines[*index]) > 0 {
-		c.Description += lines[*index] + "\n"
-		*index++
-	}
-	*index++ //skip empty line
-	return nil
-}
-
-func parseBefore(lines []string, index *int, c *checker) error {
-	if len(lines) <= *index || strings.TrimSpace(lines[*index]) != "@Before:" {
-		return errors.New("parseBefore: no @Before: section found")
-	}
-	*index++
-	for *index < len(lines) && len(lines[*index]) > 0 {
-		c.Before += lines[*index] + "\n"
-		*index++
-	}
-	*index++ //skip empty line
-	return nil
-}
-
-func parseAfter(lines []string, index *int, c *checker) error {
-	if len(lines) <= *index || strings.TrimSpace(lines[*index]) != "@After:" {
-		return errors.New("parseAfter: no @After: section found")
-	}
-	*index++
-	for *index < len(lines) && len(lines[*index]) > 0 {
-		c.After += lines[*index] + "\n"
-		*index++
-	}
-	*index++ //skip empty line
-	return nil
-}
-
-func parseNote(lines []string, index *int, c *checker) error {
-	if len(lines) <= *index {
-		return nil // No @Note: section
-	}
-	if strings.TrimSpace(lines[*index]) != "@Note:" {
-		return errors.New("parseNote: last section is not @Note")
-	}
-	*index++
-	for *index < len(lines) && len(lines[*index]) > 0 {
-		c.Note += lines[*index] + "\n"
-		*index++
-	}
-	return nil
-}
@@ -83,6 +83,7 @@ func TestCheckers(t *testing.T) {
 			ctx.SetPackageInfo(&pkgInfo.Info, pkgInfo.Pkg)
 
 			checkFiles(t, rule, ctx, prog, pkgPath)
+			checkSharedTraversal(t, prog, pkgPath)
 		})
 	}
 }
@@ -90,6 +91,18 @@ func TestCheckers(t *testing.T) {
 func checkFiles(t *testing.T, rule *Rule, ctx *Context, prog *loader.Program, pkgPath string) {
 	files := prog.Imported[pkgPath].Files
 
+	// Program checkers are run over the package and its external tests.
+	var programWarns map[*ast.File][]Warning
+	if rule.ProgramScope {
+		programWarns = make(map[*ast.File][]Warning)
+		for _, f := range files {
+			stripDirectives(f)
+		}
+		for _, r := range NewChecker(rule, ctx).CheckProgram(programPackages(prog)) {
+			programWarns[r.File] = append(programWarns[r.File], r.Warning)
+		}
+	}
+
 	for _, f := range files {
 		filename := getFilename(prog, f)
 		testFilename := filepath.Join("testdata", rule.Name(), filename)
@@ -97,9 +110,16 @@ func checkFiles(t *testing.T, rule *Rule, ctx *Context, prog *loader.Program, pk
 
 		stripDirectives(f)
 		warns := NewChecker(rule, ctx).Check(f)
+		if rule.ProgramScope {
+			warns = programWarns[f]
+		}
 
 		for _, warn := range warns {
-			line := ctx.FileSet().Position(warn.Node.Pos()).Line
+			line := ctx.FileSet().Position(warn.Pos).Line
+			if warn.Pos != warn.Node.Pos() || warn.End != warn.Node.End() {
+				t.Errorf("%s:%d: warning range doesn't match %T node range",
+					testFilename, line, warn.Node)
+			}
 
 			if w := goldenWarns.find(line, warn.Text); w != nil {
 				if w.matched {
@@ -117,6 +137,16 @@ func checkFiles(t *testing.T, rule *Rule, ctx *Context, prog *loader.Program, pk
 	}
 }
 
+// programPackages returns all packages loaded for prog arguments,
+// including external test packages.
+func programPackages(prog *loader.Program) []*Package {
+	var pkgs []*Package
+	for _, pkgInfo := range prog.InitialPackages() {
+		pkgs = append(pkgs, &Package{Pkg: pkgInfo.Pkg, Info: &pkgInfo.Info, Files: pkgInfo.Files})
+	}
+	return pkgs
+}
+
 // stripDirectives replaces "///" comments with empty single-line
 // comments, so the checkers that inspect comments see ordinary
 // comment groups (with extra newlines, but that's not important).
@@ -195,6 +225,10 @@ func newGoldenFile(t *testing.T, filename string) *goldenFile {
 	for i, l := range lines {
 		if m := warningDirectiveRE.FindStringSubmatch(l); m != nil {
 			pending = append(pending, &warning{text: m[1]})
+		} else if len(pending) != 0 && strings.TrimSpace(l) == "" {
+			// Blank lines are skipped, so directives can be separated
+			// from declarations, without becoming their doc comments.
+			continue
 		} else if len(pending) != 0 {
 			line := i + 1
 			if commentRE.MatchString(l) {
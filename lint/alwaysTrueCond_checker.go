@@ -0,0 +1,70 @@
+package lint
+
+//! Detects comparisons that are always true or always false.
+//
+// Such comparisons compare unsigned values with negative numbers,
+// values with constants out of their type range, like `b > 255` for a byte,
+// or literals with each other.
+//
+// Comparisons with named constants are not reported when
+// both operands are constants, since these are often used to
+// configure the code, like in `if debug == true`.
+//
+// @Before:
+// if uint(x) < 0 {
+// 	return errNegative
+// }
+//
+// @After:
+// if x < 0 {
+// 	return errNegative
+// }
+
+import (
+	"go/ast"
+
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+func init() {
+	addChecker(&alwaysTrueCondChecker{}, attrExperimental)
+}
+
+type alwaysTrueCondChecker struct {
+	checkerBase
+}
+
+func (c *alwaysTrueCondChecker) VisitLocalExpr(expr ast.Expr) {
+	cmp, ok := expr.(*ast.BinaryExpr)
+	if !ok || !isComparisonOp(cmp.Op) {
+		return
+	}
+	bothConst := c.ctx.isConst(cmp.X) && c.ctx.isConst(cmp.Y)
+	if bothConst && (!c.isLiteral(cmp.X) || !c.isLiteral(cmp.Y)) {
+		return
+	}
+	result, ok := c.ctx.foldCmp(cmp.X, cmp.Op, cmp.Y)
+	if !ok {
+		return
+	}
+	if bothConst {
+		c.warn(cmp, "constCompare", result)
+	} else {
+		c.warn(cmp, "valueRange", result)
+	}
+}
+
+// isLiteral reports whether x is a basic literal,
+// possibly negated and parenthesized.
+func (c *alwaysTrueCondChecker) isLiteral(x ast.Expr) bool {
+	x = astutil.Unparen(x)
+	if u, ok := x.(*ast.UnaryExpr); ok {
+		x = astutil.Unparen(u.X)
+	}
+	_, ok := x.(*ast.BasicLit)
+	return ok
+}
+
+func (c *alwaysTrueCondChecker) warn(cause *ast.BinaryExpr, code string, result bool) {
+	c.ctx.WarnWith(Warning{Node: cause, Code: code}, "`%s` is always %v", cause, result)
+}
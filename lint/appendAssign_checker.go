@@ -2,6 +2,13 @@ package lint
 
 //! Detects suspicious append result assignments.
 //
+// Also reports append calls that have their result discarded,
+// making the whole call a no-op.
+//
+// Slices that were assigned from each other inside the
+// function, like in `ys := xs[:n]`, are considered aliases
+// and their append assignments are not reported.
+//
 // @Before:
 // p.positives = append(p.negatives, x)
 // p.negatives = append(p.negatives, y)
@@ -26,22 +33,127 @@ func init() {
 
 type appendAssignChecker struct {
 	checkerBase
+
+	// aliases maps local variables to the expressions
+	// they were assigned from inside the current function.
+	aliases map[types.Object][]ast.Expr
+}
+
+func (c *appendAssignChecker) Init() {
+	c.aliases = make(map[types.Object][]ast.Expr)
+}
+
+func (c *appendAssignChecker) EnterFunc(fn *ast.FuncDecl) bool {
+	for obj := range c.aliases {
+		delete(c.aliases, obj)
+	}
+	return fn.Body != nil
 }
 
 func (c *appendAssignChecker) VisitStmt(stmt ast.Stmt) {
-	assign, ok := stmt.(*ast.AssignStmt)
-	if !ok || assign.Tok != token.ASSIGN || len(assign.Lhs) != len(assign.Rhs) {
+	switch stmt := stmt.(type) {
+	case *ast.ExprStmt:
+		c.checkDiscarded(stmt)
+	case *ast.AssignStmt:
+		if len(stmt.Lhs) == len(stmt.Rhs) {
+			for i := range stmt.Lhs {
+				c.recordAlias(stmt.Lhs[i], stmt.Rhs[i])
+			}
+		}
+		c.checkAssign(stmt)
+	case *ast.DeclStmt:
+		decl, ok := stmt.Decl.(*ast.GenDecl)
+		if !ok || decl.Tok != token.VAR {
+			return
+		}
+		for _, spec := range decl.Specs {
+			spec := spec.(*ast.ValueSpec)
+			if len(spec.Names) != len(spec.Values) {
+				continue
+			}
+			for i := range spec.Names {
+				c.recordAlias(spec.Names[i], spec.Values[i])
+			}
+		}
+	}
+}
+
+// recordAlias records x alias if it's a local variable
+// that is assigned from a simple slice expression y.
+func (c *appendAssignChecker) recordAlias(x, y ast.Expr) {
+	id, ok := x.(*ast.Ident)
+	if !ok || id.Name == "_" {
+		return
+	}
+	obj := c.ctx.typesInfo.ObjectOf(id)
+	if obj == nil {
+		return
+	}
+	y = sliceBase(y)
+	switch y.(type) {
+	case *ast.Ident, *ast.SelectorExpr, *ast.IndexExpr:
+		c.aliases[obj] = append(c.aliases[obj], y)
+	}
+}
+
+// isAlias reports whether x and y were assigned from each other.
+func (c *appendAssignChecker) isAlias(x, y ast.Expr) bool {
+	return c.aliasOf(x, y) || c.aliasOf(y, x)
+}
+
+// aliasOf reports whether x variable was assigned from y.
+func (c *appendAssignChecker) aliasOf(x, y ast.Expr) bool {
+	id, ok := x.(*ast.Ident)
+	if !ok {
+		return false
+	}
+	for _, alias := range c.aliases[c.ctx.typesInfo.ObjectOf(id)] {
+		if astequal.Expr(alias, y) {
+			return true
+		}
+	}
+	return false
+}
+
+// sliceBase returns sliced expression for x[low:high] and x itself otherwise.
+func sliceBase(x ast.Expr) ast.Expr {
+	x = astutil.Unparen(x)
+	if slice, ok := x.(*ast.SliceExpr); ok {
+		return astutil.Unparen(slice.X)
+	}
+	return x
+}
+
+func (c *appendAssignChecker) checkDiscarded(stmt *ast.ExprStmt) {
+	// Type checker rejects unused builtin append call results,
+	// so this can only be seen in a code with type errors.
+	// Still worthwhile to report, since such code can be
+	// checked by integrating applications.
+	call, ok := astutil.Unparen(stmt.X).(*ast.CallExpr)
+	if ok && c.isAppend(call) {
+		c.warnDiscarded(call)
+	}
+}
+
+func (c *appendAssignChecker) checkAssign(assign *ast.AssignStmt) {
+	if assign.Tok != token.ASSIGN || len(assign.Lhs) != len(assign.Rhs) {
 		return
 	}
 	for i, rhs := range assign.Rhs {
 		call, ok := rhs.(*ast.CallExpr)
-		if !ok || qualifiedName(call.Fun) != "append" {
+		if !ok || !c.isAppend(call) {
 			continue
 		}
 		c.checkAppend(assign.Lhs[i], call)
 	}
 }
 
+// isAppend reports whether call is a builtin append call.
+// Locally re-defined append functions are not reported.
+func (c *appendAssignChecker) isAppend(call *ast.CallExpr) bool {
+	return c.ctx.isBuiltinCall(call, "append")
+}
+
 func (c *appendAssignChecker) checkAppend(x ast.Expr, call *ast.CallExpr) {
 	if call.Ellipsis != token.NoPos {
 		// Try to detect `xs = append(ys, xs...)` idiom.
@@ -56,6 +168,10 @@ func (c *appendAssignChecker) checkAppend(x ast.Expr, call *ast.CallExpr) {
 		}
 	}
 
+	if c.isAlias(x, sliceBase(call.Args[0])) {
+		return
+	}
+
 	switch x := x.(type) {
 	case *ast.Ident:
 		if x.Name == "_" {
@@ -92,5 +208,10 @@ func (c *appendAssignChecker) matchSlices(cause ast.Node, x, y ast.Expr) {
 }
 
 func (c *appendAssignChecker) warn(cause ast.Node) {
-	c.ctx.Warn(cause, "append result not assigned to the same slice")
+	// Assigning append result to another slice is sometimes intended.
+	c.ctx.WarnWithConfidence(ConfidenceMedium, cause, "append result not assigned to the same slice")
+}
+
+func (c *appendAssignChecker) warnDiscarded(cause ast.Node) {
+	c.ctx.Warn(cause, "append result is discarded, making the call a no-op")
 }
@@ -0,0 +1,65 @@
+package lint
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestAppendAssignDiscarded checks discarded append results,
+// which are rejected by the type checker, so they can't be
+// a part of the appendAssign testdata.
+func TestAppendAssignDiscarded(t *testing.T) {
+	src := `package p
+
+func f(xs []int) {
+	append(xs, 1) // reported
+	(append(xs, 2, 3)) // reported
+	xs = append(xs, 4)
+}
+
+func g(xs []int) {
+	append := func(xs []int, vals ...int) []int { return xs }
+	append(xs, 1)
+}
+`
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	info := &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+		Defs:  make(map[*ast.Ident]types.Object),
+		Uses:  make(map[*ast.Ident]types.Object),
+	}
+	var typeErrors int
+	conf := types.Config{Error: func(error) { typeErrors++ }}
+	pkg, _ := conf.Check("p", fset, []*ast.File{f}, info)
+	if typeErrors != 2 {
+		t.Fatalf("have %d type errors, want 2", typeErrors)
+	}
+	ctx := NewContext(fset, sizes)
+	ctx.SetPackageInfo(info, pkg)
+
+	var have []int
+	for _, w := range NewChecker(findRule("appendAssign"), ctx).Check(f) {
+		if w.Text != "append result is discarded, making the call a no-op" {
+			t.Errorf("unexpected warning: %s", w.Text)
+		}
+		have = append(have, fset.Position(w.Node.Pos()).Line)
+	}
+	var want []int
+	for i, line := range strings.Split(src, "\n") {
+		if strings.HasSuffix(line, "// reported") {
+			want = append(want, i+1)
+		}
+	}
+	if !reflect.DeepEqual(have, want) {
+		t.Errorf("warnings lines:\nhave: %v\nwant: %v", have, want)
+	}
+}
@@ -17,7 +17,7 @@ import (
 )
 
 func init() {
-	addChecker(&appendCombineChecker{})
+	addChecker(&appendCombineChecker{}, attrPerformance, attrSkipTests)
 }
 
 type appendCombineChecker struct {
@@ -0,0 +1,85 @@
+package lint
+
+//! Detects suspicious arguments order.
+//
+// Reports calls to asymmetric strings and bytes functions
+// where a string literal is passed as a haystack, while the needle is not a constant.
+// Named constants are not reported, as they are often a haystack indeed.
+//
+// @Before:
+// strings.HasPrefix("#", userpass)
+//
+// @After:
+// strings.HasPrefix(userpass, "#")
+
+import (
+	"go/ast"
+	"go/token"
+
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+func init() {
+	addChecker(&argOrderChecker{}, attrExperimental)
+}
+
+type argOrderChecker struct {
+	checkerBase
+}
+
+// argOrderFuncs are functions that take haystack as the
+// first argument and the needle as the second.
+var argOrderFuncs = map[string]bool{
+	"strings.HasPrefix":  true,
+	"strings.HasSuffix":  true,
+	"strings.Contains":   true,
+	"strings.TrimPrefix": true,
+	"strings.TrimSuffix": true,
+	"strings.Split":      true,
+
+	"bytes.HasPrefix":  true,
+	"bytes.HasSuffix":  true,
+	"bytes.Contains":   true,
+	"bytes.TrimPrefix": true,
+	"bytes.TrimSuffix": true,
+	"bytes.Split":      true,
+}
+
+func (c *argOrderChecker) VisitExpr(expr ast.Expr) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || len(call.Args) != 2 || !argOrderFuncs[c.ctx.calleeName(call)] {
+		return
+	}
+	x, y := call.Args[0], call.Args[1]
+	if c.isLiteral(x) && !c.isConstant(y) {
+		c.warn(call)
+	}
+}
+
+// isLiteral reports whether x is a string literal
+// or a conversion of a string literal, like []byte("abc").
+func (c *argOrderChecker) isLiteral(x ast.Expr) bool {
+	if conv, ok := astutil.Unparen(x).(*ast.CallExpr); ok && len(conv.Args) == 1 &&
+		c.ctx.typesInfo.Types[conv.Fun].IsType() {
+		x = conv.Args[0]
+	}
+	lit, ok := astutil.Unparen(x).(*ast.BasicLit)
+	return ok && lit.Kind == token.STRING
+}
+
+// isConstant reports whether x is a constant expression
+// or a conversion of a constant, like []byte("abc").
+func (c *argOrderChecker) isConstant(x ast.Expr) bool {
+	if c.ctx.typesInfo.Types[x].Value != nil {
+		return true
+	}
+	conv, ok := x.(*ast.CallExpr)
+	return ok && len(conv.Args) == 1 &&
+		c.ctx.typesInfo.Types[conv.Fun].IsType() &&
+		c.ctx.typesInfo.Types[conv.Args[0]].Value != nil
+}
+
+func (c *argOrderChecker) warn(call *ast.CallExpr) {
+	// No fix is suggested, as the arguments order may be correct.
+	c.ctx.Warn(call, "%s arguments order looks reversed", call.Fun)
+}
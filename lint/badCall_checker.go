@@ -0,0 +1,133 @@
+package lint
+
+//! Detects suspicious function calls.
+//
+// Reports well-known standard library calls with arguments that make
+// them no-op or contradict the function intent, like strings.Replace
+// with zero n, single argument append, empty filepath.Join elements
+// and suffix-like TrimRight cutsets.
+//
+// @Before:
+// strings.Replace(s, from, to, 0)
+// strings.TrimRight(filename, ".go")
+//
+// @After:
+// strings.Replace(s, from, to, -1)
+// strings.TrimSuffix(filename, ".go")
+
+import (
+	"go/ast"
+	"go/constant"
+	"strconv"
+	"unicode"
+
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+func init() {
+	addChecker(&badCallChecker{}, attrExperimental)
+}
+
+type badCallChecker struct {
+	checkerBase
+
+	// rules maps called function full name to the function that
+	// checks its call and returns the warning message.
+	// Empty message means that call is accepted.
+	rules map[string]func(call *ast.CallExpr) string
+}
+
+func (c *badCallChecker) Init() {
+	zeroN := func(arg int) func(call *ast.CallExpr) string {
+		return func(call *ast.CallExpr) string {
+			if n, ok := c.intConst(call.Args[arg]); ok && n == 0 {
+				return "suspicious arg 0, probably meant -1"
+			}
+			return ""
+		}
+	}
+	emptyElem := func(call *ast.CallExpr) string {
+		for _, arg := range call.Args {
+			if s, ok := c.stringConst(arg); ok && s == "" {
+				return "empty string element is ignored by " + qualifiedName(astutil.Unparen(call.Fun))
+			}
+		}
+		return ""
+	}
+	suffixCutset := func(suggestion string) func(call *ast.CallExpr) string {
+		return func(call *ast.CallExpr) string {
+			if s, ok := c.stringConst(call.Args[1]); ok && isSuffixLike(s) {
+				return "cutset " + strconv.Quote(s) + " looks like a word; consider " + suggestion
+			}
+			return ""
+		}
+	}
+
+	c.rules = map[string]func(call *ast.CallExpr) string{
+		"strings.Replace": zeroN(3),
+		"strings.SplitN":  zeroN(2),
+		"bytes.Replace":   zeroN(3),
+		"bytes.SplitN":    zeroN(2),
+
+		"path.Join":          emptyElem,
+		"path/filepath.Join": emptyElem,
+
+		"strings.TrimRight": suffixCutset("strings.TrimSuffix"),
+		"strings.TrimLeft":  suffixCutset("strings.TrimPrefix"),
+		"bytes.TrimRight":   suffixCutset("bytes.TrimSuffix"),
+		"bytes.TrimLeft":    suffixCutset("bytes.TrimPrefix"),
+	}
+}
+
+func (c *badCallChecker) VisitLocalExpr(expr ast.Expr) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || call.Ellipsis.IsValid() {
+		return
+	}
+	if c.ctx.isBuiltinCall(call, "append") {
+		if len(call.Args) == 1 {
+			c.ctx.Warn(call, "no-op append call, probably missing arguments")
+		}
+		return
+	}
+	rule, ok := c.rules[c.ctx.calleeName(call)]
+	if !ok {
+		return
+	}
+	if msg := rule(call); msg != "" {
+		c.ctx.Warn(call, "%s", msg)
+	}
+}
+
+func (c *badCallChecker) intConst(x ast.Expr) (int64, bool) {
+	tv := c.ctx.typesInfo.Types[x]
+	if tv.Value == nil || tv.Value.Kind() != constant.Int {
+		return 0, false
+	}
+	return constant.Int64Val(tv.Value)
+}
+
+func (c *badCallChecker) stringConst(x ast.Expr) (string, bool) {
+	tv := c.ctx.typesInfo.Types[x]
+	if tv.Value == nil || tv.Value.Kind() != constant.String {
+		return "", false
+	}
+	return constant.StringVal(tv.Value), true
+}
+
+// isSuffixLike reports whether cutset looks like a prefix or suffix
+// rather than a set of characters: it contains letters or repeated chars.
+func isSuffixLike(cutset string) bool {
+	seen := make(map[rune]bool)
+	hasLetter := false
+	for _, ch := range cutset {
+		if seen[ch] {
+			return true
+		}
+		seen[ch] = true
+		if unicode.IsLetter(ch) {
+			hasLetter = true
+		}
+	}
+	return hasLetter && len(seen) > 1
+}
@@ -0,0 +1,138 @@
+package lint
+
+//! Detects misspelled and misplaced comment directives.
+//
+// Misspelled //go: directives, like //go:genrate, and directives
+// with a space after the slashes, like "// go:generate", are silently
+// ignored by the Go tools. So are build constraints that are
+// placed after the package clause.
+//
+// @Before:
+// //go:genrate stringer -type=Kind
+//
+// @After:
+// //go:generate stringer -type=Kind
+
+import (
+	"go/ast"
+	"strings"
+)
+
+func init() {
+	addChecker(&badDirectiveChecker{}, attrExperimental, attrSyntaxOnly)
+}
+
+// goDirectives are names of the //go: directives that are known to the Go tools.
+var goDirectives = []string{
+	"build", "generate", "embed", "linkname", "noinline", "nosplit",
+	"noescape", "norace", "nocheckptr", "nointerface", "uintptrescapes",
+	"uintptrkeepalive", "systemstack", "nowritebarrier", "nowritebarrierrec",
+	"yeswritebarrierrec", "registerparams", "notinheap", "wasmimport",
+	"wasmexport", "debug", "cgo_import_dynamic", "cgo_import_static",
+	"cgo_export_dynamic", "cgo_export_static", "cgo_dynamic_linker",
+	"cgo_ldflag", "cgo_unsafe_args",
+}
+
+type badDirectiveChecker struct {
+	checkerBase
+}
+
+func (c *badDirectiveChecker) VisitComment(cg *ast.CommentGroup) {
+	for _, comment := range cg.List {
+		if c.ctx.fileSet.Position(comment.Pos()).Column != 1 {
+			continue // Directives are only recognized at the line start
+		}
+		d, ok := parseDirective(comment)
+		if !ok {
+			c.checkSpace(cg, comment)
+			continue
+		}
+		switch {
+		case d.Tool == "go" && d.Name == "build" || d.Tool == "" && d.Name == "+build":
+			if comment.Pos() > c.ctx.file.Package {
+				c.warnMisplacedBuild(cg)
+			}
+		case d.Tool == "go":
+			if name := closestGoDirective(d.Name); name != d.Name && name != "" {
+				c.warnTypo(cg, d.Name, name)
+			}
+		}
+	}
+}
+
+// checkSpace reports "// go:name" comments, where name is a known directive.
+func (c *badDirectiveChecker) checkSpace(cg *ast.CommentGroup, comment *ast.Comment) {
+	text := strings.TrimPrefix(comment.Text, "// ")
+	if !strings.HasPrefix(text, "go:") {
+		return
+	}
+	name := strings.TrimPrefix(text, "go:")
+	if end := strings.IndexAny(name, " \t"); end != -1 {
+		name = name[:end]
+	}
+	for _, known := range goDirectives {
+		if name == known {
+			c.warnSpace(cg, name)
+			return
+		}
+	}
+}
+
+// closestGoDirective returns a known //go: directive name that is the
+// closest to name, but differs by no more than 2 edits.
+// Returns empty string if there is no such directive.
+func closestGoDirective(name string) string {
+	best, bestDist := "", 3
+	for _, known := range goDirectives {
+		if dist := editDistance(name, known); dist < bestDist && len(known) > dist*2 {
+			best, bestDist = known, dist
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min3(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+func (c *badDirectiveChecker) warnTypo(cause *ast.CommentGroup, name, suggestion string) {
+	c.ctx.WarnWith(Warning{Node: cause, Code: "typo"},
+		"//go:%s is not a known directive, did you mean //go:%s?", name, suggestion)
+}
+
+func (c *badDirectiveChecker) warnSpace(cause *ast.CommentGroup, name string) {
+	c.ctx.WarnWith(Warning{Node: cause, Code: "space"},
+		"remove space after // to make go:%s a directive", name)
+}
+
+func (c *badDirectiveChecker) warnMisplacedBuild(cause *ast.CommentGroup) {
+	c.ctx.WarnWith(Warning{Node: cause, Code: "misplacedBuild"},
+		"build constraint after the package clause is ignored")
+}
@@ -0,0 +1,161 @@
+package lint
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"io"
+	"sort"
+	"sync"
+	"unicode"
+)
+
+// Baseline is a set of known warnings, usually the warnings that were
+// reported for the code base when the linter was introduced.
+// It's used to report only the warnings that are not in the baseline.
+//
+// Warnings are identified by checker name, file, text and a fingerprint
+// of the source code lines they're reported for, so the baseline
+// remains valid when the code around warnings is edited.
+//
+// Baseline is safe for concurrent use.
+type Baseline struct {
+	mu sync.Mutex
+
+	// counts maps warning key to the number of such warnings.
+	counts map[baselineKey]int
+}
+
+// baselineKey identifies a warning independently of its position.
+type baselineKey struct {
+	Checker string `json:"checker"`
+	File    string `json:"file"`
+	Text    string `json:"text"`
+
+	// Fingerprint is a hash of warning source code lines.
+	Fingerprint string `json:"fingerprint"`
+}
+
+// baselineEntry is a Baseline file record.
+type baselineEntry struct {
+	baselineKey
+	Count int `json:"count"`
+}
+
+// NewBaseline returns new empty baseline.
+func NewBaseline() *Baseline {
+	return &Baseline{counts: make(map[baselineKey]int)}
+}
+
+// LoadBaseline reads baseline that was previously written by Baseline.Save.
+func LoadBaseline(r io.Reader) (*Baseline, error) {
+	var entries []baselineEntry
+	if err := json.NewDecoder(r).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("load baseline: %v", err)
+	}
+	b := NewBaseline()
+	for _, e := range entries {
+		b.counts[e.baselineKey] += e.Count
+	}
+	return b, nil
+}
+
+// Save writes baseline contents to w in format understood by LoadBaseline.
+// Entries are sorted, so the output is suitable for version control.
+func (b *Baseline) Save(w io.Writer) error {
+	b.mu.Lock()
+	entries := make([]baselineEntry, 0, len(b.counts))
+	for key, count := range b.counts {
+		entries = append(entries, baselineEntry{baselineKey: key, Count: count})
+	}
+	b.mu.Unlock()
+
+	sort.Slice(entries, func(i, j int) bool {
+		x, y := entries[i], entries[j]
+		switch {
+		case x.File != y.File:
+			return x.File < y.File
+		case x.Checker != y.Checker:
+			return x.Checker < y.Checker
+		case x.Text != y.Text:
+			return x.Text < y.Text
+		default:
+			return x.Fingerprint < y.Fingerprint
+		}
+	})
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+	return enc.Encode(entries)
+}
+
+// Add records warning w of the specified checker in the baseline.
+//
+// file is a name that identifies the checked file across runs,
+// like a path relative to the project root.
+// src is a source code of the file the warning belongs to.
+func (b *Baseline) Add(fset *token.FileSet, file string, src []byte, checker string, w Warning) {
+	key := newBaselineKey(fset, file, src, checker, w)
+	b.mu.Lock()
+	b.counts[key]++
+	b.mu.Unlock()
+}
+
+// Match reports whether warning w is in the baseline.
+// Arguments have the same meaning as for Add.
+//
+// Every baseline record matches only once, so if the number of
+// identical warnings increases, the new ones are not matched.
+func (b *Baseline) Match(fset *token.FileSet, file string, src []byte, checker string, w Warning) bool {
+	key := newBaselineKey(fset, file, src, checker, w)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.counts[key] == 0 {
+		return false
+	}
+	b.counts[key]--
+	return true
+}
+
+func newBaselineKey(fset *token.FileSet, file string, src []byte, checker string, w Warning) baselineKey {
+	return baselineKey{
+		Checker:     checker,
+		File:        file,
+		Text:        w.Text,
+		Fingerprint: linesFingerprint(fset, src, w),
+	}
+}
+
+// linesFingerprint returns a hash of the source lines spanned by the warning node.
+// Whitespace is ignored, so formatting changes keep the fingerprint intact.
+// Returns empty string if src is not the warning file source.
+func linesFingerprint(fset *token.FileSet, src []byte, w Warning) string {
+	tf := fset.File(w.Pos)
+	start := tf.Offset(w.Pos)
+	end := tf.Offset(w.End)
+	if end > len(src) || start > end {
+		// Source doesn't match the file, use the warning text only.
+		return ""
+	}
+	if i := bytes.LastIndexByte(src[:start], '\n'); i != -1 {
+		start = i + 1
+	} else {
+		start = 0
+	}
+	if i := bytes.IndexByte(src[end:], '\n'); i != -1 {
+		end += i
+	} else {
+		end = len(src)
+	}
+
+	h := sha256.New()
+	h.Write(bytes.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, src[start:end]))
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
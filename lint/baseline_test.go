@@ -0,0 +1,81 @@
+package lint
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+func TestBaseline(t *testing.T) {
+	// warnings returns a warning for every call expression in src.
+	warnings := func(src string) (*token.FileSet, []Warning) {
+		fset := token.NewFileSet()
+		f, err := parser.ParseFile(fset, "a.go", src, 0)
+		if err != nil {
+			t.Fatalf("parse: %v", err)
+		}
+		var list []Warning
+		ast.Inspect(f, func(n ast.Node) bool {
+			if call, ok := n.(*ast.CallExpr); ok {
+				list = append(list, Warning{Node: call, Pos: call.Pos(), End: call.End(), Text: "call"})
+			}
+			return true
+		})
+		return fset, list
+	}
+
+	const src = `package p
+func f() {
+	g(1)
+	g(2)
+	g(2)
+}`
+	fset, list := warnings(src)
+	b := NewBaseline()
+	for _, w := range list {
+		b.Add(fset, "p/a.go", []byte(src), "testChecker", w)
+	}
+	var buf bytes.Buffer
+	if err := b.Save(&buf); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	tests := []struct {
+		src  string
+		want []bool
+	}{
+		{src, []bool{true, true, true}},
+
+		// Lines are moved and reformatted.
+		{"package p\n\n// Doc.\nfunc f() {\n\tg(2)\n\tg( 1 )\n}", []bool{true, true}},
+
+		// Third identical warning is new.
+		{"package p\nfunc f() {\n\tg(2)\n\tg(2)\n\tg(2)\n}", []bool{true, true, false}},
+
+		// Changed line produces a new warning.
+		{"package p\nfunc f() {\n\tg(3)\n}", []bool{false}},
+	}
+
+	for _, test := range tests {
+		b, err := LoadBaseline(bytes.NewReader(buf.Bytes()))
+		if err != nil {
+			t.Fatalf("load: %v", err)
+		}
+		fset, list := warnings(test.src)
+		for i, w := range list {
+			have := b.Match(fset, "p/a.go", []byte(test.src), "testChecker", w)
+			if have != test.want[i] {
+				t.Errorf("%q: warning %d: have match=%v, want %v", test.src, i, have, test.want[i])
+			}
+		}
+		// Warnings of other files and checkers are never matched.
+		if b.Match(fset, "p/b.go", []byte(test.src), "testChecker", list[0]) {
+			t.Errorf("%q: matched warning of another file", test.src)
+		}
+		if b.Match(fset, "p/a.go", []byte(test.src), "otherChecker", list[0]) {
+			t.Errorf("%q: matched warning of another checker", test.src)
+		}
+	}
+}
@@ -2,20 +2,25 @@ package lint
 
 //! Detects bool expressions that can be simplified for the sake of readability.
 //
+// Checker params:
+//	pushNegations - if "true", negations are pushed inside every && and || chain, like in `!(a && b)` => `!a || !b`
+//
 // @Before:
 // a := !(elapsed >= expectElapsedMin)
 // b := !(x) == !(y)
+// c := ok == false
 //
 // @After:
 // a := elapsed < expectElapsedMin
-// b := (x) == (y)
+// b := x == y
+// c := !ok
 
 import (
 	"go/ast"
 	"go/token"
+	"go/types"
 
 	"github.com/go-toolsmith/astcopy"
-	"github.com/go-toolsmith/astequal"
 	"golang.org/x/tools/go/ast/astutil"
 )
 
@@ -28,6 +33,12 @@ type boolExprSimplifyChecker struct {
 
 	cause ast.Node // Last warning cause
 
+	// orig is an expression which copy is being simplified.
+	// Types of the copy operands are taken from orig.
+	orig ast.Expr
+
+	pushNegations bool
+
 	// nil sentinels are used as a replacements for
 	// bare nil to avoid a need to perform nil checks
 	// when doing type-assertion that may return nil.
@@ -35,85 +46,299 @@ type boolExprSimplifyChecker struct {
 	nilBinaryExpr *ast.BinaryExpr
 }
 
+func (c *boolExprSimplifyChecker) Params() []CheckerParam {
+	return []CheckerParam{
+		{Name: "pushNegations", Kind: ParamBool, Default: "false"},
+	}
+}
+
 func (c *boolExprSimplifyChecker) Init() {
 	c.nilUnaryExpr = &ast.UnaryExpr{}
 	c.nilBinaryExpr = &ast.BinaryExpr{}
+	c.pushNegations = c.ctx.BoolParam("pushNegations")
 }
 
 func (c *boolExprSimplifyChecker) EnterChilds(x ast.Node) bool { return c.cause != x }
 
 func (c *boolExprSimplifyChecker) VisitExpr(x ast.Expr) {
-	// TODO: avoid eager copy?
-	// Can't be stable until wasted copying is fixed.
-	y := c.simplifyBool(astcopy.Expr(x))
-	if !astequal.Expr(x, y) {
-		c.warn(x, y)
+	// Only expressions that can be rewritten themselves are reported,
+	// so the fix doesn't reprint the enclosing code.
+	// Simplifiable subexpressions are visited after x.
+	//
+	// Most expressions can't be simplified, so x is
+	// only copied and rewritten if some rewrite applies.
+	code := c.rewrite(x, nil)
+	if code == "" {
+		return
 	}
+	c.warn(code, x, c.simplify(x))
 }
 
+// simplify returns simplified copy of x.
+func (c *boolExprSimplifyChecker) simplify(x ast.Expr) ast.Expr {
+	c.orig = x
+	defer func() { c.orig = nil }()
+	return c.removeAtomParens(c.simplifyBool(astcopy.Expr(x)))
+}
+
+// simplifyBool applies all simplifications to x and its subexpressions.
+// Function literal bodies are left as is.
 func (c *boolExprSimplifyChecker) simplifyBool(x ast.Expr) ast.Expr {
-	return astutil.Apply(x, nil, func(cur *astutil.Cursor) bool {
-		return c.doubleNegation(cur) ||
-			c.negatedEquals(cur) ||
-			c.invertComparison(cur) ||
-			true
+	return astutil.Apply(x, func(cur *astutil.Cursor) bool {
+		_, ok := cur.Node().(*ast.FuncLit)
+		return !ok
+	}, func(cur *astutil.Cursor) bool {
+		// Rewritten node may match other simplification,
+		// like in `!(x) == !(true)` => `x == true` => `x`.
+		n := cur.Node()
+		replace := func(x ast.Node) {
+			cur.Replace(x)
+			n = x
+		}
+		for c.rewrite(n, replace) != "" {
+		}
+		return true
 	}).(ast.Expr)
 }
 
-func (c *boolExprSimplifyChecker) doubleNegation(cur *astutil.Cursor) bool {
-	neg1 := c.unaryNot(cur.Node())
+// rewrite applies the first simplification that matches n.
+// Returns the simplification name or empty string if there was no match.
+//
+// Every simplification makes n shorter or removes negations,
+// so repeated rewrites of the same node always stop.
+//
+// Simplifications call replace to substitute n or modify n in place.
+// If replace is nil, n is only matched.
+func (c *boolExprSimplifyChecker) rewrite(n ast.Node, replace func(ast.Node)) string {
+	switch {
+	case c.doubleNegation(n, replace):
+		return "doubleNegation"
+	case c.negatedEquals(n, replace):
+		return "negatedEquals"
+	case c.invertComparison(n, replace):
+		return "invertComparison"
+	case c.boolConstCompare(n, replace):
+		return "boolConstCompare"
+	case c.deMorgan(n, replace):
+		return "deMorgan"
+	default:
+		return ""
+	}
+}
+
+func (c *boolExprSimplifyChecker) doubleNegation(n ast.Node, replace func(ast.Node)) bool {
+	neg1 := c.unaryNot(n)
 	neg2 := c.unaryNot(astutil.Unparen(neg1.X))
-	if neg1 != c.nilUnaryExpr && neg2 != c.nilUnaryExpr {
-		cur.Replace(astutil.Unparen(neg2.X))
-		return true
+	if neg1 == c.nilUnaryExpr || neg2 == c.nilUnaryExpr {
+		return false
 	}
-	return false
+	if replace != nil {
+		replace(astutil.Unparen(neg2.X))
+	}
+	return true
 }
 
-func (c *boolExprSimplifyChecker) negatedEquals(cur *astutil.Cursor) bool {
-	x, ok := cur.Node().(*ast.BinaryExpr)
+func (c *boolExprSimplifyChecker) negatedEquals(n ast.Node, replace func(ast.Node)) bool {
+	x, ok := n.(*ast.BinaryExpr)
 	if !ok || x.Op != token.EQL {
 		return false
 	}
 	neg1 := c.unaryNot(x.X)
 	neg2 := c.unaryNot(x.Y)
-	if neg1 != c.nilUnaryExpr && neg2 != c.nilUnaryExpr {
+	if neg1 == c.nilUnaryExpr || neg2 == c.nilUnaryExpr {
+		return false
+	}
+	if replace != nil {
 		x.X = neg1.X
 		x.Y = neg2.X
-		return true
 	}
-	return false
+	return true
 }
 
-func (c *boolExprSimplifyChecker) invertComparison(cur *astutil.Cursor) bool {
-	neg := c.unaryNot(cur.Node())
+func (c *boolExprSimplifyChecker) invertComparison(n ast.Node, replace func(ast.Node)) bool {
+	neg := c.unaryNot(n)
 	cmp := c.binaryExpr(astutil.Unparen(neg.X))
 	if neg == c.nilUnaryExpr || cmp == c.nilBinaryExpr {
 		return false
 	}
 
-	// Replace operator to its negated form.
-	switch cmp.Op {
-	case token.EQL:
-		cmp.Op = token.NEQ
-	case token.NEQ:
-		cmp.Op = token.EQL
-	case token.LSS:
-		cmp.Op = token.GEQ
-	case token.GTR:
-		cmp.Op = token.LEQ
-	case token.LEQ:
-		cmp.Op = token.GTR
-	case token.GEQ:
-		cmp.Op = token.LSS
+	op, ok := c.invertedCmpOp(cmp)
+	if !ok {
+		return false
+	}
+	if replace != nil {
+		cmp.Op = op
+		replace(cmp)
+	}
+	return true
+}
 
-	default:
+// boolConstCompare removes comparisons with true and false,
+// like in `x == true` => `x` and `x == false` => `!x`.
+//
+// true and false are matched by name, as rewrites are applied
+// to the expression copy that has no types info.
+// Shadowing these names is pathological enough to ignore it.
+func (c *boolExprSimplifyChecker) boolConstCompare(n ast.Node, replace func(ast.Node)) bool {
+	x, ok := n.(*ast.BinaryExpr)
+	if !ok || (x.Op != token.EQL && x.Op != token.NEQ) {
+		return false
+	}
+	operand, value := x.X, x.Y
+	if c.isBoolConst(operand) {
+		operand, value = value, operand
+	}
+	// Comparisons of two constants are left as is.
+	if !c.isBoolConst(value) || c.isBoolConst(operand) {
+		return false
+	}
+	if replace != nil {
+		isTrue := astutil.Unparen(value).(*ast.Ident).Name == "true"
+		if isTrue == (x.Op == token.EQL) {
+			replace(operand)
+		} else {
+			replace(c.negate(operand, token.ILLEGAL))
+		}
+	}
+	return true
+}
+
+// isBoolConst reports whether x is a true or false identifier,
+// possibly parenthesized.
+func (c *boolExprSimplifyChecker) isBoolConst(x ast.Expr) bool {
+	id, ok := astutil.Unparen(x).(*ast.Ident)
+	return ok && (id.Name == "true" || id.Name == "false")
+}
+
+// deMorgan pushes negation inside && and || operators chain,
+// like in `!(a == b && c)` => `a != b || !c`.
+//
+// Unless pushNegations param is set, rewrite is only done if at least
+// one of the chain operands can be inverted without adding a new negation.
+func (c *boolExprSimplifyChecker) deMorgan(n ast.Node, replace func(ast.Node)) bool {
+	neg := c.unaryNot(n)
+	chain := c.binaryExpr(astutil.Unparen(neg.X))
+	if neg == c.nilUnaryExpr || (chain.Op != token.LAND && chain.Op != token.LOR) {
+		return false
+	}
+	if !c.pushNegations && !c.hasInvertibleOperand(chain, chain.Op) {
 		return false
 	}
-	cur.Replace(cmp)
+	// Parenthesis are not inserted as printer adds them
+	// where operators precedence requires it.
+	if replace != nil {
+		replace(c.negate(chain, chain.Op))
+	}
 	return true
 }
 
+// hasInvertibleOperand reports whether x chain of op operators
+// has at least one negation or comparison operand.
+func (c *boolExprSimplifyChecker) hasInvertibleOperand(x ast.Expr, op token.Token) bool {
+	if bin := c.binaryExpr(x); bin != c.nilBinaryExpr && bin.Op == op {
+		return c.hasInvertibleOperand(bin.X, op) || c.hasInvertibleOperand(bin.Y, op)
+	}
+	x = astutil.Unparen(x)
+	if c.unaryNot(x) != c.nilUnaryExpr {
+		return true
+	}
+	_, ok := c.invertedCmpOp(c.binaryExpr(x))
+	return ok
+}
+
+// invertedCmpOp returns negated form of cmp operator.
+// Returns false if cmp is not a comparison or it can't be inverted.
+//
+// Ordered comparisons of floats are false if any operand is NaN,
+// so `!(x < y)` is not the same as `x >= y` for them.
+func (c *boolExprSimplifyChecker) invertedCmpOp(cmp *ast.BinaryExpr) (token.Token, bool) {
+	op, ok := invertedCmpOp(cmp.Op)
+	if !ok || cmp.Op == token.EQL || cmp.Op == token.NEQ {
+		return op, ok
+	}
+	return op, !c.isFloatCmp(cmp)
+}
+
+// isFloatCmp reports whether cmp compares floats or complex numbers.
+//
+// If cmp is a part of the copy that has no types info,
+// the original comparison at the same position is used.
+func (c *boolExprSimplifyChecker) isFloatCmp(cmp *ast.BinaryExpr) bool {
+	typ := c.ctx.typesInfo.TypeOf(cmp.X)
+	if typ == nil && c.orig != nil && cmp.OpPos.IsValid() {
+		ast.Inspect(c.orig, func(n ast.Node) bool {
+			if x, ok := n.(*ast.BinaryExpr); ok && x.OpPos == cmp.OpPos {
+				typ = c.ctx.typesInfo.TypeOf(x.X)
+			}
+			return typ == nil
+		})
+	}
+	if typ == nil {
+		return false
+	}
+	basic, ok := typ.Underlying().(*types.Basic)
+	return ok && basic.Info()&(types.IsFloat|types.IsComplex) != 0
+}
+
+// negate returns negated form of x that is an operand of op operators chain.
+// Nested op chains are negated by De Morgan's laws.
+// token.ILLEGAL op can be used to negate x that is not a chain operand.
+func (c *boolExprSimplifyChecker) negate(x ast.Expr, op token.Token) ast.Expr {
+	if bin := c.binaryExpr(x); bin != c.nilBinaryExpr && bin.Op == op {
+		dual := token.LAND
+		if op == token.LAND {
+			dual = token.LOR
+		}
+		return &ast.BinaryExpr{
+			X:  c.negate(bin.X, op),
+			Op: dual,
+			Y:  c.negate(bin.Y, op),
+		}
+	}
+	if neg := c.unaryNot(astutil.Unparen(x)); neg != c.nilUnaryExpr {
+		return astutil.Unparen(neg.X) // Avoid double negation
+	}
+	if cmp := c.binaryExpr(astutil.Unparen(x)); cmp != c.nilBinaryExpr {
+		if inverted, ok := c.invertedCmpOp(cmp); ok {
+			return &ast.BinaryExpr{X: cmp.X, OpPos: cmp.OpPos, Op: inverted, Y: cmp.Y}
+		}
+	}
+	return &ast.UnaryExpr{Op: token.NOT, X: x}
+}
+
+// removeAtomParens removes redundant parenthesis around atomic operands.
+//
+// Atomic operands bind tighter than any operator, so enclosing
+// context precedence is never affected by such parenthesis removal.
+// Other parenthesis are preserved as they may be load-bearing,
+// like in `(a || b) && c`.
+//
+// Only applied to already simplified expressions, so parenthesis
+// alone are never reported.
+func (c *boolExprSimplifyChecker) removeAtomParens(x ast.Expr) ast.Expr {
+	return astutil.Apply(x, nil, func(cur *astutil.Cursor) bool {
+		paren, ok := cur.Node().(*ast.ParenExpr)
+		if ok && c.isAtom(paren.X) {
+			cur.Replace(paren.X)
+		}
+		return true
+	}).(ast.Expr)
+}
+
+// isAtom reports whether x is an identifier, a basic literal
+// or a selector expression with identifier base.
+func (c *boolExprSimplifyChecker) isAtom(x ast.Expr) bool {
+	switch x := x.(type) {
+	case *ast.Ident, *ast.BasicLit:
+		return true
+	case *ast.SelectorExpr:
+		_, ok := x.X.(*ast.Ident)
+		return ok
+	default:
+		return false
+	}
+}
+
 // binaryExpr coerces x into binary expr if possible,
 // otherwise returns c.nilBinaryExpr.
 func (c *boolExprSimplifyChecker) binaryExpr(x ast.Node) *ast.BinaryExpr {
@@ -134,7 +359,58 @@ func (c *boolExprSimplifyChecker) unaryNot(x ast.Node) *ast.UnaryExpr {
 	return neg
 }
 
-func (c *boolExprSimplifyChecker) warn(cause, suggestion ast.Expr) {
+func (c *boolExprSimplifyChecker) warn(code string, cause, suggestion ast.Expr) {
 	c.cause = cause
-	c.ctx.Warn(cause, "can simplify `%s` to `%s`", cause, suggestion)
+	var fix []TextEdit
+	if !c.ctx.hasComments(cause) {
+		// Printed suggestion has no comments of the cause.
+		// Suggestion that binds weaker than the cause is parenthesized,
+		// like in `!!(a || b) && c` => `(a || b) && c`.
+		replacement := suggestion
+		if c.needParens(cause, suggestion) {
+			replacement = &ast.ParenExpr{X: suggestion}
+		}
+		fix = []TextEdit{c.ctx.replaceNode(cause, replacement)}
+	}
+	c.ctx.WarnWith(Warning{
+		Node: cause,
+		Code: code,
+		Fix:  fix,
+	}, "can simplify `%s` to `%s`", cause, suggestion)
+}
+
+// needParens reports whether suggestion needs parenthesis
+// to replace cause inside its parent expression.
+func (c *boolExprSimplifyChecker) needParens(cause, suggestion ast.Expr) bool {
+	prec := token.HighestPrec
+	switch x := suggestion.(type) {
+	case *ast.BinaryExpr:
+		prec = x.Op.Precedence()
+	case *ast.UnaryExpr:
+		prec = token.UnaryPrec
+	}
+	if prec == token.HighestPrec {
+		return false
+	}
+	path, exact := astutil.PathEnclosingInterval(c.ctx.file, cause.Pos(), cause.End())
+	if !exact || len(path) < 2 || path[0] != cause {
+		return true
+	}
+	switch parent := path[1].(type) {
+	case *ast.BinaryExpr:
+		// Operators are left-associative, so the right operand
+		// of the same precedence needs parenthesis too.
+		if parent.X == cause {
+			return prec < parent.Op.Precedence()
+		}
+		return prec <= parent.Op.Precedence()
+	case *ast.UnaryExpr, *ast.StarExpr:
+		return prec < token.UnaryPrec
+	case *ast.SelectorExpr, *ast.IndexExpr, *ast.SliceExpr, *ast.TypeAssertExpr:
+		return true
+	case *ast.CallExpr:
+		return parent.Fun == cause
+	default:
+		return false
+	}
 }
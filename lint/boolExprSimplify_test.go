@@ -0,0 +1,274 @@
+package lint
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/go-toolsmith/astcopy"
+	"github.com/go-toolsmith/astequal"
+	"github.com/go-toolsmith/astfmt"
+	"github.com/go-toolsmith/strparse"
+)
+
+// TestBoolExprSimplifyEquivalence checks that suggested expressions
+// evaluate to the same values as the original ones for all inputs.
+//
+// a, b and c are bool variables, i and j are ints, f and g are floats.
+func TestBoolExprSimplifyEquivalence(t *testing.T) {
+	exprs := []string{
+		`!(a == b && c)`,
+		`!(!a && b)`,
+		`!(a && !b && c)`,
+		`!(i < j || !c)`,
+		`!(i <= j && j >= i)`,
+		`!(a || b || i != j)`,
+		`!(a != b || c) && c`,
+		`!((a || b) && i > j)`,
+		`!(!(a && b) || i == j)`,
+		`!(a == !b) || !(c && !a)`,
+		`!(!!a && !(b || c))`,
+		`!(!a || !b)`,
+		`!(!a && !(i < j))`,
+		`a == true || b != false`,
+		`a == false && false != b`,
+		`(a && b) != true`,
+		`!(i < j) == false`,
+		`!!a == (true)`,
+		`true == !a`,
+		`!a != true`,
+		`(i == j) == false`,
+		`!(f == g && i < j)`,
+		`!(!a && f < g)`,
+		`!(f <= g || !c)`,
+		`!!(f > g) || a`,
+		`!(f >= g) == false`,
+		`!(f != g || f < g)`,
+	}
+
+	// Every bool and int variable can be either 0 or 1,
+	// floats can also be NaN.
+	vars := []string{"a", "b", "c", "i", "j", "f", "g"}
+	var envs []map[string]float64
+	var fill func(env map[string]float64, k int)
+	fill = func(env map[string]float64, k int) {
+		if k == len(vars) {
+			envs = append(envs, env)
+			return
+		}
+		values := []float64{0, 1}
+		if vars[k] == "f" || vars[k] == "g" {
+			values = append(values, math.NaN())
+		}
+		for _, v := range values {
+			next := map[string]float64{vars[k]: v}
+			for name, v := range env {
+				next[name] = v
+			}
+			fill(next, k+1)
+		}
+	}
+	fill(map[string]float64{"true": 1, "false": 0}, 0)
+
+	c := newBoolExprSimplifyChecker(t, false)
+	for _, s := range exprs {
+		x, info := typecheckBoolTestExpr(t, s)
+		c.ctx.typesInfo = info
+		y := c.simplify(x)
+		if astequal.Expr(x, y) {
+			t.Errorf("%s: not simplified", s)
+			continue
+		}
+		// Evaluate the suggestion text to make sure that
+		// it's printed with all required parenthesis.
+		suggestion := astfmt.Sprint(y)
+		y = strparse.Expr(suggestion)
+
+		for _, env := range envs {
+			if evalBoolTestExpr(x, env) != evalBoolTestExpr(y, env) {
+				t.Errorf("%s: suggested %s is not equivalent for %v",
+					s, suggestion, env)
+				break
+			}
+		}
+	}
+}
+
+// TestBoolExprSimplifyRewriteMatch checks that matching rewrite
+// doesn't modify an expression and that matched expressions
+// are changed by simplifyBool.
+func TestBoolExprSimplifyRewriteMatch(t *testing.T) {
+	exprs := []string{
+		`!!x`,
+		`!(!x)`,
+		`!x == !y`,
+		`!(x) == !(y) == z`,
+		`!(i < j)`,
+		`!(a == b && c)`,
+		`f(!!x, y)`,
+		`[]bool{a, !(i >= j)}`,
+		`x`,
+		`!x`,
+		`!f(x)`,
+		`!(a && b)`,
+		`!x == y`,
+		`(x) == (y)`,
+		`!(a || b && i > j)`,
+		`i + j < 10`,
+		`x == true`,
+		`f(x) != false`,
+		`i == 0`,
+		`!(!a || !b)`,
+		`func() bool { return !!x }()`,
+	}
+
+	c := newBoolExprSimplifyChecker(t, false)
+	for _, s := range exprs {
+		x := strparse.Expr(s)
+		if x == strparse.BadExpr {
+			t.Fatalf("parse %s: bad expression", s)
+		}
+		orig := astcopy.Expr(x)
+		changed := !astequal.Expr(x, c.simplifyBool(astcopy.Expr(x)))
+		if c.rewrite(x, nil) != "" && !changed {
+			t.Errorf("%s: matched, but not simplified", s)
+		}
+		if !astequal.Expr(x, orig) {
+			t.Errorf("%s: modified to %s", s, astfmt.Sprint(x))
+		}
+	}
+}
+
+func TestBoolExprSimplifyCodes(t *testing.T) {
+	tests := []struct {
+		expr string
+		code string
+	}{
+		{`!!x`, "doubleNegation"},
+		{`!x == !y`, "negatedEquals"},
+		{`!(i < j)`, "invertComparison"},
+		{`x == true`, "boolConstCompare"},
+		{`!(!a || !b)`, "deMorgan"},
+		{`!(a && b)`, ""},
+		// Only rewrites of the expression itself are matched.
+		{`f(!!x, !(i < j))`, ""},
+		{`a && !!b`, ""},
+	}
+
+	c := newBoolExprSimplifyChecker(t, false)
+	for _, test := range tests {
+		if have := c.rewrite(strparse.Expr(test.expr), nil); have != test.code {
+			t.Errorf("%s: have %q code, want %q", test.expr, have, test.code)
+		}
+	}
+}
+
+func TestBoolExprSimplifyPushNegations(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{`!(a && b)`, `!a || !b`},
+		{`!(a || b || c)`, `!a && !b && !c`},
+		// Nested chains of the other operator are not rewritten.
+		{`!((a || b) && c)`, `!(a || b) || !c`},
+		{`!(a || b && c)`, `!a && !(b && c)`},
+		{`!f(a)`, `!f(a)`},
+	}
+
+	c := newBoolExprSimplifyChecker(t, true)
+	for _, test := range tests {
+		x := strparse.Expr(test.expr)
+		if x == strparse.BadExpr {
+			t.Fatalf("parse %s: bad expression", test.expr)
+		}
+		have := astfmt.Sprint(c.simplify(x))
+		if have != test.want {
+			t.Errorf("%s: have %s, want %s", test.expr, have, test.want)
+		}
+	}
+}
+
+// typecheckBoolTestExpr parses s and returns it with its types info.
+// s may use variables that are described by TestBoolExprSimplifyEquivalence.
+func typecheckBoolTestExpr(t *testing.T, s string) (ast.Expr, *types.Info) {
+	t.Helper()
+	src := "package p\n\nfunc _(a, b, c bool, i, j int, f, g float64) {\n\t_ = " + s + "\n}\n"
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatalf("parse %s: %v", s, err)
+	}
+	info := &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+		Defs:  make(map[*ast.Ident]types.Object),
+		Uses:  make(map[*ast.Ident]types.Object),
+	}
+	if _, err := (&types.Config{}).Check("p", fset, []*ast.File{f}, info); err != nil {
+		t.Fatalf("typecheck %s: %v", s, err)
+	}
+	body := f.Decls[0].(*ast.FuncDecl).Body
+	return body.List[0].(*ast.AssignStmt).Rhs[0], info
+}
+
+// newBoolExprSimplifyChecker returns boolExprSimplify checker
+// with the specified pushNegations param value.
+func newBoolExprSimplifyChecker(t *testing.T, pushNegations bool) *boolExprSimplifyChecker {
+	ctx := NewContext(token.NewFileSet(), sizes)
+	if err := ctx.SetCheckerParam("boolExprSimplify", "pushNegations", strconv.FormatBool(pushNegations)); err != nil {
+		t.Fatalf("set param: %v", err)
+	}
+	c := &boolExprSimplifyChecker{}
+	c.BindContext(&context{Context: ctx, checkerName: "boolExprSimplify"})
+	c.Init()
+	return c
+}
+
+// evalBoolTestExpr evaluates x that consists of logical and comparison
+// operators over variables from env. Bool variables are 0 or 1.
+func evalBoolTestExpr(x ast.Expr, env map[string]float64) float64 {
+	b2f := func(v bool) float64 {
+		if v {
+			return 1
+		}
+		return 0
+	}
+
+	switch x := x.(type) {
+	case *ast.Ident:
+		return env[x.Name]
+	case *ast.BasicLit:
+		v, _ := strconv.ParseFloat(x.Value, 64)
+		return v
+	case *ast.ParenExpr:
+		return evalBoolTestExpr(x.X, env)
+	case *ast.UnaryExpr:
+		return 1 - evalBoolTestExpr(x.X, env)
+	case *ast.BinaryExpr:
+		lhs := evalBoolTestExpr(x.X, env)
+		rhs := evalBoolTestExpr(x.Y, env)
+		switch x.Op {
+		case token.LAND:
+			return b2f(lhs == 1 && rhs == 1)
+		case token.LOR:
+			return b2f(lhs == 1 || rhs == 1)
+		case token.EQL:
+			return b2f(lhs == rhs)
+		case token.NEQ:
+			return b2f(lhs != rhs)
+		case token.LSS:
+			return b2f(lhs < rhs)
+		case token.GTR:
+			return b2f(lhs > rhs)
+		case token.LEQ:
+			return b2f(lhs <= rhs)
+		case token.GEQ:
+			return b2f(lhs >= rhs)
+		}
+	}
+	panic("unexpected expression: " + astfmt.Sprint(x))
+}
@@ -0,0 +1,455 @@
+package lint
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache stores checkers results, so files that were not changed
+// since the last run are not checked again.
+//
+// There is one entry per file and rule pair. Entry is valid while
+// everything that can affect the checker output remains the same:
+// file contents, Go version, checker params, level overrides and,
+// for rules that are not SyntaxOnly, types of the checked package
+// and its dependencies, and definitions of rules loaded by LoadRules.
+// Directory caches also take checkers implementation into account.
+// Results of rules with EnvDependent attribute are never stored.
+//
+// Cache is either in-memory, see NewCache and LoadCache, or is
+// stored in a directory, see OpenCacheDir.
+//
+// Cache is safe for concurrent use.
+type Cache struct {
+	mu sync.Mutex
+
+	entries map[string]cacheEntry
+
+	// dir is a cache directory. Empty for in-memory caches.
+	dir string
+
+	// toolID identifies checkers implementation.
+	// Results of the different implementations are never mixed.
+	toolID string
+
+	// lastPkg and lastFingerprint memoize packageFingerprint result
+	// for the package that is being checked.
+	lastPkg         *types.Package
+	lastFingerprint string
+
+	// Counters that are reported by Stats.
+	hits   int
+	misses int
+}
+
+type cacheEntry struct {
+	Key      string
+	Warnings []cachedWarning
+}
+
+// cachedWarning is a Warning with position-independent location.
+type cachedWarning struct {
+	Text       string
+	Severity   Severity
+	Confidence Confidence
+
+	// Pos and End are warning node offsets inside file.
+	Pos int
+	End int
+
+	Fix []cachedTextEdit
+
+	Code string
+}
+
+// cachedTextEdit is a TextEdit with position-independent location.
+type cachedTextEdit struct {
+	Pos     int
+	End     int
+	NewText string
+}
+
+// cachedNode is a Warning node restored from the cache.
+// It only carries the original node position information.
+type cachedNode struct {
+	pos token.Pos
+	end token.Pos
+}
+
+func (n cachedNode) Pos() token.Pos { return n.pos }
+func (n cachedNode) End() token.Pos { return n.end }
+
+// NewCache returns new empty in-memory cache.
+func NewCache() *Cache {
+	return &Cache{entries: make(map[string]cacheEntry)}
+}
+
+// LoadCache reads cache that was previously written by Cache.Save.
+func LoadCache(r io.Reader) (*Cache, error) {
+	c := NewCache()
+	if err := json.NewDecoder(r).Decode(&c.entries); err != nil {
+		return nil, fmt.Errorf("load cache: %v", err)
+	}
+	return c, nil
+}
+
+// OpenCacheDir returns cache that stores its entries in dir,
+// like the go build cache does. dir is created if it doesn't exist.
+//
+// Entries are addressed by the hash of file contents, checker
+// context and toolID, so the directory can be shared by several
+// projects. toolID should change every time checkers implementation
+// changes, like a hash of the linter executable.
+//
+// Entries are written as soon as results are known, Save is not needed.
+// Use Trim to remove entries that were not used for a long time.
+func OpenCacheDir(dir, toolID string) (*Cache, error) {
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		return nil, fmt.Errorf("open cache: %v", err)
+	}
+	return &Cache{
+		entries: make(map[string]cacheEntry),
+		dir:     dir,
+		toolID:  toolID,
+	}, nil
+}
+
+// SetToolID sets toolID, see OpenCacheDir, for the cache that
+// was created by NewCache or LoadCache. Entries that were stored
+// with a different toolID become invalid.
+//
+// It's useful when checkers are loaded at run time, like from plugins,
+// so toolID can be a hash of the loaded files.
+func (c *Cache) SetToolID(toolID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.toolID = toolID
+}
+
+// Save writes cache contents to w in format understood by LoadCache.
+func (c *Cache) Save(w io.Writer) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return json.NewEncoder(w).Encode(c.entries)
+}
+
+// Stats returns the number of Check calls that were
+// served from the cache (hits) and that run the checker (misses).
+func (c *Cache) Stats() (hits, misses int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hits, c.misses
+}
+
+// Check is like checker.Check, but returns the results of
+// the previous run if f and checker context are unchanged.
+//
+// src is a source code f was parsed from.
+//
+// Every Warning.Node that was restored from the cache is not
+// a real AST node, but it reports the original node position.
+func (c *Cache) Check(checker *Checker, f *ast.File, src []byte) []Warning {
+	warnings, key, ok := c.lookup(checker, f, src)
+	if ok {
+		return warnings
+	}
+	warnings = checker.Check(f)
+	c.store(checker, f, key, warnings)
+	return warnings
+}
+
+// lookup returns cached checker results for f.
+// If there are no valid results, returns false and
+// a key that should be used to store the results.
+func (c *Cache) lookup(checker *Checker, f *ast.File, src []byte) ([]Warning, string, bool) {
+	if checker.Rule.EnvDependent {
+		c.mu.Lock()
+		c.misses++
+		c.mu.Unlock()
+		return nil, "", false
+	}
+	tf := checker.ctx.fileSet.File(f.Pos())
+	id := tf.Name() + ":" + checker.Rule.Name()
+	key := c.entryKey(checker, src)
+
+	var cached []cachedWarning
+	var ok bool
+	if c.dir != "" {
+		cached, ok = c.readEntry(key)
+	}
+
+	c.mu.Lock()
+	if c.dir == "" {
+		var e cacheEntry
+		e, ok = c.entries[id]
+		ok = ok && e.Key == key
+		cached = e.Warnings
+	}
+	if !ok {
+		c.misses++
+		c.mu.Unlock()
+		return nil, key, false
+	}
+	c.hits++
+	c.mu.Unlock()
+
+	warnings := make([]Warning, len(cached))
+	for i, w := range cached {
+		warnings[i] = Warning{
+			Node:       cachedNode{pos: tf.Pos(w.Pos), end: tf.Pos(w.End)},
+			Pos:        tf.Pos(w.Pos),
+			End:        tf.Pos(w.End),
+			Text:       w.Text,
+			Severity:   w.Severity,
+			Confidence: w.Confidence,
+			Code:       w.Code,
+		}
+		for _, edit := range w.Fix {
+			warnings[i].Fix = append(warnings[i].Fix, TextEdit{
+				Pos:     tf.Pos(edit.Pos),
+				End:     tf.Pos(edit.End),
+				NewText: edit.NewText,
+			})
+		}
+	}
+	return warnings, key, true
+}
+
+// store saves checker results for f under the key returned by lookup.
+func (c *Cache) store(checker *Checker, f *ast.File, key string, warnings []Warning) {
+	if checker.Rule.EnvDependent {
+		return
+	}
+	tf := checker.ctx.fileSet.File(f.Pos())
+	id := tf.Name() + ":" + checker.Rule.Name()
+	e := cacheEntry{Key: key, Warnings: make([]cachedWarning, len(warnings))}
+	for i, w := range warnings {
+		e.Warnings[i] = cachedWarning{
+			Text:       w.Text,
+			Severity:   w.Severity,
+			Confidence: w.Confidence,
+			Pos:        tf.Offset(w.Pos),
+			End:        tf.Offset(w.End),
+			Code:       w.Code,
+		}
+		for _, edit := range w.Fix {
+			e.Warnings[i].Fix = append(e.Warnings[i].Fix, cachedTextEdit{
+				Pos:     tf.Offset(edit.Pos),
+				End:     tf.Offset(edit.End),
+				NewText: edit.NewText,
+			})
+		}
+	}
+	if c.dir != "" {
+		c.writeEntry(key, e.Warnings)
+		return
+	}
+	c.mu.Lock()
+	c.entries[id] = e
+	c.mu.Unlock()
+}
+
+const (
+	// cacheTrimAge is how long unused cache directory entries are kept.
+	cacheTrimAge = 5 * 24 * time.Hour
+
+	// cacheTrimInterval is how often cache directory is trimmed.
+	cacheTrimInterval = 24 * time.Hour
+
+	// cacheMtimeInterval is how often entry modification time is
+	// updated on use. It's used to find out when entry was used last time.
+	cacheMtimeInterval = time.Hour
+)
+
+// entryFile returns a path of cache directory entry file.
+func (c *Cache) entryFile(key string) string {
+	return filepath.Join(c.dir, key[:2], key+"-w")
+}
+
+// readEntry returns warnings stored in the cache directory under key.
+// Missing and malformed entries are reported as cache misses.
+func (c *Cache) readEntry(key string) ([]cachedWarning, bool) {
+	filename := c.entryFile(key)
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, false
+	}
+	var warnings []cachedWarning
+	if err := json.Unmarshal(data, &warnings); err != nil {
+		return nil, false
+	}
+	if info, err := os.Stat(filename); err == nil && time.Since(info.ModTime()) > cacheMtimeInterval {
+		now := time.Now()
+		os.Chtimes(filename, now, now) // Error is only important to Trim
+	}
+	return warnings, true
+}
+
+// writeEntry stores warnings in the cache directory under key.
+//
+// Entry is written to a temporary file that is renamed afterwards,
+// so concurrent readers never see partially written entries.
+// Errors are ignored: entry is re-computed the next time it's needed.
+func (c *Cache) writeEntry(key string, warnings []cachedWarning) {
+	data, err := json.Marshal(warnings)
+	if err != nil {
+		return
+	}
+	filename := c.entryFile(key)
+	if err := os.MkdirAll(filepath.Dir(filename), 0777); err != nil {
+		return
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(filename), key+"-*.tmp")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), filename)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+}
+
+// Trim removes cache directory entries that were not used for
+// several days. Directory is scanned at most once a day,
+// so it's cheap to call Trim after every run.
+//
+// Trim does nothing for in-memory caches.
+func (c *Cache) Trim() error {
+	if c.dir == "" {
+		return nil
+	}
+	now := time.Now()
+	marker := filepath.Join(c.dir, "trim.txt")
+	if data, err := ioutil.ReadFile(marker); err == nil {
+		last, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+		if err == nil && now.Sub(time.Unix(last, 0)) < cacheTrimInterval {
+			return nil
+		}
+	}
+
+	subdirs, err := ioutil.ReadDir(c.dir)
+	if err != nil {
+		return fmt.Errorf("trim cache: %v", err)
+	}
+	for _, subdir := range subdirs {
+		if !subdir.IsDir() {
+			continue
+		}
+		dir := filepath.Join(c.dir, subdir.Name())
+		entries, err := ioutil.ReadDir(dir)
+		if err != nil {
+			return fmt.Errorf("trim cache: %v", err)
+		}
+		for _, e := range entries {
+			if now.Sub(e.ModTime()) > cacheTrimAge {
+				os.Remove(filepath.Join(dir, e.Name()))
+			}
+		}
+	}
+
+	data := []byte(strconv.FormatInt(now.Unix(), 10) + "\n")
+	if err := ioutil.WriteFile(marker, data, 0666); err != nil {
+		return fmt.Errorf("trim cache: %v", err)
+	}
+	return nil
+}
+
+// entryKey returns a hash of everything that can affect checker results.
+func (c *Cache) entryKey(checker *Checker, src []byte) string {
+	h := sha256.New()
+	h.Write(src)
+	fmt.Fprintf(h, "\x00%s\x00%s\x00%s", checker.Rule.Name(), checker.Rule.definition, c.toolID)
+	fmt.Fprintf(h, "\x00go%d.%d", checker.ctx.goVersion.major, checker.ctx.goVersion.minor)
+	fmt.Fprintf(h, "\x00%d", checker.ctx.minConfidence)
+	fmt.Fprintf(h, "\x00%d", checker.ctx.minSeverity)
+	fmt.Fprintf(h, "\x00%d", checker.ctx.severityOverrides[checker.Rule.Name()])
+	fmt.Fprintf(h, "\x00%d", checker.ctx.confidenceOverrides[checker.Rule.Name()])
+
+	params := checker.ctx.checkerParams[checker.Rule.Name()]
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(h, "\x00%s=%s", name, params[name])
+	}
+
+	if !checker.Rule.SyntaxOnly && checker.ctx.pkg != nil {
+		fmt.Fprintf(h, "\x00%s", c.packageFingerprint(checker.ctx.pkg))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// packageFingerprint returns a hash of pkg types and types
+// of all packages it depends on, directly or indirectly.
+func (c *Cache) packageFingerprint(pkg *types.Package) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.lastPkg == pkg {
+		return c.lastFingerprint
+	}
+
+	var deps []*types.Package
+	visited := make(map[*types.Package]bool)
+	var visit func(p *types.Package)
+	visit = func(p *types.Package) {
+		if visited[p] {
+			return
+		}
+		visited[p] = true
+		deps = append(deps, p)
+		for _, imported := range p.Imports() {
+			visit(imported)
+		}
+	}
+	visit(pkg)
+	sort.Slice(deps, func(i, j int) bool {
+		return deps[i].Path() < deps[j].Path()
+	})
+
+	h := sha256.New()
+	for _, p := range deps {
+		fmt.Fprintf(h, "package %s\n", p.Path())
+		scope := p.Scope()
+		for _, name := range scope.Names() {
+			obj := scope.Lookup(name)
+			fmt.Fprintln(h, types.ObjectString(obj, nil))
+			switch obj := obj.(type) {
+			case *types.Const:
+				fmt.Fprintf(h, "= %s\n", obj.Val())
+			case *types.TypeName:
+				named, ok := obj.Type().(*types.Named)
+				if !ok {
+					break
+				}
+				for i := 0; i < named.NumMethods(); i++ {
+					fmt.Fprintln(h, types.ObjectString(named.Method(i), nil))
+				}
+			}
+		}
+	}
+	c.lastPkg = pkg
+	c.lastFingerprint = hex.EncodeToString(h.Sum(nil))
+	return c.lastFingerprint
+}
@@ -0,0 +1,218 @@
+package lint
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	rule := findRule("dupSubExpr")
+	if rule == nil {
+		t.Fatal("dupSubExpr rule not found")
+	}
+	pkgPath := testdataPkgPath + rule.Name()
+	prog := newProg(t, pkgPath)
+	pkgInfo := prog.Imported[pkgPath]
+	ctx := NewContext(prog.Fset, sizes)
+	ctx.SetPackageInfo(&pkgInfo.Info, pkgInfo.Pkg)
+	var f *ast.File
+	for _, file := range pkgInfo.Files {
+		if getFilename(prog, file) == "positive_tests.go" {
+			f = file
+		}
+	}
+	if f == nil {
+		t.Fatal("positive_tests.go not found")
+	}
+	src, err := ioutil.ReadFile(prog.Fset.Position(f.Pos()).Filename)
+	if err != nil {
+		t.Fatalf("read source: %v", err)
+	}
+
+	checker := NewChecker(rule, ctx)
+	want := warningsString(prog.Fset, checker.Check(f))
+	if want == "" {
+		t.Fatal("expected dupSubExpr to produce warnings")
+	}
+
+	cache := NewCache()
+	check := func(cache *Cache, src []byte, wantHits, wantMisses int) {
+		t.Helper()
+		have := warningsString(prog.Fset, cache.Check(checker, f, src))
+		if have != want {
+			t.Errorf("warnings mismatch:\nhave:\n%s\nwant:\n%s", have, want)
+		}
+		hits, misses := cache.Stats()
+		if hits != wantHits || misses != wantMisses {
+			t.Errorf("have %d hits and %d misses, want %d and %d",
+				hits, misses, wantHits, wantMisses)
+		}
+	}
+
+	check(cache, src, 0, 1)
+	check(cache, src, 1, 1)
+
+	// Edited file is checked again. Since f is not re-parsed,
+	// warnings are the same, but they are not taken from the cache.
+	edited := append(append([]byte{}, src...), "\n// Edited.\n"...)
+	check(cache, edited, 1, 2)
+	check(cache, edited, 2, 2)
+
+	// Cache that is loaded from the disk remembers the last run results.
+	var buf bytes.Buffer
+	if err := cache.Save(&buf); err != nil {
+		t.Fatalf("save cache: %v", err)
+	}
+	loaded, err := LoadCache(&buf)
+	if err != nil {
+		t.Fatalf("load cache: %v", err)
+	}
+	check(loaded, edited, 1, 0)
+	check(loaded, src, 1, 1)
+
+	// Results of the other checkers implementation are not used.
+	loaded.SetToolID("plugins")
+	check(loaded, src, 1, 2)
+	check(loaded, src, 2, 2)
+
+	// Results of the rules that depend on the environment are not cached.
+	rule.EnvDependent = true
+	defer func() { rule.EnvDependent = false }()
+	check(loaded, src, 2, 3)
+	check(loaded, src, 2, 4)
+}
+
+func TestCacheDir(t *testing.T) {
+	rule := findRule("dupSubExpr")
+	pkgPath := testdataPkgPath + rule.Name()
+	prog := newProg(t, pkgPath)
+	pkgInfo := prog.Imported[pkgPath]
+	ctx := NewContext(prog.Fset, sizes)
+	ctx.SetPackageInfo(&pkgInfo.Info, pkgInfo.Pkg)
+	f := pkgInfo.Files[0]
+	src, err := ioutil.ReadFile(prog.Fset.Position(f.Pos()).Filename)
+	if err != nil {
+		t.Fatalf("read source: %v", err)
+	}
+	checker := NewChecker(rule, ctx)
+	want := warningsString(prog.Fset, checker.Check(f))
+
+	dir, err := ioutil.TempDir("", "gocritic-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	check := func(toolID string, wantHits, wantMisses int) {
+		t.Helper()
+		cache, err := OpenCacheDir(dir, toolID)
+		if err != nil {
+			t.Fatalf("open cache: %v", err)
+		}
+		have := warningsString(prog.Fset, cache.Check(checker, f, src))
+		if have != want {
+			t.Errorf("warnings mismatch:\nhave:\n%s\nwant:\n%s", have, want)
+		}
+		hits, misses := cache.Stats()
+		if hits != wantHits || misses != wantMisses {
+			t.Errorf("%s: have %d hits and %d misses, want %d and %d",
+				toolID, hits, misses, wantHits, wantMisses)
+		}
+	}
+
+	check("v1", 0, 1)
+	check("v1", 1, 0)
+	// Results of the other linter version are not used.
+	check("v2", 0, 1)
+	check("v1", 1, 0)
+
+	// Trim removes entries that were not used for a long time.
+	cache, err := OpenCacheDir(dir, "v1")
+	if err != nil {
+		t.Fatalf("open cache: %v", err)
+	}
+	old := time.Now().Add(-2 * cacheTrimAge)
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		return os.Chtimes(path, old, old)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cache.Trim(); err != nil {
+		t.Fatalf("trim: %v", err)
+	}
+	check("v1", 0, 1)
+}
+
+func TestPackageFingerprint(t *testing.T) {
+	typecheck := func(depSrc string) *types.Package {
+		fset := token.NewFileSet()
+		parse := func(src string) *ast.File {
+			f, err := parser.ParseFile(fset, "", src, 0)
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			return f
+		}
+		dep, err := new(types.Config).Check("dep", fset, []*ast.File{parse(depSrc)}, nil)
+		if err != nil {
+			t.Fatalf("typecheck dep: %v", err)
+		}
+		conf := types.Config{Importer: importerFunc(func(string) (*types.Package, error) {
+			return dep, nil
+		})}
+		src := "package p; import \"dep\"; var x dep.T; var _ = x + x"
+		pkg, err := conf.Check("p", fset, []*ast.File{parse(src)}, nil)
+		if err != nil {
+			t.Fatalf("typecheck p: %v", err)
+		}
+		return pkg
+	}
+
+	fingerprint := func(pkg *types.Package) string {
+		return NewCache().packageFingerprint(pkg)
+	}
+
+	base := fingerprint(typecheck("package dep; type T = float64"))
+	same := fingerprint(typecheck("package dep; type T = float64"))
+	if base != same {
+		t.Errorf("fingerprint changed for the same dependency")
+	}
+	for _, depSrc := range []string{
+		"package dep; type T = int",
+		"package dep; type T = float64; const C = 1",
+		"package dep; type T = float64; const unexported = 1",
+	} {
+		if fingerprint(typecheck(depSrc)) == base {
+			t.Errorf("%q: fingerprint not changed after dependency edit", depSrc)
+		}
+	}
+}
+
+type importerFunc func(path string) (*types.Package, error)
+
+func (f importerFunc) Import(path string) (*types.Package, error) { return f(path) }
+
+func warningsString(fset *token.FileSet, warnings []Warning) string {
+	var buf bytes.Buffer
+	for _, w := range warnings {
+		buf.WriteString(fset.Position(w.Node.Pos()).String())
+		buf.WriteString("-")
+		buf.WriteString(fset.Position(w.Node.End()).String())
+		buf.WriteString(": ")
+		buf.WriteString(w.Text)
+		buf.WriteString("\n")
+	}
+	return buf.String()
+}
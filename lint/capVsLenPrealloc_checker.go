@@ -0,0 +1,124 @@
+package lint
+
+//! Detects slices that are allocated with non-zero length and then appended to.
+//
+// Appending to a slice created by make([]T, len(x)) adds elements
+// after len(x) zero values instead of filling the slice.
+//
+// @Before:
+// dst := make([]int, len(src))
+// for _, x := range src {
+// 	dst = append(dst, x*2)
+// }
+//
+// @After:
+// dst := make([]int, 0, len(src))
+// for _, x := range src {
+// 	dst = append(dst, x*2)
+// }
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/go-toolsmith/astequal"
+)
+
+func init() {
+	addChecker(&capVsLenPreallocChecker{}, attrExperimental)
+}
+
+type capVsLenPreallocChecker struct {
+	checkerBase
+}
+
+func (c *capVsLenPreallocChecker) VisitStmtList(list []ast.Stmt) {
+	for i := 0; i < len(list)-1; i++ {
+		slice, call := c.matchMake(list[i])
+		if call == nil {
+			continue
+		}
+		var body *ast.BlockStmt
+		switch loop := list[i+1].(type) {
+		case *ast.ForStmt:
+			body = loop.Body
+		case *ast.RangeStmt:
+			body = loop.Body
+		default:
+			continue
+		}
+		if c.appendsTo(body, slice) {
+			c.warn(call)
+		}
+	}
+}
+
+// matchMake matches `x := make([]T, len(y))` and alike statements.
+// Returns x and make call, or nil call if stmt is not matched.
+func (c *capVsLenPreallocChecker) matchMake(stmt ast.Stmt) (ast.Expr, *ast.CallExpr) {
+	var lhs, rhs ast.Expr
+	switch stmt := stmt.(type) {
+	case *ast.AssignStmt:
+		if len(stmt.Lhs) != 1 || len(stmt.Rhs) != 1 {
+			return nil, nil
+		}
+		lhs, rhs = stmt.Lhs[0], stmt.Rhs[0]
+	case *ast.DeclStmt:
+		decl := stmt.Decl.(*ast.GenDecl)
+		if decl.Tok != token.VAR || len(decl.Specs) != 1 {
+			return nil, nil
+		}
+		spec := decl.Specs[0].(*ast.ValueSpec)
+		if len(spec.Names) != 1 || len(spec.Values) != 1 {
+			return nil, nil
+		}
+		lhs, rhs = spec.Names[0], spec.Values[0]
+	default:
+		return nil, nil
+	}
+
+	call, ok := rhs.(*ast.CallExpr)
+	if !ok || len(call.Args) != 2 || !c.ctx.isBuiltinCall(call, "make") {
+		return nil, nil
+	}
+	typ := c.ctx.typesInfo.TypeOf(call)
+	if typ == nil {
+		return nil, nil
+	}
+	if _, ok := typ.Underlying().(*types.Slice); !ok {
+		return nil, nil
+	}
+	length, ok := call.Args[1].(*ast.CallExpr)
+	if !ok || !c.ctx.isBuiltinCall(length, "len") {
+		return nil, nil
+	}
+	return lhs, call
+}
+
+// appendsTo reports whether body contains `slice = append(slice, ...)`.
+// Function literals are not inspected.
+func (c *capVsLenPreallocChecker) appendsTo(body *ast.BlockStmt, slice ast.Expr) bool {
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.AssignStmt:
+			if len(n.Lhs) != 1 || len(n.Rhs) != 1 || !astequal.Expr(n.Lhs[0], slice) {
+				return true
+			}
+			call, ok := n.Rhs[0].(*ast.CallExpr)
+			if ok && len(call.Args) != 0 && c.ctx.isBuiltinCall(call, "append") &&
+				astequal.Expr(call.Args[0], slice) {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+func (c *capVsLenPreallocChecker) warn(cause ast.Node) {
+	c.ctx.Warn(cause, "make([]T, len(x)) then append produces len(x) extra zero elements; use cap")
+}
@@ -2,6 +2,12 @@ package lint
 
 //! Detects capitalized names for local variables.
 //
+// Only function-local names are checked: params, results,
+// receivers and variables or constants declared inside function bodies.
+//
+// Checker params:
+//	paramsOnly - if "true", only params, results and receivers are checked
+//
 // @Before:
 // func f(IN int, OUT *int) (ERR error) {}
 //
@@ -23,9 +29,18 @@ type captLocalChecker struct {
 	checkerBase
 
 	upcaseNames map[string]bool
+	paramsOnly  bool
+}
+
+func (c *captLocalChecker) Params() []CheckerParam {
+	return []CheckerParam{
+		{Name: "paramsOnly", Kind: ParamBool, Default: "false"},
+	}
 }
 
 func (c *captLocalChecker) Init() {
+	c.paramsOnly = c.ctx.BoolParam("paramsOnly")
+
 	c.upcaseNames = map[string]bool{
 		"IN":    true,
 		"OUT":   true,
@@ -36,6 +51,9 @@ func (c *captLocalChecker) Init() {
 }
 
 func (c *captLocalChecker) VisitLocalDef(def astwalk.Name, _ ast.Expr) {
+	if c.paramsOnly && def.Kind != astwalk.NameParam {
+		return
+	}
 	switch {
 	case c.upcaseNames[def.ID.Name]:
 		c.warnUpcase(def.ID)
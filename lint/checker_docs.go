@@ -0,0 +1,85 @@
+// Code generated by cmd/makedocs; DO NOT EDIT.
+
+package lint
+
+// checkerDocs maps checker name to its documentation comment text.
+var checkerDocs = map[string]string{
+	"alwaysTrueCond":      "! Detects comparisons that are always true or always false.\n\nSuch comparisons compare unsigned values with negative numbers,\nvalues with constants out of their type range, like `b > 255` for a byte,\nor literals with each other.\n\nComparisons with named constants are not reported when\nboth operands are constants, since these are often used to\nconfigure the code, like in `if debug == true`.\n\n@Before:\nif uint(x) < 0 {\n\treturn errNegative\n}\n\n@After:\nif x < 0 {\n\treturn errNegative\n}\n",
+	"appendAssign":        "! Detects suspicious append result assignments.\n\nAlso reports append calls that have their result discarded,\nmaking the whole call a no-op.\n\nSlices that were assigned from each other inside the\nfunction, like in `ys := xs[:n]`, are considered aliases\nand their append assignments are not reported.\n\n@Before:\np.positives = append(p.negatives, x)\np.negatives = append(p.negatives, y)\n\n@After:\np.positives = append(p.positives, x)\np.negatives = append(p.negatives, y)\n",
+	"appendCombine":       "! Detects `append` chains to the same slice that can be done in a single `append` call.\n\n@Before:\nxs = append(xs, 1)\nxs = append(xs, 2)\n\n@After:\nxs = append(xs, 1, 2)\n",
+	"argOrder":            "! Detects suspicious arguments order.\n\nReports calls to asymmetric strings and bytes functions\nwhere a string literal is passed as a haystack, while the needle is not a constant.\nNamed constants are not reported, as they are often a haystack indeed.\n\n@Before:\nstrings.HasPrefix(\"#\", userpass)\n\n@After:\nstrings.HasPrefix(userpass, \"#\")\n",
+	"badCall":             "! Detects suspicious function calls.\n\nReports well-known standard library calls with arguments that make\nthem no-op or contradict the function intent, like strings.Replace\nwith zero n, single argument append, empty filepath.Join elements\nand suffix-like TrimRight cutsets.\n\n@Before:\nstrings.Replace(s, from, to, 0)\nstrings.TrimRight(filename, \".go\")\n\n@After:\nstrings.Replace(s, from, to, -1)\nstrings.TrimSuffix(filename, \".go\")\n",
+	"badDirective":        "! Detects misspelled and misplaced comment directives.\n\nMisspelled //go: directives, like //go:genrate, and directives\nwith a space after the slashes, like \"// go:generate\", are silently\nignored by the Go tools. So are build constraints that are\nplaced after the package clause.\n\n@Before:\n//go:genrate stringer -type=Kind\n\n@After:\n//go:generate stringer -type=Kind\n",
+	"boolExprSimplify":    "! Detects bool expressions that can be simplified for the sake of readability.\n\nChecker params:\n\tpushNegations - if \"true\", negations are pushed inside every && and || chain, like in `!(a && b)` => `!a || !b`\n\n@Before:\na := !(elapsed >= expectElapsedMin)\nb := !(x) == !(y)\nc := ok == false\n\n@After:\na := elapsed < expectElapsedMin\nb := x == y\nc := !ok\n",
+	"boolFuncPrefix":      "! Detects function returning only bool and suggests to add Is/Has/Contains prefix to it's name.\n\n@Before:\nfunc Enabled() bool\n\n@After:\nfunc IsEnabled() bool\n",
+	"builtinShadow":       "! Detects when predeclared identifiers shadowed in assignments.\n\n@Before:\nfunc main() {\n\t// shadowing len function\n\tlen := 10\n\tprintln(len)\n}\n\n@After:\nfunc main() {\n\t// change identificator name\n\tlength := 10\n\tprintln(length)\n}\n",
+	"capVsLenPrealloc":    "! Detects slices that are allocated with non-zero length and then appended to.\n\nAppending to a slice created by make([]T, len(x)) adds elements\nafter len(x) zero values instead of filling the slice.\n\n@Before:\ndst := make([]int, len(src))\nfor _, x := range src {\n\tdst = append(dst, x*2)\n}\n\n@After:\ndst := make([]int, 0, len(src))\nfor _, x := range src {\n\tdst = append(dst, x*2)\n}\n",
+	"captLocal":           "! Detects capitalized names for local variables.\n\nOnly function-local names are checked: params, results,\nreceivers and variables or constants declared inside function bodies.\n\nChecker params:\n\tparamsOnly - if \"true\", only params, results and receivers are checked\n\n@Before:\nfunc f(IN int, OUT *int) (ERR error) {}\n\n@After:\nfunc f(in int, out *int) (err error) {}\n",
+	"caseOrder":           "! Detects erroneous case order inside switch statements.\n\n@Before:\nswitch x.(type) {\ncase ast.Expr:\n\tfmt.Println(\"expr\")\ncase *ast.BasicLit:\n\tfmt.Println(\"basic lit\") // Never executed\n}\n\n@After:\nswitch x.(type) {\ncase *ast.BasicLit:\n\tfmt.Println(\"basic lit\") // Now reachable\ncase ast.Expr:\n\tfmt.Println(\"expr\")\n}\n",
+	"commentedOutCode":    "! Detects commented-out code.\n\nComments inside function bodies are checked for statements,\npackage-level comments are checked for func, var and const declarations.\nDoc comments are not checked, since they often contain usage examples.\n\nChecker params:\n\tminLength - comments that are shorter are not reported, unless they print something\n\n@Before:\n// fmt.Println(\"Debugging hard\")\nfoo(1, 2)\n\n@After:\nfoo(1, 2)\n",
+	"contextFirstParam":   "! Detects context.Context params that are not the first ones.\n\nBy convention, context.Context is the first function param,\noptionally preceded only by a *testing.T-like param.\nStoring context.Context in a struct field is reported too.\n\nChecker params:\n\tcheckStructFields - if \"true\", struct fields of context.Context type are reported\n\n@Before:\nfunc f(x int, ctx context.Context)\n\n@After:\nfunc f(ctx context.Context, x int)\n",
+	"conversionChain":     "! Detects suspicious chains of integer type conversions.\n\nReports conversions that narrow a signed integer and then convert\nit back to the original type, which silently truncates the value,\nand conversions to the type the value already has.\nUnsigned narrowing is not reported as it's commonly used for masking,\nneither are range checks like `int64(int32(x)) == x`.\n\n@Before:\nfunc f(x int64) int64 { return int64(int32(x)) }\n\nfunc g(x int32) int { return int(int(x)) }\n\n@After:\nfunc f(x int64) int64 { return x }\n\nfunc g(x int32) int { return int(x) }\n",
+	"defaultCaseOrder":    "! Detects when default case in switch isn't on 1st or last position.\n\n@Before:\nswitch {\ncase x > y:\n\t// ...\ndefault: // <- not the best position\n\t// ...\ncase x == 10:\n\t// ...\n}\n\n@After:\nswitch {\ncase x > y:\n\t// ...\ncase x == 10:\n\t// ...\ndefault: // <- everything is good\n\t// ...\n}\n",
+	"deferInLoop":         "! Detects defer in loop and warns that it will not be executed till the end of function's scope.\n\nDefers inside nested blocks of the loop body are reported too,\nwhile defers inside function literals are not.\n\nChecker params:\n\tcloseOnly - if \"true\", only deferred Close, Unlock, RUnlock and Release method calls are reported\n\n@Before:\nfor i := range [10]int{} {\n\tdefer f(i) // will be executed only at the end of func\n}\n\n@After:\nfor i := range [10]int{} {\n\tfunc(i int) {\n\t\tdefer f(i)\n\t}(i)\n}\n",
+	"deprecatedComment":   "! Detects malformed \"Deprecated:\" notices in doc comments.\n\nTools like godoc and staticcheck only recognize a paragraph\nthat starts with \"Deprecated: \" as a deprecation notice.\nWrong casing, missing colon and notices that don't start\ntheir own paragraph are reported.\n\n@Before:\nfunc Baz() {}\n\n// Foo is bar.\n// deprecated, use Baz instead.\nfunc Foo() {}\n\n@After:\nfunc Baz() {}\n\n// Foo is bar.\n//\n// Deprecated: use Baz instead.\nfunc Foo() {}\n",
+	"docStub":             "! Detects comments that silence go lint complaints about doc-comment.\n\n@Before:\n// Foo ...\nfunc Foo() {\n}\n\n@After:\nfunc Foo() {\n}\n\n@Note:\n> You can either remove a comment to let go lint find it or change stub to useful comment.\n> This checker makes it easier to detect stubs, the action is up to you.\n",
+	"dupArg":              "! Detects suspicious duplicated arguments.\n\nReported functions are listed in a table along with the\nargument pairs that are expected to be different.\n\n@Before:\ncopy(dst, dst)\n\n@After:\ncopy(dst, src)\n",
+	"dupBranchBody":       "! Detects duplicated branch bodies inside conditional statements.\n\nFor switch statements, every case body is compared with the\npreceding cases. Empty bodies and cases that take part in\nfallthrough are not reported, as well as type switch cases.\n\n@Before:\nif cond {\n\tprintln(\"cond=true\")\n} else {\n\tprintln(\"cond=true\")\n}\n\n@After:\nif cond {\n\tprintln(\"cond=true\")\n} else {\n\tprintln(\"cond=false\")\n}\n",
+	"dupCase":             "! Detects duplicated case clauses inside switch statements.\n\nType switches are not checked: duplicated types, including\naliases of the already listed types, are rejected by the compiler.\n\n@Before:\nswitch x {\ncase ys[0], ys[1], ys[2], ys[0], ys[4]:\n}\n\n@After:\nswitch x {\ncase ys[0], ys[1], ys[2], ys[3], ys[4]:\n}\n",
+	"dupFunc":             "! Detects functions of the same package that have identical bodies.\n\nFunction bodies are compared after local names normalization,\nso functions that differ only in param and variable names are reported.\nFunctions that only differ in literal values and param types are\nreported as nearly identical, with medium confidence.\nSmall functions, like getters and setters, are not reported.\n\nChecker params:\n\tminStmts - functions with less statements are not reported\n\n@Before:\nfunc sumInts(xs []int) int {\n\ttotal := 0\n\tfor _, x := range xs {\n\t\ttotal += x\n\t}\n\treturn total\n}\n\nfunc sumWeights(weights []int) int {\n\tsum := 0\n\tfor _, w := range weights {\n\t\tsum += w\n\t}\n\treturn sum\n}\n\n@After:\nfunc sumInts(xs []int) int {\n\ttotal := 0\n\tfor _, x := range xs {\n\t\ttotal += x\n\t}\n\treturn total\n}\n\n@Note:\nDuplicates in all package files are found, unless the checker\nis run for a single file at a time, like in editor integrations.\n",
+	"dupSubExpr":          "! Detects suspicious duplicated sub-expressions.\n\nDuplicated float operands are not reported, as they are legit for NaN values.\nOperands of type params that permit float types are treated as floats.\nNaN checks like `x != x` are reported as info with math.IsNaN suggestion.\n\nChecker params:\n\tcheckFloats - if \"true\", other duplicated float operands are reported with low confidence\n\n@Before:\nsort.Slice(xs, func(i, j int) bool {\n\treturn xs[i].v < xs[i].v // Duplicated index\n})\n\n@After:\nsort.Slice(xs, func(i, j int) bool {\n\treturn xs[i].v < xs[j].v\n})\n",
+	"elseif":              "! Detects else with nested if statement that can be replaced with else-if.\n\n@Before:\nif cond1 {\n} else {\n\tif x := cond2; x {\n\t}\n}\n\n@After:\nif cond1 {\n} else if x := cond2; x {\n}\n",
+	"emptyFmt":            "! Detects usages of formatting functions without formatting arguments.\n\n@Before:\nfmt.Sprintf(\"whatever\")\nfmt.Errorf(\"wherever\")\n\n@After:\nfmt.Sprint(\"whatever\")\nerrors.New(\"wherever\")\n",
+	"emptySelect":         "! Detects empty select statements that block forever.\n\nEmpty select is sometimes used intentionally to block main,\nso it's reported with info severity.\n\nChecker params:\n\tskipMain - if \"true\", main function of main package is not checked\n\n@Before:\nselect {}\n\n@After:\nselect {\ncase <-done:\n}\n",
+	"errorsJoinSingle":    "! Detects errors.Join calls with less than 2 arguments.\n\nJoining a single error is redundant, while errors.Join\nwithout arguments always returns nil.\nSingle argument is only reported if it has the error type.\nOnly reported for Go 1.20 and newer, where errors.Join is available.\n\n@Before:\nreturn errors.Join(err)\n\n@After:\nreturn err\n",
+	"evalOrder":           "! Detects potentially unsafe dependencies on evaluation order.\n\nChecks return statements, assignments and var declarations values.\nAlso reports assignments like `i, xs[i] = 1, 2`, where LHS index\noperands use the values that are assigned by the same statement.\n\n@Before:\nreturn mayModifySlice(&xs), xs[0]\n\n@After:\n// A)\nv := mayModifySlice(&xs)\nreturn v, xs[0]\n// B)\nv := xs[0]\nreturn mayModifySlice(&xs), v\n",
+	"exitAfterDefer":      "! Detects calls to exit/fatal inside functions that use defer.\n\nDeferred calls are not executed if program is terminated\nby os.Exit or log.Fatal functions.\nFunction literals are checked as separate functions.\n\n@Before:\ndefer os.Remove(filename)\nif bad {\n\tlog.Fatalf(\"something bad happened\")\n}\n\n@After:\ndefer os.Remove(filename)\nif bad {\n\tlog.Printf(\"something bad happened\")\n\treturn\n}\n",
+	"flagDeref":           "! Detects immediate dereferencing of `flag` package pointers.\n\nSuggests using `XxxVar` functions to achieve desired effect.\nBoth flag package functions and flag.FlagSet methods are checked.\n\n@Before:\nb := *flag.Bool(\"b\", false, \"b docs\")\n\n@After:\nvar b bool\nflag.BoolVar(&b, \"b\", false, \"b docs\")\n\n@Note:\n> Dereferencing returned pointers will lead to hard to find errors\n> where flag values are not updated after flag.Parse().\n",
+	"floatSumLoop":        "! Detects naive float64 summation inside range loops.\n\nRepeated float64 additions accumulate rounding error, which can\nbe significant for large inputs. Kahan (compensated) summation\nor summing sorted values helps to reduce the error.\n\n@Before:\nvar sum float64\nfor _, x := range xs {\n\tsum += x\n}\n\n@After:\nvar sum, c float64\nfor _, x := range xs {\n\ty := x - c\n\tt := sum + y\n\tc = (t - sum) - y\n\tsum = t\n}\n\n@Note:\n> This is an advisory heuristic: the checker can't tell how big the\n> input is or whether precision matters, so most reports are false\n> positives outside of numerical code.\n",
+	"goGenerateTool":      "! Detects go:generate directives that reference tools that can't be found.\n\nTool is looked up in PATH, while \"go run\" packages are resolved\nrelative to the directory of the file being checked.\n\nChecker params:\n\tstrategy - which tools to check: \"all\" (default), \"path\" or \"goRun\"\n\tskip     - comma-separated list of tool names that are never reported\n\n@Before:\n//go:generate stringer-old -type=Kind\n\n@After:\n//go:generate stringer -type=Kind\n\n@Note:\nResults depend on the environment the linter is running in.\n",
+	"hugeParam":           "! Detects params that incur excessive amount of copying.\n\nMethods that implement interfaces are not reported, as changing\ntheir signature would break the interface implementation.\nOnly interfaces of the checked package and its imports are considered.\n\nChecker params:\n\tsizeThreshold - minimal param size in bytes that is reported (80 by default)\n\n@Before:\nfunc f(x [1024]int) {}\n\n@After:\nfunc f(x *[1024]int) {}\n",
+	"ifElseChain":         "! Detects repeated if-else statements and suggests to replace them with switch statement.\n\nPermits single else or else-if; repeated else-if or else + else-if\nwill trigger suggestion to use switch statement.\nIf every condition compares the same expression with ==,\ntagged switch over that expression is suggested.\n\nChecker params:\n\tminThreshold - min number of else and else-if branches that is reported (2 by default)\n\n@Before:\nif cond1 {\n\t// Code A.\n} else if cond2 {\n\t// Code B.\n} else {\n\t// Code C.\n}\n\n@After:\nswitch {\ncase cond1:\n\t// Code A.\ncase cond2:\n\t// Code B.\ndefault:\n\t// Code C.\n}\n",
+	"importShadow":        "! Detects when imported package names shadowed in assignments.\n\n@Before:\n// \"path/filepath\" is imported.\nfunc myFunc(filepath string) {\n}\n\n@After:\nfunc myFunc(filename string) {\n}\n",
+	"indexOnlyLoop":       "! Detects for loops that can benefit from rewrite to range loop.\n\nSuggests to use for key, v := range container form.\n\n@Before:\nfor i := range files {\n\tif files[i] != nil {\n\t\tfiles[i].Close()\n\t}\n}\n\n@After:\nfor _, f := range files {\n\tif f != nil {\n\t\tf.Close()\n\t}\n}\n",
+	"lockCopy":            "! Detects copies of values that contain sync package locks.\n\nReports value receivers and params, assignments, function\narguments and range value copies of values whose type contains\nsync.Mutex, sync.RWMutex, sync.WaitGroup, sync.Once or sync.Cond,\ndirectly or inside nested struct fields and arrays.\nCopied lock is not shared with the original value, so it doesn't\nprotect anything.\n\n@Before:\nfunc (c Counter) Inc() {\n\tc.mu.Lock()\n\tc.n++\n\tc.mu.Unlock()\n}\n\n@After:\nfunc (c *Counter) Inc() {\n\tc.mu.Lock()\n\tc.n++\n\tc.mu.Unlock()\n}\n",
+	"longChain":           "! Detects repeated expression chains and suggest to refactor them.\n\n@Before:\na := q.w.e.r.t + 1\nb := q.w.e.r.t + 2\nc := q.w.e.r.t + 3\nv := (a + xs[i+1]) + (b + xs[i+1]) + (c + xs[i+1])\n\n@After:\nx := xs[i+1]\nqwert := q.w.e.r.t\na := qwert + 1\nb := qwert + 2\nc := qwert + 3\nv := (a + x) + (b + x) + (c + x)\n",
+	"manualContains":      "! Detects loops that check slice membership and can use slices.Contains.\n\nOnly reported for Go 1.21 and newer, where slices package is available.\nFix is suggested if the flag is initialized right before the loop\nor if the loop is followed by `return false`.\n\n@Before:\nfound := false\nfor _, v := range list {\n\tif v == target {\n\t\tfound = true\n\t\tbreak\n\t}\n}\n\n@After:\nfound := slices.Contains(list, target)\n",
+	"manualMinMax":        "! Detects if-else statements that can be replaced with min/max builtin calls.\n\nOnly reported for Go 1.21 and newer, where min and max builtins are available.\nFloat operands are reported with low confidence and without a fix,\nas min and max propagate NaN values, unlike the comparison.\n\n@Before:\nif a < b {\n\tm = a\n} else {\n\tm = b\n}\n\n@After:\nm = min(a, b)\n",
+	"mapDuplicateKeys":    "! Detects duplicated keys in map literals.\n\nDuplicated constant keys are rejected by the compiler,\nso only non-constant keys without side effects are reported,\nlike variables, field selectors and composite literals.\n\n@Before:\nm := map[string]int{\n\tp.name: 1,\n\tp.name: 2,\n}\n\n@After:\nm := map[string]int{\n\tp.name:  1,\n\tp2.name: 2,\n}\n",
+	"mapOrderDependence":  "! Detects functions that promise ordered results built from map iteration.\n\nReports map keys or values appended to a slice that is\nreturned unsorted by a function named Sorted* or Ordered*.\n\n@Before:\nfunc SortedKeys(m map[string]int) []string {\n\tvar keys []string\n\tfor k := range m {\n\t\tkeys = append(keys, k)\n\t}\n\treturn keys\n}\n\n@After:\nfunc SortedKeys(m map[string]int) []string {\n\tvar keys []string\n\tfor k := range m {\n\t\tkeys = append(keys, k)\n\t}\n\tsort.Strings(keys)\n\treturn keys\n}\n",
+	"missingExportedDoc":  "! Detects exported declarations without doc comments.\n\nDeclarations inside documented const and var groups are\nconsidered documented. Methods of unexported types are not checked.\n\nChecker params:\n\tcheckPrefix - if \"true\", doc comment must start with the declared name\n\n@Before:\nfunc Parse(s string) (*Config, error)\n\n@After:\n// Parse returns config described by s.\nfunc Parse(s string) (*Config, error)\n",
+	"namedConst":          "! Detects literals that can be replaced with defined named const.\n\n@Before:\n// pos has type of token.Pos.\nreturn pos != 0\n\n@After:\nreturn pos != token.NoPos\n",
+	"nestingReduce":       "! Finds where nesting level could be reduced.\n\n@Before:\nfor _, v := range a {\n\tif v.Bool {\n\t\tbody()\n\t}\n}\n\n@After:\nfor _, v := range a {\n\tif !v.Bool {\n\t\tcontinue\n\t}\n\tbody()\n}\n",
+	"nilByteSliceCompare": "! Detects []byte nil checks that are likely meant to be emptiness checks.\n\nNon-nil empty slice is not equal to nil, while\nreflect.DeepEqual with []byte{} is false for nil slice.\nNil checks combined with len checks are not reported.\n\n@Before:\nif b == nil {\n\treturn errEmpty\n}\nif reflect.DeepEqual(b, []byte{}) {\n\treturn errEmpty\n}\n\n@After:\nif len(b) == 0 {\n\treturn errEmpty\n}\nif len(b) == 0 {\n\treturn errEmpty\n}\n",
+	"nilValReturn":        "! Detects return statements that swallow or misuse the checked error.\n\nReports `if err != nil { return nil }` bodies that don't use err,\nso the error is silently lost, and `if err == nil { return err }`,\nwhere returned value is always nil.\n\n@Before:\nif err != nil {\n\treturn nil\n}\n\n@After:\nif err != nil {\n\treturn err\n}\n",
+	"numericLiteral":      "! Detects numeric literals that can be written in a more readable way.\n\nReports mixed-case hex literals, old-style octal literals\nand long decimal literals without digit separators.\nOctal and separator suggestions are only made for Go 1.13 and newer,\nwhere 0o prefix and _ separators are available.\n\nChecker params:\n\tcheckHex     - if \"true\", mixed-case hex literals are reported\n\tcheckOctal   - if \"true\", old-style octal literals are reported\n\tminSepDigits - decimal literals with at least that many digits need separators, 0 disables the check\n\n@Before:\nconst (\n\tmask = 0xFf\n\tperm = 0755\n\tsize = 10000000\n)\n\n@After:\nconst (\n\tmask = 0xff\n\tperm = 0o755\n\tsize = 10_000_000\n)\n",
+	"paramTypeCombine":    "! Detects if function parameters could be combined by type and suggest the way to do it.\n\nChecker params:\n\tskipExported - if \"true\", exported functions and methods of exported types are not checked\n\n@Before:\nfunc foo(a, b int, c, d int, e, f int, g int) {}\n\n@After:\nfunc foo(a, b, c, d, e, f, g int) {}\n",
+	"preferTimeHelpers":   "! Detects time.Now() expressions that can be replaced with time package helpers.\n\ntime.Until suggestions are only made for Go 1.8 and newer.\n\n@Before:\nelapsed := time.Now().Sub(start)\nleft := deadline.Sub(time.Now())\n\n@After:\nelapsed := time.Since(start)\nleft := time.Until(deadline)\n",
+	"ptrToRefParam":       "! Detects input and output parameters that have a type of pointer to referential type.\n\n@Before:\nfunc f(m *map[string]int) (ch *chan *int)\n\n@After:\nfunc f(m map[string]int) (ch chan *int)\n\n@Note:\n> Slices are not as referential as maps or channels, but it's usually\n> better to return them by value rather than modyfing them by pointer.\n",
+	"rangeExprCopy":       "! Detects expensive copies of `for` loop range expressions.\n\nSuggests to use pointer to array to avoid the copy using `&` on range expression.\n\n@Before:\nvar xs [256]byte\nfor _, x := range xs {\n\t// Loop body.\n}\n\n@After:\nvar xs [256]byte\nfor _, x := range &xs {\n\t// Loop body.\n}\n",
+	"rangeValCopy":        "! Detects loops that copy big objects during each iteration.\n\nSuggests to use index access or take address and make use pointer instead.\n\nChecker params:\n\tsizeThreshold - minimal element size in bytes that is reported (48 by default)\n\n@Before:\nxs := make([][1024]byte, length)\nfor _, x := range xs {\n\t// Loop body.\n}\n\n@After:\nxs := make([][1024]byte, length)\nfor i := range xs {\n\tx := &xs[i]\n\t// Loop body.\n}\n",
+	"regexpCompileInLoop": "! Detects regexp compilation of constant patterns inside loops.\n\nReports regexp.Compile and regexp.MustCompile calls, as well as\nregexp.Match functions, that compile the same pattern on every iteration.\nOnly loop bodies are checked, calls inside function literals are not reported.\n\n@Before:\nfor _, s := range lines {\n\tre := regexp.MustCompile(`^\\d+$`)\n\tif re.MatchString(s) {\n\t\tn++\n\t}\n}\n\n@After:\nvar digitsRE = regexp.MustCompile(`^\\d+$`)\n\nfor _, s := range lines {\n\tif digitsRE.MatchString(s) {\n\t\tn++\n\t}\n}\n",
+	"regexpMust":          "! Detects `regexp.Compile*` that can be replaced with `regexp.MustCompile*`.\n\n@Before:\nre, _ := regexp.Compile(`const pattern`)\n\n@After:\nre := regexp.MustCompile(`const pattern`)\n",
+	"regexpPlainLiteral":  "! Detects regexp matching with patterns that have no metacharacters.\n\nSuch patterns match a literal string, so regexp usage\ncan be replaced with much faster strings/bytes functions.\n\n@Before:\nok := regexp.MustCompile(\"abc\").MatchString(s)\n\n@After:\nok := strings.Contains(s, \"abc\")\n",
+	"reverseIndexLoop":    "! Detects reverse index loops that can use slices.Backward.\n\nLoop is only reported if its index is used to read slice\nelements and nothing else. Only reported for Go 1.23 and newer.\n\n@Before:\nfor i := len(xs) - 1; i >= 0; i-- {\n\tfmt.Println(xs[i])\n}\n\n@After:\nfor _, v := range slices.Backward(xs) {\n\tfmt.Println(v)\n}\n",
+	"singleCaseSwitch":    "! Detects switch statements that could be better written as if statements.\n\nType switches get a type assertion suggestion.\nSwitches with a break statement inside the case body are not reported,\nsince break would refer to the enclosing loop after the rewrite.\n\n@Before:\nswitch x := x.(type) {\ncase int:\n\tbody()\n}\n\n@After:\nif x, ok := x.(int); ok {\n\tbody()\n}\n",
+	"sloppyLen":           "! Detects len() comparisons that are always true, always false or can be simplified.\n\nLength can't be negative, so `len(x) >= 0` is always true,\n`len(x) < 0` is always false and `len(x) <= 0` means `len(x) == 0`.\n\n@Before:\nif len(arr) <= 0 {\n\treturn\n}\n\n@After:\nif len(arr) == 0 {\n\treturn\n}\n",
+	"sprintfInt":          "! Detects fmt.Sprintf(\"%d\", n) calls with int argument that can use strconv.Itoa.\n\nstrconv.Itoa avoids fmt formatting and reflection overhead.\n\n@Before:\ns := fmt.Sprintf(\"%d\", n)\n\n@After:\ns := strconv.Itoa(n)\n",
+	"sprintfQuotedString": "! Detects \"%s\" formatting directives wrapped in quotes and redundant fmt.Sprintf(\"%s\", s) calls.\n\n%q verb quotes and escapes the value, so it's better\nthan manual quoting that produces broken output for\nstrings that contain quotes or control characters.\n\n@Before:\nfmt.Printf(\"unknown name \\\"%s\\\"\\n\", name)\ns := fmt.Sprintf(\"%s\", name)\n\n@After:\nfmt.Printf(\"unknown name %q\\n\", name)\ns := name\n\n@Note:\nValues of named string types are converted to string.\n",
+	"stdExpr":             "! Detects constant expressions that can be replaced by a named constant\n from standard library, like `math.MaxInt32`.\n\n@Before:\nintBytes := make([]byte, unsafe.Sizeof(0))\nmaxVal := 1<<7 - 1\n\n@After:\nintBytes := make([]byte, bits.IntSize)\nmaxVal := math.MaxInt8\n",
+	"stringXbytes":        "! Detects redundant conversions between string and []byte.\n\nReports conversion round-trips like `string([]byte(s))`,\n`copy(b, []byte(s))`, as copy accepts string source,\nand `w.Write([]byte(fmt.Sprintf(...)))` that can use fmt.Fprintf.\n\n@Before:\ncopy(b, []byte(s))\nw.Write([]byte(fmt.Sprintf(\"%d items\", n)))\n\n@After:\ncopy(b, s)\nfmt.Fprintf(w, \"%d items\", n)\n",
+	"switchHygiene":       "! Detects redundant fallthrough and break statements in switch cases.\n\nReports cases that contain only fallthrough, so they can be merged with\nthe next case, fallthrough into an empty case and cases that contain\nonly unlabeled break, which is a no-op in Go.\n\n@Before:\nswitch kind {\ncase 'a':\n\tfallthrough\ncase 'b':\n\tletter()\ncase 'c':\n\tbreak\n}\n\n@After:\nswitch kind {\ncase 'a', 'b':\n\tletter()\ncase 'c':\n}\n",
+	"switchTrue":          "! Detects switch-over-bool statements that use explicit `true` tag value.\n\n@Before:\nswitch true {\ncase x > y:\n\t// ...\n}\n\n@After:\nswitch {\ncase x > y:\n\t// ...\n}\n",
+	"truncateCmp":         "! Detects potential truncation issues when comparing ints of different sizes.\n\nReports comparisons where one operand is converted to a narrower\ninteger type to match the other operand type.\nComparison result may change after the truncation,\nso it's better to convert the other operand to the wider type.\n\nChecker params:\n\tskipArchDependent - if \"true\", int, uint and uintptr sizes are assumed to be\n\t  the least favorable for the truncation (32 bits for the source type\n\t  and 64 bits for the result type), so reports don't depend on the target arch\n\n@Before:\nfunc f(x int32, y int16) bool {\n\treturn int16(x) < y\n}\n\n@After:\nfunc f(x int32, y int16) bool {\n\treturn x < int32(y)\n}\n",
+	"typeSwitchVar":       "! Detects type switches that can benefit from type guard clause with variable.\n\nCase types and asserted types are compared by identity,\nso assertions to type aliases are reported as well.\n\n@Before:\nswitch v.(type) {\ncase int:\n\treturn v.(int)\ncase point:\n\treturn v.(point).x + v.(point).y\ndefault:\n\treturn 0\n}\n\n@After:\nswitch v := v.(type) {\ncase int:\n\treturn v\ncase point:\n\treturn v.x + v.y\ndefault:\n\treturn 0\n}\n",
+	"typeUnparen":         "! Detects unneded parenthesis inside type expressions and suggests to remove them.\n\n@Before:\ntype foo [](func([](func())))\n\n@After:\ntype foo []func([]func())\n",
+	"underef":             "! Detects dereference expressions that can be omitted.\n\n@Before:\n(*k).field = 5\n_ := (*a)[5] // only if a is array\n\n@After:\nk.field = 5\n_ := a[5]\n",
+	"unexportedCall":      "! Detects calls of unexported method from unexported type outside that type.\n\n@Before:\nfunc baz(f foo) {\n\tfo.bar()\n}\n\n@After:\nfunc baz(f foo) {\n\tfo.Bar() // Made method exported\n}\n",
+	"unlambda":            "! Detects function literals that can be simplified.\n\nReports literals that only forward their params to another\nfunction with identical signature, so the function itself can be used.\nMethod values are reported with medium confidence, since\nthe receiver is evaluated once instead of on every call.\n\n@Before:\nf := func(x int) int { return fn(x) }\n\n@After:\nf := fn\n",
+	"unnamedResult":       "! For functions with multiple return values, detects unnamed results\n that do not match `(T, error)` or `(T, bool)` pattern.\n\n@Before:\nfunc f() (float64, float64)\n\n@After:\nfunc f() (x, y float64)\n",
+	"unslice":             "! Detects slice expressions that can be simplified to sliced expression itself.\n\nSlicing arrays and pointers to arrays is not reported,\nsince it converts them to slices.\n\n@Before:\nf(s[:])               // s is string\ncopy(b[:], values...) // b is []byte\n\n@After:\nf(s)\ncopy(b, values...)\n",
+	"unusedExported":      "! Detects exported identifiers that are not used outside of their package.\n\nPackage-level funcs, types, vars and consts are reported.\nMethods and struct fields are not, as they can be required\nby interfaces or used by reflection.\nOnly analyzed packages are searched for external uses,\nso the checker is run only for the whole program analysis,\nsee -wholeProgram flag.\n\n@Before:\n// Max is only used inside that package.\nfunc Max(x, y int) int {\n\tif x > y {\n\t\treturn x\n\t}\n\treturn y\n}\n\n@After:\nfunc max(x, y int) int {\n\tif x > y {\n\t\treturn x\n\t}\n\treturn y\n}\n\n@Note:\nIdentifiers of libraries are intended to be used by other modules,\nso the checker is mostly useful for the applications code.\n",
+	"unusedParam":         "! Detects unused params and suggests to name them as `_` (underscore).\n\n@Before:\nfunc f(a int, b float64) // b isn't used inside function body\n\n@After:\nfunc f(a int, _ float64) // everything is cool\n",
+	"weakCond":            "! Detects conditions that are unsafe due to not being exhaustive.\n\nNil check doesn't protect from indexing an empty slice,\nand `s == nil || len(s) != 0` is true for every slice except empty non-nil ones.\n\n@Before:\nxs != nil && xs[0] != nil\n\n@After:\nlen(xs) != 0 && xs[0] != nil\n",
+	"yodaStyleExpr":       "! Detects Yoda style expressions that suggest to replace them.\n\n@Before:\nreturn nil != ptr\n\n@After:\nreturn ptr != nil\n",
+}
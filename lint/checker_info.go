@@ -0,0 +1,173 @@
+package lint
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// CheckerDoc is a parsed checker documentation.
+//
+// Every checker is documented by a special "//!" comment
+// that is placed inside the checker implementation file:
+//
+//	//! Summary line, possibly continued
+//	// on the next lines.
+//	//
+//	// Optional details paragraphs.
+//	//
+//	// @Before:
+//	// code that triggers a warning
+//	//
+//	// @After:
+//	// code with the issue fixed
+//	//
+//	// @Note:
+//	// Optional notes.
+type CheckerDoc struct {
+	// Summary is a short one sentence checker description.
+	Summary string
+
+	// Details is an optional extended checker description.
+	Details string
+
+	// Before is a code snippet that triggers the checker warning.
+	Before string
+
+	// After is a code snippet that is a fixed Before version.
+	After string
+
+	// Note is an optional text that is shown after the examples.
+	Note string
+}
+
+// CheckerInfo describes a checker that is known to the lint package.
+type CheckerInfo struct {
+	AttributeSet
+	CheckerDoc
+
+	// Name is a checker name, the same as associated rule name.
+	Name string
+
+	// Tags is a list of tags that can be used to select the checker.
+	Tags []string
+
+	// Params are parameters that checker accepts.
+	// Their Doc is taken from the "Checker params:" part of Details.
+	Params []CheckerParam
+
+	// GoFeatures are Go features that checker requires.
+	GoFeatures []GoFeature
+}
+
+// CheckersInfo returns info for every checker that can be
+// created with NewChecker.
+// Slice is sorted by checker names.
+func CheckersInfo() []CheckerInfo {
+	rules := RuleList()
+	infoList := make([]CheckerInfo, 0, len(rules))
+	for _, rule := range rules {
+		info := CheckerInfo{
+			AttributeSet: rule.AttributeSet,
+			Name:         rule.Name(),
+			Tags:         rule.Tags(),
+			GoFeatures:   append([]GoFeature(nil), rule.GoFeatures...),
+		}
+		if doc := checkerPrototypes[rule.Name()].doc; doc != nil {
+			info.CheckerDoc = *doc
+		} else {
+			// Docs table is generated by the makedocs that reports
+			// all parsing errors, so there is no need to check them here.
+			info.CheckerDoc, _ = ParseCheckerDoc(checkerDocs[rule.Name()])
+		}
+		for _, p := range rule.Params {
+			if p.Doc == "" {
+				p.Doc = paramDoc(info.Details, p.Name)
+			}
+			info.Params = append(info.Params, p)
+		}
+		infoList = append(infoList, info)
+	}
+	return infoList
+}
+
+// paramDoc returns the description of the name param from the
+// checker details text. Params are documented as "\tname - doc" lines.
+func paramDoc(details, name string) string {
+	for _, line := range strings.Split(details, "\n") {
+		if !strings.HasPrefix(line, "\t") {
+			continue
+		}
+		fields := strings.SplitN(strings.TrimSpace(line), " - ", 2)
+		if len(fields) == 2 && strings.TrimSpace(fields[0]) == name {
+			return strings.TrimSpace(fields[1])
+		}
+	}
+	return ""
+}
+
+// ParseCheckerDoc parses checker "//!" documentation comment text.
+//
+// Text is expected to be in the ast.CommentGroup.Text format.
+// On error, the returned doc contains all sections that were parsed
+// successfully before the error was encountered.
+func ParseCheckerDoc(text string) (CheckerDoc, error) {
+	var doc CheckerDoc
+
+	if !strings.HasPrefix(text, "!") {
+		return doc, errors.New("doc comment should start with `!`")
+	}
+	lines := strings.Split(strings.TrimPrefix(text, "!"), "\n")
+
+	// Summary is the first paragraph.
+	i := 0
+	var summary []string
+	for ; i < len(lines) && !isDocBlank(lines[i]); i++ {
+		summary = append(summary, strings.TrimSpace(lines[i]))
+	}
+	doc.Summary = strings.Join(summary, " ")
+	if doc.Summary == "" {
+		return doc, errors.New("empty summary")
+	}
+
+	// Everything else is split into sections by the @-markers.
+	// Text that precedes the first marker is a details section.
+	sections := map[string]*string{
+		"":         &doc.Details,
+		"@Before:": &doc.Before,
+		"@After:":  &doc.After,
+		"@Note:":   &doc.Note,
+	}
+	section := ""
+	var body []string
+	flush := func() {
+		*sections[section] = strings.Trim(strings.Join(body, "\n"), "\n")
+		body = body[:0]
+	}
+	for ; i < len(lines); i++ {
+		l := lines[i]
+		if !strings.HasPrefix(l, "@") {
+			body = append(body, l)
+			continue
+		}
+		flush()
+		marker := strings.TrimSpace(l)
+		if _, ok := sections[marker]; !ok {
+			return doc, fmt.Errorf("unexpected %s section", marker)
+		}
+		section = marker
+	}
+	flush()
+
+	switch {
+	case doc.Before == "":
+		return doc, errors.New("no @Before: section found")
+	case doc.After == "":
+		return doc, errors.New("no @After: section found")
+	}
+	return doc, nil
+}
+
+func isDocBlank(line string) bool {
+	return strings.TrimSpace(line) == ""
+}
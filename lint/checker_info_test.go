@@ -0,0 +1,183 @@
+package lint
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCheckersInfo(t *testing.T) {
+	infoByName := make(map[string]CheckerInfo)
+	for _, info := range CheckersInfo() {
+		infoByName[info.Name] = info
+	}
+	if len(infoByName) != len(ruleList) {
+		t.Errorf("checkers info count mismatch: have %d, want %d",
+			len(infoByName), len(ruleList))
+	}
+
+	tests := []struct {
+		name string
+		doc  CheckerDoc
+	}{
+		{
+			name: "boolExprSimplify",
+			doc: CheckerDoc{
+				Summary: "Detects bool expressions that can be simplified for the sake of readability.",
+				Details: "Checker params:\n\tpushNegations - if \"true\", negations are pushed inside every && and || chain, like in `!(a && b)` => `!a || !b`",
+				Before:  "a := !(elapsed >= expectElapsedMin)\nb := !(x) == !(y)\nc := ok == false",
+				After:   "a := elapsed < expectElapsedMin\nb := x == y\nc := !ok",
+			},
+		},
+		{
+			name: "dupSubExpr",
+			doc: CheckerDoc{
+				Summary: "Detects suspicious duplicated sub-expressions.",
+				Details: "Duplicated float operands are not reported, as they are legit for NaN values.\n" +
+					"Operands of type params that permit float types are treated as floats.\n" +
+					"NaN checks like `x != x` are reported as info with math.IsNaN suggestion.\n\n" +
+					"Checker params:\n\tcheckFloats - if \"true\", other duplicated float operands are reported with low confidence",
+				Before: "sort.Slice(xs, func(i, j int) bool {\n\treturn xs[i].v < xs[i].v // Duplicated index\n})",
+				After:  "sort.Slice(xs, func(i, j int) bool {\n\treturn xs[i].v < xs[j].v\n})",
+			},
+		},
+		{
+			name: "flagDeref",
+			doc: CheckerDoc{
+				Summary: "Detects immediate dereferencing of `flag` package pointers.",
+				Details: "Suggests using `XxxVar` functions to achieve desired effect.\n" +
+					"Both flag package functions and flag.FlagSet methods are checked.",
+				Before: `b := *flag.Bool("b", false, "b docs")`,
+				After:  "var b bool\nflag.BoolVar(&b, \"b\", false, \"b docs\")",
+				Note: "> Dereferencing returned pointers will lead to hard to find errors\n" +
+					"> where flag values are not updated after flag.Parse().",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		info, ok := infoByName[test.name]
+		if !ok {
+			t.Errorf("%s: checker info not found", test.name)
+			continue
+		}
+		if info.CheckerDoc != test.doc {
+			t.Errorf("%s: doc mismatch:\nhave: %#v\nwant: %#v",
+				test.name, info.CheckerDoc, test.doc)
+		}
+	}
+}
+
+func TestCheckersInfoParams(t *testing.T) {
+	var info CheckerInfo
+	for _, x := range CheckersInfo() {
+		if x.Name == "goGenerateTool" {
+			info = x
+		}
+	}
+	if want := []string{TagExperimental}; !reflect.DeepEqual(info.Tags, want) {
+		t.Errorf("have %q tags, want %q", info.Tags, want)
+	}
+	if !info.EnvDependent {
+		t.Errorf("goGenerateTool is not EnvDependent")
+	}
+	want := []CheckerParam{
+		{
+			Name:    "strategy",
+			Default: "all",
+			Values:  []string{"all", "path", "goRun"},
+			Doc:     `which tools to check: "all" (default), "path" or "goRun"`,
+		},
+		{
+			Name: "skip",
+			Doc:  "comma-separated list of tool names that are never reported",
+		},
+	}
+	if !reflect.DeepEqual(info.Params, want) {
+		t.Errorf("params mismatch:\nhave: %+v\nwant: %+v", info.Params, want)
+	}
+}
+
+func TestCheckerAttributes(t *testing.T) {
+	for _, info := range CheckersInfo() {
+		rule := findRule(info.Name)
+		if rule == nil {
+			t.Errorf("%s: rule not found", info.Name)
+			continue
+		}
+		if info.AttributeSet != rule.AttributeSet {
+			t.Errorf("%s: attributes mismatch", info.Name)
+		}
+	}
+}
+
+// TestCheckerDocsGenerated makes sure that checkers docs table
+// is in sync with checkers source code comments.
+// Run `make docs` to re-generate docs table if this test fails.
+func TestCheckerDocsGenerated(t *testing.T) {
+	pkgs, err := parser.ParseDir(token.NewFileSet(), ".", func(info os.FileInfo) bool {
+		return strings.HasSuffix(info.Name(), "_checker.go")
+	}, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("parse checkers: %v", err)
+	}
+
+	for _, rule := range ruleList {
+		f := pkgs["lint"].Files[rule.Name()+"_checker.go"]
+		if f == nil {
+			t.Errorf("%s: checker source file not found", rule.Name())
+			continue
+		}
+		text := ""
+		for _, cg := range f.Comments {
+			if strings.HasPrefix(cg.Text(), "!") {
+				text = cg.Text()
+				break
+			}
+		}
+		if _, err := ParseCheckerDoc(text); err != nil {
+			t.Errorf("%s: parse doc: %v", rule.Name(), err)
+		}
+		if checkerDocs[rule.Name()] != text {
+			t.Errorf("%s: docs table is outdated", rule.Name())
+		}
+	}
+}
+
+func TestParseCheckerDoc(t *testing.T) {
+	tests := []struct {
+		text string
+		err  string
+	}{
+		{"", "doc comment should start with `!`"},
+		{"!\n\n@Before:\nx\n", "empty summary"},
+		{"! Summary.\n\n@After:\ny\n", "no @Before: section found"},
+		{"! Summary.\n\n@Before:\nx\n", "no @After: section found"},
+		{"! Summary.\n\n@Before:\nx\n\n@Unknown:\n", "unexpected @Unknown: section"},
+		{"! Summary.\n\n@Before:\nx\n\n\n@After:\ny\n", ""},
+	}
+
+	for _, test := range tests {
+		_, err := ParseCheckerDoc(test.text)
+		have := ""
+		if err != nil {
+			have = err.Error()
+		}
+		if have != test.err {
+			t.Errorf("parse %q:\nhave error: %q\nwant error: %q",
+				test.text, have, test.err)
+		}
+	}
+}
+
+func findRule(name string) *Rule {
+	for _, rule := range ruleList {
+		if rule.Name() == name {
+			return rule
+		}
+	}
+	return nil
+}
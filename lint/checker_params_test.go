@@ -0,0 +1,665 @@
+package lint
+
+import (
+	"go/ast"
+	"reflect"
+	"testing"
+
+	"github.com/go-toolsmith/astfmt"
+)
+
+func TestSetCheckerParam(t *testing.T) {
+	tests := []struct {
+		checker string
+		name    string
+		value   string
+		err     string
+	}{
+		{"goGenerateTool", "skip", "stringer", ""},
+		{"goGenerateTool", "strategy", "path", ""},
+		{"emptySelect", "skipMain", "true", ""},
+		{"noSuchChecker", "skip", "", "noSuchChecker: checker not found"},
+		{"goGenerateTool", "noSuchParam", "", "goGenerateTool.noSuchParam: param not found"},
+		{"goGenerateTool", "strategy", "none", `goGenerateTool.strategy: "none" is not one of all, path, goRun`},
+		{"emptySelect", "skipMain", "yes", `emptySelect.skipMain: "yes" is not a bool`},
+	}
+
+	for _, test := range tests {
+		ctx := NewContext(nil, sizes)
+		err := ctx.SetCheckerParam(test.checker, test.name, test.value)
+		have := ""
+		if err != nil {
+			have = err.Error()
+		}
+		if have != test.err {
+			t.Errorf("set %s.%s=%q:\nhave error: %q\nwant error: %q",
+				test.checker, test.name, test.value, have, test.err)
+		}
+	}
+}
+
+// TestCheckerParamsDocumented makes sure that every declared
+// checker parameter is mentioned in the checker documentation.
+func TestCheckerParamsDocumented(t *testing.T) {
+	for _, info := range CheckersInfo() {
+		for _, p := range info.Params {
+			if p.Doc == "" {
+				t.Errorf("%s: param %s is not documented", info.Name, p.Name)
+			}
+		}
+	}
+}
+
+func TestParamDefaults(t *testing.T) {
+	ctx := NewContext(nil, sizes)
+	c := NewChecker(findRule("goGenerateTool"), ctx)
+	if c.ctx.Param("strategy") != "all" {
+		t.Errorf("have %q strategy, want default %q", c.ctx.Param("strategy"), "all")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected panic for undeclared param")
+		}
+	}()
+	c.ctx.Param("noSuchParam")
+}
+
+func TestGoGenerateToolParams(t *testing.T) {
+	rule := findRule("goGenerateTool")
+	if rule == nil {
+		t.Fatal("goGenerateTool rule not found")
+	}
+	pkgPath := testdataPkgPath + rule.Name()
+	prog := newProg(t, pkgPath)
+	pkgInfo := prog.Imported[pkgPath]
+
+	tests := []struct {
+		params map[string]string
+		want   []string
+	}{
+		{
+			params: map[string]string{"strategy": "goRun"},
+			want:   []string{"./nogen", "./nogen", "non-existing-gocritic-gen.go"},
+		},
+		{
+			params: map[string]string{"strategy": "path", "skip": "non-existing-gocritic-tool,gen"},
+			want:   []string{"./gen.sh"},
+		},
+		{
+			params: map[string]string{"skip": "./gen.sh,./nogen,gen"},
+			want:   []string{"non-existing-gocritic-tool", "non-existing-gocritic-gen.go"},
+		},
+	}
+
+	for _, test := range tests {
+		ctx := NewContext(prog.Fset, sizes)
+		ctx.SetPackageInfo(&pkgInfo.Info, pkgInfo.Pkg)
+		for name, value := range test.params {
+			if err := ctx.SetCheckerParam(rule.Name(), name, value); err != nil {
+				t.Fatalf("set param: %v", err)
+			}
+		}
+
+		var have []string
+		c := NewChecker(rule, ctx)
+		for _, f := range pkgInfo.Files {
+			for _, warn := range c.Check(f) {
+				have = append(have, warn.Text)
+			}
+		}
+		var want []string
+		for _, tool := range test.want {
+			want = append(want, "go:generate references tool \""+tool+"\" which was not found")
+		}
+		if !reflect.DeepEqual(have, want) {
+			t.Errorf("params %v:\nhave: %q\nwant: %q", test.params, have, want)
+		}
+	}
+}
+
+func TestEmptySelectParams(t *testing.T) {
+	rule := findRule("emptySelect")
+	if rule == nil {
+		t.Fatal("emptySelect rule not found")
+	}
+	pkgPath := testdataPkgPath + rule.Name()
+	prog := newProg(t, pkgPath)
+	pkgInfo := prog.Imported[pkgPath]
+
+	tests := []struct {
+		skipMain string
+		want     []string
+	}{
+		{"false", []string{"main", "serve", "wait"}},
+		{"true", []string{"serve", "wait"}},
+	}
+
+	for _, test := range tests {
+		ctx := NewContext(prog.Fset, sizes)
+		ctx.SetPackageInfo(&pkgInfo.Info, pkgInfo.Pkg)
+		if err := ctx.SetCheckerParam(rule.Name(), "skipMain", test.skipMain); err != nil {
+			t.Fatalf("set param: %v", err)
+		}
+
+		var have []string
+		c := NewChecker(rule, ctx)
+		for _, f := range pkgInfo.Files {
+			for _, warn := range c.Check(f) {
+				if warn.Severity != SeverityInfo {
+					t.Errorf("have %s severity, want info", warn.Severity)
+				}
+				for _, decl := range f.Decls {
+					if decl.Pos() <= warn.Node.Pos() && warn.Node.End() <= decl.End() {
+						have = append(have, decl.(*ast.FuncDecl).Name.Name)
+					}
+				}
+			}
+		}
+		if !reflect.DeepEqual(have, test.want) {
+			t.Errorf("skipMain=%s:\nhave: %q\nwant: %q", test.skipMain, have, test.want)
+		}
+	}
+}
+
+func TestMissingExportedDocParams(t *testing.T) {
+	rule := findRule("missingExportedDoc")
+	if rule == nil {
+		t.Fatal("missingExportedDoc rule not found")
+	}
+	pkgPath := testdataPkgPath + rule.Name()
+	prog := newProg(t, pkgPath)
+	pkgInfo := prog.Imported[pkgPath]
+
+	tests := []struct {
+		checkPrefix string
+		want        []string
+	}{
+		{"false", nil},
+		{"true", []string{`comment on exported func Prefixed should be of the form "Prefixed ..."`}},
+	}
+
+	for _, test := range tests {
+		ctx := NewContext(prog.Fset, sizes)
+		ctx.SetPackageInfo(&pkgInfo.Info, pkgInfo.Pkg)
+		if err := ctx.SetCheckerParam(rule.Name(), "checkPrefix", test.checkPrefix); err != nil {
+			t.Fatalf("set param: %v", err)
+		}
+
+		var have []string
+		c := NewChecker(rule, ctx)
+		for _, f := range pkgInfo.Files {
+			if getFilename(prog, f) != "negative_tests.go" {
+				continue
+			}
+			for _, warn := range c.Check(f) {
+				have = append(have, warn.Text)
+			}
+		}
+		if !reflect.DeepEqual(have, test.want) {
+			t.Errorf("checkPrefix=%s:\nhave: %q\nwant: %q", test.checkPrefix, have, test.want)
+		}
+	}
+}
+
+func TestDupSubExprParams(t *testing.T) {
+	rule := findRule("dupSubExpr")
+	if rule == nil {
+		t.Fatal("dupSubExpr rule not found")
+	}
+	pkgPath := testdataPkgPath + rule.Name()
+	prog := newProg(t, pkgPath)
+	pkgInfo := prog.Imported[pkgPath]
+
+	tests := []struct {
+		checkFloats string
+		want        []string
+	}{
+		{"false", nil},
+		{"true", []string{"<=", ">=", "/", "-", "-", "/"}},
+	}
+
+	for _, test := range tests {
+		ctx := NewContext(prog.Fset, sizes)
+		ctx.SetPackageInfo(&pkgInfo.Info, pkgInfo.Pkg)
+		if err := ctx.SetCheckerParam(rule.Name(), "checkFloats", test.checkFloats); err != nil {
+			t.Fatalf("set param: %v", err)
+		}
+
+		var have []string
+		c := NewChecker(rule, ctx)
+		for _, f := range pkgInfo.Files {
+			if getFilename(prog, f) != "negative_tests.go" {
+				continue
+			}
+			for _, warn := range c.Check(f) {
+				if warn.Confidence != ConfidenceLow {
+					t.Errorf("have %s confidence, want low", warn.Confidence)
+				}
+				have = append(have, warn.Node.(*ast.BinaryExpr).Op.String())
+			}
+		}
+		if !reflect.DeepEqual(have, test.want) {
+			t.Errorf("checkFloats=%s:\nhave: %q\nwant: %q", test.checkFloats, have, test.want)
+		}
+	}
+}
+
+func TestRangeValCopyParams(t *testing.T) {
+	rule := findRule("rangeValCopy")
+	if rule == nil {
+		t.Fatal("rangeValCopy rule not found")
+	}
+	pkgPath := testdataPkgPath + rule.Name()
+	prog := newProg(t, pkgPath)
+	pkgInfo := prog.Imported[pkgPath]
+
+	tests := []struct {
+		sizeThreshold string
+		want          int
+	}{
+		{"48", 2},
+		{"32", 3},
+		{"1032", 2},
+		{"1033", 0},
+	}
+
+	for _, test := range tests {
+		ctx := NewContext(prog.Fset, sizes)
+		ctx.SetPackageInfo(&pkgInfo.Info, pkgInfo.Pkg)
+		if err := ctx.SetCheckerParam(rule.Name(), "sizeThreshold", test.sizeThreshold); err != nil {
+			t.Fatalf("set param: %v", err)
+		}
+
+		have := 0
+		c := NewChecker(rule, ctx)
+		for _, f := range pkgInfo.Files {
+			have += len(c.Check(f))
+		}
+		if have != test.want {
+			t.Errorf("sizeThreshold=%s: have %d warnings, want %d", test.sizeThreshold, have, test.want)
+		}
+	}
+}
+
+func TestHugeParamParams(t *testing.T) {
+	rule := findRule("hugeParam")
+	if rule == nil {
+		t.Fatal("hugeParam rule not found")
+	}
+	pkgPath := testdataPkgPath + rule.Name()
+	prog := newProg(t, pkgPath)
+	pkgInfo := prog.Imported[pkgPath]
+
+	tests := []struct {
+		sizeThreshold string
+		want          int
+	}{
+		{"80", 12},
+		{"1024", 3},
+		{"1601", 0},
+	}
+
+	for _, test := range tests {
+		ctx := NewContext(prog.Fset, sizes)
+		ctx.SetPackageInfo(&pkgInfo.Info, pkgInfo.Pkg)
+		if err := ctx.SetCheckerParam(rule.Name(), "sizeThreshold", test.sizeThreshold); err != nil {
+			t.Fatalf("set param: %v", err)
+		}
+
+		have := 0
+		c := NewChecker(rule, ctx)
+		for _, f := range pkgInfo.Files {
+			have += len(c.Check(f))
+		}
+		if have != test.want {
+			t.Errorf("sizeThreshold=%s: have %d warnings, want %d", test.sizeThreshold, have, test.want)
+		}
+	}
+}
+
+func TestDeferInLoopParams(t *testing.T) {
+	rule := findRule("deferInLoop")
+	if rule == nil {
+		t.Fatal("deferInLoop rule not found")
+	}
+	pkgPath := testdataPkgPath + rule.Name()
+	prog := newProg(t, pkgPath)
+	pkgInfo := prog.Imported[pkgPath]
+
+	tests := []struct {
+		closeOnly string
+		want      []string
+	}{
+		{"false", []string{"ff", "func literal", "f", "f.Close", "println"}},
+		{"true", []string{"f.Close"}},
+	}
+
+	for _, test := range tests {
+		ctx := NewContext(prog.Fset, sizes)
+		ctx.SetPackageInfo(&pkgInfo.Info, pkgInfo.Pkg)
+		if err := ctx.SetCheckerParam(rule.Name(), "closeOnly", test.closeOnly); err != nil {
+			t.Fatalf("set param: %v", err)
+		}
+
+		var have []string
+		c := NewChecker(rule, ctx)
+		for _, f := range pkgInfo.Files {
+			for _, warn := range c.Check(f) {
+				fn := warn.Node.(*ast.DeferStmt).Call.Fun
+				if _, ok := fn.(*ast.FuncLit); ok {
+					have = append(have, "func literal")
+				} else {
+					have = append(have, astfmt.Sprint(fn))
+				}
+			}
+		}
+		if !reflect.DeepEqual(have, test.want) {
+			t.Errorf("closeOnly=%s:\nhave: %q\nwant: %q", test.closeOnly, have, test.want)
+		}
+	}
+}
+
+func TestCaptLocalParams(t *testing.T) {
+	rule := findRule("captLocal")
+	if rule == nil {
+		t.Fatal("captLocal rule not found")
+	}
+	pkgPath := testdataPkgPath + rule.Name()
+	prog := newProg(t, pkgPath)
+	pkgInfo := prog.Imported[pkgPath]
+
+	tests := []struct {
+		paramsOnly string
+		want       int
+	}{
+		{"false", 24},
+		{"true", 11},
+	}
+
+	for _, test := range tests {
+		ctx := NewContext(prog.Fset, sizes)
+		ctx.SetPackageInfo(&pkgInfo.Info, pkgInfo.Pkg)
+		if err := ctx.SetCheckerParam(rule.Name(), "paramsOnly", test.paramsOnly); err != nil {
+			t.Fatalf("set param: %v", err)
+		}
+
+		have := 0
+		c := NewChecker(rule, ctx)
+		for _, f := range pkgInfo.Files {
+			have += len(c.Check(f))
+		}
+		if have != test.want {
+			t.Errorf("paramsOnly=%s: have %d warnings, want %d", test.paramsOnly, have, test.want)
+		}
+	}
+}
+
+func TestCommentedOutCodeParams(t *testing.T) {
+	rule := findRule("commentedOutCode")
+	if rule == nil {
+		t.Fatal("commentedOutCode rule not found")
+	}
+	pkgPath := testdataPkgPath + rule.Name()
+	prog := newProg(t, pkgPath)
+	pkgInfo := prog.Imported[pkgPath]
+	for _, f := range pkgInfo.Files {
+		stripDirectives(f)
+	}
+
+	tests := []struct {
+		minLength string
+		want      int
+	}{
+		{"0", 10},
+		{"15", 8},
+		{"1000", 1},
+	}
+
+	for _, test := range tests {
+		ctx := NewContext(prog.Fset, sizes)
+		ctx.SetPackageInfo(&pkgInfo.Info, pkgInfo.Pkg)
+		if err := ctx.SetCheckerParam(rule.Name(), "minLength", test.minLength); err != nil {
+			t.Fatalf("set param: %v", err)
+		}
+
+		have := 0
+		c := NewChecker(rule, ctx)
+		for _, f := range pkgInfo.Files {
+			have += len(c.Check(f))
+		}
+		if have != test.want {
+			t.Errorf("minLength=%s: have %d warnings, want %d", test.minLength, have, test.want)
+		}
+	}
+}
+
+func TestContextFirstParamParams(t *testing.T) {
+	rule := findRule("contextFirstParam")
+	if rule == nil {
+		t.Fatal("contextFirstParam rule not found")
+	}
+	pkgPath := testdataPkgPath + rule.Name()
+	prog := newProg(t, pkgPath)
+	pkgInfo := prog.Imported[pkgPath]
+
+	tests := []struct {
+		checkStructFields string
+		want              int
+	}{
+		{"true", 8},
+		{"false", 6},
+	}
+
+	for _, test := range tests {
+		ctx := NewContext(prog.Fset, sizes)
+		ctx.SetPackageInfo(&pkgInfo.Info, pkgInfo.Pkg)
+		if err := ctx.SetCheckerParam(rule.Name(), "checkStructFields", test.checkStructFields); err != nil {
+			t.Fatalf("set param: %v", err)
+		}
+
+		have := 0
+		c := NewChecker(rule, ctx)
+		for _, f := range pkgInfo.Files {
+			have += len(c.Check(f))
+		}
+		if have != test.want {
+			t.Errorf("checkStructFields=%s: have %d warnings, want %d", test.checkStructFields, have, test.want)
+		}
+	}
+}
+
+func TestTruncateCmpParams(t *testing.T) {
+	rule := findRule("truncateCmp")
+	if rule == nil {
+		t.Fatal("truncateCmp rule not found")
+	}
+	pkgPath := testdataPkgPath + rule.Name()
+	prog := newProg(t, pkgPath)
+	pkgInfo := prog.Imported[pkgPath]
+
+	tests := []struct {
+		skipArchDependent string
+		want              int
+	}{
+		{"true", 6},
+		{"false", 7},
+	}
+
+	for _, test := range tests {
+		ctx := NewContext(prog.Fset, sizes)
+		ctx.SetPackageInfo(&pkgInfo.Info, pkgInfo.Pkg)
+		if err := ctx.SetCheckerParam(rule.Name(), "skipArchDependent", test.skipArchDependent); err != nil {
+			t.Fatalf("set param: %v", err)
+		}
+
+		have := 0
+		c := NewChecker(rule, ctx)
+		for _, f := range pkgInfo.Files {
+			have += len(c.Check(f))
+		}
+		if have != test.want {
+			t.Errorf("skipArchDependent=%s: have %d warnings, want %d", test.skipArchDependent, have, test.want)
+		}
+	}
+}
+
+func TestNumericLiteralParams(t *testing.T) {
+	rule := findRule("numericLiteral")
+	if rule == nil {
+		t.Fatal("numericLiteral rule not found")
+	}
+	pkgPath := testdataPkgPath + rule.Name()
+	prog := newProg(t, pkgPath)
+	pkgInfo := prog.Imported[pkgPath]
+
+	tests := []struct {
+		param   string
+		value   string
+		version string
+		want    int
+	}{
+		{"checkHex", "true", "", 8},
+		{"checkHex", "false", "", 5},
+		{"checkOctal", "false", "", 5},
+		{"minSepDigits", "0", "", 6},
+		{"minSepDigits", "6", "", 9},
+		{"checkHex", "true", "go1.12", 3},
+	}
+
+	for _, test := range tests {
+		ctx := NewContext(prog.Fset, sizes)
+		ctx.SetPackageInfo(&pkgInfo.Info, pkgInfo.Pkg)
+		if err := ctx.SetCheckerParam(rule.Name(), test.param, test.value); err != nil {
+			t.Fatalf("set param: %v", err)
+		}
+		if test.version != "" {
+			if err := ctx.SetGoVersion(test.version); err != nil {
+				t.Fatalf("set Go version: %v", err)
+			}
+		}
+
+		have := 0
+		c := NewChecker(rule, ctx)
+		for _, f := range pkgInfo.Files {
+			have += len(c.Check(f))
+		}
+		if have != test.want {
+			t.Errorf("%s=%s (%s): have %d warnings, want %d",
+				test.param, test.value, test.version, have, test.want)
+		}
+	}
+}
+
+func TestDupFuncParams(t *testing.T) {
+	rule := findRule("dupFunc")
+	pkgPath := testdataPkgPath + "dupFunc"
+	prog := newProg(t, pkgPath)
+	pkgInfo := prog.Imported[pkgPath]
+
+	tests := []struct {
+		minStmts string
+		want     int
+	}{
+		{"4", 3},
+		{"6", 2},
+		{"100", 0},
+	}
+	for _, test := range tests {
+		ctx := NewContext(prog.Fset, sizes)
+		ctx.SetPackageInfo(&pkgInfo.Info, pkgInfo.Pkg)
+		if err := ctx.SetCheckerParam("dupFunc", "minStmts", test.minStmts); err != nil {
+			t.Fatal(err)
+		}
+		c := NewChecker(rule, ctx)
+		have := 0
+		for _, f := range pkgInfo.Files {
+			if getFilename(prog, f) == "positive_tests.go" {
+				have += len(c.Check(f))
+			}
+		}
+		if have != test.want {
+			t.Errorf("minStmts=%s: have %d warnings, want %d", test.minStmts, have, test.want)
+		}
+	}
+}
+
+func TestIfElseChainParams(t *testing.T) {
+	rule := findRule("ifElseChain")
+	if rule == nil {
+		t.Fatal("ifElseChain rule not found")
+	}
+	pkgPath := testdataPkgPath + rule.Name()
+	prog := newProg(t, pkgPath)
+	pkgInfo := prog.Imported[pkgPath]
+
+	tests := []struct {
+		minThreshold string
+		want         int
+	}{
+		{"2", 10},
+		{"3", 2},
+		{"4", 0},
+	}
+
+	for _, test := range tests {
+		ctx := NewContext(prog.Fset, sizes)
+		ctx.SetPackageInfo(&pkgInfo.Info, pkgInfo.Pkg)
+		if err := ctx.SetCheckerParam(rule.Name(), "minThreshold", test.minThreshold); err != nil {
+			t.Fatalf("set param: %v", err)
+		}
+
+		have := 0
+		c := NewChecker(rule, ctx)
+		for _, f := range pkgInfo.Files {
+			if getFilename(prog, f) != "positive_tests.go" {
+				continue
+			}
+			have += len(c.Check(f))
+		}
+		if have != test.want {
+			t.Errorf("minThreshold=%s: have %d warnings, want %d", test.minThreshold, have, test.want)
+		}
+	}
+}
+
+func TestParamTypeCombineParams(t *testing.T) {
+	rule := findRule("paramTypeCombine")
+	if rule == nil {
+		t.Fatal("paramTypeCombine rule not found")
+	}
+	pkgPath := testdataPkgPath + rule.Name()
+	prog := newProg(t, pkgPath)
+	pkgInfo := prog.Imported[pkgPath]
+
+	tests := []struct {
+		skipExported string
+		want         []string
+	}{
+		{"false", []string{"Exported", "Method", "Method"}},
+		{"true", []string{"Method"}},
+	}
+
+	for _, test := range tests {
+		ctx := NewContext(prog.Fset, sizes)
+		ctx.SetPackageInfo(&pkgInfo.Info, pkgInfo.Pkg)
+		if err := ctx.SetCheckerParam(rule.Name(), "skipExported", test.skipExported); err != nil {
+			t.Fatalf("set param: %v", err)
+		}
+
+		var have []string
+		c := NewChecker(rule, ctx)
+		for _, f := range pkgInfo.Files {
+			for _, warn := range c.Check(f) {
+				for _, decl := range f.Decls {
+					decl, ok := decl.(*ast.FuncDecl)
+					if ok && warn.Node == decl.Type && decl.Name.IsExported() {
+						have = append(have, decl.Name.Name)
+					}
+				}
+			}
+		}
+		if !reflect.DeepEqual(have, test.want) {
+			t.Errorf("skipExported=%s:\nhave: %q\nwant: %q", test.skipExported, have, test.want)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package lint
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+// checkstyleReporter writes reports in Checkstyle XML format.
+//
+// Reports are grouped by file, checker names are used as error sources.
+// The format is understood by Jenkins and many other CI systems.
+type checkstyleReporter struct{}
+
+// Types below describe the subset of Checkstyle format that is used by checkstyleReporter.
+
+type checkstyleOutput struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr"`
+	Column   int    `xml:"column,attr"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+func (checkstyleReporter) Write(w io.Writer, reports []Report) error {
+	out := checkstyleOutput{Version: "5.0"}
+
+	// Reports are grouped by file, keeping the first appearance order.
+	files := make(map[string]int)
+	for _, r := range reports {
+		i, ok := files[r.Pos.Filename]
+		if !ok {
+			i = len(out.Files)
+			files[r.Pos.Filename] = i
+			out.Files = append(out.Files, checkstyleFile{Name: r.Pos.Filename})
+		}
+		out.Files[i].Errors = append(out.Files[i].Errors, checkstyleError{
+			Line:     r.Pos.Line,
+			Column:   r.Pos.Column,
+			Severity: r.Severity.String(),
+			Message:  r.Text,
+			Source:   r.Checker,
+		})
+	}
+
+	return writeXML(w, out)
+}
+
+// writeXML writes v as an indented XML document.
+func writeXML(w io.Writer, v interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "\t")
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -1,6 +1,13 @@
 package lint
 
-//! Detects commented-out code inside function bodies.
+//! Detects commented-out code.
+//
+// Comments inside function bodies are checked for statements,
+// package-level comments are checked for func, var and const declarations.
+// Doc comments are not checked, since they often contain usage examples.
+//
+// Checker params:
+//	minLength - comments that are shorter are not reported, unless they print something
 //
 // @Before:
 // // fmt.Println("Debugging hard")
@@ -11,10 +18,9 @@ package lint
 
 import (
 	"go/ast"
+	"go/parser"
 	"go/token"
 	"strings"
-
-	"github.com/go-toolsmith/strparse"
 )
 
 func init() {
@@ -23,9 +29,33 @@ func init() {
 
 type commentedOutCodeChecker struct {
 	checkerBase
+
+	minLength int
+
+	// file is a file that docs and funcs were collected for.
+	file  *ast.File
+	docs  map[*ast.CommentGroup]bool
+	funcs []*ast.FuncDecl
+}
+
+func (c *commentedOutCodeChecker) Params() []CheckerParam {
+	return []CheckerParam{
+		{Name: "minLength", Kind: ParamInt, Default: "15"},
+	}
+}
+
+func (c *commentedOutCodeChecker) Init() {
+	c.minLength = c.ctx.IntParam("minLength")
 }
 
-func (c *commentedOutCodeChecker) VisitLocalComment(cg *ast.CommentGroup) {
+func (c *commentedOutCodeChecker) VisitComment(cg *ast.CommentGroup) {
+	if c.ctx.file != c.file {
+		c.collectFileInfo(c.ctx.file)
+	}
+	if c.docs[cg] {
+		return
+	}
+
 	s := cg.Text() // Collect text once
 
 	// We do multiple heuristics to avoid false positives.
@@ -50,7 +80,7 @@ func (c *commentedOutCodeChecker) VisitLocalComment(cg *ast.CommentGroup) {
 	// Some very short comment that can be skipped.
 	// Usually triggering on these results in false positive.
 	// Unless there is a very popular call like print/println.
-	cond := len(s) < len("quite too short") &&
+	cond := len(s) < c.minLength &&
 		!strings.Contains(s, "print") &&
 		!strings.Contains(s, "fmt.") &&
 		!strings.Contains(s, "log.")
@@ -58,13 +88,77 @@ func (c *commentedOutCodeChecker) VisitLocalComment(cg *ast.CommentGroup) {
 		return
 	}
 
-	stmt := strparse.Stmt(s)
-	if stmt == strparse.BadStmt {
+	if c.isLocal(cg) {
+		c.checkStmts(cg, s)
+	} else {
+		c.checkDecls(cg, s)
+	}
+}
+
+func (c *commentedOutCodeChecker) collectFileInfo(f *ast.File) {
+	c.file = f
+	c.docs = make(map[*ast.CommentGroup]bool)
+	c.funcs = c.funcs[:0]
+
+	if f.Doc != nil {
+		c.docs[f.Doc] = true
+	}
+	ast.Inspect(f, func(x ast.Node) bool {
+		var doc *ast.CommentGroup
+		switch x := x.(type) {
+		case *ast.FuncDecl:
+			doc = x.Doc
+			if x.Body != nil {
+				c.funcs = append(c.funcs, x)
+			}
+		case *ast.GenDecl:
+			doc = x.Doc
+		case *ast.ValueSpec:
+			doc = x.Doc
+		case *ast.TypeSpec:
+			doc = x.Doc
+		case *ast.Field:
+			doc = x.Doc
+		}
+		if doc != nil {
+			c.docs[doc] = true
+		}
+		return true
+	})
+}
+
+func (c *commentedOutCodeChecker) isLocal(cg *ast.CommentGroup) bool {
+	for _, decl := range c.funcs {
+		if cg.Pos() > decl.Body.Lbrace && cg.End() <= decl.Body.Rbrace {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *commentedOutCodeChecker) checkStmts(cg *ast.CommentGroup, s string) {
+	f, err := parser.ParseFile(token.NewFileSet(), "", "package main;func main() {"+s+"\n}", 0)
+	if err != nil {
 		return // Most likely not a code
 	}
+	for _, stmt := range f.Decls[0].(*ast.FuncDecl).Body.List {
+		if !c.isPermittedStmt(stmt) {
+			c.warn(cg)
+			return
+		}
+	}
+}
 
-	if !c.isPermittedStmt(stmt) {
-		c.warn(cg)
+func (c *commentedOutCodeChecker) checkDecls(cg *ast.CommentGroup, s string) {
+	f, err := parser.ParseFile(token.NewFileSet(), "", "package main;"+s, 0)
+	if err != nil {
+		return // Most likely not a code
+	}
+	for _, decl := range f.Decls {
+		if !c.isPermittedDecl(decl) {
+			c.warn(cg)
+			return
+		}
 	}
 }
 
@@ -75,13 +169,19 @@ func (c *commentedOutCodeChecker) isPermittedStmt(stmt ast.Stmt) bool {
 	case *ast.LabeledStmt:
 		return c.isPermittedStmt(stmt.Stmt)
 	case *ast.DeclStmt:
-		decl := stmt.Decl.(*ast.GenDecl)
-		return decl.Tok == token.TYPE
+		return c.isPermittedDecl(stmt.Decl)
 	default:
 		return false
 	}
 }
 
+func (c *commentedOutCodeChecker) isPermittedDecl(decl ast.Decl) bool {
+	// Type declarations are too similar to a prose like "type foo bar".
+	// Import decls are permitted to avoid reporting import comments.
+	gen, ok := decl.(*ast.GenDecl)
+	return ok && (gen.Tok == token.TYPE || gen.Tok == token.IMPORT)
+}
+
 func (c *commentedOutCodeChecker) isPermittedExpr(x ast.Expr) bool {
 	// Permit anything except expressions that can be used
 	// with complete result discarding.
@@ -0,0 +1,65 @@
+package lint
+
+import (
+	"fmt"
+)
+
+// Confidence describes how likely the warning points to a real issue.
+//
+// Greater values mean higher confidence.
+type Confidence int
+
+// Confidence levels.
+const (
+	// ConfidenceLow is used for heuristic warnings that are
+	// frequently false positives.
+	ConfidenceLow Confidence = iota + 1
+
+	// ConfidenceMedium is used for warnings that are true positives
+	// most of the time, but not always.
+	ConfidenceMedium
+
+	// ConfidenceHigh is used for near-certain issues.
+	// This is a default confidence level.
+	ConfidenceHigh
+)
+
+// String returns confidence level name.
+func (conf Confidence) String() string {
+	switch conf {
+	case ConfidenceLow:
+		return "low"
+	case ConfidenceMedium:
+		return "medium"
+	case ConfidenceHigh:
+		return "high"
+	default:
+		return fmt.Sprintf("Confidence(%d)", int(conf))
+	}
+}
+
+// ParseConfidence returns confidence level by its name.
+// Valid names are "low", "medium" and "high".
+func ParseConfidence(s string) (Confidence, error) {
+	for _, conf := range []Confidence{ConfidenceLow, ConfidenceMedium, ConfidenceHigh} {
+		if conf.String() == s {
+			return conf, nil
+		}
+	}
+	return 0, fmt.Errorf("%s: unknown confidence level", s)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (conf Confidence) MarshalText() ([]byte, error) {
+	return []byte(conf.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (conf *Confidence) UnmarshalText(text []byte) error {
+	v, err := ParseConfidence(string(text))
+	if err != nil {
+		return err
+	}
+	*conf = v
+	return nil
+}
@@ -0,0 +1,129 @@
+package lint
+
+import (
+	"go/ast"
+	"go/types"
+	"testing"
+)
+
+func TestParseConfidence(t *testing.T) {
+	for _, conf := range []Confidence{ConfidenceLow, ConfidenceMedium, ConfidenceHigh} {
+		have, err := ParseConfidence(conf.String())
+		if err != nil {
+			t.Errorf("parse %s: unexpected error: %v", conf, err)
+			continue
+		}
+		if have != conf {
+			t.Errorf("parse %s: have %s", conf, have)
+		}
+	}
+	if _, err := ParseConfidence("certain"); err == nil {
+		t.Errorf("expected error for unknown confidence level")
+	}
+}
+
+func TestMinConfidence(t *testing.T) {
+	rule := findRule("dupSubExpr")
+	if rule == nil {
+		t.Fatal("dupSubExpr rule not found")
+	}
+	pkgPath := testdataPkgPath + rule.Name()
+	prog := newProg(t, pkgPath)
+	pkgInfo := prog.Imported[pkgPath]
+
+	check := func(minConfidence Confidence) []Warning {
+		ctx := NewContext(prog.Fset, sizes)
+		ctx.SetPackageInfo(&pkgInfo.Info, pkgInfo.Pkg)
+		if minConfidence != 0 {
+			ctx.SetMinConfidence(minConfidence)
+		}
+		var warnings []Warning
+		c := NewChecker(rule, ctx)
+		for _, f := range pkgInfo.Files {
+			warnings = append(warnings, c.Check(f)...)
+		}
+		return warnings
+	}
+
+	// Index expressions make the warning heuristic.
+	hasIndex := func(w Warning) bool {
+		return findNode(w.Node.(*ast.BinaryExpr).X, func(n ast.Node) bool {
+			_, ok := n.(*ast.IndexExpr)
+			return ok
+		}) != nil
+	}
+
+	all := check(0)
+	if len(all) != len(check(ConfidenceLow)) {
+		t.Errorf("default threshold should report all warnings")
+	}
+	heuristic := 0
+	for _, w := range all {
+		want := ConfidenceHigh
+		if hasIndex(w) {
+			want = ConfidenceMedium
+			heuristic++
+		}
+		if w.Confidence != want {
+			t.Errorf("%s: have %s confidence, want %s",
+				prog.Fset.Position(w.Node.Pos()), w.Confidence, want)
+		}
+	}
+	if heuristic == 0 || heuristic == len(all) {
+		t.Fatalf("testdata should contain both heuristic and certain warnings")
+	}
+
+	filtered := check(ConfidenceHigh)
+	if len(filtered) != len(all)-heuristic {
+		t.Errorf("have %d warnings with high confidence, want %d",
+			len(filtered), len(all)-heuristic)
+	}
+	for _, w := range filtered {
+		if hasIndex(w) {
+			t.Errorf("%s: heuristic warning is not filtered",
+				prog.Fset.Position(w.Node.Pos()))
+		}
+	}
+}
+
+func TestManualMinMaxConfidence(t *testing.T) {
+	rule := findRule("manualMinMax")
+	if rule == nil {
+		t.Fatal("manualMinMax rule not found")
+	}
+	pkgPath := testdataPkgPath + rule.Name()
+	prog := newProg(t, pkgPath)
+	pkgInfo := prog.Imported[pkgPath]
+	ctx := NewContext(prog.Fset, sizes)
+	ctx.SetPackageInfo(&pkgInfo.Info, pkgInfo.Pkg)
+
+	c := NewChecker(rule, ctx)
+	low := 0
+	for _, f := range pkgInfo.Files {
+		for _, w := range c.Check(f) {
+			cond := w.Node.(*ast.IfStmt).Cond.(*ast.BinaryExpr)
+			want := ConfidenceHigh
+			switch typ := pkgInfo.TypeOf(cond.X).(type) {
+			case *types.TypeParam:
+				want = ConfidenceLow
+			case *types.Basic:
+				if typ.Info()&types.IsFloat != 0 {
+					want = ConfidenceLow
+				}
+			}
+			if want == ConfidenceLow {
+				low++
+				if w.Fix != nil {
+					t.Errorf("%s: unexpected fix for float operands", prog.Fset.Position(w.Pos))
+				}
+			}
+			if w.Confidence != want {
+				t.Errorf("%s: have %s confidence, want %s",
+					prog.Fset.Position(w.Pos), w.Confidence, want)
+			}
+		}
+	}
+	if low == 0 {
+		t.Fatalf("testdata should contain float operands")
+	}
+}
@@ -0,0 +1,168 @@
+package lint
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"math"
+
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// constValue returns x constant value that is known to the type checker.
+// Returns nil if x is not a constant expression.
+func (ctx *context) constValue(x ast.Expr) constant.Value {
+	return ctx.typesInfo.Types[x].Value
+}
+
+// isConst reports whether x is a constant expression.
+func (ctx *context) isConst(x ast.Expr) bool {
+	return ctx.constValue(x) != nil
+}
+
+// isConstZero reports whether x is an integer constant that is equal to 0.
+func (ctx *context) isConstZero(x ast.Expr) bool {
+	v := ctx.constValue(x)
+	return v != nil && v.Kind() == constant.Int && constant.Sign(v) == 0
+}
+
+// intRange returns the inclusive range of values that
+// non-constant integer expression x can have.
+//
+// Range is derived from x type, while len and cap results
+// are known to be non-negative. Ranges of int, uint and uintptr are
+// the 64-bit platform ones, that are the widest, so conclusions made
+// with them hold for every platform.
+func (ctx *context) intRange(x ast.Expr) (lo, hi constant.Value, ok bool) {
+	if ctx.isConst(x) {
+		return nil, nil, false
+	}
+	t := ctx.typesInfo.TypeOf(x)
+	if t == nil {
+		return nil, nil, false
+	}
+	typ, ok := t.Underlying().(*types.Basic)
+	if !ok || typ.Info()&types.IsInteger == 0 {
+		return nil, nil, false
+	}
+
+	bits := 64
+	switch typ.Kind() {
+	case types.Int8, types.Uint8:
+		bits = 8
+	case types.Int16, types.Uint16:
+		bits = 16
+	case types.Int32, types.Uint32:
+		bits = 32
+	}
+	if typ.Info()&types.IsUnsigned != 0 {
+		lo = constant.MakeInt64(0)
+		hi = constant.MakeUint64(math.MaxUint64 >> uint(64-bits))
+	} else {
+		lo = constant.MakeInt64(math.MinInt64 >> uint(64-bits))
+		hi = constant.MakeInt64(math.MaxInt64 >> uint(64-bits))
+	}
+	if call, ok := astutil.Unparen(x).(*ast.CallExpr); ok && len(call.Args) == 1 {
+		if ctx.isBuiltinCall(call, "len") || ctx.isBuiltinCall(call, "cap") {
+			lo = constant.MakeInt64(0)
+		}
+	}
+	return lo, hi, true
+}
+
+// foldCmp evaluates x op y comparison that has a result known
+// at compile time. Such comparisons are either constant expressions
+// or compare a constant with an integer expression which range,
+// see intRange, makes the result the same for any value.
+//
+// Returns false if comparison result is not known.
+func (ctx *context) foldCmp(x ast.Expr, op token.Token, y ast.Expr) (result, ok bool) {
+	if !isComparisonOp(op) {
+		return false, false
+	}
+	vx, vy := ctx.constValue(x), ctx.constValue(y)
+	if vx != nil && vy != nil {
+		return constant.Compare(vx, op, vy), true
+	}
+	// Normalize to the `x op const` form.
+	if vx != nil {
+		x, op, vy = y, swappedCmpOp(op), vx
+	}
+	if vy == nil {
+		return false, false
+	}
+	v := constant.ToInt(vy)
+	if v.Kind() != constant.Int {
+		return false, false
+	}
+	lo, hi, ok := ctx.intRange(x)
+	if !ok {
+		return false, false
+	}
+
+	switch op {
+	case token.EQL, token.NEQ:
+		// Values out of range are never equal to x.
+		if constant.Compare(v, token.LSS, lo) || constant.Compare(v, token.GTR, hi) {
+			return op == token.NEQ, true
+		}
+	default:
+		// Ordered comparisons are monotonic, so if range bounds
+		// give the same result, every value in between gives it too.
+		atLo := constant.Compare(lo, op, v)
+		if atLo == constant.Compare(hi, op, v) {
+			return atLo, true
+		}
+	}
+	return false, false
+}
+
+// isComparisonOp reports whether op is a comparison operator.
+func isComparisonOp(op token.Token) bool {
+	switch op {
+	case token.EQL, token.NEQ, token.LSS, token.GTR, token.LEQ, token.GEQ:
+		return true
+	default:
+		return false
+	}
+}
+
+// swappedCmpOp returns comparison operator that gives the same
+// result when operands are swapped, like > for <.
+// Returns op itself for == and != and for non-comparison operators.
+func swappedCmpOp(op token.Token) token.Token {
+	switch op {
+	case token.LSS:
+		return token.GTR
+	case token.GTR:
+		return token.LSS
+	case token.LEQ:
+		return token.GEQ
+	case token.GEQ:
+		return token.LEQ
+	default:
+		return op
+	}
+}
+
+// invertedCmpOp returns negated form of op comparison operator, like >= for <.
+// Returns false if op is not a comparison.
+func invertedCmpOp(op token.Token) (token.Token, bool) {
+	switch op {
+	case token.EQL:
+		return token.NEQ, true
+	case token.NEQ:
+		return token.EQL, true
+	case token.LSS:
+		return token.GEQ, true
+	case token.GTR:
+		return token.LEQ, true
+	case token.LEQ:
+		return token.GTR, true
+	case token.GEQ:
+		return token.LSS, true
+	default:
+		return op, false
+	}
+}
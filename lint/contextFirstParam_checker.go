@@ -0,0 +1,108 @@
+package lint
+
+//! Detects context.Context params that are not the first ones.
+//
+// By convention, context.Context is the first function param,
+// optionally preceded only by a *testing.T-like param.
+// Storing context.Context in a struct field is reported too.
+//
+// Checker params:
+//	checkStructFields - if "true", struct fields of context.Context type are reported
+//
+// @Before:
+// func f(x int, ctx context.Context)
+//
+// @After:
+// func f(ctx context.Context, x int)
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+func init() {
+	addChecker(&contextFirstParamChecker{}, attrExperimental)
+}
+
+type contextFirstParamChecker struct {
+	checkerBase
+
+	checkStructFields bool
+}
+
+func (c *contextFirstParamChecker) Params() []CheckerParam {
+	return []CheckerParam{
+		{Name: "checkStructFields", Kind: ParamBool, Default: "true"},
+	}
+}
+
+func (c *contextFirstParamChecker) Init() {
+	c.checkStructFields = c.ctx.BoolParam("checkStructFields")
+}
+
+func (c *contextFirstParamChecker) VisitExpr(x ast.Expr) {
+	switch x := x.(type) {
+	case *ast.FuncType:
+		c.checkParams(x)
+	case *ast.StructType:
+		if c.checkStructFields {
+			c.checkFields(x)
+		}
+	}
+}
+
+func (c *contextFirstParamChecker) checkParams(typ *ast.FuncType) {
+	i := 0
+	for _, field := range typ.Params.List {
+		n := len(field.Names)
+		if n == 0 {
+			n = 1
+		}
+		if c.isContext(field.Type) {
+			if i != 0 && !(i == 1 && c.isTestingParam(typ.Params.List[0].Type)) {
+				c.warnParam(field)
+			}
+			return
+		}
+		i += n
+	}
+}
+
+func (c *contextFirstParamChecker) checkFields(typ *ast.StructType) {
+	for _, field := range typ.Fields.List {
+		if c.isContext(field.Type) {
+			c.warnField(field)
+		}
+	}
+}
+
+func (c *contextFirstParamChecker) isContext(x ast.Expr) bool {
+	return c.isNamed(c.ctx.typesInfo.TypeOf(x), "context", "Context")
+}
+
+// isTestingParam reports whether x is one of *testing.T, *testing.B
+// or testing.TB types that are conventionally passed before context.
+func (c *contextFirstParamChecker) isTestingParam(x ast.Expr) bool {
+	typ := c.ctx.typesInfo.TypeOf(x)
+	if ptr, ok := typ.(*types.Pointer); ok {
+		return c.isNamed(ptr.Elem(), "testing", "T") || c.isNamed(ptr.Elem(), "testing", "B")
+	}
+	return c.isNamed(typ, "testing", "TB")
+}
+
+func (c *contextFirstParamChecker) isNamed(typ types.Type, pkgPath, name string) bool {
+	named, ok := typ.(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == pkgPath && obj.Name() == name
+}
+
+func (c *contextFirstParamChecker) warnParam(cause ast.Node) {
+	c.ctx.WarnWith(Warning{Node: cause, Code: "param"}, "context.Context should be the first param")
+}
+
+func (c *contextFirstParamChecker) warnField(cause ast.Node) {
+	c.ctx.WarnWith(Warning{Node: cause, Code: "structField"}, "don't store context.Context in a struct field, pass it as a param instead")
+}
@@ -0,0 +1,123 @@
+package lint
+
+//! Detects suspicious chains of integer type conversions.
+//
+// Reports conversions that narrow a signed integer and then convert
+// it back to the original type, which silently truncates the value,
+// and conversions to the type the value already has.
+// Unsigned narrowing is not reported as it's commonly used for masking,
+// neither are range checks like `int64(int32(x)) == x`.
+//
+// @Before:
+// func f(x int64) int64 { return int64(int32(x)) }
+//
+// func g(x int32) int { return int(int(x)) }
+//
+// @After:
+// func f(x int64) int64 { return x }
+//
+// func g(x int32) int { return int(x) }
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/go-toolsmith/astequal"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+func init() {
+	addChecker(&conversionChainChecker{}, attrExperimental)
+}
+
+type conversionChainChecker struct {
+	checkerBase
+}
+
+func (c *conversionChainChecker) VisitExpr(expr ast.Expr) {
+	outer, ok := c.conversion(expr)
+	if !ok {
+		return
+	}
+	inner, ok := c.conversion(astutil.Unparen(outer.Args[0]))
+	if !ok {
+		return
+	}
+	x := inner.Args[0]
+	outerType := c.intType(outer)
+	innerType := c.intType(inner)
+	srcType := c.intType(x)
+	if outerType == nil || innerType == nil || srcType == nil || srcType.Info()&types.IsUntyped != 0 {
+		return
+	}
+
+	switch {
+	case types.Identical(outerType, innerType):
+		c.warnRedundant(outer, inner)
+	case types.Identical(outerType, srcType) && c.isSigned(srcType) && c.isSigned(innerType) &&
+		c.ctx.sizesInfo.Sizeof(innerType) < c.ctx.sizesInfo.Sizeof(srcType) &&
+		!c.isRangeCheck(outer, x):
+		c.warnLossy(outer)
+	}
+}
+
+// conversion returns x as a single argument type conversion.
+func (c *conversionChainChecker) conversion(x ast.Expr) (*ast.CallExpr, bool) {
+	call, ok := x.(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 || !c.ctx.typesInfo.Types[call.Fun].IsType() {
+		return nil, false
+	}
+	return call, true
+}
+
+// intType returns x type if it's an integer type. Otherwise returns nil.
+//
+// Named types are preserved, so conversions
+// between them are not considered redundant.
+func (c *conversionChainChecker) intType(x ast.Expr) *types.Basic {
+	typ := c.ctx.typesInfo.TypeOf(x)
+	if typ == nil {
+		return nil
+	}
+	if _, ok := typ.(*types.Named); ok {
+		return nil
+	}
+	basic, ok := typ.(*types.Basic)
+	if !ok || basic.Info()&types.IsInteger == 0 {
+		return nil
+	}
+	return basic
+}
+
+// isRangeCheck reports whether chain is compared to its source operand x,
+// which checks that x value fits into the narrowed type.
+func (c *conversionChainChecker) isRangeCheck(chain *ast.CallExpr, x ast.Expr) bool {
+	path, _ := astutil.PathEnclosingInterval(c.ctx.file, chain.Pos(), chain.End())
+	for _, n := range path[1:] {
+		switch n := n.(type) {
+		case *ast.ParenExpr:
+			continue
+		case *ast.BinaryExpr:
+			if n.Op != token.EQL && n.Op != token.NEQ {
+				return false
+			}
+			return astequal.Expr(astutil.Unparen(n.X), astutil.Unparen(x)) ||
+				astequal.Expr(astutil.Unparen(n.Y), astutil.Unparen(x))
+		}
+		return false
+	}
+	return false
+}
+
+func (c *conversionChainChecker) isSigned(typ *types.Basic) bool {
+	return typ.Info()&types.IsUnsigned == 0
+}
+
+func (c *conversionChainChecker) warnLossy(cause ast.Node) {
+	c.ctx.Warn(cause, "conversion narrows then widens, losing data")
+}
+
+func (c *conversionChainChecker) warnRedundant(cause, suggestion ast.Node) {
+	c.ctx.Warn(cause, "redundant conversion chain; %s can be simplified to %s", cause, suggestion)
+}
@@ -2,6 +2,12 @@ package lint
 
 //! Detects defer in loop and warns that it will not be executed till the end of function's scope.
 //
+// Defers inside nested blocks of the loop body are reported too,
+// while defers inside function literals are not.
+//
+// Checker params:
+//	closeOnly - if "true", only deferred Close, Unlock, RUnlock and Release method calls are reported
+//
 // @Before:
 // for i := range [10]int{} {
 // 	defer f(i) // will be executed only at the end of func
@@ -17,7 +23,7 @@ package lint
 import (
 	"go/ast"
 
-	"github.com/go-toolsmith/astp"
+	"golang.org/x/tools/go/ast/astutil"
 )
 
 func init() {
@@ -26,22 +32,60 @@ func init() {
 
 type deferInLoopChecker struct {
 	checkerBase
+
+	closeOnly bool
+}
+
+func (c *deferInLoopChecker) Params() []CheckerParam {
+	return []CheckerParam{
+		{Name: "closeOnly", Kind: ParamBool, Default: "false"},
+	}
+}
+
+func (c *deferInLoopChecker) Init() {
+	c.closeOnly = c.ctx.BoolParam("closeOnly")
 }
 
 func (c *deferInLoopChecker) VisitStmt(stmt ast.Stmt) {
 	switch stmt := stmt.(type) {
 	case *ast.RangeStmt:
-		c.checkLoopBody(stmt.Body.List)
+		c.checkLoopBody(stmt.Body)
 	case *ast.ForStmt:
-		c.checkLoopBody(stmt.Body.List)
+		c.checkLoopBody(stmt.Body)
 	}
 }
 
-func (c *deferInLoopChecker) checkLoopBody(body []ast.Stmt) {
-	for _, s := range body {
-		if astp.IsDeferStmt(s) {
-			c.warn(s)
+func (c *deferInLoopChecker) checkLoopBody(body *ast.BlockStmt) {
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			// Function literal has its own defer scope.
+			return false
+		case *ast.ForStmt, *ast.RangeStmt:
+			// Nested loops are checked by their own VisitStmt call.
+			return false
+		case *ast.DeferStmt:
+			if !c.closeOnly || c.isRelease(n.Call) {
+				c.warn(n)
+			}
+			return false
 		}
+		return true
+	})
+}
+
+// isRelease reports whether call is a Close or Unlock-like method call
+// that releases some resource.
+func (c *deferInLoopChecker) isRelease(call *ast.CallExpr) bool {
+	sel, ok := astutil.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	switch sel.Sel.Name {
+	case "Close", "Unlock", "RUnlock", "Release":
+		return true
+	default:
+		return false
 	}
 }
 
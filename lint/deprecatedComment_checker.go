@@ -0,0 +1,98 @@
+package lint
+
+//! Detects malformed "Deprecated:" notices in doc comments.
+//
+// Tools like godoc and staticcheck only recognize a paragraph
+// that starts with "Deprecated: " as a deprecation notice.
+// Wrong casing, missing colon and notices that don't start
+// their own paragraph are reported.
+//
+// @Before:
+// func Baz() {}
+//
+// // Foo is bar.
+// // deprecated, use Baz instead.
+// func Foo() {}
+//
+// @After:
+// func Baz() {}
+//
+// // Foo is bar.
+// //
+// // Deprecated: use Baz instead.
+// func Foo() {}
+
+import (
+	"go/ast"
+	"strings"
+	"unicode"
+)
+
+func init() {
+	addChecker(&deprecatedCommentChecker{}, attrExperimental, attrSyntaxOnly)
+}
+
+type deprecatedCommentChecker struct {
+	checkerBase
+}
+
+func (c *deprecatedCommentChecker) VisitDocComment(doc *ast.CommentGroup) {
+	prevEmpty := true
+	for _, comment := range doc.List {
+		if !strings.HasPrefix(comment.Text, "//") {
+			// Block comments are rarely used for docs, skip them.
+			prevEmpty = true
+			continue
+		}
+		line := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+		c.checkLine(doc, line, prevEmpty)
+		prevEmpty = line == ""
+	}
+}
+
+func (c *deprecatedCommentChecker) checkLine(cause *ast.CommentGroup, line string, prevEmpty bool) {
+	const marker = "Deprecated: "
+
+	if strings.HasPrefix(line, marker) {
+		if !prevEmpty {
+			c.warnParagraph(cause)
+		}
+		return
+	}
+	if strings.Contains(line, ". "+marker) {
+		c.warnParagraph(cause)
+		return
+	}
+
+	const word = "deprecated"
+	if len(line) <= len(word) || !strings.EqualFold(line[:len(word)], word) {
+		return
+	}
+	head, rest := line[:len(word)], line[len(word):]
+	switch {
+	case rest[0] == ':':
+		if head != "Deprecated" {
+			c.warnCasing(cause, head+":")
+		} else if rest != ":" && rest[1] != ' ' {
+			c.warnFormat(cause)
+		}
+	case strings.ContainsRune(".,;-", rune(rest[0])):
+		c.warnFormat(cause)
+	case rest[0] == ' ' && unicode.IsUpper(rune(head[0])):
+		// Lower-cased "deprecated" is likely a part of a sentence
+		// that was wrapped to the next line.
+		c.warnFormat(cause)
+	}
+}
+
+func (c *deprecatedCommentChecker) warnCasing(cause ast.Node, have string) {
+	c.ctx.WarnWith(Warning{Node: cause, Code: "casing"}, "use `Deprecated: ` (note the casing) instead of `%s `", have)
+}
+
+func (c *deprecatedCommentChecker) warnFormat(cause ast.Node) {
+	c.ctx.WarnWith(Warning{Node: cause, Code: "format"}, "the proper format is `Deprecated: <text>`")
+}
+
+func (c *deprecatedCommentChecker) warnParagraph(cause ast.Node) {
+	c.ctx.WarnWith(Warning{Node: cause, Code: "paragraph"}, "`Deprecated: ` notices should be in a dedicated paragraph, separated from the rest")
+}
@@ -0,0 +1,136 @@
+package lint
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// DiffFilter selects warnings that are located on lines
+// added or modified by a unified diff, like `git diff` output.
+//
+// It's used to gate changes on the linter without fixing
+// the issues in the code that was not touched.
+type DiffFilter struct {
+	// changed maps file path from the diff to its changed lines.
+	changed map[string]map[int]bool
+}
+
+// ParseDiff reads unified diff from r.
+//
+// File paths are taken from the "+++" headers of the diff,
+// with git "b/" prefix removed. Deleted files are ignored.
+func ParseDiff(r io.Reader) (*DiffFilter, error) {
+	d := &DiffFilter{changed: make(map[string]map[int]bool)}
+
+	var lines map[int]bool // Changed lines of the current file
+	// Remaining old and new file lines of the current hunk.
+	var oldLeft, newLeft int
+	line := 0
+
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, 1024*1024)
+	for lineNum := 1; s.Scan(); lineNum++ {
+		text := s.Text()
+		if oldLeft > 0 || newLeft > 0 {
+			switch {
+			case strings.HasPrefix(text, "+"):
+				if lines != nil {
+					lines[line] = true
+				}
+				line++
+				newLeft--
+			case strings.HasPrefix(text, "-"):
+				oldLeft--
+			case strings.HasPrefix(text, "\\"):
+				// "\ No newline at end of file" marker.
+			default:
+				// Context line, it's allowed to lose the leading space.
+				line++
+				oldLeft--
+				newLeft--
+			}
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(text, "+++ "):
+			path := strings.TrimPrefix(text, "+++ ")
+			if i := strings.IndexByte(path, '\t'); i != -1 {
+				path = path[:i] // Timestamp follows the path
+			}
+			if path == "/dev/null" {
+				lines = nil
+				continue
+			}
+			path = strings.TrimPrefix(path, "b/")
+			lines = d.changed[path]
+			if lines == nil {
+				lines = make(map[int]bool)
+				d.changed[path] = lines
+			}
+		case strings.HasPrefix(text, "@@ "):
+			var err error
+			oldLeft, newLeft, line, err = parseHunkHeader(text)
+			if err != nil {
+				return nil, fmt.Errorf("diff:%d: %v", lineNum, err)
+			}
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, fmt.Errorf("diff: %v", err)
+	}
+	return d, nil
+}
+
+// parseHunkHeader parses "@@ -oldStart,oldCount +newStart,newCount @@" line.
+// Counts are optional and default to 1.
+func parseHunkHeader(text string) (oldCount, newCount, newStart int, err error) {
+	fields := strings.Fields(text)
+	if len(fields) < 4 || fields[3] != "@@" ||
+		!strings.HasPrefix(fields[1], "-") || !strings.HasPrefix(fields[2], "+") {
+		return 0, 0, 0, fmt.Errorf("malformed hunk header %q", text)
+	}
+	_, oldCount, err = parseHunkRange(fields[1][1:])
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("malformed hunk header %q", text)
+	}
+	newStart, newCount, err = parseHunkRange(fields[2][1:])
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("malformed hunk header %q", text)
+	}
+	return oldCount, newCount, newStart, nil
+}
+
+func parseHunkRange(s string) (start, count int, err error) {
+	count = 1
+	if i := strings.IndexByte(s, ','); i != -1 {
+		count, err = strconv.Atoi(s[i+1:])
+		if err != nil {
+			return 0, 0, err
+		}
+		s = s[:i]
+	}
+	start, err = strconv.Atoi(s)
+	return start, count, err
+}
+
+// Match reports whether line of the filename file was added or
+// modified by the diff.
+//
+// Diff paths are usually relative to the repository root, so filename
+// matches if it's equal to the diff path or ends with it.
+func (d *DiffFilter) Match(filename string, line int) bool {
+	filename = filepath.ToSlash(filename)
+	for path, lines := range d.changed {
+		if filename == path || strings.HasSuffix(filename, "/"+path) {
+			if lines[line] {
+				return true
+			}
+		}
+	}
+	return false
+}
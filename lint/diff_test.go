@@ -0,0 +1,73 @@
+package lint
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiffFilter(t *testing.T) {
+	const diff = `diff --git a/pkg/a.go b/pkg/a.go
+index 1111111..2222222 100644
+--- a/pkg/a.go
++++ b/pkg/a.go
+@@ -2,4 +2,5 @@ package pkg
+ func f() {
+-	g(1)
++	g(2)
++	g(3)
+ }
+
+@@ -20 +21 @@ func h() {
+-	return 1
++	return 2
+diff --git a/pkg/b.go b/pkg/b.go
+new file mode 100644
+--- /dev/null
++++ b/pkg/b.go	2018-01-01 00:00:00
+@@ -0,0 +1,2 @@
++package pkg
++++ x
+diff --git a/pkg/c.go b/pkg/c.go
+deleted file mode 100644
+--- a/pkg/c.go
++++ /dev/null
+@@ -1 +0,0 @@
+-package pkg
+`
+
+	d, err := ParseDiff(strings.NewReader(diff))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	tests := []struct {
+		filename string
+		line     int
+		want     bool
+	}{
+		{"/src/pkg/a.go", 2, false},
+		{"/src/pkg/a.go", 3, true},
+		{"/src/pkg/a.go", 4, true},
+		{"/src/pkg/a.go", 5, false},
+		{"/src/pkg/a.go", 21, true},
+		{"pkg/a.go", 21, true},
+		{"/src/otherpkg/a.go", 3, false},
+		{"/src/xpkg/a.go", 3, false},
+		{"/src/pkg/b.go", 1, true},
+		// Added line that looks like a file header.
+		{"/src/pkg/b.go", 2, true},
+		{"/src/x", 1, false},
+		{"/src/pkg/c.go", 1, false},
+	}
+	for _, test := range tests {
+		have := d.Match(test.filename, test.line)
+		if have != test.want {
+			t.Errorf("match %s:%d: have %v, want %v", test.filename, test.line, have, test.want)
+		}
+	}
+
+	_, err = ParseDiff(strings.NewReader("+++ b/a.go\n@@ -1 +x @@\n"))
+	if err == nil || err.Error() != `diff:2: malformed hunk header "@@ -1 +x @@"` {
+		t.Errorf("expected malformed hunk header error, got %v", err)
+	}
+}
@@ -0,0 +1,111 @@
+package lint
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+)
+
+// Directive is a comment directive, like "//go:generate stringer -type=Kind".
+//
+// Directives are "//tool:name" line comments without spaces after
+// the slashes, "//line", "//export" and "//extern" comments and
+// "// +build" constraints, the same comments go/ast treats as directives.
+type Directive struct {
+	// Comment is a comment the directive is written in.
+	Comment *ast.Comment
+
+	// Tool is a directive namespace, like "go" for "//go:generate".
+	// Empty for line, export, extern and +build directives.
+	Tool string
+
+	// Name is a directive name, like "generate", "line" or "+build".
+	Name string
+
+	// Args is the directive text after the name, like "stringer -type=Kind".
+	Args string
+}
+
+// parseDirective parses comment as a directive.
+// Returns false if comment is not a directive.
+func parseDirective(comment *ast.Comment) (Directive, bool) {
+	text := comment.Text
+	if !strings.HasPrefix(text, "//") {
+		return Directive{}, false
+	}
+	if strings.HasPrefix(text, "// +build ") {
+		return Directive{Comment: comment, Name: "+build", Args: strings.TrimSpace(text[len("// +build"):])}, true
+	}
+	text = text[len("//"):]
+	for _, name := range []string{"line", "export", "extern"} {
+		if strings.HasPrefix(text, name+" ") {
+			return Directive{Comment: comment, Name: name, Args: strings.TrimSpace(text[len(name):])}, true
+		}
+	}
+
+	colon := strings.Index(text, ":")
+	if colon <= 0 || colon+1 == len(text) || !isDirectiveWord(text[:colon]) || !isDirectiveWord(text[colon+1:colon+2]) {
+		return Directive{}, false
+	}
+	d := Directive{Comment: comment, Tool: text[:colon]}
+	name := text[colon+1:]
+	if end := strings.IndexAny(name, " \t"); end != -1 {
+		name, d.Args = name[:end], strings.TrimSpace(name[end:])
+	}
+	d.Name = name
+	return d, true
+}
+
+// isDirectiveWord reports whether s consists of [a-z0-9] characters.
+func isDirectiveWord(s string) bool {
+	for _, ch := range s {
+		if (ch < 'a' || ch > 'z') && (ch < '0' || ch > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// TokenFile returns token file of the checked file,
+// that gives access to its line offsets and size.
+func (ctx *context) TokenFile() *token.File {
+	if ctx.file == nil {
+		return nil
+	}
+	return ctx.fileSet.File(ctx.file.Pos())
+}
+
+// CommentMap returns comment map of the checked file,
+// that associates comment groups with the nodes they describe.
+//
+// Map is built once per checked file on the first call.
+func (ctx *context) CommentMap() ast.CommentMap {
+	if ctx.file == nil {
+		return nil
+	}
+	if ctx.commentMap == nil {
+		ctx.commentMap = ast.NewCommentMap(ctx.fileSet, ctx.file, ctx.file.Comments)
+	}
+	return ctx.commentMap
+}
+
+// Directives returns all directives of the checked file in source order,
+// including the ones inside function bodies.
+//
+// Directives are collected once per checked file on the first call.
+func (ctx *context) Directives() []Directive {
+	if ctx.file == nil {
+		return nil
+	}
+	if ctx.directives == nil {
+		ctx.directives = []Directive{}
+		for _, cg := range ctx.file.Comments {
+			for _, comment := range cg.List {
+				if d, ok := parseDirective(comment); ok {
+					ctx.directives = append(ctx.directives, d)
+				}
+			}
+		}
+	}
+	return ctx.directives
+}
@@ -0,0 +1,90 @@
+package lint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+func TestParseDirective(t *testing.T) {
+	tests := []struct {
+		text string
+		want Directive
+		ok   bool
+	}{
+		{"//go:generate stringer -type=Kind", Directive{Tool: "go", Name: "generate", Args: "stringer -type=Kind"}, true},
+		{"//go:noinline", Directive{Tool: "go", Name: "noinline"}, true},
+		{"//go:build\tlinux && amd64", Directive{Tool: "go", Name: "build", Args: "linux && amd64"}, true},
+		{"//lint:ignore SA1000 reason", Directive{Tool: "lint", Name: "ignore", Args: "SA1000 reason"}, true},
+		{"// +build linux", Directive{Name: "+build", Args: "linux"}, true},
+		{"//line foo.go:10", Directive{Name: "line", Args: "foo.go:10"}, true},
+		{"//export foo", Directive{Name: "export", Args: "foo"}, true},
+		{"// go:generate stringer", Directive{}, false},
+		{"//go: generate", Directive{}, false},
+		{"//Go:generate", Directive{}, false},
+		{"//go:", Directive{}, false},
+		{"/*go:generate stringer*/", Directive{}, false},
+		{"// TODO: fix", Directive{}, false},
+	}
+
+	for _, test := range tests {
+		comment := &ast.Comment{Text: test.text}
+		have, ok := parseDirective(comment)
+		if ok != test.ok {
+			t.Errorf("%q: have ok=%v, want %v", test.text, ok, test.ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		test.want.Comment = comment
+		if have != test.want {
+			t.Errorf("%q: have %+v, want %+v", test.text, have, test.want)
+		}
+	}
+}
+
+func TestContextFileData(t *testing.T) {
+	src := `//go:build linux
+
+// Package p does things.
+package p
+
+// f is documented.
+//go:noinline
+func f() {
+	//lint:ignore X reason
+	_ = 1 // trailing
+}
+`
+	fset := token.NewFileSet()
+	ctx := context{Context: NewContext(fset, sizes)}
+	if ctx.TokenFile() != nil || ctx.CommentMap() != nil || ctx.Directives() != nil {
+		t.Fatalf("expected no file data without a file")
+	}
+
+	f, err := parser.ParseFile(fset, "p.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	ctx.file = f
+
+	if tf := ctx.TokenFile(); tf == nil || tf.Name() != "p.go" || tf.LineCount() != 11 {
+		t.Errorf("unexpected token file: %v", tf)
+	}
+
+	decl := f.Decls[0].(*ast.FuncDecl)
+	groups := ctx.CommentMap()[decl]
+	if len(groups) != 1 || groups[0] != decl.Doc {
+		t.Errorf("unexpected f comments: %v", groups)
+	}
+	var names []string
+	for _, d := range ctx.Directives() {
+		names = append(names, d.Tool+":"+d.Name)
+	}
+	if have, want := fmt.Sprint(names), "[go:build go:noinline lint:ignore]"; have != want {
+		t.Errorf("directives: have %s, want %s", have, want)
+	}
+}
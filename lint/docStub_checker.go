@@ -21,7 +21,7 @@ import (
 )
 
 func init() {
-	addChecker(&docStubChecker{}, attrSyntaxOnly, attrExperimental)
+	addChecker(&docStubChecker{}, attrSyntaxOnly, attrExperimental, attrSkipTests)
 }
 
 type docStubChecker struct {
@@ -0,0 +1,486 @@
+package lint
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/go-toolsmith/astequal"
+)
+
+// LoadRules reads user-defined pattern rules from r and registers
+// a checker for each of them, so they can be selected and run
+// like any other checker.
+//
+// Rules are described by a JSON array:
+//
+//	[{
+//		"name": "sprintfString",
+//		"doc": "Detects fmt.Sprintf calls that only copy a string.",
+//		"match": ["fmt.Sprintf(\"%s\", $s)"],
+//		"where": {"s": {"type": "string"}},
+//		"report": "fmt.Sprintf call can be replaced with $s",
+//		"suggest": "$s"
+//	}]
+//
+// Every match pattern is a Go expression. $name matches any expression,
+// the same name must match the same expression everywhere in the pattern.
+// $*name matches any number of call arguments or composite literal
+// elements. $_ and $*_ match anything and can be repeated.
+// Package selectors like fmt.Sprintf also match renamed imports.
+//
+// Captured expressions can be constrained by "where":
+//
+//	type  - expression type, like "string", "[]byte" or "*bytes.Buffer"
+//	const - if true, expression must be a constant
+//	pure  - if true, expression must have no side effects
+//
+// $name in "report" and "suggest" is replaced with the captured
+// expression source. If "suggest" is set, matched expression can
+// be replaced with it as a fix. Optional "severity" and "confidence"
+// set warning levels, they default to "warning" and "high".
+//
+// filename is only used in error messages and checkers docs.
+// Rules are returned in the file order.
+func LoadRules(filename string, r io.Reader) ([]*Rule, error) {
+	var specs []dslRuleSpec
+	if err := json.NewDecoder(r).Decode(&specs); err != nil {
+		return nil, fmt.Errorf("%s: %v", filename, err)
+	}
+
+	seen := make(map[string]bool)
+	rules := make([]*dslRule, len(specs))
+	for i, spec := range specs {
+		rule, err := compileDSLRule(spec)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", filename, err)
+		}
+		if _, ok := checkerPrototypes[spec.Name]; ok || seen[spec.Name] {
+			return nil, fmt.Errorf("%s: %s: checker already exists", filename, spec.Name)
+		}
+		seen[spec.Name] = true
+		rules[i] = rule
+	}
+
+	result := make([]*Rule, len(rules))
+	for i, rule := range rules {
+		rule := rule
+		result[i] = &Rule{name: rule.spec.Name, definition: rule.definitionHash()}
+		// Without type constraints results only depend on the file syntax.
+		// Package selectors are resolved using the file imports.
+		result[i].SyntaxOnly = !rule.usesTypes()
+		registerChecker(result[i], rule.doc(filename), func() abstractChecker {
+			return &dslChecker{rule: rule}
+		})
+	}
+	return result, nil
+}
+
+// dslRuleSpec is a rule description from the rules file.
+type dslRuleSpec struct {
+	Name       string                   `json:"name"`
+	Doc        string                   `json:"doc"`
+	Match      []string                 `json:"match"`
+	Where      map[string]dslConstraint `json:"where"`
+	Report     string                   `json:"report"`
+	Suggest    string                   `json:"suggest"`
+	Severity   Severity                 `json:"severity"`
+	Confidence Confidence               `json:"confidence"`
+}
+
+// dslConstraint restricts expressions that can be captured by a variable.
+type dslConstraint struct {
+	Type  string `json:"type"`
+	Const bool   `json:"const"`
+	Pure  bool   `json:"pure"`
+}
+
+// definitionHash returns a hash of the rule spec, so cached results
+// are invalidated when the rule description changes.
+func (rule *dslRule) definitionHash() string {
+	// Map keys are sorted by the encoder, so the output is stable.
+	data, err := json.Marshal(rule.spec)
+	if err != nil {
+		panic(fmt.Sprintf("marshal %s rule: %v", rule.spec.Name, err))
+	}
+	h := sha256.Sum256(data)
+	return hex.EncodeToString(h[:])
+}
+
+// dslRule is a compiled dslRuleSpec.
+type dslRule struct {
+	spec     dslRuleSpec
+	patterns []ast.Expr
+}
+
+var (
+	// dslVarRE matches $name and $*name pattern variables.
+	dslVarRE = regexp.MustCompile(`\$(\*?)(\w+)`)
+
+	// dslRefRE matches $name variable references in report and suggest.
+	dslRefRE = regexp.MustCompile(`\$(\w+)`)
+)
+
+// Pattern variables are replaced with identifiers that have
+// these prefixes, so patterns can be parsed as Go expressions.
+const (
+	dslVarPrefix     = "__dslVar_"
+	dslListVarPrefix = "__dslListVar_"
+)
+
+func compileDSLRule(spec dslRuleSpec) (*dslRule, error) {
+	switch {
+	case !token.IsIdentifier(spec.Name):
+		return nil, fmt.Errorf("invalid rule name %q", spec.Name)
+	case len(spec.Match) == 0:
+		return nil, fmt.Errorf("%s: no match patterns", spec.Name)
+	case spec.Report == "":
+		return nil, fmt.Errorf("%s: empty report", spec.Name)
+	}
+	if spec.Severity == 0 {
+		spec.Severity = SeverityWarning
+	}
+	if spec.Confidence == 0 {
+		spec.Confidence = ConfidenceHigh
+	}
+
+	rule := &dslRule{spec: spec}
+	vars := make(map[string]bool)
+	for _, src := range spec.Match {
+		pattern, err := parser.ParseExpr(dslVarRE.ReplaceAllStringFunc(src, func(v string) string {
+			m := dslVarRE.FindStringSubmatch(v)
+			vars[m[2]] = true
+			if m[1] != "" {
+				return dslListVarPrefix + m[2]
+			}
+			return dslVarPrefix + m[2]
+		}))
+		if err != nil {
+			return nil, fmt.Errorf("%s: match %q: %v", spec.Name, src, err)
+		}
+		rule.patterns = append(rule.patterns, pattern)
+	}
+	for name := range spec.Where {
+		if !vars[name] {
+			return nil, fmt.Errorf("%s: where: $%s is not used in match patterns", spec.Name, name)
+		}
+	}
+	for _, text := range []string{spec.Report, spec.Suggest} {
+		for _, m := range dslRefRE.FindAllStringSubmatch(text, -1) {
+			if !vars[m[1]] || m[1] == "_" {
+				return nil, fmt.Errorf("%s: $%s is not captured by match patterns", spec.Name, m[1])
+			}
+		}
+	}
+	return rule, nil
+}
+
+func (r *dslRule) usesTypes() bool {
+	for _, c := range r.spec.Where {
+		if c.Type != "" || c.Const {
+			return true
+		}
+	}
+	return false
+}
+
+// doc returns checker documentation that is built from the rule spec.
+func (r *dslRule) doc(filename string) *CheckerDoc {
+	doc := &CheckerDoc{
+		Summary: r.spec.Doc,
+		Details: fmt.Sprintf("User-defined rule from %s.", filename),
+		Before:  strings.Join(r.spec.Match, "\n"),
+		After:   r.spec.Suggest,
+	}
+	if doc.Summary == "" {
+		doc.Summary = fmt.Sprintf("Detects `%s` expressions.", r.spec.Match[0])
+	}
+	return doc
+}
+
+// dslChecker reports expressions that match user-defined rule patterns.
+type dslChecker struct {
+	checkerBase
+
+	rule *dslRule
+
+	m dslMatcher
+}
+
+func (c *dslChecker) VisitExpr(x ast.Expr) {
+	c.m.info = c.ctx.typesInfo
+	for _, pattern := range c.rule.patterns {
+		c.m.captures = c.m.captures[:0]
+		if c.m.match(pattern, x) && c.checkConstraints() {
+			c.warn(x)
+			return
+		}
+	}
+}
+
+func (c *dslChecker) checkConstraints() bool {
+	for _, capture := range c.m.captures {
+		constraint, ok := c.rule.spec.Where[capture.name]
+		if !ok {
+			continue
+		}
+		for _, x := range capture.exprs {
+			if !c.satisfies(x, constraint) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (c *dslChecker) satisfies(x ast.Expr, constraint dslConstraint) bool {
+	if constraint.Pure && !isPureExpr(x) {
+		return false
+	}
+	if constraint.Type == "" && !constraint.Const {
+		return true
+	}
+	if c.ctx.typesInfo == nil {
+		return false
+	}
+	tv, ok := c.ctx.typesInfo.Types[x]
+	if !ok {
+		return false
+	}
+	if constraint.Const && tv.Value == nil {
+		return false
+	}
+	if constraint.Type != "" {
+		qualifier := func(p *types.Package) string { return p.Name() }
+		if tv.Type == nil || types.TypeString(tv.Type, qualifier) != constraint.Type {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *dslChecker) warn(x ast.Expr) {
+	w := Warning{
+		Text:       c.interpolate(c.rule.spec.Report),
+		Node:       x,
+		Severity:   c.rule.spec.Severity,
+		Confidence: c.rule.spec.Confidence,
+	}
+	if c.rule.spec.Suggest != "" {
+		w.Fix = []TextEdit{{
+			Pos:     x.Pos(),
+			End:     x.End(),
+			NewText: c.interpolate(c.rule.spec.Suggest),
+		}}
+	}
+	c.ctx.addWarning(w)
+}
+
+// interpolate replaces variable references in text with captured expressions.
+func (c *dslChecker) interpolate(text string) string {
+	return dslRefRE.ReplaceAllStringFunc(text, func(ref string) string {
+		exprs := c.m.lookup(ref[len("$"):])
+		parts := make([]string, len(exprs))
+		for i, x := range exprs {
+			parts[i] = c.ctx.printer.Sprint(x)
+		}
+		return strings.Join(parts, ", ")
+	})
+}
+
+// isPureExpr reports whether x evaluation has no side effects.
+// Like dupSubExpr, it's conservative and permits index
+// expressions even though they may panic.
+func isPureExpr(x ast.Expr) bool {
+	switch x := x.(type) {
+	case *ast.BinaryExpr:
+		return isPureExpr(x.X) && isPureExpr(x.Y)
+	case *ast.UnaryExpr:
+		return x.Op != token.ARROW && isPureExpr(x.X)
+	case *ast.BasicLit, *ast.Ident:
+		return true
+	case *ast.IndexExpr:
+		return isPureExpr(x.X) && isPureExpr(x.Index)
+	case *ast.SelectorExpr:
+		return isPureExpr(x.X)
+	case *ast.ParenExpr:
+		return isPureExpr(x.X)
+	case *ast.StarExpr:
+		return isPureExpr(x.X)
+	default:
+		return false
+	}
+}
+
+// dslMatcher matches AST nodes against rule patterns.
+type dslMatcher struct {
+	// info is used to resolve package selectors. Can be nil.
+	info *types.Info
+
+	// captures are variables bound by the current match.
+	captures []dslCapture
+}
+
+type dslCapture struct {
+	name  string
+	exprs []ast.Expr
+}
+
+func (m *dslMatcher) lookup(name string) []ast.Expr {
+	for _, capture := range m.captures {
+		if capture.name == name {
+			return capture.exprs
+		}
+	}
+	return nil
+}
+
+// bind captures exprs under the name variable. If variable is
+// already bound, reports whether exprs are the same expressions.
+func (m *dslMatcher) bind(name string, exprs []ast.Expr) bool {
+	if name == "_" {
+		return true
+	}
+	for _, capture := range m.captures {
+		if capture.name != name {
+			continue
+		}
+		if len(capture.exprs) != len(exprs) {
+			return false
+		}
+		for i := range exprs {
+			if !astequal.Expr(capture.exprs[i], exprs[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	m.captures = append(m.captures, dslCapture{name: name, exprs: exprs})
+	return true
+}
+
+func varName(pattern ast.Node, prefix string) (string, bool) {
+	id, ok := pattern.(*ast.Ident)
+	if !ok || !strings.HasPrefix(id.Name, prefix) {
+		return "", false
+	}
+	return id.Name[len(prefix):], true
+}
+
+func (m *dslMatcher) match(pattern, x ast.Node) bool {
+	if name, ok := varName(pattern, dslVarPrefix); ok {
+		x, ok := x.(ast.Expr)
+		return ok && m.bind(name, []ast.Expr{x})
+	}
+
+	switch pattern := pattern.(type) {
+	case *ast.Ident:
+		x, ok := x.(*ast.Ident)
+		return ok && x.Name == pattern.Name
+	case *ast.BasicLit:
+		x, ok := x.(*ast.BasicLit)
+		return ok && x.Kind == pattern.Kind && x.Value == pattern.Value
+	case *ast.ParenExpr:
+		x, ok := x.(*ast.ParenExpr)
+		return ok && m.match(pattern.X, x.X)
+	case *ast.SelectorExpr:
+		x, ok := x.(*ast.SelectorExpr)
+		return ok && x.Sel.Name == pattern.Sel.Name && m.matchQualifier(pattern.X, x.X)
+	case *ast.CallExpr:
+		x, ok := x.(*ast.CallExpr)
+		return ok && x.Ellipsis.IsValid() == pattern.Ellipsis.IsValid() &&
+			m.match(pattern.Fun, x.Fun) &&
+			m.matchList(pattern.Args, x.Args)
+	case *ast.IndexExpr:
+		x, ok := x.(*ast.IndexExpr)
+		return ok && m.match(pattern.X, x.X) && m.match(pattern.Index, x.Index)
+	case *ast.SliceExpr:
+		x, ok := x.(*ast.SliceExpr)
+		return ok && x.Slice3 == pattern.Slice3 &&
+			m.match(pattern.X, x.X) &&
+			m.matchOptional(pattern.Low, x.Low) &&
+			m.matchOptional(pattern.High, x.High) &&
+			m.matchOptional(pattern.Max, x.Max)
+	case *ast.StarExpr:
+		x, ok := x.(*ast.StarExpr)
+		return ok && m.match(pattern.X, x.X)
+	case *ast.UnaryExpr:
+		x, ok := x.(*ast.UnaryExpr)
+		return ok && x.Op == pattern.Op && m.match(pattern.X, x.X)
+	case *ast.BinaryExpr:
+		x, ok := x.(*ast.BinaryExpr)
+		return ok && x.Op == pattern.Op &&
+			m.match(pattern.X, x.X) &&
+			m.match(pattern.Y, x.Y)
+	case *ast.KeyValueExpr:
+		x, ok := x.(*ast.KeyValueExpr)
+		return ok && m.match(pattern.Key, x.Key) && m.match(pattern.Value, x.Value)
+	case *ast.CompositeLit:
+		x, ok := x.(*ast.CompositeLit)
+		return ok && m.matchOptional(pattern.Type, x.Type) &&
+			m.matchList(pattern.Elts, x.Elts)
+	case *ast.TypeAssertExpr:
+		x, ok := x.(*ast.TypeAssertExpr)
+		return ok && m.match(pattern.X, x.X) && m.matchOptional(pattern.Type, x.Type)
+	case *ast.ArrayType:
+		x, ok := x.(*ast.ArrayType)
+		return ok && m.matchOptional(pattern.Len, x.Len) && m.match(pattern.Elt, x.Elt)
+	case *ast.MapType:
+		x, ok := x.(*ast.MapType)
+		return ok && m.match(pattern.Key, x.Key) && m.match(pattern.Value, x.Value)
+	default:
+		// Variables are not supported inside other nodes.
+		return astequal.Node(pattern, x)
+	}
+}
+
+// matchQualifier matches selector expression operands.
+// Package names also match renamed package imports.
+func (m *dslMatcher) matchQualifier(pattern, x ast.Expr) bool {
+	pkg, ok := pattern.(*ast.Ident)
+	id, ok2 := x.(*ast.Ident)
+	if ok && ok2 && m.info != nil {
+		if _, isVar := varName(pkg, dslVarPrefix); !isVar {
+			if pkgName, ok := m.info.Uses[id].(*types.PkgName); ok {
+				return pkgName.Imported().Name() == pkg.Name
+			}
+		}
+	}
+	return m.match(pattern, x)
+}
+
+func (m *dslMatcher) matchOptional(pattern, x ast.Expr) bool {
+	if pattern == nil || x == nil {
+		return pattern == nil && x == nil
+	}
+	return m.match(pattern, x)
+}
+
+// matchList matches expression lists, $*name variables
+// can match any number of list elements.
+func (m *dslMatcher) matchList(patterns, xs []ast.Expr) bool {
+	if len(patterns) == 0 {
+		return len(xs) == 0
+	}
+	if name, ok := varName(patterns[0], dslListVarPrefix); ok {
+		for n := 0; n <= len(xs); n++ {
+			captured := len(m.captures)
+			if m.bind(name, xs[:n]) && m.matchList(patterns[1:], xs[n:]) {
+				return true
+			}
+			m.captures = m.captures[:captured]
+		}
+		return false
+	}
+	return len(xs) != 0 &&
+		m.match(patterns[0], xs[0]) &&
+		m.matchList(patterns[1:], xs[1:])
+}
@@ -0,0 +1,153 @@
+package lint
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testRules = `[
+	{
+		"name": "testSprintfString",
+		"match": ["fmt.Sprintf(\"%s\", $s)"],
+		"where": {"s": {"type": "string"}},
+		"report": "fmt.Sprintf call can be replaced with $s",
+		"suggest": "$s"
+	},
+	{
+		"name": "testStringsIndex",
+		"doc": "Detects strings.Index calls that can be replaced with strings.Contains.",
+		"match": ["strings.Index($s, $sub) >= 0"],
+		"report": "strings.Index($s, $sub) >= 0 can be simplified to strings.Contains($s, $sub)",
+		"severity": "info"
+	},
+	{
+		"name": "testSelfSub",
+		"match": ["$x - $x"],
+		"where": {"x": {"pure": true}},
+		"report": "$x - $x is always 0"
+	},
+	{
+		"name": "testBufferWrite",
+		"match": ["$buf.Write([]byte($s))"],
+		"where": {"buf": {"type": "*bytes.Buffer"}},
+		"report": "$buf.Write([]byte($s)) should be $buf.WriteString($s)"
+	},
+	{
+		"name": "testAppendArgs",
+		"match": ["append($_, $*args)"],
+		"report": "append arguments: [$args]"
+	}
+]`
+
+func TestLoadRules(t *testing.T) {
+	rules, err := LoadRules("rules.json", strings.NewReader(testRules))
+	if err != nil {
+		t.Fatalf("load rules: %v", err)
+	}
+	defer func() {
+		for _, rule := range rules {
+			delete(checkerPrototypes, rule.Name())
+		}
+	}()
+
+	pkgPath := testdataPkgPath + "_dsl"
+	prog := newProg(t, pkgPath)
+	pkgInfo := prog.Imported[pkgPath]
+	ctx := NewContext(prog.Fset, sizes)
+	ctx.SetPackageInfo(&pkgInfo.Info, pkgInfo.Pkg)
+
+	for _, f := range pkgInfo.Files {
+		testFilename := filepath.Join("testdata", "_dsl", getFilename(prog, f))
+		goldenWarns := newGoldenFile(t, testFilename)
+		for _, rule := range rules {
+			for _, warn := range NewChecker(rule, ctx).Check(f) {
+				line := ctx.FileSet().Position(warn.Node.Pos()).Line
+				if w := goldenWarns.find(line, warn.Text); w != nil {
+					w.matched = true
+				} else {
+					t.Errorf("%s:%d: unexpected warn: %s: %s",
+						testFilename, line, rule.Name(), warn.Text)
+				}
+			}
+		}
+		goldenWarns.checkUnmatched(t, testFilename)
+	}
+
+	byName := make(map[string]CheckerInfo)
+	for _, info := range CheckersInfo() {
+		byName[info.Name] = info
+	}
+	if info := byName["testSprintfString"]; info.Summary != "Detects `fmt.Sprintf(\"%s\", $s)` expressions." ||
+		info.After != "$s" || info.SyntaxOnly {
+		t.Errorf("testSprintfString: unexpected info %+v", info)
+	}
+	if info := byName["testSelfSub"]; !info.SyntaxOnly {
+		t.Errorf("testSelfSub: expected to be syntax-only")
+	}
+}
+
+func TestLoadRulesCache(t *testing.T) {
+	pkgPath := testdataPkgPath + "_dsl"
+	prog := newProg(t, pkgPath)
+	pkgInfo := prog.Imported[pkgPath]
+	ctx := NewContext(prog.Fset, sizes)
+	ctx.SetPackageInfo(&pkgInfo.Info, pkgInfo.Pkg)
+	f := pkgInfo.Files[0]
+	src, err := ioutil.ReadFile(prog.Fset.Position(f.Pos()).Filename)
+	if err != nil {
+		t.Fatalf("read source: %v", err)
+	}
+
+	cache := NewCache()
+	check := func(report string) string {
+		t.Helper()
+		rules, err := LoadRules("rules.json", strings.NewReader(`[{
+			"name": "testCachedRule",
+			"match": ["$x - $x"],
+			"report": "`+report+`"
+		}]`))
+		if err != nil {
+			t.Fatalf("load rules: %v", err)
+		}
+		defer delete(checkerPrototypes, rules[0].Name())
+		return warningsString(prog.Fset, cache.Check(NewChecker(rules[0], ctx), f, src))
+	}
+
+	first := check("first message")
+	if !strings.Contains(first, "first message") {
+		t.Fatalf("expected warnings with the first message, have:\n%s", first)
+	}
+	// Edited rule definition invalidates cached results.
+	if have := check("second message"); strings.Contains(have, "first message") || !strings.Contains(have, "second message") {
+		t.Errorf("cached results of the old rule definition are used:\n%s", have)
+	}
+	if hits, misses := cache.Stats(); hits != 0 || misses != 2 {
+		t.Errorf("have %d hits and %d misses, want 0 and 2", hits, misses)
+	}
+}
+
+func TestLoadRulesErrors(t *testing.T) {
+	tests := []struct {
+		rules string
+		err   string
+	}{
+		{`{}`, "rules.json: json: cannot unmarshal object into Go value of type []lint.dslRuleSpec"},
+		{`[{"name": "x-y", "match": ["x"], "report": "x"}]`, `rules.json: invalid rule name "x-y"`},
+		{`[{"name": "x", "report": "x"}]`, "rules.json: x: no match patterns"},
+		{`[{"name": "x", "match": ["x"]}]`, "rules.json: x: empty report"},
+		{`[{"name": "x", "match": ["f("], "report": "x"}]`, `rules.json: x: match "f(": 1:3: expected ')', found 'EOF'`},
+		{`[{"name": "x", "match": ["$x"], "where": {"y": {}}, "report": "x"}]`, "rules.json: x: where: $y is not used in match patterns"},
+		{`[{"name": "x", "match": ["$x"], "report": "$y"}]`, "rules.json: x: $y is not captured by match patterns"},
+		{`[{"name": "x", "match": ["$_"], "report": "$_"}]`, "rules.json: x: $_ is not captured by match patterns"},
+		{`[{"name": "x", "match": ["x"], "report": "x"}, {"name": "x", "match": ["x"], "report": "x"}]`, "rules.json: x: checker already exists"},
+		{`[{"name": "unslice", "match": ["x"], "report": "x"}]`, "rules.json: unslice: checker already exists"},
+	}
+	for _, test := range tests {
+		_, err := LoadRules("rules.json", strings.NewReader(test.rules))
+		if err == nil || err.Error() != test.err {
+			t.Errorf("%s:\nhave error: %v\nwant error: %s", test.rules, err, test.err)
+		}
+	}
+}
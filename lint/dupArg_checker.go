@@ -0,0 +1,79 @@
+package lint
+
+//! Detects suspicious duplicated arguments.
+//
+// Reported functions are listed in a table along with the
+// argument pairs that are expected to be different.
+//
+// @Before:
+// copy(dst, dst)
+//
+// @After:
+// copy(dst, src)
+
+import (
+	"go/ast"
+
+	"github.com/go-toolsmith/astequal"
+)
+
+func init() {
+	addChecker(&dupArgChecker{}, attrExperimental)
+}
+
+type dupArgChecker struct {
+	checkerBase
+
+	// funcSet maps function full name to the argument index pairs
+	// that make no sense with duplicated (same) values.
+	funcSet map[string][][2]int
+}
+
+func (c *dupArgChecker) Init() {
+	c.funcSet = map[string][][2]int{
+		"copy": {{0, 1}},
+
+		"strings.Replace":    {{1, 2}},
+		"strings.ReplaceAll": {{1, 2}},
+		"strings.EqualFold":  {{0, 1}},
+		"strings.Compare":    {{0, 1}},
+		"bytes.Replace":      {{1, 2}},
+		"bytes.ReplaceAll":   {{1, 2}},
+		"bytes.Equal":        {{0, 1}},
+		"bytes.EqualFold":    {{0, 1}},
+		"bytes.Compare":      {{0, 1}},
+
+		"reflect.DeepEqual": {{0, 1}},
+
+		"math.Max":       {{0, 1}},
+		"math.Min":       {{0, 1}},
+		"math.Dim":       {{0, 1}},
+		"math.Mod":       {{0, 1}},
+		"math.Remainder": {{0, 1}},
+	}
+}
+
+func (c *dupArgChecker) VisitExpr(expr ast.Expr) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return
+	}
+	name := c.ctx.calleeName(call)
+	if c.ctx.isBuiltinCall(call, "copy") {
+		name = "copy"
+	}
+	for _, pair := range c.funcSet[name] {
+		if pair[1] >= len(call.Args) {
+			continue
+		}
+		x, y := call.Args[pair[0]], call.Args[pair[1]]
+		if isSafeExpr(x) && astequal.Expr(x, y) {
+			c.warn(call, x)
+			return
+		}
+	}
+}
+
+func (c *dupArgChecker) warn(cause *ast.CallExpr, arg ast.Expr) {
+	c.ctx.Warn(cause, "suspicious duplicated `%s` argument in `%s` call", arg, cause.Fun)
+}
@@ -2,6 +2,10 @@ package lint
 
 //! Detects duplicated branch bodies inside conditional statements.
 //
+// For switch statements, every case body is compared with the
+// preceding cases. Empty bodies and cases that take part in
+// fallthrough are not reported, as well as type switch cases.
+//
 // @Before:
 // if cond {
 // 	println("cond=true")
@@ -31,11 +35,13 @@ type dupBranchBodyChecker struct {
 }
 
 func (c *dupBranchBodyChecker) VisitStmt(stmt ast.Stmt) {
-	// TODO(quasilyte): extend to check switch statements as well.
-	// Should be very careful with type switches.
-
-	if stmt, ok := stmt.(*ast.IfStmt); ok {
+	// Type switches are not checked, because identical bodies
+	// can have different meaning for different types.
+	switch stmt := stmt.(type) {
+	case *ast.IfStmt:
 		c.checkIf(stmt)
+	case *ast.SwitchStmt:
+		c.checkSwitch(stmt)
 	}
 }
 
@@ -47,6 +53,31 @@ func (c *dupBranchBodyChecker) checkIf(stmt *ast.IfStmt) {
 	}
 }
 
+func (c *dupBranchBodyChecker) checkSwitch(stmt *ast.SwitchStmt) {
+	var clauses []*ast.CaseClause
+	fallsInto := false // Whether previous case ends with fallthrough
+	for _, x := range stmt.Body.List {
+		cc := x.(*ast.CaseClause)
+		fallsFrom := endsWithFallthrough(cc.Body)
+		if len(cc.Body) == 0 || fallsInto || fallsFrom {
+			fallsInto = fallsFrom
+			continue
+		}
+		fallsInto = false
+		for _, prev := range clauses {
+			if astequal.Stmt(&ast.BlockStmt{List: prev.Body}, &ast.BlockStmt{List: cc.Body}) {
+				c.warnSwitch(cc)
+				break
+			}
+		}
+		clauses = append(clauses, cc)
+	}
+}
+
 func (c *dupBranchBodyChecker) warnIf(cause ast.Node) {
 	c.ctx.Warn(cause, "both branches in if statement has same body")
 }
+
+func (c *dupBranchBodyChecker) warnSwitch(cause *ast.CaseClause) {
+	c.ctx.Warn(cause, "switch case body duplicates one of the preceding cases")
+}
@@ -2,6 +2,9 @@ package lint
 
 //! Detects duplicated case clauses inside switch statements.
 //
+// Type switches are not checked: duplicated types, including
+// aliases of the already listed types, are rejected by the compiler.
+//
 // @Before:
 // switch x {
 // case ys[0], ys[1], ys[2], ys[0], ys[4]:
@@ -29,6 +32,9 @@ type dupCaseChecker struct {
 }
 
 func (c *dupCaseChecker) VisitStmt(stmt ast.Stmt) {
+	// Duplicated constant cases and type switch cases
+	// are compile errors, so only expression switches
+	// with non-constant cases can be reported.
 	if stmt, ok := stmt.(*ast.SwitchStmt); ok {
 		c.checkSwitch(stmt)
 	}
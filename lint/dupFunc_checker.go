@@ -0,0 +1,239 @@
+package lint
+
+//! Detects functions of the same package that have identical bodies.
+//
+// Function bodies are compared after local names normalization,
+// so functions that differ only in param and variable names are reported.
+// Functions that only differ in literal values and param types are
+// reported as nearly identical, with medium confidence.
+// Small functions, like getters and setters, are not reported.
+//
+// Checker params:
+//	minStmts - functions with less statements are not reported
+//
+// @Before:
+// func sumInts(xs []int) int {
+// 	total := 0
+// 	for _, x := range xs {
+// 		total += x
+// 	}
+// 	return total
+// }
+//
+// func sumWeights(weights []int) int {
+// 	sum := 0
+// 	for _, w := range weights {
+// 		sum += w
+// 	}
+// 	return sum
+// }
+//
+// @After:
+// func sumInts(xs []int) int {
+// 	total := 0
+// 	for _, x := range xs {
+// 		total += x
+// 	}
+// 	return total
+// }
+//
+// @Note:
+// Duplicates in all package files are found, unless the checker
+// is run for a single file at a time, like in editor integrations.
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"hash/fnv"
+	"strings"
+)
+
+func init() {
+	addChecker(&dupFuncChecker{}, attrExperimental)
+}
+
+type dupFuncChecker struct {
+	checkerBase
+
+	minStmts int
+}
+
+func (c *dupFuncChecker) Params() []CheckerParam {
+	return []CheckerParam{
+		{Name: "minStmts", Kind: ParamInt, Default: "4"},
+	}
+}
+
+func (c *dupFuncChecker) Init() {
+	c.minStmts = c.ctx.IntParam("minStmts")
+}
+
+func (c *dupFuncChecker) VisitPackage(files []*ast.File) {
+	exact := make(map[uint64]*ast.FuncDecl)
+	near := make(map[uint64]*ast.FuncDecl)
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			decl, ok := decl.(*ast.FuncDecl)
+			if !ok || decl.Body == nil || countStmts(decl.Body) < c.minStmts {
+				continue
+			}
+
+			key := c.fingerprint(decl, false)
+			if orig, ok := exact[key]; ok {
+				c.warnExact(decl, orig)
+				continue
+			}
+			exact[key] = decl
+
+			key = c.fingerprint(decl, true)
+			if orig, ok := near[key]; ok {
+				c.warnNear(decl, orig)
+				continue
+			}
+			near[key] = decl
+		}
+	}
+}
+
+// fingerprint returns decl hash that is the same for functions
+// that differ only in local names.
+// If near is true, literal values and signatures are not hashed.
+func (c *dupFuncChecker) fingerprint(decl *ast.FuncDecl, near bool) uint64 {
+	p := funcPrinter{
+		info:   c.ctx.typesInfo,
+		fn:     decl,
+		locals: make(map[types.Object]int),
+		near:   near,
+	}
+	if !near {
+		p.print(decl.Type)
+	}
+	p.print(decl.Body)
+	h := fnv.New64a()
+	h.Write([]byte(p.buf.String()))
+	return h.Sum64()
+}
+
+// countStmts returns the number of statements inside body, including nested ones.
+func countStmts(body *ast.BlockStmt) int {
+	n := 0
+	ast.Inspect(body, func(x ast.Node) bool {
+		if _, ok := x.(ast.Stmt); ok {
+			if _, ok := x.(*ast.BlockStmt); !ok {
+				n++
+			}
+		}
+		return true
+	})
+	return n
+}
+
+// funcPrinter prints normalized AST of the function parts.
+type funcPrinter struct {
+	info *types.Info
+
+	// fn is a function that is printed.
+	// Objects declared inside it are locals.
+	fn *ast.FuncDecl
+
+	// locals maps local objects to the order of their first appearance.
+	locals map[types.Object]int
+
+	// near enables literal values omitting.
+	near bool
+
+	buf strings.Builder
+}
+
+func (p *funcPrinter) print(root ast.Node) {
+	ast.Inspect(root, func(n ast.Node) bool {
+		if n == nil {
+			p.buf.WriteByte(')')
+			return false
+		}
+		fmt.Fprintf(&p.buf, "(%T", n)
+		switch n := n.(type) {
+		case *ast.Ident:
+			p.printIdent(n)
+		case *ast.BasicLit:
+			if !p.near {
+				fmt.Fprintf(&p.buf, " %s", n.Value)
+			}
+		case *ast.BinaryExpr:
+			fmt.Fprintf(&p.buf, " %s", n.Op)
+		case *ast.UnaryExpr:
+			fmt.Fprintf(&p.buf, " %s", n.Op)
+		case *ast.AssignStmt:
+			fmt.Fprintf(&p.buf, " %s", n.Tok)
+		case *ast.IncDecStmt:
+			fmt.Fprintf(&p.buf, " %s", n.Tok)
+		case *ast.BranchStmt:
+			fmt.Fprintf(&p.buf, " %s", n.Tok)
+		case *ast.GenDecl:
+			fmt.Fprintf(&p.buf, " %s", n.Tok)
+		case *ast.RangeStmt:
+			// Make key-only and value-only loops distinguishable.
+			fmt.Fprintf(&p.buf, " %v %v", n.Key != nil, n.Value != nil)
+		case *ast.ForStmt:
+			fmt.Fprintf(&p.buf, " %v %v %v", n.Init != nil, n.Cond != nil, n.Post != nil)
+		case *ast.IfStmt:
+			fmt.Fprintf(&p.buf, " %v", n.Init != nil)
+		case *ast.SliceExpr:
+			fmt.Fprintf(&p.buf, " %v %v %v", n.Low != nil, n.High != nil, n.Max != nil)
+		}
+		return true
+	})
+}
+
+func (p *funcPrinter) printIdent(id *ast.Ident) {
+	obj := p.info.ObjectOf(id)
+	switch {
+	case obj == nil:
+		fmt.Fprintf(&p.buf, " %s", id.Name)
+	case obj.Pos() >= p.fn.Pos() && obj.Pos() < p.fn.End():
+		i, ok := p.locals[obj]
+		if !ok {
+			i = len(p.locals)
+			p.locals[obj] = i
+		}
+		fmt.Fprintf(&p.buf, " #%d", i)
+	case obj.Pkg() != nil:
+		fmt.Fprintf(&p.buf, " %s.%s", obj.Pkg().Path(), obj.Name())
+	default:
+		fmt.Fprintf(&p.buf, " %s", obj.Name())
+	}
+}
+
+// funcName returns decl name, methods are prefixed with receiver type name.
+func (c *dupFuncChecker) funcName(decl *ast.FuncDecl) string {
+	if decl.Recv == nil || len(decl.Recv.List) == 0 {
+		return decl.Name.Name
+	}
+	typ := decl.Recv.List[0].Type
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+	switch x := typ.(type) {
+	case *ast.IndexExpr:
+		typ = x.X
+	case *ast.IndexListExpr:
+		typ = x.X
+	}
+	if id, ok := typ.(*ast.Ident); ok {
+		return id.Name + "." + decl.Name.Name
+	}
+	return decl.Name.Name
+}
+
+func (c *dupFuncChecker) warnExact(decl, orig *ast.FuncDecl) {
+	c.ctx.WarnWith(Warning{Node: decl.Name, Code: "identical"},
+		"%s body is identical to %s body; consider reusing it",
+		c.funcName(decl), c.funcName(orig))
+}
+
+func (c *dupFuncChecker) warnNear(decl, orig *ast.FuncDecl) {
+	c.ctx.WarnWith(Warning{Node: decl.Name, Code: "nearlyIdentical", Confidence: ConfidenceMedium},
+		"%s body is nearly identical to %s body; consider extracting common code",
+		c.funcName(decl), c.funcName(orig))
+}
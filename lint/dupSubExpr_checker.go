@@ -2,6 +2,13 @@ package lint
 
 //! Detects suspicious duplicated sub-expressions.
 //
+// Duplicated float operands are not reported, as they are legit for NaN values.
+// Operands of type params that permit float types are treated as floats.
+// NaN checks like `x != x` are reported as info with math.IsNaN suggestion.
+//
+// Checker params:
+//	checkFloats - if "true", other duplicated float operands are reported with low confidence
+//
 // @Before:
 // sort.Slice(xs, func(i, j int) bool {
 // 	return xs[i].v < xs[i].v // Duplicated index
@@ -18,6 +25,7 @@ import (
 	"go/types"
 
 	"github.com/go-toolsmith/astequal"
+	"golang.org/x/tools/go/ast/astutil"
 )
 
 func init() {
@@ -32,6 +40,18 @@ type dupSubExprChecker struct {
 	opSet map[token.Token]bool
 
 	floatOpsSet map[token.Token]bool
+
+	// chainParts is a set of nested && and || chain nodes
+	// that were already checked as a part of the enclosing chain.
+	chainParts map[*ast.BinaryExpr]bool
+
+	checkFloats bool
+}
+
+func (c *dupSubExprChecker) Params() []CheckerParam {
+	return []CheckerParam{
+		{Name: "checkFloats", Kind: ParamBool, Default: "false"},
+	}
 }
 
 func (c *dupSubExprChecker) Init() {
@@ -57,6 +77,8 @@ func (c *dupSubExprChecker) Init() {
 		{op: token.SUB, float: true}, // x - x
 	}
 
+	c.checkFloats = c.ctx.BoolParam("checkFloats")
+	c.chainParts = make(map[*ast.BinaryExpr]bool)
 	c.opSet = make(map[token.Token]bool)
 	c.floatOpsSet = make(map[token.Token]bool)
 	for _, opInfo := range ops {
@@ -77,45 +99,131 @@ func (c *dupSubExprChecker) checkBinaryExpr(expr *ast.BinaryExpr) {
 	if !c.opSet[expr.Op] {
 		return
 	}
+	if expr.Op == token.LAND || expr.Op == token.LOR {
+		c.checkChain(expr)
+		return
+	}
 	if c.resultIsFloat(expr.X) && c.floatOpsSet[expr.Op] {
+		c.checkFloatExpr(expr)
+		return
+	}
+	if isSafeExpr(expr) && c.opSet[expr.Op] && astequal.Expr(expr.X, expr.Y) {
+		c.warn(expr, c.confidence(expr.X))
+	}
+}
+
+// checkFloatExpr reports duplicated float operands of expr.
+//
+// Such expressions give different results for NaN operands,
+// so they are only reported with low confidence if checkFloats is set.
+// NaN checks are reported separately, as they are usually intentional.
+func (c *dupSubExprChecker) checkFloatExpr(expr *ast.BinaryExpr) {
+	if !isSafeExpr(expr) || !astequal.Expr(expr.X, expr.Y) {
 		return
 	}
-	if c.isSafe(expr) && c.opSet[expr.Op] && astequal.Expr(expr.X, expr.Y) {
-		c.warn(expr)
+	switch {
+	case expr.Op == token.NEQ:
+		c.warnNaNCheck(expr, "math.IsNaN")
+	case expr.Op == token.EQL:
+		c.warnNaNCheck(expr, "!math.IsNaN")
+	case c.checkFloats:
+		c.warn(expr, ConfidenceLow)
 	}
 }
 
+// confidence returns the warning confidence for duplicated x operand.
+//
+// Index expressions are permitted by isSafe, but
+// they may cause panics, so duplicating them could be legit.
+func (c *dupSubExprChecker) confidence(x ast.Expr) Confidence {
+	hasIndex := findNode(x, func(n ast.Node) bool {
+		_, ok := n.(*ast.IndexExpr)
+		return ok
+	}) != nil
+	if hasIndex {
+		return ConfidenceMedium
+	}
+	return ConfidenceHigh
+}
+
+// checkChain reports duplicated operands inside && and || chains,
+// like `x || y || x`. Operands are collected through the nested
+// chain nodes, so the chain is reported only once.
+func (c *dupSubExprChecker) checkChain(expr *ast.BinaryExpr) {
+	if c.chainParts[expr] {
+		delete(c.chainParts, expr)
+		return
+	}
+	operands := c.chainOperands(expr, expr.Op, nil)
+	delete(c.chainParts, expr)
+	for i, x := range operands {
+		if !isSafeExpr(x) {
+			continue
+		}
+		for _, y := range operands[i+1:] {
+			if astequal.Expr(x, y) {
+				c.warn(expr, c.confidence(x))
+				return
+			}
+		}
+	}
+}
+
+// chainOperands appends operands of the x chain of op operators to dst.
+func (c *dupSubExprChecker) chainOperands(x ast.Expr, op token.Token, dst []ast.Expr) []ast.Expr {
+	x = astutil.Unparen(x)
+	if bin, ok := x.(*ast.BinaryExpr); ok && bin.Op == op {
+		c.chainParts[bin] = true
+		dst = c.chainOperands(bin.X, op, dst)
+		return c.chainOperands(bin.Y, op, dst)
+	}
+	return append(dst, x)
+}
+
 func (c *dupSubExprChecker) resultIsFloat(expr ast.Expr) bool {
-	typ, ok := c.ctx.typesInfo.TypeOf(expr).(*types.Basic)
-	return ok && typ.Info()&types.IsFloat != 0
+	switch typ := c.ctx.typesInfo.TypeOf(expr).(type) {
+	case *types.Basic:
+		return typ.Info()&types.IsFloat != 0
+	case *types.TypeParam:
+		// Type argument may be a float, so the operands may be NaN.
+		return typeSetHasFloat(typ.Constraint())
+	default:
+		return false
+	}
 }
 
-func (c *dupSubExprChecker) isSafe(expr ast.Expr) bool {
-	// This list switch is not comprehensive and uses
-	// whitelist to be on the conservative side.
-	// Can be extended as needed.
-	//
-	// Note that it is not very strict "safe" as
-	// index expressions are permitted even though they
-	// may cause panics.
-	switch expr := expr.(type) {
-	case *ast.BinaryExpr:
-		return c.isSafe(expr.X) && c.isSafe(expr.Y)
-	case *ast.UnaryExpr:
-		return expr.Op != token.ARROW && c.isSafe(expr.X)
-	case *ast.BasicLit, *ast.Ident:
-		return true
-	case *ast.IndexExpr:
-		return c.isSafe(expr.X) && c.isSafe(expr.Index)
-	case *ast.SelectorExpr:
-		return c.isSafe(expr.X)
-	case *ast.ParenExpr:
-		return c.isSafe(expr.X)
+// typeSetHasFloat reports whether any term of the constraint
+// type set is a float or complex type.
+func typeSetHasFloat(constraint types.Type) bool {
+	switch typ := constraint.(type) {
+	case *types.Union:
+		for i := 0; i < typ.Len(); i++ {
+			if typeSetHasFloat(typ.Term(i).Type()) {
+				return true
+			}
+		}
+		return false
+	}
+	switch typ := constraint.Underlying().(type) {
+	case *types.Interface:
+		for i := 0; i < typ.NumEmbeddeds(); i++ {
+			if typeSetHasFloat(typ.EmbeddedType(i)) {
+				return true
+			}
+		}
+		return false
+	case *types.Basic:
+		return typ.Info()&(types.IsFloat|types.IsComplex) != 0
 	default:
 		return false
 	}
 }
 
-func (c *dupSubExprChecker) warn(cause *ast.BinaryExpr) {
-	c.ctx.Warn(cause, "suspicious identical LHS and RHS for `%s` operator", cause.Op)
+func (c *dupSubExprChecker) warnNaNCheck(cause *ast.BinaryExpr, suggestion string) {
+	c.ctx.WarnWithSeverity(SeverityInfo, cause,
+		"`%s` looks like a NaN check, consider `%s(%s)`", cause, suggestion, astutil.Unparen(cause.X))
+}
+
+func (c *dupSubExprChecker) warn(cause *ast.BinaryExpr, conf Confidence) {
+	c.ctx.WarnWithConfidence(conf, cause, "suspicious identical LHS and RHS for `%s` operator", cause.Op)
 }
@@ -0,0 +1,60 @@
+package lint
+
+//! Detects empty select statements that block forever.
+//
+// Empty select is sometimes used intentionally to block main,
+// so it's reported with info severity.
+//
+// Checker params:
+//	skipMain - if "true", main function of main package is not checked
+//
+// @Before:
+// select {}
+//
+// @After:
+// select {
+// case <-done:
+// }
+
+import (
+	"go/ast"
+)
+
+func init() {
+	addChecker(&emptySelectChecker{}, attrExperimental, attrSyntaxOnly)
+}
+
+type emptySelectChecker struct {
+	checkerBase
+
+	skipMain bool
+}
+
+func (c *emptySelectChecker) Params() []CheckerParam {
+	return []CheckerParam{
+		{Name: "skipMain", Kind: ParamBool, Default: "false"},
+	}
+}
+
+func (c *emptySelectChecker) Init() {
+	c.skipMain = c.ctx.BoolParam("skipMain")
+}
+
+func (c *emptySelectChecker) VisitFuncDecl(decl *ast.FuncDecl) {
+	if decl.Body == nil {
+		return
+	}
+	if c.skipMain && decl.Recv == nil && decl.Name.Name == "main" && c.ctx.file.Name.Name == "main" {
+		return
+	}
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		if stmt, ok := n.(*ast.SelectStmt); ok && len(stmt.Body.List) == 0 {
+			c.warn(stmt)
+		}
+		return true
+	})
+}
+
+func (c *emptySelectChecker) warn(cause ast.Node) {
+	c.ctx.WarnWithSeverity(SeverityInfo, cause, "select {} blocks forever; ensure this is intentional")
+}
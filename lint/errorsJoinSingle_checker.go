@@ -0,0 +1,64 @@
+package lint
+
+//! Detects errors.Join calls with less than 2 arguments.
+//
+// Joining a single error is redundant, while errors.Join
+// without arguments always returns nil.
+// Single argument is only reported if it has the error type.
+// Only reported for Go 1.20 and newer, where errors.Join is available.
+//
+// @Before:
+// return errors.Join(err)
+//
+// @After:
+// return err
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+func init() {
+	addChecker(&errorsJoinSingleChecker{}, attrExperimental)
+}
+
+type errorsJoinSingleChecker struct {
+	checkerBase
+}
+
+func (c *errorsJoinSingleChecker) GoFeatures() []GoFeature {
+	return []GoFeature{GoFeatureErrorsJoin}
+}
+
+func (c *errorsJoinSingleChecker) VisitExpr(expr ast.Expr) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || call.Ellipsis != token.NoPos || c.ctx.calleeName(call) != "errors.Join" {
+		return
+	}
+	switch len(call.Args) {
+	case 0:
+		c.warnNoArgs(call)
+	case 1:
+		// Only plain errors can replace the call: multi-value calls
+		// are spread into several arguments, while concrete error
+		// types make nil pointers non-nil errors.
+		if types.Identical(c.ctx.typesInfo.TypeOf(call.Args[0]), types.Universe.Lookup("error").Type()) {
+			c.warnSingle(call)
+		}
+	}
+}
+
+func (c *errorsJoinSingleChecker) warnNoArgs(cause *ast.CallExpr) {
+	c.ctx.Warn(cause, "errors.Join with no arguments returns nil")
+}
+
+func (c *errorsJoinSingleChecker) warnSingle(cause *ast.CallExpr) {
+	// Argument may become an operand of a selector, like in
+	// `errors.Join(<-ch).Error()`, so it's parenthesized if needed.
+	fix := []TextEdit{c.ctx.replaceNode(cause, parenIfNeeded(cause.Args[0]))}
+	if spec := c.ctx.unusedImport("errors", cause); spec != nil {
+		fix = append(fix, c.ctx.deleteImportEdit(spec))
+	}
+	c.ctx.WarnWithFix(fix, cause, "errors.Join with a single error is redundant")
+}
@@ -2,6 +2,10 @@ package lint
 
 //! Detects potentially unsafe dependencies on evaluation order.
 //
+// Checks return statements, assignments and var declarations values.
+// Also reports assignments like `i, xs[i] = 1, 2`, where LHS index
+// operands use the values that are assigned by the same statement.
+//
 // @Before:
 // return mayModifySlice(&xs), xs[0]
 //
@@ -15,6 +19,7 @@ package lint
 
 import (
 	"go/ast"
+	"go/token"
 	"go/types"
 
 	"github.com/go-critic/go-critic/lint/internal/lintutil"
@@ -42,12 +47,18 @@ func (c *evalOrderChecker) VisitStmt(stmt ast.Stmt) {
 	case *ast.ReturnStmt:
 		c.checkReturn(stmt)
 	case *ast.AssignStmt:
-		// TODO(quasilyte):
-		//	https://github.com/golang/go/issues/23188
-		//	https://github.com/golang/go/issues/24448
-		//	https://github.com/golang/go/issues/23017
+		// See https://github.com/golang/go/issues/23188
+		// and https://github.com/golang/go/issues/24448.
+		c.checkAssign(stmt)
 	case *ast.DeclStmt:
-		//	TODO(quasilyte): do same handling as in AssignStmt.
+		decl, ok := stmt.Decl.(*ast.GenDecl)
+		if !ok || decl.Tok != token.VAR {
+			return
+		}
+		for _, spec := range decl.Specs {
+			spec := spec.(*ast.ValueSpec)
+			c.checkValues(spec, spec.Values, nil)
+		}
 	}
 }
 
@@ -57,16 +68,70 @@ func (c *evalOrderChecker) reset() {
 }
 
 func (c *evalOrderChecker) checkReturn(ret *ast.ReturnStmt) {
-	if len(ret.Results) < 2 {
+	c.checkValues(ret, ret.Results, nil)
+}
+
+func (c *evalOrderChecker) checkAssign(assign *ast.AssignStmt) {
+	if assign.Tok != token.ASSIGN {
+		c.checkValues(assign, assign.Rhs, nil)
+		return
+	}
+	c.checkAssignedOperands(assign.Lhs)
+	// Operands of LHS index expressions are evaluated
+	// along with RHS values, in unspecified order.
+	var lhsDeps []ast.Expr
+	for _, x := range assign.Lhs {
+		if x, ok := x.(*ast.IndexExpr); ok {
+			lhsDeps = append(lhsDeps, x.X)
+		}
+	}
+	c.checkValues(assign, assign.Rhs, lhsDeps)
+}
+
+// checkAssignedOperands reports LHS expressions that use variables
+// assigned by the same statement, like xs[i] in `i, xs[i] = 1, 2`.
+// Such operands are evaluated before any assignment happens.
+func (c *evalOrderChecker) checkAssignedOperands(lhs []ast.Expr) {
+	if len(lhs) < 2 {
+		return
+	}
+	assigned := make(map[types.Object]bool)
+	for _, x := range lhs {
+		if id, ok := x.(*ast.Ident); ok && id.Name != "_" {
+			if obj := c.ctx.typesInfo.ObjectOf(id); obj != nil {
+				assigned[obj] = true
+			}
+		}
+	}
+	for _, x := range lhs {
+		if _, ok := x.(*ast.Ident); ok {
+			continue
+		}
+		used := findNode(x, func(n ast.Node) bool {
+			id, ok := n.(*ast.Ident)
+			return ok && assigned[c.ctx.typesInfo.ObjectOf(id)]
+		})
+		if used != nil {
+			c.warnAssigned(x, used.(*ast.Ident))
+		}
+	}
+}
+
+// checkValues reports dependencies between values that are
+// evaluated by the cause statement.
+// Extra passive dependencies can be provided with deps.
+func (c *evalOrderChecker) checkValues(cause ast.Node, values, deps []ast.Expr) {
+	if len(values)+len(deps) < 2 {
 		return
 	}
 	c.reset()
-	for _, x := range ret.Results {
+	c.passive = append(c.passive, deps...)
+	for _, x := range values {
 		c.collectDeps(x)
 	}
 	// TODO(quasilyte): we can parametrize the threshold later.
 	if deps := c.depsCount(); deps != 0 {
-		c.warn(ret, deps)
+		c.warn(cause, deps)
 		return
 	}
 }
@@ -154,3 +219,7 @@ func (c *evalOrderChecker) warn(cause ast.Node, deps int) {
 	}
 	c.ctx.Warn(cause, "potential dependency on evaluation order (%s)", tag)
 }
+
+func (c *evalOrderChecker) warnAssigned(cause ast.Expr, id *ast.Ident) {
+	c.ctx.Warn(cause, "%s uses %s value before the assignment", cause, id)
+}
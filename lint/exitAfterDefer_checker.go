@@ -0,0 +1,80 @@
+package lint
+
+//! Detects calls to exit/fatal inside functions that use defer.
+//
+// Deferred calls are not executed if program is terminated
+// by os.Exit or log.Fatal functions.
+// Function literals are checked as separate functions.
+//
+// @Before:
+// defer os.Remove(filename)
+// if bad {
+// 	log.Fatalf("something bad happened")
+// }
+//
+// @After:
+// defer os.Remove(filename)
+// if bad {
+// 	log.Printf("something bad happened")
+// 	return
+// }
+
+import (
+	"go/ast"
+
+	"github.com/go-toolsmith/astfmt"
+)
+
+func init() {
+	addChecker(&exitAfterDeferChecker{}, attrExperimental)
+}
+
+type exitAfterDeferChecker struct {
+	checkerBase
+
+	exitFuncs map[string]bool
+}
+
+func (c *exitAfterDeferChecker) Init() {
+	c.exitFuncs = map[string]bool{"os.Exit": true}
+	for _, name := range []string{"Fatal", "Fatalf", "Fatalln"} {
+		c.exitFuncs["log."+name] = true
+		c.exitFuncs["(*log.Logger)."+name] = true
+	}
+}
+
+func (c *exitAfterDeferChecker) VisitFuncDecl(decl *ast.FuncDecl) {
+	c.checkBody(decl.Body)
+}
+
+// checkBody reports exit calls that follow any defer inside body.
+func (c *exitAfterDeferChecker) checkBody(body *ast.BlockStmt) {
+	var deferStmt *ast.DeferStmt
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			c.checkBody(n.Body)
+			return false
+		case *ast.DeferStmt:
+			if deferStmt == nil {
+				deferStmt = n
+			}
+		case *ast.CallExpr:
+			name := c.ctx.calleeName(n)
+			if deferStmt != nil && c.exitFuncs[name] {
+				c.warn(n, deferStmt)
+			}
+		}
+		return true
+	})
+}
+
+func (c *exitAfterDeferChecker) warn(cause *ast.CallExpr, deferStmt *ast.DeferStmt) {
+	var s string
+	if _, ok := deferStmt.Call.Fun.(*ast.FuncLit); ok {
+		s = "func(){...}(...)"
+	} else {
+		s = astfmt.Sprint(deferStmt.Call)
+	}
+	c.ctx.Warn(cause, "%s will exit, and `defer %s` will not run", cause.Fun, s)
+}
@@ -0,0 +1,142 @@
+package lint
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ExitPolicy decides whether reported warnings should fail the linter run,
+// so go-critic can be adopted in CI gradually.
+//
+// Zero policy fails the run if there is any warning
+// with severity above SeverityInfo.
+type ExitPolicy struct {
+	// FailSeverity is a minimal severity of warnings that fail the run.
+	// If zero, SeverityWarning is used.
+	FailSeverity Severity
+
+	// IgnoreExperimental makes warnings of experimental checkers
+	// not fail the run, unless the checker is listed in FailOn or Budgets.
+	IgnoreExperimental bool
+
+	// FailOn is a list of checkers which every warning fails the run,
+	// regardless of its severity, as if they were errors.
+	FailOn []string
+
+	// Budgets maps checker name to a number of its warnings that are tolerated.
+	// The run fails only if checker reports more warnings than its budget,
+	// severity and experimental status of the checker are not taken into account.
+	Budgets map[string]int
+}
+
+// PolicyViolation describes a checker which warnings fail the run.
+type PolicyViolation struct {
+	// Checker is a checker name.
+	Checker string
+
+	// Warnings is a number of checker warnings that fail the run.
+	// For checkers with a budget, it's a number of all checker warnings.
+	Warnings int
+
+	// Budget is a number of tolerated checker warnings,
+	// or -1 if checker has no budget.
+	Budget int
+}
+
+// String returns a human-readable violation description.
+func (v PolicyViolation) String() string {
+	if v.Budget >= 0 {
+		return fmt.Sprintf("%s: %d warnings exceed the budget of %d", v.Checker, v.Warnings, v.Budget)
+	}
+	return fmt.Sprintf("%s: %d failing warnings", v.Checker, v.Warnings)
+}
+
+// Empty reports whether policy is the default one.
+func (p *ExitPolicy) Empty() bool {
+	return p.FailSeverity == 0 && !p.IgnoreExperimental && len(p.FailOn) == 0 && len(p.Budgets) == 0
+}
+
+// Validate returns an error for unknown checker names and negative budgets.
+// Checker can't be both in FailOn and Budgets.
+func (p *ExitPolicy) Validate() error {
+	failOn := make(map[string]bool, len(p.FailOn))
+	for _, name := range p.FailOn {
+		if _, ok := checkerInfoByName(name); !ok {
+			return fmt.Errorf("%s: unknown checker", name)
+		}
+		failOn[name] = true
+	}
+	for _, name := range sortedBudgetCheckers(p.Budgets) {
+		if _, ok := checkerInfoByName(name); !ok {
+			return fmt.Errorf("%s: unknown checker", name)
+		}
+		if p.Budgets[name] < 0 {
+			return fmt.Errorf("%s: negative budget %d", name, p.Budgets[name])
+		}
+		if failOn[name] {
+			return fmt.Errorf("%s: checker can't have a budget and fail on every warning", name)
+		}
+	}
+	return nil
+}
+
+// Evaluate returns checkers which reports fail the run according to p.
+// Run succeeds if the result is empty.
+// Violations are sorted by checker names.
+func (p *ExitPolicy) Evaluate(reports []Report) []PolicyViolation {
+	failSeverity := p.FailSeverity
+	if failSeverity == 0 {
+		failSeverity = SeverityWarning
+	}
+	failOn := make(map[string]bool, len(p.FailOn))
+	for _, name := range p.FailOn {
+		failOn[name] = true
+	}
+
+	all := make(map[string]int)     // Number of all checker reports
+	failing := make(map[string]int) // Number of failing checker reports
+	for _, r := range reports {
+		all[r.Checker]++
+		if _, ok := p.Budgets[r.Checker]; ok {
+			continue
+		}
+		switch {
+		case failOn[r.Checker]:
+			failing[r.Checker]++
+		case p.IgnoreExperimental && p.isExperimental(r.Checker):
+			// Not failing.
+		case r.Severity >= failSeverity:
+			failing[r.Checker]++
+		}
+	}
+
+	var violations []PolicyViolation
+	for name, n := range failing {
+		violations = append(violations, PolicyViolation{Checker: name, Warnings: n, Budget: -1})
+	}
+	for name, budget := range p.Budgets {
+		if all[name] > budget {
+			violations = append(violations, PolicyViolation{Checker: name, Warnings: all[name], Budget: budget})
+		}
+	}
+	sort.Slice(violations, func(i, j int) bool {
+		return violations[i].Checker < violations[j].Checker
+	})
+	return violations
+}
+
+// isExperimental reports whether checker with the specified name is experimental.
+// Unknown checkers, like ones from the plugins that are not loaded, are not.
+func (p *ExitPolicy) isExperimental(checker string) bool {
+	info, ok := checkerInfoByName(checker)
+	return ok && info.Experimental
+}
+
+func sortedBudgetCheckers(budgets map[string]int) []string {
+	names := make([]string, 0, len(budgets))
+	for name := range budgets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,93 @@
+package lint
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExitPolicy(t *testing.T) {
+	// unslice is a stable checker, alwaysTrueCond and
+	// emptySelect are experimental.
+	reports := []Report{
+		{Checker: "unslice", Severity: SeverityWarning},
+		{Checker: "unslice", Severity: SeverityInfo},
+		{Checker: "alwaysTrueCond", Severity: SeverityWarning},
+		{Checker: "alwaysTrueCond", Severity: SeverityError},
+		{Checker: "emptySelect", Severity: SeverityInfo},
+		{Checker: "emptySelect", Severity: SeverityInfo},
+	}
+	violation := func(checker string, warnings int) PolicyViolation {
+		return PolicyViolation{Checker: checker, Warnings: warnings, Budget: -1}
+	}
+
+	tests := []struct {
+		name   string
+		policy ExitPolicy
+		want   []PolicyViolation
+	}{
+		{
+			name:   "default",
+			policy: ExitPolicy{},
+			want:   []PolicyViolation{violation("alwaysTrueCond", 2), violation("unslice", 1)},
+		},
+		{
+			name:   "severity",
+			policy: ExitPolicy{FailSeverity: SeverityError},
+			want:   []PolicyViolation{violation("alwaysTrueCond", 1)},
+		},
+		{
+			name:   "ignoreExperimental",
+			policy: ExitPolicy{IgnoreExperimental: true},
+			want:   []PolicyViolation{violation("unslice", 1)},
+		},
+		{
+			name:   "failOn",
+			policy: ExitPolicy{IgnoreExperimental: true, FailOn: []string{"emptySelect"}},
+			want:   []PolicyViolation{violation("emptySelect", 2), violation("unslice", 1)},
+		},
+		{
+			name:   "budgets",
+			policy: ExitPolicy{Budgets: map[string]int{"unslice": 2, "alwaysTrueCond": 1, "emptySelect": 0}},
+			want: []PolicyViolation{
+				{Checker: "alwaysTrueCond", Warnings: 2, Budget: 1},
+				{Checker: "emptySelect", Warnings: 2, Budget: 0},
+			},
+		},
+		{
+			name:   "pass",
+			policy: ExitPolicy{FailSeverity: SeverityError, Budgets: map[string]int{"alwaysTrueCond": 5}},
+			want:   nil,
+		},
+	}
+	for _, test := range tests {
+		if err := test.policy.Validate(); err != nil {
+			t.Errorf("%s: validate: %v", test.name, err)
+			continue
+		}
+		have := test.policy.Evaluate(reports)
+		if !reflect.DeepEqual(have, test.want) {
+			t.Errorf("%s:\nhave: %v\nwant: %v", test.name, have, test.want)
+		}
+	}
+}
+
+func TestExitPolicyValidate(t *testing.T) {
+	tests := []struct {
+		policy ExitPolicy
+		err    string
+	}{
+		{ExitPolicy{FailOn: []string{"noSuchChecker"}}, "noSuchChecker: unknown checker"},
+		{ExitPolicy{Budgets: map[string]int{"noSuchChecker": 1}}, "noSuchChecker: unknown checker"},
+		{ExitPolicy{Budgets: map[string]int{"unslice": -1}}, "unslice: negative budget -1"},
+		{
+			ExitPolicy{FailOn: []string{"unslice"}, Budgets: map[string]int{"unslice": 1}},
+			"unslice: checker can't have a budget and fail on every warning",
+		},
+	}
+	for _, test := range tests {
+		err := test.policy.Validate()
+		if err == nil || err.Error() != test.err {
+			t.Errorf("%+v: have error %v, want %q", test.policy, err, test.err)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ExplainLevel describes how much of the checker documentation
+// is attached to every reported warning.
+type ExplainLevel int
+
+// Explain levels.
+const (
+	// ExplainNone attaches nothing, only the warning text is reported.
+	// This is a default level.
+	ExplainNone ExplainLevel = iota
+
+	// ExplainLink attaches a link to the checker documentation.
+	ExplainLink
+
+	// ExplainFull attaches the checker rationale and
+	// its before/after example, followed by the documentation link.
+	ExplainFull
+)
+
+// String returns explain level name.
+func (lvl ExplainLevel) String() string {
+	switch lvl {
+	case ExplainNone:
+		return "none"
+	case ExplainLink:
+		return "link"
+	case ExplainFull:
+		return "full"
+	default:
+		return fmt.Sprintf("ExplainLevel(%d)", int(lvl))
+	}
+}
+
+// ParseExplainLevel returns explain level by its name.
+// Valid names are "none", "link" and "full".
+func ParseExplainLevel(s string) (ExplainLevel, error) {
+	for _, lvl := range []ExplainLevel{ExplainNone, ExplainLink, ExplainFull} {
+		if lvl.String() == s {
+			return lvl, nil
+		}
+	}
+	return 0, fmt.Errorf("%s: unknown explain level", s)
+}
+
+// CheckerDocURL returns a link to the documentation of the checker
+// with the specified name.
+func CheckerDocURL(checker string) string {
+	return "https://github.com/go-critic/go-critic/blob/master/docs/overview.md#" + checker + "-ref"
+}
+
+// Explain returns info documentation rendered as a plain text
+// explanation of the checker warnings.
+// Returns an empty string for ExplainNone level.
+//
+// Full explanation consists of the summary, details, indented
+// before/after examples, note and the documentation link.
+// Sections that are absent in the documentation are omitted.
+func Explain(info CheckerInfo, lvl ExplainLevel) string {
+	switch lvl {
+	case ExplainLink:
+		return "see " + CheckerDocURL(info.Name)
+	case ExplainFull:
+		// Handled below.
+	default:
+		return ""
+	}
+
+	var parts []string
+	for _, s := range []string{info.Summary, info.Details} {
+		if s != "" {
+			parts = append(parts, s)
+		}
+	}
+	if info.Before != "" && info.After != "" {
+		parts = append(parts,
+			"Before:\n"+indentLines(info.Before),
+			"After:\n"+indentLines(info.After))
+	}
+	if info.Note != "" {
+		parts = append(parts, info.Note)
+	}
+	parts = append(parts, "See "+CheckerDocURL(info.Name))
+	return strings.Join(parts, "\n\n")
+}
+
+// ExplainChecker is like Explain, but finds the checker info by its name.
+// Returns an empty string if there is no such checker.
+func ExplainChecker(checker string, lvl ExplainLevel) string {
+	if lvl == ExplainNone {
+		return ""
+	}
+	info, ok := checkerInfoByName(checker)
+	if !ok {
+		return ""
+	}
+	return Explain(info, lvl)
+}
+
+var checkersInfoIndex struct {
+	sync.Mutex
+	byName map[string]CheckerInfo
+
+	// rules is a number of rules the index was built for.
+	// Index is rebuilt when rules are added, by plugins or rule files.
+	rules int
+}
+
+// checkerInfoByName returns CheckerInfo of the checker with the specified name.
+func checkerInfoByName(name string) (CheckerInfo, bool) {
+	index := &checkersInfoIndex
+	index.Lock()
+	defer index.Unlock()
+	if n := len(checkerPrototypes); index.byName == nil || index.rules != n {
+		index.byName = make(map[string]CheckerInfo, n)
+		for _, info := range CheckersInfo() {
+			index.byName[info.Name] = info
+		}
+		index.rules = n
+	}
+	info, ok := index.byName[name]
+	return info, ok
+}
+
+// indentLines prefixes every non-empty line of s with a tab.
+func indentLines(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		if l != "" {
+			lines[i] = "\t" + l
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,76 @@
+package lint
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseExplainLevel(t *testing.T) {
+	for _, lvl := range []ExplainLevel{ExplainNone, ExplainLink, ExplainFull} {
+		have, err := ParseExplainLevel(lvl.String())
+		if err != nil {
+			t.Errorf("parse %s: unexpected error: %v", lvl, err)
+			continue
+		}
+		if have != lvl {
+			t.Errorf("parse %s: have %s", lvl, have)
+		}
+	}
+	if _, err := ParseExplainLevel("verbose"); err == nil {
+		t.Errorf("expected error for unknown explain level")
+	}
+}
+
+func TestExplain(t *testing.T) {
+	info := CheckerInfo{
+		Name: "example",
+		CheckerDoc: CheckerDoc{
+			Summary: "Detects examples.",
+			Details: "Examples are bad.",
+			Before:  "if x {\n\tbad()\n}",
+			After:   "good()",
+			Note:    "It's a note.",
+		},
+	}
+	url := CheckerDocURL("example")
+
+	tests := []struct {
+		lvl  ExplainLevel
+		info CheckerInfo
+		want string
+	}{
+		{ExplainNone, info, ""},
+		{ExplainLink, info, "see " + url},
+		{ExplainFull, info, strings.Join([]string{
+			"Detects examples.",
+			"Examples are bad.",
+			"Before:\n\tif x {\n\t\tbad()\n\t}",
+			"After:\n\tgood()",
+			"It's a note.",
+			"See " + url,
+		}, "\n\n")},
+		{ExplainFull, CheckerInfo{Name: "example", CheckerDoc: CheckerDoc{Summary: "Detects examples."}},
+			"Detects examples.\n\nSee " + url},
+	}
+	for _, test := range tests {
+		if have := Explain(test.info, test.lvl); have != test.want {
+			t.Errorf("explain %s: have:\n%s\nwant:\n%s", test.lvl, have, test.want)
+		}
+	}
+}
+
+func TestExplainChecker(t *testing.T) {
+	have := ExplainChecker("flagDeref", ExplainFull)
+	for _, want := range []string{
+		"Detects immediate dereferencing of `flag` package pointers.",
+		"Before:\n\tb := *flag.Bool(",
+		"See " + CheckerDocURL("flagDeref"),
+	} {
+		if !strings.Contains(have, want) {
+			t.Errorf("flagDeref explanation doesn't contain %q:\n%s", want, have)
+		}
+	}
+	if have := ExplainChecker("noSuchChecker", ExplainFull); have != "" {
+		t.Errorf("unknown checker: have %q, want empty explanation", have)
+	}
+}
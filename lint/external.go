@@ -0,0 +1,194 @@
+package lint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/go-critic/go-critic/lint/internal/astwalk"
+)
+
+// Visitor interfaces that checkers registered by AddChecker implement.
+// Checker is called for every node it's interested in.
+type (
+	// FuncDeclVisitor visits every top-level function declaration.
+	FuncDeclVisitor = astwalk.FuncDeclVisitor
+
+	// ExprVisitor visits every expression inside AST file.
+	ExprVisitor = astwalk.ExprVisitor
+
+	// LocalExprVisitor visits every expression inside function body.
+	LocalExprVisitor = astwalk.LocalExprVisitor
+
+	// StmtListVisitor visits every statement list inside function body.
+	StmtListVisitor = astwalk.StmtListVisitor
+
+	// StmtVisitor visits every statement inside function body.
+	StmtVisitor = astwalk.StmtVisitor
+
+	// TypeExprVisitor visits every type describing expression.
+	TypeExprVisitor = astwalk.TypeExprVisitor
+
+	// LocalCommentVisitor visits every comment inside function body.
+	LocalCommentVisitor = astwalk.LocalCommentVisitor
+
+	// CommentVisitor visits every comment group inside AST file.
+	CommentVisitor = astwalk.CommentVisitor
+
+	// DocCommentVisitor visits every doc comment group inside AST file.
+	DocCommentVisitor = astwalk.DocCommentVisitor
+
+	// DeclVisitor visits every top-level declaration inside AST file.
+	DeclVisitor = astwalk.DeclVisitor
+)
+
+// AddChecker registers a checker that is implemented outside of
+// the lint package, so it can be selected and run like any built-in one.
+// It's intended to be called from init functions, for example from
+// the init of a Go plugin that is loaded by the linter.
+//
+// info describes the checker: name, attributes, docs and params,
+// including their docs. Tags are inferred from attributes.
+//
+// newChecker is called by NewChecker and should return a value that
+// implements one of the visitor interfaces, like ExprVisitor.
+// Embed CheckerBase to get the default EnterFunc and EnterChilds.
+// ctx is the checker-local context that is used to report warnings.
+//
+// AddChecker is not safe for concurrent use.
+func AddChecker(info *CheckerInfo, newChecker func(ctx *CheckerContext) interface{}) error {
+	if !token.IsIdentifier(info.Name) {
+		return fmt.Errorf("invalid checker name %q", info.Name)
+	}
+	if _, ok := checkerPrototypes[info.Name]; ok {
+		return fmt.Errorf("%s: checker already exists", info.Name)
+	}
+	rule := &Rule{
+		AttributeSet: info.AttributeSet,
+		Params:       append([]CheckerParam(nil), info.Params...),
+		GoFeatures:   append([]GoFeature(nil), info.GoFeatures...),
+		name:         info.Name,
+	}
+	if err := rule.validateParams(); err != nil {
+		return err
+	}
+	if err := validateGoFeatures(rule.GoFeatures); err != nil {
+		return fmt.Errorf("%s: %v", info.Name, err)
+	}
+	doc := info.CheckerDoc
+
+	proto := checkerProto{rule: rule, doc: &doc}
+	proto.clone = func(ctx context) *Checker {
+		ctx.checkerName = rule.name
+		clone := &Checker{
+			Rule: rule,
+			ctx:  ctx,
+		}
+		v := newChecker(&CheckerContext{ctx: &clone.ctx})
+		clone.walker = newFileWalker(&clone.ctx, v)
+		return clone
+	}
+	checkerPrototypes[rule.name] = proto
+	return nil
+}
+
+// CheckerBase can be embedded into checkers registered by AddChecker.
+// It makes checker skip functions without body and traverse all nodes.
+type CheckerBase struct{}
+
+// EnterFunc makes checker not enter external functions.
+func (CheckerBase) EnterFunc(fn *ast.FuncDecl) bool { return fn.Body != nil }
+
+// EnterChilds makes checker unconditionally traverse every node siblings.
+func (CheckerBase) EnterChilds(x ast.Node) bool { return true }
+
+// CheckerContext is a checker-local context of the checker
+// registered by AddChecker.
+//
+// It gives access to the checked package info and
+// collects warnings that are reported by the checker.
+type CheckerContext struct {
+	ctx *context
+}
+
+// FileSet returns a file set of the checked package.
+func (c *CheckerContext) FileSet() *token.FileSet { return c.ctx.fileSet }
+
+// Pkg returns the checked package.
+func (c *CheckerContext) Pkg() *types.Package { return c.ctx.pkg }
+
+// TypesInfo returns types information of the checked package.
+func (c *CheckerContext) TypesInfo() *types.Info { return c.ctx.typesInfo }
+
+// SizesInfo returns alignment and type size information.
+func (c *CheckerContext) SizesInfo() types.Sizes { return c.ctx.sizesInfo }
+
+// File returns the file that is being checked.
+func (c *CheckerContext) File() *ast.File { return c.ctx.file }
+
+// TokenFile returns token file of the file that is being checked.
+func (c *CheckerContext) TokenFile() *token.File { return c.ctx.TokenFile() }
+
+// CommentMap returns comment map of the file that is being checked.
+// Map is built once per file.
+func (c *CheckerContext) CommentMap() ast.CommentMap { return c.ctx.CommentMap() }
+
+// Directives returns directive comments of the file that is being checked.
+func (c *CheckerContext) Directives() []Directive { return c.ctx.Directives() }
+
+// GoVersionAtLeast reports whether checked code targets
+// Go version that is not older than major.minor.
+func (c *CheckerContext) GoVersionAtLeast(major, minor int) bool {
+	return c.ctx.GoVersionAtLeast(major, minor)
+}
+
+// GoFeatureAvailable reports whether checked code can use f.
+// Panics if f is unknown.
+func (c *CheckerContext) GoFeatureAvailable(f GoFeature) bool {
+	return c.ctx.GoFeatureAvailable(f)
+}
+
+// Param returns checker parameter value or its declared default.
+// Panics if checker does not declare the parameter.
+func (c *CheckerContext) Param(name string) string { return c.ctx.Param(name) }
+
+// BoolParam is like Param, but for ParamBool parameters.
+func (c *CheckerContext) BoolParam(name string) bool { return c.ctx.BoolParam(name) }
+
+// IntParam is like Param, but for ParamInt parameters.
+func (c *CheckerContext) IntParam(name string) int { return c.ctx.IntParam(name) }
+
+// Warn adds a Warning with high confidence to checker output.
+// Format verbs print AST nodes as Go source.
+func (c *CheckerContext) Warn(node ast.Node, format string, args ...interface{}) {
+	c.ctx.Warn(node, format, args...)
+}
+
+// WarnWithConfidence adds a Warning with specified confidence to checker output.
+func (c *CheckerContext) WarnWithConfidence(conf Confidence, node ast.Node, format string, args ...interface{}) {
+	c.ctx.WarnWithConfidence(conf, node, format, args...)
+}
+
+// WarnWithSeverity adds a Warning with specified severity to checker output.
+func (c *CheckerContext) WarnWithSeverity(sev Severity, node ast.Node, format string, args ...interface{}) {
+	c.ctx.WarnWithSeverity(sev, node, format, args...)
+}
+
+// WarnWithLevels adds a Warning with specified severity and confidence
+// to checker output.
+func (c *CheckerContext) WarnWithLevels(sev Severity, conf Confidence, node ast.Node, format string, args ...interface{}) {
+	c.ctx.WarnWithLevels(sev, conf, node, format, args...)
+}
+
+// WarnWithFix adds a Warning with high confidence and a fix to checker output.
+func (c *CheckerContext) WarnWithFix(fix []TextEdit, node ast.Node, format string, args ...interface{}) {
+	c.ctx.WarnWithFix(fix, node, format, args...)
+}
+
+// WarnWith adds w with the formatted Text to checker output.
+// Zero Severity and Confidence mean SeverityWarning and ConfidenceHigh.
+// It can be used to report warnings with Code.
+func (c *CheckerContext) WarnWith(w Warning, format string, args ...interface{}) {
+	c.ctx.WarnWith(w, format, args...)
+}
@@ -0,0 +1,118 @@
+package lint
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+)
+
+// testExternalChecker reports integer literals that are greater than limit param.
+type testExternalChecker struct {
+	CheckerBase
+
+	ctx *CheckerContext
+}
+
+func (c *testExternalChecker) VisitExpr(x ast.Expr) {
+	lit, ok := x.(*ast.BasicLit)
+	if !ok || lit.Kind != token.INT {
+		return
+	}
+	tv := c.ctx.TypesInfo().Types[lit]
+	if tv.Value == nil {
+		return
+	}
+	if v, ok := constant.Int64Val(tv.Value); ok && v > int64(c.ctx.IntParam("limit")) {
+		c.ctx.WarnWithSeverity(SeverityInfo, lit, "%s is too big", lit)
+	}
+}
+
+func TestAddChecker(t *testing.T) {
+	info := &CheckerInfo{
+		Name:         "testExternal",
+		AttributeSet: AttributeSet{Experimental: true},
+		CheckerDoc: CheckerDoc{
+			Summary: "Detects big integer literals.",
+			Before:  "x := 9",
+			After:   "x := 1",
+		},
+		Params: []CheckerParam{
+			{Name: "limit", Kind: ParamInt, Default: "5", Doc: "max allowed literal"},
+		},
+	}
+	err := AddChecker(info, func(ctx *CheckerContext) interface{} {
+		return &testExternalChecker{ctx: ctx}
+	})
+	if err != nil {
+		t.Fatalf("add checker: %v", err)
+	}
+	defer delete(checkerPrototypes, info.Name)
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "x.go", "package p; var _ = []int{1, 6, 9}", 0)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	typesInfo := &types.Info{Types: make(map[ast.Expr]types.TypeAndValue)}
+	pkg, err := new(types.Config).Check("p", fset, []*ast.File{f}, typesInfo)
+	if err != nil {
+		t.Fatalf("typecheck: %v", err)
+	}
+
+	ctx := NewContext(fset, sizes)
+	ctx.SetPackageInfo(typesInfo, pkg)
+	if err := ctx.SetCheckerParam("testExternal", "limit", "7"); err != nil {
+		t.Fatalf("set param: %v", err)
+	}
+	var rule *Rule
+	for _, r := range RuleList() {
+		if r.Name() == info.Name {
+			rule = r
+		}
+	}
+	if rule == nil || !rule.HasTag(TagExperimental) {
+		t.Fatalf("registered rule not found or has no experimental tag: %v", rule)
+	}
+	warnings := NewChecker(rule, ctx).Check(f)
+	if len(warnings) != 1 || warnings[0].Text != "9 is too big" || warnings[0].Severity != SeverityInfo {
+		t.Errorf("unexpected warnings: %+v", warnings)
+	}
+
+	for _, x := range CheckersInfo() {
+		if x.Name != info.Name {
+			continue
+		}
+		if x.Summary != info.Summary || len(x.Params) != 1 || x.Params[0].Doc != "max allowed literal" {
+			t.Errorf("unexpected checker info: %+v", x)
+		}
+	}
+
+	tests := []struct {
+		info CheckerInfo
+		err  string
+	}{
+		{CheckerInfo{Name: "testExternal"}, "testExternal: checker already exists"},
+		{CheckerInfo{Name: "unslice"}, "unslice: checker already exists"},
+		{CheckerInfo{Name: ""}, `invalid checker name ""`},
+		{
+			CheckerInfo{
+				Name:   "testExternal2",
+				Params: []CheckerParam{{Name: "limit", Kind: ParamInt, Default: "x"}},
+			},
+			`testExternal2.limit: invalid default: "x" is not an int`,
+		},
+		{
+			CheckerInfo{Name: "testExternal3", GoFeatures: []GoFeature{"goroutines"}},
+			`testExternal3: unknown Go feature "goroutines"`,
+		},
+	}
+	for _, test := range tests {
+		err := AddChecker(&test.info, nil)
+		if err == nil || err.Error() != test.err {
+			t.Errorf("%s: have error %v, want %s", test.info.Name, err, test.err)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package lint
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// ApplyFixes returns src with fixes of warnings applied.
+// src is a contents of the file all warnings belong to.
+//
+// Warning fix is applied as a whole: if any of its edits overlaps
+// with edits of the previously applied fixes, it's skipped.
+// Identical edits of different fixes, like added imports, are applied once.
+// Imports that are no longer used after all fixes are applied are removed.
+// Result is formatted with go/format.
+//
+// Returns the number of applied fixes.
+func ApplyFixes(fset *token.FileSet, src []byte, warnings []Warning) ([]byte, int, error) {
+	var edits []TextEdit
+	applied := 0
+	for _, w := range warnings {
+		if len(w.Fix) == 0 {
+			continue
+		}
+		if canApplyFix(edits, w.Fix) {
+			edits = appendEdits(edits, w.Fix)
+			applied++
+		}
+	}
+	if applied == 0 {
+		return src, 0, nil
+	}
+
+	tf := fset.File(edits[0].Pos)
+	if tf == nil || tf.Size() != len(src) {
+		return nil, 0, fmt.Errorf("fix positions don't match the source")
+	}
+	// Insertions go before replacements that start at the same position.
+	sort.SliceStable(edits, func(i, j int) bool {
+		if edits[i].Pos != edits[j].Pos {
+			return edits[i].Pos < edits[j].Pos
+		}
+		return edits[i].End < edits[j].End
+	})
+	var buf []byte
+	offset := 0
+	for _, edit := range edits {
+		if fset.File(edit.Pos) != tf {
+			return nil, 0, fmt.Errorf("fixes belong to different files")
+		}
+		pos, end := tf.Offset(edit.Pos), tf.Offset(edit.End)
+		buf = append(buf, src[offset:pos]...)
+		buf = append(buf, edit.NewText...)
+		offset = end
+	}
+	buf = append(buf, src[offset:]...)
+
+	buf, err := removeUnusedImports(src, buf)
+	if err != nil {
+		return nil, 0, err
+	}
+	fixed, err := format.Source(buf)
+	if err != nil {
+		return nil, 0, fmt.Errorf("format fixed source: %v", err)
+	}
+	return fixed, applied, nil
+}
+
+// canApplyFix reports whether fix edits don't overlap with edits.
+// Identical edits are not considered overlapping.
+func canApplyFix(edits, fix []TextEdit) bool {
+	for _, x := range fix {
+		for _, y := range edits {
+			// Insertions are conflicting only if they
+			// are made strictly inside the replaced range.
+			if x != y && x.Pos < y.End && y.Pos < x.End {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// appendEdits appends fix to edits, skipping edits that are already present.
+func appendEdits(edits, fix []TextEdit) []TextEdit {
+	for _, x := range fix {
+		dup := false
+		for _, y := range edits {
+			if x == y {
+				dup = true
+				break
+			}
+		}
+		if !dup {
+			edits = append(edits, x)
+		}
+	}
+	return edits
+}
+
+// removeUnusedImports removes imports of fixed that were used in src,
+// but are not used in fixed.
+//
+// Usages are matched syntactically by the package name, so imports
+// which package name differs from their path are never removed.
+func removeUnusedImports(src, fixed []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	orig, err := parser.ParseFile(fset, "", src, 0)
+	if err != nil {
+		return nil, fmt.Errorf("parse source: %v", err)
+	}
+	f, err := parser.ParseFile(fset, "", fixed, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("parse fixed source: %v", err)
+	}
+
+	used, stillUsed := qualifiers(orig), qualifiers(f)
+	var unused []*ast.ImportSpec
+	for _, spec := range f.Imports {
+		if name := importName(spec); used[name] && !stillUsed[name] {
+			unused = append(unused, spec)
+		}
+	}
+	removed := false
+	for _, spec := range unused {
+		pkgPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		specName := ""
+		if spec.Name != nil {
+			specName = spec.Name.Name
+		}
+		if astutil.DeleteNamedImport(fset, f, specName, pkgPath) {
+			removed = true
+		}
+	}
+	if !removed {
+		return fixed, nil
+	}
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, f); err != nil {
+		return nil, fmt.Errorf("format fixed source: %v", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// qualifiers returns names that are used as selector
+// expression operands in f, like "fmt" in fmt.Println.
+func qualifiers(f *ast.File) map[string]bool {
+	names := make(map[string]bool)
+	ast.Inspect(f, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if id, ok := sel.X.(*ast.Ident); ok {
+				names[id.Name] = true
+			}
+		}
+		return true
+	})
+	return names
+}
+
+var majorVersionRE = regexp.MustCompile(`^v[0-9]+$`)
+
+// importName returns a name the package imported by spec is referenced by.
+// If spec has no explicit name, the name is guessed from the import path.
+// Returns empty string for blank and dot imports.
+func importName(spec *ast.ImportSpec) string {
+	if spec.Name != nil {
+		if spec.Name.Name == "_" || spec.Name.Name == "." {
+			return ""
+		}
+		return spec.Name.Name
+	}
+	pkgPath, err := strconv.Unquote(spec.Path.Value)
+	if err != nil {
+		return ""
+	}
+	name := path.Base(pkgPath)
+	if majorVersionRE.MatchString(name) && path.Dir(pkgPath) != "." {
+		name = path.Base(path.Dir(pkgPath))
+	}
+	return name
+}
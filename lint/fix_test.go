@@ -0,0 +1,466 @@
+package lint
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestWarningFix(t *testing.T) {
+	rule := findRule("errorsJoinSingle")
+	if rule == nil {
+		t.Fatal("errorsJoinSingle rule not found")
+	}
+	pkgPath := testdataPkgPath + rule.Name()
+	prog := newProg(t, pkgPath)
+	pkgInfo := prog.Imported[pkgPath]
+	ctx := NewContext(prog.Fset, sizes)
+	ctx.SetPackageInfo(&pkgInfo.Info, pkgInfo.Pkg)
+
+	var fixes []string
+	c := NewChecker(rule, ctx)
+	for _, f := range pkgInfo.Files {
+		for _, w := range c.Check(f) {
+			if w.Fix == nil {
+				continue
+			}
+			// The first edit replaces the call,
+			// the other ones remove unused imports.
+			edit := w.Fix[0]
+			if edit.Pos != w.Node.Pos() || edit.End != w.Node.End() {
+				t.Errorf("%s: edit should replace the whole warning node",
+					prog.Fset.Position(w.Node.Pos()))
+			}
+			fixes = append(fixes, edit.NewText)
+		}
+	}
+
+	want := []string{"(<-ch)", "err", "f()"}
+	if len(fixes) != len(want) {
+		t.Fatalf("have %q fixes, want %q", fixes, want)
+	}
+	for i := range want {
+		if fixes[i] != want[i] {
+			t.Errorf("fix %d: have %q, want %q", i, fixes[i], want[i])
+		}
+	}
+}
+
+func TestErrorsJoinSingleFix(t *testing.T) {
+	fixed := fixedSource(t, "errorsJoinSingle", "import_delete_tests.go")
+	for _, want := range []string{
+		"package checker_test\n\nfunc received",
+		"return (<-ch).Error()\n",
+	} {
+		if !strings.Contains(fixed, want) {
+			t.Errorf("fixed source does not contain %q:\n%s", want, fixed)
+		}
+	}
+}
+
+func TestBoolExprSimplifyFix(t *testing.T) {
+	fixed := fixedSource(t, "boolExprSimplify", "positive_tests.go")
+	for _, want := range []string{
+		"_ = (a || b) && c\n",
+		"_ = c && (a || b)\n",
+		"_ = a == b && !c && c\n",
+		"_ = a && (b)\n",
+		"// Only the return value is reported.\n",
+		"\t\treturn a != b\n",
+		"_ = !(a /* a */ == b)\n",
+	} {
+		if !strings.Contains(fixed, want) {
+			t.Errorf("fixed source does not contain %q:\n%s", want, fixed)
+		}
+	}
+}
+
+func TestImportFix(t *testing.T) {
+	fixed := fixedSource(t, "manualContains", "positive_tests.go")
+	for _, want := range []string{
+		"import (\n\t\"fmt\"\n\t\"slices\"\n)",
+		"found := slices.Contains(list, target)\n\treturn found",
+		"found := slices.Contains(list, 10)\n\tfmt.Println(found)",
+		"return slices.Contains(xs, p.x)\n}",
+		"for _, v := range list {",
+	} {
+		if !strings.Contains(fixed, want) {
+			t.Errorf("fixed source does not contain %q:\n%s", want, fixed)
+		}
+	}
+}
+
+func TestReverseIndexLoopFix(t *testing.T) {
+	fixed := fixedSource(t, "reverseIndexLoop", "positive_tests.go")
+	for _, want := range []string{
+		"import (\n\t\"fmt\"\n\t\"slices\"\n)",
+		"for _, v := range slices.Backward(xs) {\n\t\tfmt.Println(v)\n",
+		"for _, v := range slices.Backward(s.items) {\n\t\ttotal += v * v\n",
+		"if v.x > 0 {\n\t\t\treturn v, true\n",
+		"fmt.Println(v.String())",
+		"for i := len(xs) - 1; i >= 0; i-- {\n\t\tfmt.Println(xs[i] + v)",
+	} {
+		if !strings.Contains(fixed, want) {
+			t.Errorf("fixed source does not contain %q:\n%s", want, fixed)
+		}
+	}
+}
+
+func TestManualMinMaxFix(t *testing.T) {
+	fixed := fixedSource(t, "manualMinMax", "positive_tests.go")
+	for _, want := range []string{
+		"m = min(a, b)\n\n\t_ = m\n",
+		"longest = max(s1, s2)\n",
+		// Float operands may be NaN.
+		"if a > b {\n\t\txs[0] = a\n",
+		"if a < b {\n\t\tm = a\n",
+		"if f() < b {\n",
+		"// Keep the smallest.\n",
+	} {
+		if !strings.Contains(fixed, want) {
+			t.Errorf("fixed source does not contain %q:\n%s", want, fixed)
+		}
+	}
+	if n := strings.Count(fixed, "m = min(a, b)\n"); n != 3 {
+		t.Errorf("expected 3 min calls, got %d:\n%s", n, fixed)
+	}
+}
+
+func TestSwitchTrueFix(t *testing.T) {
+	fixed := fixedSource(t, "switchTrue", "positive_tests.go")
+	for _, want := range []string{
+		"switch {\n\t}\n",
+		"switch {\n\tcase true && false:",
+		"switch true := false; true {\n",
+		"switch _ = true; {\n",
+	} {
+		if !strings.Contains(fixed, want) {
+			t.Errorf("fixed source does not contain %q:\n%s", want, fixed)
+		}
+	}
+}
+
+func TestStringXbytesFix(t *testing.T) {
+	fixed := fixedSource(t, "stringXbytes", "positive_tests.go")
+	for _, want := range []string{
+		"_ = s\n",
+		"_ = (s + \"x\")\n",
+		"_ = (*ps)[0]\n",
+		"_ = []byte(string(b))\n",
+		"copy(dst, s)\n",
+		"_ = copy(dst[1:], \"abc\")\n",
+		"fmt.Fprintf(w, \"%d items\", n)\n",
+		"fmt.Fprint(buf, n)\n",
+		"_, _ = fmt.Fprintln(os.Stdout, args...)\n",
+	} {
+		if !strings.Contains(fixed, want) {
+			t.Errorf("fixed source does not contain %q:\n%s", want, fixed)
+		}
+	}
+}
+
+func TestSprintfIntFix(t *testing.T) {
+	tests := []struct {
+		filename string
+		want     []string
+	}{
+		{
+			filename: "positive_tests.go",
+			want: []string{
+				"import (\n\t\"fmt\"\n\t\"strconv\"\n)",
+				"return strconv.Itoa(n)\n",
+				"s := strconv.Itoa(c.n + 1)\n",
+				"return strconv.Itoa(len(s))\n",
+				"return strconv.Itoa(10)\n",
+			},
+		},
+		{
+			filename: "import_swap_tests.go",
+			want: []string{
+				"import \"strconv\"\n",
+				"return strconv.Itoa(len(xs))\n",
+			},
+		},
+		{
+			filename: "import_multi_tests.go",
+			want: []string{
+				"import \"strconv\"\n",
+				"a := strconv.Itoa(x)\n",
+				"b := strconv.Itoa(y)\n",
+			},
+		},
+		{
+			filename: "import_delete_tests.go",
+			want: []string{
+				"import (\n\t\"strconv\"\n)",
+				"return strconv.Itoa(n) + strconv.FormatBool(b)\n",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		fixed := fixedSource(t, "sprintfInt", test.filename)
+		for _, want := range test.want {
+			if !strings.Contains(fixed, want) {
+				t.Errorf("%s: fixed source does not contain %q:\n%s", test.filename, want, fixed)
+			}
+		}
+	}
+}
+
+// fixedSource returns the contents of rule testdata file
+// with all suggested fixes applied.
+// Fixed source is type checked together with the other package files.
+func fixedSource(t *testing.T, ruleName, filename string) string {
+	rule := findRule(ruleName)
+	if rule == nil {
+		t.Fatalf("%s rule not found", ruleName)
+	}
+	pkgPath := testdataPkgPath + rule.Name()
+	prog := newProg(t, pkgPath)
+	pkgInfo := prog.Imported[pkgPath]
+	ctx := NewContext(prog.Fset, sizes)
+	ctx.SetPackageInfo(&pkgInfo.Info, pkgInfo.Pkg)
+
+	var f *ast.File
+	for _, file := range pkgInfo.Files {
+		if getFilename(prog, file) == filename {
+			f = file
+		}
+	}
+	if f == nil {
+		t.Fatalf("%s not found", filename)
+	}
+	src, err := ioutil.ReadFile(prog.Fset.Position(f.Pos()).Filename)
+	if err != nil {
+		t.Fatalf("read source: %v", err)
+	}
+
+	fixed, _, err := ApplyFixes(prog.Fset, src, NewChecker(rule, ctx).Check(f))
+	if err != nil {
+		t.Fatalf("apply fixes: %v", err)
+	}
+
+	fset := token.NewFileSet()
+	files := []*ast.File{}
+	for _, file := range pkgInfo.Files {
+		name := prog.Fset.Position(file.Pos()).Filename
+		var src interface{}
+		if file == f {
+			src = fixed
+		}
+		file, err := parser.ParseFile(fset, name, src, 0)
+		if err != nil {
+			t.Fatalf("parse %s: %v", name, err)
+		}
+		files = append(files, file)
+	}
+	conf := types.Config{Importer: fixTestImporter, Sizes: sizes}
+	if _, err := conf.Check(pkgPath, fset, files, nil); err != nil {
+		t.Errorf("%s: fixed source doesn't type check: %v\n%s", filename, err, fixed)
+	}
+	return string(fixed)
+}
+
+// fixTestImporter is shared by fix tests, so imported
+// packages are loaded once.
+var fixTestImporter = importer.ForCompiler(token.NewFileSet(), "source", nil)
+
+func TestApplyFixes(t *testing.T) {
+	const src = "package p\n\nvar x = 1 + 2 + 3\n"
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	tf := fset.File(f.Pos())
+	// edit replaces src[from:to] with text.
+	edit := func(from, to int, text string) TextEdit {
+		return TextEdit{Pos: tf.Pos(from), End: tf.Pos(to), NewText: text}
+	}
+	imports := edit(9, 9, "\n\nimport \"fmt\"")
+	use := edit(len(src)-1, len(src)-1, "\nvar _ = fmt.Sprint")
+	one, two, three := strings.Index(src, "1"), strings.Index(src, "2"), strings.Index(src, "3")
+
+	tests := []struct {
+		fixes   [][]TextEdit
+		want    string
+		applied int
+	}{
+		{
+			fixes:   nil,
+			want:    src,
+			applied: 0,
+		},
+		{
+			fixes: [][]TextEdit{
+				{edit(one, one+1, "10")},
+				{edit(three, three+1, "30")},
+			},
+			want:    "package p\n\nvar x = 10 + 2 + 30\n",
+			applied: 2,
+		},
+		{
+			// Second fix overlaps with the first one and is skipped.
+			fixes: [][]TextEdit{
+				{edit(one, two+1, "12")},
+				{edit(two, three+1, "23")},
+				{edit(three, three+1, "30")},
+			},
+			want:    "package p\n\nvar x = 12 + 30\n",
+			applied: 2,
+		},
+		{
+			// Identical import edits are applied once.
+			fixes: [][]TextEdit{
+				{imports, use},
+				{imports, edit(one, one+1, "len(fmt.Sprint())")},
+			},
+			want:    "package p\n\nimport \"fmt\"\n\nvar x = len(fmt.Sprint()) + 2 + 3\nvar _ = fmt.Sprint\n",
+			applied: 2,
+		},
+		{
+			// Insertion is applied before the replacement at the same position.
+			fixes: [][]TextEdit{
+				{edit(one, three+1, "6")},
+				{edit(one, one, "-")},
+			},
+			want:    "package p\n\nvar x = -6\n",
+			applied: 2,
+		},
+	}
+
+	for _, test := range tests {
+		var warnings []Warning
+		for _, fix := range test.fixes {
+			warnings = append(warnings, Warning{Node: f, Fix: fix})
+		}
+		// Warnings without fixes are ignored.
+		warnings = append(warnings, Warning{Node: f})
+		fixed, applied, err := ApplyFixes(fset, []byte(src), warnings)
+		if err != nil {
+			t.Errorf("apply %v: %v", test.fixes, err)
+			continue
+		}
+		if string(fixed) != test.want || applied != test.applied {
+			t.Errorf("apply %v:\nhave (%d): %q\nwant (%d): %q",
+				test.fixes, applied, fixed, test.applied, test.want)
+		}
+	}
+}
+
+func TestApplyFixesUnusedImports(t *testing.T) {
+	const src = `package p
+
+import (
+	"fmt"
+	"math/rand/v2"
+	str "strconv"
+	"strings"
+	_ "unsafe"
+)
+
+var a = fmt.Sprint(1) + rand.N("x")
+var b = strings.ToUpper("x") + fmt.Sprint(2)
+var c = str.Itoa(3)
+`
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	tf := fset.File(f.Pos())
+	// replace returns a fix that replaces old text with text.
+	replace := func(old, text string) []TextEdit {
+		pos := strings.Index(src, old)
+		return []TextEdit{{Pos: tf.Pos(pos), End: tf.Pos(pos + len(old)), NewText: text}}
+	}
+
+	warnings := []Warning{
+		{Node: f, Fix: replace("fmt.Sprint(1)", `"1"`)},
+		{Node: f, Fix: replace(`rand.N("x")`, `"x"`)},
+		{Node: f, Fix: replace(`strings.ToUpper("x")`, `"X"`)},
+		{Node: f, Fix: replace("str.Itoa(3)", `"3"`)},
+	}
+	fixed, _, err := ApplyFixes(fset, []byte(src), warnings)
+	if err != nil {
+		t.Fatalf("apply fixes: %v", err)
+	}
+	// fmt is still used, unsafe was not used before the fixes.
+	const want = `package p
+
+import (
+	"fmt"
+	_ "unsafe"
+)
+
+var a = "1" + "x"
+var b = "X" + fmt.Sprint(2)
+var c = "3"
+`
+	if string(fixed) != want {
+		t.Errorf("have:\n%s\nwant:\n%s", fixed, want)
+	}
+}
+
+func TestPreferTimeHelpersFix(t *testing.T) {
+	tests := []struct {
+		filename string
+		want     []string
+	}{
+		{
+			filename: "positive_tests.go",
+			want: []string{
+				"_ = time.Since(start)\n",
+				"_ = time.Until(deadline)\n",
+				"_ = time.Since(timeouts[0])\n",
+				"if time.Now().Before(deadline) {\n",
+			},
+		},
+		{
+			filename: "renamed_import_tests.go",
+			want:     []string{"_ = stdtime.Since(start)\n"},
+		},
+	}
+
+	for _, test := range tests {
+		fixed := fixedSource(t, "preferTimeHelpers", test.filename)
+		for _, want := range test.want {
+			if !strings.Contains(fixed, want) {
+				t.Errorf("%s: fixed source does not contain %q:\n%s", test.filename, want, fixed)
+			}
+		}
+	}
+}
+
+func TestSprintfQuotedStringFix(t *testing.T) {
+	fixed := fixedSource(t, "sprintfQuotedString", "positive_tests.go")
+	for _, want := range []string{
+		`fmt.Printf("unknown name %q\n", s)`,
+		"_ = fmt.Sprintf(`%q is not %q`, s, s)",
+		`_ = fmt.Errorf("bad value %q at %d", s, 10)`,
+		`fmt.Fprintf(os.Stderr, "%q", s)`,
+		`_ = fmt.Sprintf(format, s)`,
+		"_ = s\n",
+		"s = string(n)\n",
+		`_ = (s + "x") + "y"`,
+	} {
+		if !strings.Contains(fixed, want) {
+			t.Errorf("fixed source does not contain %q:\n%s", want, fixed)
+		}
+	}
+}
+
+func TestSprintfQuotedStringImportFix(t *testing.T) {
+	fixed := fixedSource(t, "sprintfQuotedString", "import_delete_tests.go")
+	want := "package checker_test\n\nfunc onlyFmtUse(s string) string {\n" +
+		"\t/// s is already a string; fmt.Sprintf call is redundant\n\treturn s\n}\n"
+	if fixed != want {
+		t.Errorf("have:\n%s\nwant:\n%s", fixed, want)
+	}
+}
@@ -1,7 +1,9 @@
 package lint
 
 //! Detects immediate dereferencing of `flag` package pointers.
+//
 // Suggests using `XxxVar` functions to achieve desired effect.
+// Both flag package functions and flag.FlagSet methods are checked.
 //
 // @Before:
 // b := *flag.Bool("b", false, "b docs")
@@ -16,10 +18,13 @@ package lint
 
 import (
 	"go/ast"
+
+	"github.com/go-toolsmith/astfmt"
+	"golang.org/x/tools/go/ast/astutil"
 )
 
 func init() {
-	addChecker(&flagDerefChecker{}, attrSyntaxOnly)
+	addChecker(&flagDerefChecker{})
 }
 
 type flagDerefChecker struct {
@@ -29,27 +34,23 @@ type flagDerefChecker struct {
 }
 
 func (c *flagDerefChecker) Init() {
-	c.flagPtrFuncs = map[string]bool{
-		"flag.Bool":     true,
-		"flag.Duration": true,
-		"flag.Float64":  true,
-		"flag.Int":      true,
-		"flag.Int64":    true,
-		"flag.String":   true,
-		"flag.Uint":     true,
-		"flag.Uint64":   true,
+	c.flagPtrFuncs = make(map[string]bool)
+	for _, name := range []string{"Bool", "Duration", "Float64", "Int", "Int64", "String", "Uint", "Uint64"} {
+		c.flagPtrFuncs["flag."+name] = true
+		c.flagPtrFuncs["(*flag.FlagSet)."+name] = true
 	}
 }
 
 func (c *flagDerefChecker) VisitExpr(expr ast.Expr) {
 	if expr, ok := expr.(*ast.StarExpr); ok {
-		call, ok := expr.X.(*ast.CallExpr)
+		call, ok := astutil.Unparen(expr.X).(*ast.CallExpr)
 		if !ok {
 			return
 		}
-		called := qualifiedName(call.Fun)
-		if c.flagPtrFuncs[called] {
-			c.warn(expr, called+"Var")
+		if c.flagPtrFuncs[c.ctx.calleeName(call)] {
+			// Var function is called the same way, through
+			// the package name or the flag set expression.
+			c.warn(expr, astfmt.Sprint(call.Fun)+"Var")
 		}
 	}
 }
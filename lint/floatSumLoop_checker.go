@@ -0,0 +1,118 @@
+package lint
+
+//! Detects naive float64 summation inside range loops.
+//
+// Repeated float64 additions accumulate rounding error, which can
+// be significant for large inputs. Kahan (compensated) summation
+// or summing sorted values helps to reduce the error.
+//
+// @Before:
+// var sum float64
+// for _, x := range xs {
+// 	sum += x
+// }
+//
+// @After:
+// var sum, c float64
+// for _, x := range xs {
+// 	y := x - c
+// 	t := sum + y
+// 	c = (t - sum) - y
+// 	sum = t
+// }
+//
+// @Note:
+// > This is an advisory heuristic: the checker can't tell how big the
+// > input is or whether precision matters, so most reports are false
+// > positives outside of numerical code.
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/go-toolsmith/astequal"
+)
+
+func init() {
+	addChecker(&floatSumLoopChecker{}, attrExperimental, attrVeryOpinionated)
+}
+
+type floatSumLoopChecker struct {
+	checkerBase
+}
+
+func (c *floatSumLoopChecker) VisitStmt(stmt ast.Stmt) {
+	rng, ok := stmt.(*ast.RangeStmt)
+	if !ok || !c.isCollection(c.ctx.typesInfo.TypeOf(rng.X)) {
+		return
+	}
+	ast.Inspect(rng.Body, func(x ast.Node) bool {
+		switch x := x.(type) {
+		case *ast.FuncLit, *ast.RangeStmt:
+			// Function literals may be not called inside loop.
+			// Nested range loops are checked separately.
+			return false
+		case *ast.AssignStmt:
+			if sum := c.accumulator(x); sum != nil && sum.Pos() < rng.Pos() {
+				c.warn(x)
+			}
+			return false
+		}
+		return true
+	})
+}
+
+// isCollection reports whether range over typ may iterate
+// over unbounded amount of elements.
+func (c *floatSumLoopChecker) isCollection(typ types.Type) bool {
+	if typ == nil {
+		return false
+	}
+	switch typ.Underlying().(type) {
+	case *types.Slice, *types.Map, *types.Chan:
+		return true
+	default:
+		return false
+	}
+}
+
+// accumulator returns float64 variable that is incremented by assign.
+// Both "sum += x" and "sum = sum + x" forms are recognized.
+// Returns nil if assign is not a float64 accumulation.
+func (c *floatSumLoopChecker) accumulator(assign *ast.AssignStmt) types.Object {
+	if len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+		return nil
+	}
+	lhs, ok := assign.Lhs[0].(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	switch assign.Tok {
+	case token.ADD_ASSIGN:
+		// sum += x
+	case token.ASSIGN:
+		// sum = sum + x
+		rhs, ok := assign.Rhs[0].(*ast.BinaryExpr)
+		if !ok || rhs.Op != token.ADD || !astequal.Expr(lhs, rhs.X) {
+			return nil
+		}
+	default:
+		return nil
+	}
+	obj, ok := c.ctx.typesInfo.ObjectOf(lhs).(*types.Var)
+	if !ok {
+		return nil
+	}
+	typ, ok := obj.Type().Underlying().(*types.Basic)
+	if !ok || typ.Kind() != types.Float64 {
+		return nil
+	}
+	return obj
+}
+
+func (c *floatSumLoopChecker) warn(cause ast.Node) {
+	// Precision loss is only noticeable for large inputs
+	// that can't be detected statically.
+	c.ctx.WarnWithLevels(SeverityInfo, ConfidenceLow, cause, "naive float64 summation in a loop can accumulate error; consider Kahan summation for large inputs")
+}
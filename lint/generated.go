@@ -0,0 +1,39 @@
+package lint
+
+import (
+	"go/ast"
+	"strings"
+)
+
+// IsGenerated reports whether f is a machine-generated file.
+//
+// Generated files are recognized by the standard
+// "// Code generated ... DO NOT EDIT." line comment that
+// appears before the package clause, see https://golang.org/s/generatedcode.
+// Issues in such files can't be fixed by editing them,
+// so linters usually don't check them.
+func IsGenerated(f *ast.File) bool {
+	for _, group := range f.Comments {
+		if group.Pos() > f.Package {
+			break
+		}
+		for _, c := range group.List {
+			if isGeneratedComment(c.Text) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isGeneratedComment reports whether comment text is
+// a "Code generated" header comment.
+func isGeneratedComment(text string) bool {
+	const (
+		prefix = "// Code generated "
+		suffix = " DO NOT EDIT."
+	)
+	return len(text) >= len(prefix)+len(suffix) &&
+		strings.HasPrefix(text, prefix) &&
+		strings.HasSuffix(text, suffix)
+}
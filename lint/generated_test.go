@@ -0,0 +1,35 @@
+package lint
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+func TestIsGenerated(t *testing.T) {
+	tests := []struct {
+		src  string
+		want bool
+	}{
+		{"// Code generated by stringer; DO NOT EDIT.\n\npackage p", true},
+		{"// Code generated by protoc-gen-go. DO NOT EDIT.\n// source: p.proto\n\npackage p", true},
+		{"// +build linux\n\n// Code generated by mockgen. DO NOT EDIT.\n\npackage p", true},
+		{"// Copyright 2018 Foo.\n\n// Code generated by hand. DO NOT EDIT.\npackage p", true},
+		{"package p", false},
+		{"// Package p does things.\npackage p", false},
+		{"/* Code generated by goyacc. DO NOT EDIT. */\npackage p", false},
+		{"// Code generated by goyacc. DO NOT EDIT\npackage p", false},
+		{"// Code generated DO NOT EDIT.\npackage p", false},
+		{"package p\n\n// Code generated by stringer; DO NOT EDIT.\nvar x int", false},
+	}
+
+	for _, test := range tests {
+		f, err := parser.ParseFile(token.NewFileSet(), "p.go", test.src, parser.ParseComments)
+		if err != nil {
+			t.Fatalf("parse %q: %v", test.src, err)
+		}
+		if have := IsGenerated(f); have != test.want {
+			t.Errorf("%q: have %v, want %v", test.src, have, test.want)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package lint
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// githubReporter writes reports as GitHub Actions workflow commands,
+// so warnings are shown as annotations of the pull request files.
+//
+// See https://docs.github.com/en/actions/using-workflows/workflow-commands-for-github-actions.
+type githubReporter struct{}
+
+func (githubReporter) Write(w io.Writer, reports []Report) error {
+	for _, r := range reports {
+		props := []string{
+			"file=" + githubEscapeProperty(relativePath(r.Pos.Filename)),
+			fmt.Sprintf("line=%d", r.Pos.Line),
+			fmt.Sprintf("col=%d", r.Pos.Column),
+		}
+		if r.End.Line != 0 {
+			props = append(props,
+				fmt.Sprintf("endLine=%d", r.End.Line),
+				fmt.Sprintf("endColumn=%d", r.End.Column))
+		}
+		props = append(props, "title="+githubEscapeProperty("gocritic: "+reportID(r)))
+		text := r.Text
+		if r.Explanation != "" {
+			text += "\n\n" + r.Explanation
+		}
+		_, err := fmt.Fprintf(w, "::%s %s::%s\n",
+			githubLevel(r.Severity), strings.Join(props, ","), githubEscapeData(text))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// githubLevel maps severity to the workflow command name.
+func githubLevel(sev Severity) string {
+	switch sev {
+	case SeverityInfo:
+		return "notice"
+	case SeverityError:
+		return "error"
+	default:
+		return "warning"
+	}
+}
+
+var (
+	githubDataEscaper     = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+	githubPropertyEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C")
+)
+
+// githubEscapeData escapes workflow command message.
+func githubEscapeData(s string) string {
+	return githubDataEscaper.Replace(s)
+}
+
+// githubEscapeProperty escapes workflow command property value.
+func githubEscapeProperty(s string) string {
+	return githubPropertyEscaper.Replace(s)
+}
+
+// relativePath returns filename relative to the working directory,
+// as CI tools expect paths relative to the repository root.
+// Paths outside of the working directory are returned as is.
+func relativePath(filename string) string {
+	if !filepath.IsAbs(filename) {
+		return filepath.ToSlash(filename)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return filename
+	}
+	rel, err := filepath.Rel(wd, filename)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return filename
+	}
+	return filepath.ToSlash(rel)
+}
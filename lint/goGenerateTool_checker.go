@@ -0,0 +1,202 @@
+package lint
+
+//! Detects go:generate directives that reference tools that can't be found.
+//
+// Tool is looked up in PATH, while "go run" packages are resolved
+// relative to the directory of the file being checked.
+//
+// Checker params:
+//	strategy - which tools to check: "all" (default), "path" or "goRun"
+//	skip     - comma-separated list of tool names that are never reported
+//
+// @Before:
+// //go:generate stringer-old -type=Kind
+//
+// @After:
+// //go:generate stringer -type=Kind
+//
+// @Note:
+// Results depend on the environment the linter is running in.
+
+import (
+	"go/ast"
+	"go/build"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+func init() {
+	addChecker(&goGenerateToolChecker{}, attrExperimental, attrEnvDependent)
+}
+
+type goGenerateToolChecker struct {
+	checkerBase
+
+	checkPath  bool
+	checkGoRun bool
+	skip       map[string]bool
+
+	// file is a file that was seen during the last VisitComment call.
+	// Used to reset aliases defined by "go:generate -command".
+	file    *token.File
+	aliases map[string]bool
+}
+
+func (c *goGenerateToolChecker) Params() []CheckerParam {
+	return []CheckerParam{
+		{Name: "strategy", Default: "all", Values: []string{"all", "path", "goRun"}},
+		{Name: "skip"},
+	}
+}
+
+func (c *goGenerateToolChecker) Init() {
+	switch c.ctx.Param("strategy") {
+	case "all":
+		c.checkPath = true
+		c.checkGoRun = true
+	case "path":
+		c.checkPath = true
+	case "goRun":
+		c.checkGoRun = true
+	}
+
+	c.skip = make(map[string]bool)
+	for _, name := range strings.Split(c.ctx.Param("skip"), ",") {
+		if name != "" {
+			c.skip[name] = true
+		}
+	}
+}
+
+func (c *goGenerateToolChecker) VisitComment(cg *ast.CommentGroup) {
+	if f := c.ctx.TokenFile(); f != c.file {
+		c.file = f
+		c.aliases = make(map[string]bool)
+	}
+
+	for _, comment := range cg.List {
+		d, ok := parseDirective(comment)
+		if !ok || d.Tool != "go" || d.Name != "generate" {
+			continue
+		}
+		pos := c.ctx.fileSet.Position(comment.Pos())
+		if pos.Column != 1 {
+			continue // Not a directive, go generate ignores it
+		}
+		args := strings.Fields(d.Args)
+		if len(args) >= 2 && args[0] == "-command" {
+			c.aliases[args[1]] = true
+			args = args[2:]
+		}
+		if len(args) == 0 {
+			continue
+		}
+		if tool, ok := c.missingTool(filepath.Dir(pos.Filename), args); ok {
+			c.warn(cg, tool)
+		}
+	}
+}
+
+// missingTool returns a tool referenced by the directive args
+// that can't be resolved from the dir directory.
+// If all tools are found, returns false.
+func (c *goGenerateToolChecker) missingTool(dir string, args []string) (string, bool) {
+	tool := strings.Trim(args[0], `"`)
+	switch {
+	case c.skip[tool] || c.aliases[tool]:
+		return "", false
+	case strings.Contains(tool, "$"):
+		// Depends on the go generate environment, like $GOFILE.
+		return "", false
+	case tool == "go":
+		if len(args) < 2 || args[1] != "run" || !c.checkGoRun {
+			return "", false
+		}
+		pkg := goRunPackage(args[2:])
+		if pkg == "" || c.skip[pkg] || strings.Contains(pkg, "$") || goRunPackageExists(dir, pkg) {
+			return "", false
+		}
+		return pkg, true
+	default:
+		if !c.checkPath || toolExists(dir, tool) {
+			return "", false
+		}
+		return tool, true
+	}
+}
+
+// goRunValueFlags are "go run" build flags that are followed by a value,
+// unless it's given as -flag=value.
+var goRunValueFlags = map[string]bool{
+	"asmflags":      true,
+	"buildmode":     true,
+	"compiler":      true,
+	"covermode":     true,
+	"coverpkg":      true,
+	"exec":          true,
+	"gccgoflags":    true,
+	"gcflags":       true,
+	"installsuffix": true,
+	"ldflags":       true,
+	"mod":           true,
+	"modfile":       true,
+	"overlay":       true,
+	"p":             true,
+	"pgo":           true,
+	"pkgdir":        true,
+	"tags":          true,
+	"toolexec":      true,
+}
+
+// goRunPackage returns a package (or file) argument of the "go run" command.
+// Returns empty string if there is no such argument.
+func goRunPackage(args []string) string {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") {
+			return strings.Trim(arg, `"`)
+		}
+		name := strings.TrimLeft(arg, "-")
+		if name == "C" {
+			// Package is resolved relative to the -C directory.
+			return ""
+		}
+		if goRunValueFlags[name] {
+			i++ // Skip the flag value
+		}
+	}
+	return ""
+}
+
+func goRunPackageExists(dir, pkg string) bool {
+	switch {
+	case strings.Contains(pkg, "@"):
+		// Versioned package is downloaded by the go command.
+		return true
+	case strings.HasSuffix(pkg, ".go"):
+		_, err := os.Stat(filepath.Join(dir, pkg))
+		return err == nil
+	default:
+		_, err := build.Import(pkg, dir, build.FindOnly)
+		return err == nil
+	}
+}
+
+func toolExists(dir, tool string) bool {
+	if strings.ContainsRune(tool, filepath.Separator) || strings.Contains(tool, "/") {
+		if !filepath.IsAbs(tool) {
+			tool = filepath.Join(dir, tool)
+		}
+		_, err := os.Stat(tool)
+		return err == nil
+	}
+	_, err := exec.LookPath(tool)
+	return err == nil
+}
+
+func (c *goGenerateToolChecker) warn(cause ast.Node, tool string) {
+	c.ctx.Warn(cause, "go:generate references tool %q which was not found", tool)
+}
@@ -0,0 +1,118 @@
+package lint
+
+import (
+	"fmt"
+)
+
+// GoFeature is a Go language or standard library feature
+// that is available since some Go version.
+//
+// Checkers declare features their suggestions rely on, so the
+// suggestions are not reported for the code that targets older
+// Go versions, see Context.SetGoVersion.
+type GoFeature string
+
+// Known Go features.
+const (
+	// GoFeatureTimeUntil is time.Until function.
+	GoFeatureTimeUntil GoFeature = "time.Until"
+
+	// GoFeatureErrorWrapping is fmt.Errorf %w verb along with
+	// errors.Is, errors.As and errors.Unwrap functions.
+	GoFeatureErrorWrapping GoFeature = "errorWrapping"
+
+	// GoFeatureNumberLiterals is 0o octal prefix, binary and
+	// hex float literals and _ digit separators.
+	GoFeatureNumberLiterals GoFeature = "numberLiterals"
+
+	// GoFeatureOSReadFile is os.ReadFile, os.WriteFile and os.ReadDir,
+	// replacing io/ioutil package functions.
+	GoFeatureOSReadFile GoFeature = "os.ReadFile"
+
+	// GoFeatureGenerics is type parameters and any alias.
+	GoFeatureGenerics GoFeature = "generics"
+
+	// GoFeatureErrorsJoin is errors.Join function.
+	GoFeatureErrorsJoin GoFeature = "errors.Join"
+
+	// GoFeatureMinMax is min and max builtins.
+	GoFeatureMinMax GoFeature = "minMax"
+
+	// GoFeatureSlices is slices and maps standard packages.
+	GoFeatureSlices GoFeature = "slices"
+
+	// GoFeatureRangeOverInt is range over integers.
+	GoFeatureRangeOverInt GoFeature = "rangeOverInt"
+
+	// GoFeatureRangeOverFunc is range over iterator functions,
+	// along with slices.All, slices.Backward and other iterators.
+	GoFeatureRangeOverFunc GoFeature = "rangeOverFunc"
+)
+
+// goFeatureVersions maps known features to the Go versions they appeared in.
+var goFeatureVersions = map[GoFeature]goVersion{
+	GoFeatureTimeUntil:      {major: 1, minor: 8},
+	GoFeatureErrorWrapping:  {major: 1, minor: 13},
+	GoFeatureNumberLiterals: {major: 1, minor: 13},
+	GoFeatureOSReadFile:     {major: 1, minor: 16},
+	GoFeatureGenerics:       {major: 1, minor: 18},
+	GoFeatureErrorsJoin:     {major: 1, minor: 20},
+	GoFeatureMinMax:         {major: 1, minor: 21},
+	GoFeatureSlices:         {major: 1, minor: 21},
+	GoFeatureRangeOverInt:   {major: 1, minor: 22},
+	GoFeatureRangeOverFunc:  {major: 1, minor: 23},
+}
+
+// Version returns Go version the feature appeared in, like "1.21".
+// Returns empty string for unknown features.
+func (f GoFeature) Version() string {
+	v, ok := goFeatureVersions[f]
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("%d.%d", v.major, v.minor)
+}
+
+// validateGoFeatures returns an error if some of features are unknown.
+func validateGoFeatures(features []GoFeature) error {
+	for _, f := range features {
+		if _, ok := goFeatureVersions[f]; !ok {
+			return fmt.Errorf("unknown Go feature %q", f)
+		}
+	}
+	return nil
+}
+
+// goFeaturesDeclarer is implemented by checkers that can't
+// suggest anything useful without the specified Go features.
+// Such checkers don't run for the code that targets older Go versions.
+//
+// Checkers that need a feature only for some of their suggestions
+// should set Warning.GoFeature instead.
+//
+// GoFeatures is called once for the registered checker prototype,
+// so it should not depend on the checker state.
+type goFeaturesDeclarer interface {
+	GoFeatures() []GoFeature
+}
+
+// GoFeatureAvailable reports whether f can be used by the checked code.
+//
+// Panics if f is unknown.
+func (ctx *context) GoFeatureAvailable(f GoFeature) bool {
+	v, ok := goFeatureVersions[f]
+	if !ok {
+		panic(fmt.Sprintf("unknown Go feature %q", f))
+	}
+	return ctx.goVersion.atLeast(v)
+}
+
+// goFeaturesAvailable reports whether all features can be used by the checked code.
+func (ctx *context) goFeaturesAvailable(features []GoFeature) bool {
+	for _, f := range features {
+		if !ctx.GoFeatureAvailable(f) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,166 @@
+package lint
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseGoVersion(t *testing.T) {
+	tests := []struct {
+		s    string
+		want goVersion
+		ok   bool
+	}{
+		{"1.21", goVersion{major: 1, minor: 21}, true},
+		{"go1.21", goVersion{major: 1, minor: 21}, true},
+		{"go1.9.7", goVersion{major: 1, minor: 9}, true},
+		{"", goVersion{}, false},
+		{"1", goVersion{}, false},
+		{"go1.x", goVersion{}, false},
+		{"1.2.3.4", goVersion{}, false},
+	}
+
+	for _, test := range tests {
+		have, err := parseGoVersion(test.s)
+		if test.ok != (err == nil) {
+			t.Errorf("parse %q: unexpected error state: %v", test.s, err)
+			continue
+		}
+		if have != test.want {
+			t.Errorf("parse %q: have %v, want %v", test.s, have, test.want)
+		}
+	}
+}
+
+func TestGoVersionAtLeast(t *testing.T) {
+	tests := []struct {
+		v     goVersion
+		other goVersion
+		want  bool
+	}{
+		{goVersion{}, goVersion{1, 21}, true},
+		{goVersion{1, 21}, goVersion{1, 21}, true},
+		{goVersion{1, 22}, goVersion{1, 21}, true},
+		{goVersion{2, 0}, goVersion{1, 21}, true},
+		{goVersion{1, 20}, goVersion{1, 21}, false},
+	}
+
+	for _, test := range tests {
+		if have := test.v.atLeast(test.other); have != test.want {
+			t.Errorf("%v.atLeast(%v): have %v, want %v",
+				test.v, test.other, have, test.want)
+		}
+	}
+}
+
+func TestGoVersionSuggestions(t *testing.T) {
+	tests := []struct {
+		rule    string
+		version string
+	}{
+		{"manualMinMax", "go1.20"},
+		{"errorsJoinSingle", "go1.19"},
+		{"manualContains", "go1.20"},
+		{"reverseIndexLoop", "go1.22"},
+	}
+
+	for _, test := range tests {
+		rule := findRule(test.rule)
+		if rule == nil {
+			t.Fatalf("%s rule not found", test.rule)
+		}
+		pkgPath := testdataPkgPath + rule.Name()
+		prog := newProg(t, pkgPath)
+		pkgInfo := prog.Imported[pkgPath]
+
+		ctx := NewContext(prog.Fset, sizes)
+		ctx.SetPackageInfo(&pkgInfo.Info, pkgInfo.Pkg)
+		if err := ctx.SetGoVersion(test.version); err != nil {
+			t.Fatalf("set Go version: %v", err)
+		}
+
+		for _, f := range pkgInfo.Files {
+			warns := NewChecker(rule, ctx).Check(f)
+			if len(warns) != 0 {
+				t.Errorf("%s: %s: expected no warnings for %s, got %d",
+					test.rule, getFilename(prog, f), test.version, len(warns))
+			}
+		}
+	}
+}
+
+func TestGoFeatureAvailable(t *testing.T) {
+	tests := []struct {
+		version string
+		feature GoFeature
+		want    bool
+	}{
+		{"go1.7", GoFeatureTimeUntil, false},
+		{"go1.8", GoFeatureTimeUntil, true},
+		{"go1.12", GoFeatureNumberLiterals, false},
+		{"go1.13", GoFeatureErrorWrapping, true},
+		{"go1.15", GoFeatureOSReadFile, false},
+		{"go1.20", GoFeatureMinMax, false},
+		{"go1.21", GoFeatureSlices, true},
+		{"go1.22", GoFeatureRangeOverFunc, false},
+	}
+
+	for _, test := range tests {
+		ctx := context{Context: NewContext(nil, sizes)}
+		if err := ctx.SetGoVersion(test.version); err != nil {
+			t.Fatalf("set Go version: %v", err)
+		}
+		if have := ctx.GoFeatureAvailable(test.feature); have != test.want {
+			t.Errorf("%s available in %s: have %v, want %v",
+				test.feature, test.version, have, test.want)
+		}
+	}
+
+	for f := range goFeatureVersions {
+		if f.Version() == "" {
+			t.Errorf("%s: empty version", f)
+		}
+	}
+	if v := GoFeatureMinMax.Version(); v != "1.21" {
+		t.Errorf("%s version: have %s, want 1.21", GoFeatureMinMax, v)
+	}
+	if v := GoFeature("goroutines").Version(); v != "" {
+		t.Errorf("unknown feature version: have %s, want empty", v)
+	}
+}
+
+func TestGoFeatureSuggestions(t *testing.T) {
+	rule := findRule("preferTimeHelpers")
+	pkgPath := testdataPkgPath + rule.Name()
+	prog := newProg(t, pkgPath)
+	pkgInfo := prog.Imported[pkgPath]
+
+	ctx := NewContext(prog.Fset, sizes)
+	ctx.SetPackageInfo(&pkgInfo.Info, pkgInfo.Pkg)
+	if err := ctx.SetGoVersion("go1.7"); err != nil {
+		t.Fatalf("set Go version: %v", err)
+	}
+	var texts []string
+	for _, f := range pkgInfo.Files {
+		for _, w := range NewChecker(rule, ctx).Check(f) {
+			texts = append(texts, w.Text)
+		}
+	}
+	if len(texts) == 0 {
+		t.Fatalf("expected time.Since suggestions for go1.7")
+	}
+	for _, text := range texts {
+		if strings.Contains(text, "Until") {
+			t.Errorf("unexpected time.Until suggestion for go1.7: %s", text)
+		}
+	}
+
+	for _, info := range CheckersInfo() {
+		if info.Name != "manualMinMax" {
+			continue
+		}
+		if len(info.GoFeatures) != 1 || info.GoFeatures[0] != GoFeatureMinMax {
+			t.Errorf("manualMinMax features: have %v, want [%s]", info.GoFeatures, GoFeatureMinMax)
+		}
+	}
+}
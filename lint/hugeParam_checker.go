@@ -2,10 +2,18 @@ package lint
 
 import (
 	"go/ast"
+	"go/types"
 )
 
 //! Detects params that incur excessive amount of copying.
 //
+// Methods that implement interfaces are not reported, as changing
+// their signature would break the interface implementation.
+// Only interfaces of the checked package and its imports are considered.
+//
+// Checker params:
+//	sizeThreshold - minimal param size in bytes that is reported (80 by default)
+//
 // @Before:
 // func f(x [1024]int) {}
 //
@@ -13,35 +21,110 @@ import (
 // func f(x *[1024]int) {}
 
 func init() {
-	addChecker(&hugeParamChecker{}, attrExperimental)
+	addChecker(&hugeParamChecker{}, attrExperimental, attrPerformance, attrSkipTests)
 }
 
 type hugeParamChecker struct {
 	checkerBase
+
+	sizeThreshold int64
+
+	// ifacesPkg is a package for which ifaces were collected.
+	ifacesPkg *types.Package
+	ifaces    []*types.Interface
+}
+
+func (c *hugeParamChecker) Params() []CheckerParam {
+	return []CheckerParam{
+		{Name: "sizeThreshold", Kind: ParamInt, Default: "80"},
+	}
+}
+
+func (c *hugeParamChecker) Init() {
+	c.sizeThreshold = int64(c.ctx.IntParam("sizeThreshold"))
 }
 
 func (c *hugeParamChecker) VisitFuncDecl(decl *ast.FuncDecl) {
-	// TODO(quasilyte): maybe it's worthwhile to permit skipping
-	// test files for this checker?
 	if decl.Recv != nil {
+		if c.implementsInterface(decl) {
+			return
+		}
 		c.checkParams(decl, decl.Recv.List)
 	}
 	c.checkParams(decl, decl.Type.Params.List)
 }
 
 func (c *hugeParamChecker) checkParams(decl *ast.FuncDecl, params []*ast.Field) {
-	const sizeThreshold = 80 // TODO(quasilyte): should be configurable
 	for _, p := range params {
 		for _, id := range p.Names {
 			typ := c.ctx.typesInfo.TypeOf(id)
+			if typ == nil || hasTypeParam(typ) {
+				continue
+			}
 			size := c.ctx.sizesInfo.Sizeof(typ)
-			if size >= sizeThreshold {
+			if size >= c.sizeThreshold {
 				c.warn(id, size)
 			}
 		}
 	}
 }
 
+// implementsInterface reports whether decl method is required
+// by any known interface that is implemented by the method receiver.
+func (c *hugeParamChecker) implementsInterface(decl *ast.FuncDecl) bool {
+	fn, ok := c.ctx.typesInfo.ObjectOf(decl.Name).(*types.Func)
+	if !ok {
+		return false
+	}
+	recv := fn.Type().(*types.Signature).Recv().Type()
+	for _, iface := range c.interfaces() {
+		if hasMethod(iface, fn.Name()) && types.Implements(recv, iface) {
+			return true
+		}
+	}
+	return false
+}
+
+// interfaces returns non-empty interfaces declared in the
+// checked package scope, its imports and the universe scope.
+func (c *hugeParamChecker) interfaces() []*types.Interface {
+	if c.ifacesPkg == c.ctx.pkg {
+		return c.ifaces
+	}
+	c.ifacesPkg = c.ctx.pkg
+	c.ifaces = c.ifaces[:0]
+	scopes := []*types.Scope{types.Universe}
+	if c.ctx.pkg != nil {
+		scopes = append(scopes, c.ctx.pkg.Scope())
+		for _, imp := range c.ctx.pkg.Imports() {
+			scopes = append(scopes, imp.Scope())
+		}
+	}
+	for _, scope := range scopes {
+		for _, name := range scope.Names() {
+			obj, ok := scope.Lookup(name).(*types.TypeName)
+			if !ok {
+				continue
+			}
+			iface, ok := obj.Type().Underlying().(*types.Interface)
+			if ok && iface.NumMethods() != 0 {
+				c.ifaces = append(c.ifaces, iface)
+			}
+		}
+	}
+	return c.ifaces
+}
+
+// hasMethod reports whether iface method set contains method with specified name.
+func hasMethod(iface *types.Interface, name string) bool {
+	for i := 0; i < iface.NumMethods(); i++ {
+		if iface.Method(i).Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *hugeParamChecker) warn(cause *ast.Ident, size int64) {
 	c.ctx.Warn(cause, "%s is heavy (%d bytes); consider passing it by pointer",
 		cause, size)
@@ -4,6 +4,11 @@ package lint
 //
 // Permits single else or else-if; repeated else-if or else + else-if
 // will trigger suggestion to use switch statement.
+// If every condition compares the same expression with ==,
+// tagged switch over that expression is suggested.
+//
+// Checker params:
+//	minThreshold - min number of else and else-if branches that is reported (2 by default)
 //
 // @Before:
 // if cond1 {
@@ -26,6 +31,9 @@ package lint
 
 import (
 	"go/ast"
+	"go/token"
+
+	"github.com/go-toolsmith/astequal"
 )
 
 func init() {
@@ -37,6 +45,18 @@ type ifElseChainChecker struct {
 
 	cause   *ast.IfStmt
 	visited map[*ast.IfStmt]bool
+
+	minThreshold int
+}
+
+func (c *ifElseChainChecker) Params() []CheckerParam {
+	return []CheckerParam{
+		{Name: "minThreshold", Kind: ParamInt, Default: "2"},
+	}
+}
+
+func (c *ifElseChainChecker) Init() {
+	c.minThreshold = c.ctx.IntParam("minThreshold")
 }
 
 func (c *ifElseChainChecker) EnterFunc(fn *ast.FuncDecl) bool {
@@ -58,12 +78,54 @@ func (c *ifElseChainChecker) VisitStmt(stmt ast.Stmt) {
 }
 
 func (c *ifElseChainChecker) checkIfStmt(stmt *ast.IfStmt) {
-	const minThreshold = 2
-	if c.countIfelseLen(stmt) >= minThreshold {
+	if c.countIfelseLen(stmt) < c.minThreshold {
+		return
+	}
+	if tag := c.switchTag(stmt); tag != nil {
+		c.warnTag(tag)
+	} else {
 		c.warn()
 	}
 }
 
+// switchTag returns an expression that is compared with == by
+// every condition of the stmt chain, so it can be used as a switch tag.
+// Returns nil if there is no such expression.
+func (c *ifElseChainChecker) switchTag(stmt *ast.IfStmt) ast.Expr {
+	var tag ast.Expr
+	for stmt != nil {
+		x := c.condTag(stmt.Cond)
+		if x == nil || (tag != nil && !astequal.Expr(tag, x)) {
+			return nil
+		}
+		tag = x
+		stmt, _ = stmt.Else.(*ast.IfStmt)
+	}
+	return tag
+}
+
+// condTag returns LHS of cond if it's an `x == y` comparison
+// or a || chain of such comparisons with the same LHS.
+// Returns nil otherwise.
+func (c *ifElseChainChecker) condTag(cond ast.Expr) ast.Expr {
+	bin, ok := cond.(*ast.BinaryExpr)
+	if !ok {
+		return nil
+	}
+	switch bin.Op {
+	case token.EQL:
+		if isSafeExpr(bin.X) {
+			return bin.X
+		}
+	case token.LOR:
+		x, y := c.condTag(bin.X), c.condTag(bin.Y)
+		if x != nil && y != nil && astequal.Expr(x, y) {
+			return x
+		}
+	}
+	return nil
+}
+
 func (c *ifElseChainChecker) countIfelseLen(stmt *ast.IfStmt) int {
 	count := 0
 	for {
@@ -89,3 +151,7 @@ func (c *ifElseChainChecker) countIfelseLen(stmt *ast.IfStmt) int {
 func (c *ifElseChainChecker) warn() {
 	c.ctx.Warn(c.cause, "should rewrite if-else to switch statement")
 }
+
+func (c *ifElseChainChecker) warnTag(tag ast.Expr) {
+	c.ctx.Warn(c.cause, "should rewrite if-else to `switch %s` statement", tag)
+}
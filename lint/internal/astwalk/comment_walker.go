@@ -0,0 +1,13 @@
+package astwalk
+
+import "go/ast"
+
+type commentWalker struct {
+	visitor CommentVisitor
+}
+
+func (w *commentWalker) WalkFile(f *ast.File) {
+	for _, c := range f.Comments {
+		w.visitor.VisitComment(c)
+	}
+}
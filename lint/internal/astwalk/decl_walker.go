@@ -0,0 +1,16 @@
+package astwalk
+
+import "go/ast"
+
+type declWalker struct {
+	visitor DeclVisitor
+}
+
+func (w *declWalker) WalkFile(f *ast.File) {
+	for _, decl := range f.Decls {
+		if decl, ok := decl.(*ast.FuncDecl); ok && !w.visitor.EnterFunc(decl) {
+			continue
+		}
+		w.visitor.VisitDecl(decl)
+	}
+}
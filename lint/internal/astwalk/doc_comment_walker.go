@@ -0,0 +1,48 @@
+package astwalk
+
+import "go/ast"
+
+type docCommentWalker struct {
+	visitor DocCommentVisitor
+}
+
+func (w *docCommentWalker) WalkFile(f *ast.File) {
+	w.visit(f.Doc)
+	for _, decl := range f.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if !w.visitor.EnterFunc(decl) {
+				continue
+			}
+			w.visit(decl.Doc)
+		case *ast.GenDecl:
+			w.visit(decl.Doc)
+			for _, spec := range decl.Specs {
+				switch spec := spec.(type) {
+				case *ast.ValueSpec:
+					w.visit(spec.Doc)
+				case *ast.TypeSpec:
+					w.visit(spec.Doc)
+					w.walkFields(spec.Type)
+				}
+			}
+		}
+	}
+}
+
+// walkFields visits struct field and interface method docs of typ,
+// including nested anonymous types.
+func (w *docCommentWalker) walkFields(typ ast.Expr) {
+	ast.Inspect(typ, func(x ast.Node) bool {
+		if field, ok := x.(*ast.Field); ok {
+			w.visit(field.Doc)
+		}
+		return true
+	})
+}
+
+func (w *docCommentWalker) visit(doc *ast.CommentGroup) {
+	if doc != nil {
+		w.visitor.VisitDocComment(doc)
+	}
+}
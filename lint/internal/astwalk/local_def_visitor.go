@@ -31,7 +31,7 @@ func (w *localDefWalker) walkFuncBody(decl *ast.FuncDecl) {
 		switch x := x.(type) {
 		case *ast.AssignStmt:
 			if x.Tok != token.DEFINE {
-				return false
+				return true
 			}
 			if len(x.Lhs) != len(x.Rhs) {
 				// Multi-value assignment.
@@ -55,14 +55,15 @@ func (w *localDefWalker) walkFuncBody(decl *ast.FuncDecl) {
 					w.visitor.VisitLocalDef(def, x.Rhs[i])
 				}
 			}
-			return false
+			// Continue to visit function literals from the RHS.
+			return true
 
 		case *ast.GenDecl:
 			// Decls always introduce new names.
 			for _, spec := range x.Specs {
 				spec, ok := spec.(*ast.ValueSpec)
 				if !ok { // Ignore type/import specs
-					return false
+					continue
 				}
 				switch {
 				case len(spec.Values) == 0:
@@ -89,7 +90,10 @@ func (w *localDefWalker) walkFuncBody(decl *ast.FuncDecl) {
 					}
 				}
 			}
-			return false
+			return true
+
+		case *ast.FuncLit:
+			w.walkFuncType(x.Type)
 		}
 
 		return true
@@ -97,22 +101,26 @@ func (w *localDefWalker) walkFuncBody(decl *ast.FuncDecl) {
 }
 
 func (w *localDefWalker) walkSignature(decl *ast.FuncDecl) {
-	for _, p := range decl.Type.Params.List {
+	w.walkFuncType(decl.Type)
+	if decl.Recv != nil && len(decl.Recv.List[0].Names) != 0 {
+		def := Name{ID: decl.Recv.List[0].Names[0], Kind: NameParam}
+		w.visitor.VisitLocalDef(def, nil)
+	}
+}
+
+func (w *localDefWalker) walkFuncType(typ *ast.FuncType) {
+	for _, p := range typ.Params.List {
 		for _, id := range p.Names {
 			def := Name{ID: id, Kind: NameParam}
 			w.visitor.VisitLocalDef(def, nil)
 		}
 	}
-	if decl.Type.Results != nil {
-		for _, p := range decl.Type.Results.List {
+	if typ.Results != nil {
+		for _, p := range typ.Results.List {
 			for _, id := range p.Names {
 				def := Name{ID: id, Kind: NameParam}
 				w.visitor.VisitLocalDef(def, nil)
 			}
 		}
 	}
-	if decl.Recv != nil && len(decl.Recv.List[0].Names) != 0 {
-		def := Name{ID: decl.Recv.List[0].Names[0], Kind: NameParam}
-		w.visitor.VisitLocalDef(def, nil)
-	}
 }
@@ -0,0 +1,250 @@
+package astwalk
+
+import "go/ast"
+
+// SharedWalker runs several file walkers at once.
+//
+// Walkers for FuncDeclVisitor, DeclVisitor, ExprVisitor, LocalExprVisitor,
+// StmtVisitor and StmtListVisitor share a single file traversal.
+// Other walkers traverse the file on their own.
+//
+// Every visitor gets the same calls in the same order as
+// with its own walker, so they can be used interchangeably.
+type SharedWalker struct {
+	// separate are walkers that can't share the traversal.
+	separate []int
+
+	decls []*sharedVisitor
+	nodes []*sharedVisitor
+
+	walkers []FileWalker
+
+	// running is an index of the walker which visitor is being called.
+	running int
+}
+
+// sharedVisitor adapts walker visitor to the shared traversal.
+type sharedVisitor struct {
+	// index is a walker index inside SharedWalker.
+	index int
+
+	// enter reports whether visitor is interested in decl.
+	enter func(decl ast.Decl) bool
+
+	// visit is called for every node visitor is interested in.
+	// Returns false if node children should be skipped.
+	visit func(n ast.Node) bool
+
+	// bodyOnly is set for visitors that only visit function bodies.
+	bodyOnly bool
+
+	// skipped is a node which children are skipped by visitor.
+	skipped ast.Node
+}
+
+// NewSharedWalker returns a walker that runs all walkers.
+// Walkers should be created by this package WalkerFor functions.
+func NewSharedWalker(walkers []FileWalker) *SharedWalker {
+	w := &SharedWalker{walkers: walkers}
+	for i, walker := range walkers {
+		switch walker := walker.(type) {
+		case *funcDeclWalker:
+			v := walker.visitor
+			w.decls = append(w.decls, &sharedVisitor{
+				index: i,
+				enter: enterFuncDecl(v),
+				visit: func(n ast.Node) bool {
+					v.VisitFuncDecl(n.(*ast.FuncDecl))
+					return true
+				},
+			})
+		case *declWalker:
+			v := walker.visitor
+			w.decls = append(w.decls, &sharedVisitor{
+				index: i,
+				enter: enterDecl(v),
+				visit: func(n ast.Node) bool {
+					v.VisitDecl(n.(ast.Decl))
+					return true
+				},
+			})
+		case *exprWalker:
+			v := walker.visitor
+			w.nodes = append(w.nodes, &sharedVisitor{
+				index: i,
+				enter: enterDecl(v),
+				visit: func(n ast.Node) bool {
+					if x, ok := n.(ast.Expr); ok {
+						v.VisitExpr(x)
+						return v.EnterChilds(x)
+					}
+					return true
+				},
+			})
+		case *localExprWalker:
+			v := walker.visitor
+			w.nodes = append(w.nodes, &sharedVisitor{
+				index: i,
+				enter: enterFuncDecl(v),
+				visit: func(n ast.Node) bool {
+					if x, ok := n.(ast.Expr); ok {
+						v.VisitLocalExpr(x)
+						return v.EnterChilds(x)
+					}
+					return true
+				},
+			})
+		case *stmtWalker:
+			v := walker.visitor
+			w.nodes = append(w.nodes, &sharedVisitor{
+				index:    i,
+				enter:    enterFuncDecl(v),
+				bodyOnly: true,
+				visit: func(n ast.Node) bool {
+					if x, ok := n.(ast.Stmt); ok {
+						v.VisitStmt(x)
+						return v.EnterChilds(x)
+					}
+					return true
+				},
+			})
+		case *stmtListWalker:
+			v := walker.visitor
+			w.nodes = append(w.nodes, &sharedVisitor{
+				index:    i,
+				enter:    enterFuncDecl(v),
+				bodyOnly: true,
+				visit: func(n ast.Node) bool {
+					switch n := n.(type) {
+					case *ast.BlockStmt:
+						v.VisitStmtList(n.List)
+					case *ast.CaseClause:
+						v.VisitStmtList(n.Body)
+					case *ast.CommClause:
+						v.VisitStmtList(n.Body)
+					}
+					return true
+				},
+			})
+		default:
+			w.separate = append(w.separate, i)
+		}
+	}
+	return w
+}
+
+// enterDecl returns enter function for visitors that
+// are interested in every declaration.
+func enterDecl(v walkerEvents) func(ast.Decl) bool {
+	return func(decl ast.Decl) bool {
+		fn, ok := decl.(*ast.FuncDecl)
+		return !ok || v.EnterFunc(fn)
+	}
+}
+
+// enterFuncDecl returns enter function for visitors that
+// are only interested in function declarations.
+func enterFuncDecl(v walkerEvents) func(ast.Decl) bool {
+	return func(decl ast.Decl) bool {
+		fn, ok := decl.(*ast.FuncDecl)
+		return ok && v.EnterFunc(fn)
+	}
+}
+
+// Running returns an index of the walker which visitor is being run.
+// Can be used to find out which visitor has panicked.
+func (w *SharedWalker) Running() int {
+	return w.running
+}
+
+// WalkFile runs all walkers over f.
+func (w *SharedWalker) WalkFile(f *ast.File) {
+	for _, i := range w.separate {
+		w.running = i
+		w.walkers[i].WalkFile(f)
+	}
+
+	var active []*sharedVisitor
+	for _, decl := range f.Decls {
+		for _, v := range w.decls {
+			w.running = v.index
+			if v.enter(decl) {
+				v.visit(decl)
+			}
+		}
+
+		active = active[:0]
+		for _, v := range w.nodes {
+			w.running = v.index
+			if v.enter(decl) {
+				active = append(active, v)
+			}
+		}
+		if len(active) != 0 {
+			w.walkDecl(decl, active)
+		}
+	}
+}
+
+// walkDecl traverses decl once, calling every active visitor
+// for the nodes it would be called for by its own walker.
+func (w *SharedWalker) walkDecl(decl ast.Decl, active []*sharedVisitor) {
+	var body ast.Node
+	if fn, ok := decl.(*ast.FuncDecl); ok && fn.Body != nil {
+		body = fn.Body
+	}
+	inBody := false
+
+	// stack holds nodes which children are being traversed.
+	var stack []ast.Node
+	ast.Inspect(decl, func(n ast.Node) bool {
+		if n == nil {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for _, v := range active {
+				if v.skipped == top {
+					v.skipped = nil
+				}
+			}
+			if top == body {
+				inBody = false
+			}
+			return true
+		}
+
+		if n == body {
+			inBody = true
+		}
+		descend := false
+		for _, v := range active {
+			switch {
+			case v.skipped != nil:
+				continue
+			case v.bodyOnly && !inBody:
+				// Body is a direct child of the declaration.
+				descend = descend || (n == decl && body != nil)
+				continue
+			}
+			w.running = v.index
+			if v.visit(n) {
+				descend = true
+			} else {
+				v.skipped = n
+			}
+		}
+
+		if !descend {
+			for _, v := range active {
+				if v.skipped == n {
+					v.skipped = nil
+				}
+			}
+			if n == body {
+				inBody = false
+			}
+			return false
+		}
+		stack = append(stack, n)
+		return true
+	})
+}
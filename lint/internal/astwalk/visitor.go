@@ -62,12 +62,34 @@ type (
 		walkerEvents
 		VisitLocalComment(*ast.CommentGroup)
 	}
+
+	// CommentVisitor visits every comment group inside AST file,
+	// including package-level and function-local comments.
+	CommentVisitor interface {
+		walkerEvents
+		VisitComment(*ast.CommentGroup)
+	}
+
+	// DocCommentVisitor visits every doc comment group inside AST file:
+	// package, top-level declaration, spec and struct field docs.
+	DocCommentVisitor interface {
+		walkerEvents
+		VisitDocComment(*ast.CommentGroup)
+	}
+
+	// DeclVisitor visits every top-level declaration inside AST file.
+	DeclVisitor interface {
+		walkerEvents
+		VisitDecl(ast.Decl)
+	}
 )
 
 // walkerEvents describes common hooks available for every visitor.
 type walkerEvents interface {
 	// EnterFunc is called for every function declaration that is about
 	// to be traversed. If false is returned, function is not visited.
+	//
+	// Not applicable to CommentVisitor.
 	EnterFunc(*ast.FuncDecl) bool
 
 	// EnterChilds is called for every visited node.
@@ -79,6 +101,9 @@ type walkerEvents interface {
 	//	- StmtListVisitor
 	//	- LocalDefVisitor
 	//	- LocalCommentVisitor
+	//	- CommentVisitor
+	//	- DocCommentVisitor
+	//	- DeclVisitor
 	EnterChilds(ast.Node) bool
 }
 
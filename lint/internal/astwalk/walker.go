@@ -50,3 +50,18 @@ func WalkerForTypeExpr(v TypeExprVisitor, info *types.Info) FileWalker {
 func WalkerForLocalComment(v LocalCommentVisitor) FileWalker {
 	return &localCommentVisitor{visitor: v}
 }
+
+// WalkerForComment returns file walker implementation for CommentVisitor.
+func WalkerForComment(v CommentVisitor) FileWalker {
+	return &commentWalker{visitor: v}
+}
+
+// WalkerForDocComment returns file walker implementation for DocCommentVisitor.
+func WalkerForDocComment(v DocCommentVisitor) FileWalker {
+	return &docCommentWalker{visitor: v}
+}
+
+// WalkerForDecl returns file walker implementation for DeclVisitor.
+func WalkerForDecl(v DeclVisitor) FileWalker {
+	return &declWalker{visitor: v}
+}
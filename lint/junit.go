@@ -0,0 +1,69 @@
+package lint
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// junitReporter writes reports in JUnit XML format.
+//
+// Every file with warnings becomes a test suite and every
+// warning becomes a failed test case named after the checker.
+type junitReporter struct{}
+
+// Types below describe the subset of JUnit format that is used by junitReporter.
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string       `xml:"name,attr"`
+	ClassName string       `xml:"classname,attr"`
+	Failure   junitFailure `xml:"failure"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Content string `xml:",chardata"`
+}
+
+func (junitReporter) Write(w io.Writer, reports []Report) error {
+	var out junitTestSuites
+
+	// Reports are grouped by file, keeping the first appearance order.
+	suites := make(map[string]int)
+	for _, r := range reports {
+		i, ok := suites[r.Pos.Filename]
+		if !ok {
+			i = len(out.Suites)
+			suites[r.Pos.Filename] = i
+			out.Suites = append(out.Suites, junitTestSuite{Name: r.Pos.Filename})
+		}
+		loc := fmt.Sprintf("%s:%d:%d", r.Pos.Filename, r.Pos.Line, r.Pos.Column)
+		suite := &out.Suites[i]
+		suite.Tests++
+		suite.Failures++
+		suite.TestCases = append(suite.TestCases, junitTestCase{
+			Name:      r.Checker,
+			ClassName: loc,
+			Failure: junitFailure{
+				Message: r.Text,
+				Type:    r.Severity.String(),
+				Content: loc + ": " + r.Checker + ": " + r.Text,
+			},
+		})
+	}
+
+	return writeXML(w, out)
+}
@@ -6,6 +6,8 @@ import (
 	"go/token"
 	"go/types"
 	"sort"
+	"sync"
+	"time"
 
 	"github.com/go-critic/go-critic/lint/internal/astwalk"
 	"github.com/go-toolsmith/astfmt"
@@ -44,13 +46,65 @@ type AttributeSet struct {
 	// VeryOpinionated marks rule as controversial for some audience and
 	// that it might be not suitable for everyone.
 	VeryOpinionated bool
+
+	// Performance marks rules that detect code that can be made faster.
+	Performance bool
+
+	// SharedState marks rules which checkers mutate state that is
+	// shared with other checkers, like package-level caches.
+	// Such checkers are never run concurrently by the Runner.
+	SharedState bool
+
+	// SkipTests marks rules that are not useful for test code,
+	// like most style and performance rules.
+	// Checkers of such rules don't check test files, see IsTestFile.
+	SkipTests bool
+
+	// EnvDependent marks rules which checkers results depend on
+	// the environment, like files on disk or tools available in PATH.
+	// Results of such checkers are never cached, see Cache.
+	EnvDependent bool
+
+	// PackageScope marks rules which checkers analyze all package
+	// files together, so their results for a file depend on other files.
+	// Such checkers should be run by Checker.CheckPackage,
+	// Check analyzes only the given file.
+	PackageScope bool
+
+	// ProgramScope marks rules which checkers analyze all loaded
+	// packages together, like the ones that look for identifiers
+	// that are never used by other packages.
+	// Such checkers are only run by Checker.CheckProgram,
+	// the other Checker methods and Runner report nothing for them.
+	ProgramScope bool
+}
+
+// Package describes a type-checked package for Checker.CheckProgram.
+type Package struct {
+	Pkg   *types.Package
+	Info  *types.Info
+	Files []*ast.File
 }
 
 // Rule describes a named check that can be performed by the linter.
 type Rule struct {
 	AttributeSet
 
+	// Params are parameters that rule checker accepts.
+	// Values can be set by Context.SetCheckerParam.
+	Params []CheckerParam
+
+	// GoFeatures are Go features that rule checker requires.
+	// Checker reports nothing for the code that targets Go versions
+	// that don't have some of them, see Context.SetGoVersion.
+	GoFeatures []GoFeature
+
 	name string
+
+	// definition identifies rule implementation that is not
+	// a part of the linter executable, like a hash of the
+	// rules file description. Cache keys include it.
+	definition string
 }
 
 // String returns r short printed representation (name only).
@@ -71,10 +125,14 @@ func NewChecker(rule *Rule, ctx *Context) *Checker {
 	if !ok {
 		panic(fmt.Sprintf("rule %q is undefined", rule.Name()))
 	}
-	return c.clone(context{
+	checkerCtx := context{
 		Context: ctx,
 		printer: astfmt.NewPrinter(ctx.fileSet),
-	})
+	}
+	if ctx.collectStats {
+		checkerCtx.stats = &CheckerStats{Checker: rule.Name()}
+	}
+	return c.clone(checkerCtx)
 }
 
 // Checker analyzes given file for potential issues.
@@ -85,23 +143,276 @@ type Checker struct {
 	ctx context
 
 	walker astwalk.FileWalker
+
+	// pkgVisitor is set instead of walker for PackageScope rules.
+	pkgVisitor packageVisitor
+
+	// progVisitor is set instead of walker for ProgramScope rules.
+	progVisitor programVisitor
 }
 
 // Check runs rule checker over file f.
+//
+// Rules with SkipTests attribute report nothing for test files.
+// Checkers of PackageScope rules treat f as the only package file.
+// Checkers of ProgramScope rules report nothing.
 func (c *Checker) Check(f *ast.File) []Warning {
-	c.ctx.warnings = c.ctx.warnings[:0]
-	c.walker.WalkFile(f)
+	if c.progVisitor != nil {
+		return nil
+	}
+	c.beginFile(f)
+	if c.skips(f) {
+		return nil
+	}
+	c.walkFile(f)
+	c.endFile()
 	return c.ctx.warnings
 }
 
+// CheckPackage runs rule checker over all package files.
+// Warnings for files[i] are returned at index i.
+//
+// For rules that are not PackageScope, it's equivalent
+// to calling Check for every file.
+func (c *Checker) CheckPackage(files []*ast.File) [][]Warning {
+	warnings := make([][]Warning, len(files))
+	if c.pkgVisitor == nil {
+		for i, f := range files {
+			warnings[i] = append([]Warning(nil), c.Check(f)...)
+		}
+		return warnings
+	}
+	return c.checkFiles(files, c.visitPackage)
+}
+
+// CheckProgram runs rule checker over all pkgs at once.
+// Results are sorted by file (in pkgs files order) and warning position.
+//
+// Only checkers of ProgramScope rules report anything.
+// Their results for a package depend on all other pkgs,
+// so pkgs should include all packages that can use each other.
+func (c *Checker) CheckProgram(pkgs []*Package) []Result {
+	if c.progVisitor == nil {
+		return nil
+	}
+	var files []*ast.File
+	for _, pkg := range pkgs {
+		files = append(files, pkg.Files...)
+	}
+	visit := func([]*ast.File) {
+		if s := c.ctx.stats; s != nil {
+			defer s.visit(time.Now())
+		}
+		c.progVisitor.VisitProgram(pkgs)
+	}
+	var results []Result
+	for i, warnings := range c.checkFiles(files, visit) {
+		sort.SliceStable(warnings, func(i, j int) bool {
+			return warnings[i].Pos < warnings[j].Pos
+		})
+		for _, w := range warnings {
+			results = append(results, Result{Warning: w, Checker: c, File: files[i]})
+		}
+	}
+	return results
+}
+
+// checkFiles calls visit with files that checker checks and
+// distributes reported warnings by files, applying suppressions.
+// Warnings for files[i] are returned at index i.
+func (c *Checker) checkFiles(files []*ast.File, visit func(files []*ast.File)) [][]Warning {
+	warnings := make([][]Warning, len(files))
+	var checked []*ast.File
+	index := make(map[*token.File]int, len(files))
+	for i, f := range files {
+		if c.skips(f) {
+			continue
+		}
+		checked = append(checked, f)
+		index[c.ctx.fileSet.File(f.Pos())] = i
+	}
+	c.ctx.warnings = c.ctx.warnings[:0]
+	c.ctx.file = nil
+	c.ctx.commentMap = nil
+	c.ctx.directives = nil
+	c.ctx.fileSuppressions = nil
+	visit(checked)
+
+	suppressions := make(map[int]*suppressionSet, len(checked))
+	for _, f := range checked {
+		set := c.ctx.suppressionsOf(f)
+		set.markRan(c.Rule.Name())
+		suppressions[index[c.ctx.fileSet.File(f.Pos())]] = set
+	}
+	for _, w := range c.ctx.warnings {
+		i, ok := index[c.ctx.fileSet.File(w.Pos)]
+		if !ok {
+			continue // Not one of the checked files
+		}
+		if suppressions[i].match(c.Rule.Name(), w.Code, c.ctx.fileSet.Position(w.Pos).Line) {
+			continue
+		}
+		warnings[i] = append(warnings[i], w)
+	}
+	if s := c.ctx.stats; s != nil {
+		s.Files += len(checked)
+		s.Warnings += len(c.ctx.warnings)
+	}
+	return warnings
+}
+
+// walkFile runs checker visitor over f.
+func (c *Checker) walkFile(f *ast.File) {
+	if c.pkgVisitor != nil {
+		c.visitPackage([]*ast.File{f})
+	} else {
+		c.walker.WalkFile(f)
+	}
+}
+
+// visitPackage runs package visitor over files.
+func (c *Checker) visitPackage(files []*ast.File) {
+	if s := c.ctx.stats; s != nil {
+		defer s.visit(time.Now())
+	}
+	c.pkgVisitor.VisitPackage(files)
+}
+
+// CheckFile runs every checker over file f, like Checker.Check does.
+// Warnings of checkers[i] are returned at index i.
+//
+// Checkers that visit declarations, expressions and statements share
+// a single traversal of f, so it's faster than calling Check for
+// every checker.
+func CheckFile(checkers []*Checker, f *ast.File) [][]Warning {
+	// running are indexes of checkers that check f.
+	var running []int
+	// walking are checkers that share the traversal, walkers[i] is walking[i] walker.
+	var walking []*Checker
+	var walkers []astwalk.FileWalker
+	var pkgCheckers []*Checker
+	for i, c := range checkers {
+		if c.progVisitor != nil {
+			continue
+		}
+		c.beginFile(f)
+		if c.skips(f) {
+			continue
+		}
+		running = append(running, i)
+		if c.pkgVisitor != nil {
+			pkgCheckers = append(pkgCheckers, c)
+		} else {
+			walking = append(walking, c)
+			walkers = append(walkers, c.walker)
+		}
+	}
+	w := astwalk.NewSharedWalker(walkers)
+	var current *Checker // Running package checker
+	defer func() {
+		if r := recover(); r != nil {
+			c := current
+			if c == nil {
+				c = walking[w.Running()]
+			}
+			panic(checkerPanic{rule: c.Rule, value: r})
+		}
+	}()
+	w.WalkFile(f)
+	for _, c := range pkgCheckers {
+		current = c
+		c.walkFile(f)
+	}
+
+	warnings := make([][]Warning, len(checkers))
+	for _, i := range running {
+		checkers[i].endFile()
+		warnings[i] = checkers[i].ctx.warnings
+	}
+	return warnings
+}
+
+// skips reports whether checker doesn't check f.
+func (c *Checker) skips(f *ast.File) bool {
+	if !c.ctx.goFeaturesAvailable(c.Rule.GoFeatures) {
+		return true
+	}
+	return c.Rule.SkipTests && IsTestFile(c.ctx.fileSet, f)
+}
+
+// beginFile prepares checker context for checking f.
+func (c *Checker) beginFile(f *ast.File) {
+	c.ctx.warnings = c.ctx.warnings[:0]
+	c.ctx.file = f
+	c.ctx.commentMap = nil
+	c.ctx.directives = nil
+	c.ctx.fileSuppressions = c.ctx.suppressionsOf(f)
+	c.ctx.fileSuppressions.markRan(c.Rule.Name())
+}
+
+// checkerPanic wraps a panic value of the checker run by CheckFile,
+// so it's known which checker has failed.
+type checkerPanic struct {
+	rule  *Rule
+	value interface{}
+}
+
+func (p checkerPanic) String() string {
+	return fmt.Sprintf("%s: %v", p.rule, p.value)
+}
+
 // Warning represents issue that is found by rule checker.
 type Warning struct {
 	// Node is an AST node that caused warning to trigger.
-	// Can be used to obtain proper error location.
 	Node ast.Node
 
+	// Pos and End describe the reported source code range.
+	// Checkers report the whole Node range, so editors
+	// can highlight the entire offending code.
+	Pos token.Pos
+	End token.Pos
+
 	// Text is warning message without source location info.
 	Text string
+
+	// Severity describes how important the issue is.
+	Severity Severity
+
+	// Confidence describes how likely the warning points to a real issue.
+	Confidence Confidence
+
+	// Fix is a list of source code edits that fix the issue.
+	// Nil for warnings that can't be fixed automatically.
+	Fix []TextEdit
+
+	// Code identifies the checker sub-rule that reported the issue,
+	// like "doubleNegation" for boolExprSimplify.
+	// Empty for checkers that report only one kind of issues.
+	Code string
+
+	// GoFeature is a Go feature the suggestion relies on.
+	// Warning is not reported for the code that targets Go versions
+	// without the feature. Empty if suggestion works for any Go version.
+	GoFeature GoFeature
+}
+
+// WarningID returns a stable warning identifier composed
+// of the checker name and the warning code, if any,
+// like "boolExprSimplify/doubleNegation".
+func WarningID(checker, code string) string {
+	if code == "" {
+		return checker
+	}
+	return checker + "/" + code
+}
+
+// TextEdit describes a source code replacement of [Pos, End) range.
+type TextEdit struct {
+	Pos token.Pos
+	End token.Pos
+
+	// NewText is inserted instead of the replaced range.
+	NewText string
 }
 
 // Context is a readonly state shared among every checker.
@@ -121,6 +432,34 @@ type Context struct {
 	// sizesInfo carries alignment and type size information.
 	// Arch-dependent.
 	sizesInfo types.Sizes
+
+	// goVersion is a Go version the checked code targets.
+	// Zero value means "the latest Go version".
+	goVersion goVersion
+
+	// checkerParams maps checker name to its parameters.
+	checkerParams map[string]map[string]string
+
+	// minConfidence is a confidence level threshold.
+	// Warnings with lower confidence are not reported.
+	minConfidence Confidence
+
+	// minSeverity is a severity level threshold.
+	// Warnings with lower severity are not reported.
+	minSeverity Severity
+
+	// severityOverrides and confidenceOverrides map checker name
+	// to the level that replaces levels of all its warnings.
+	severityOverrides   map[string]Severity
+	confidenceOverrides map[string]Confidence
+
+	// collectStats makes new checkers collect CheckerStats.
+	collectStats bool
+
+	// suppressions maps file to its suppression comments.
+	// Filled lazily by checkers, so it's guarded by mutex.
+	suppressionsMu sync.Mutex
+	suppressions   map[*ast.File]*suppressionSet
 }
 
 // NewContext returns new shared context to be used by every checker.
@@ -135,6 +474,23 @@ func NewContext(fset *token.FileSet, sizes types.Sizes) *Context {
 	}
 }
 
+// withFileSet returns a copy of c settings that uses fset.
+// Package info and state collected while checking files are not copied.
+func (c *Context) withFileSet(fset *token.FileSet) *Context {
+	return &Context{
+		fileSet:             fset,
+		sizesInfo:           c.sizesInfo,
+		typesInfo:           &types.Info{},
+		goVersion:           c.goVersion,
+		checkerParams:       c.checkerParams,
+		minConfidence:       c.minConfidence,
+		minSeverity:         c.minSeverity,
+		severityOverrides:   c.severityOverrides,
+		confidenceOverrides: c.confidenceOverrides,
+		collectStats:        c.collectStats,
+	}
+}
+
 // FileSet returns file set used upon context creation.
 func (c *Context) FileSet() *token.FileSet { return c.fileSet }
 
@@ -154,6 +510,97 @@ func (c *Context) SetPackageInfo(info *types.Info, pkg *types.Package) {
 	c.pkg = pkg
 }
 
+// SetGoVersion sets Go version the checked code is written for.
+// Version is specified as "1.21" or "go1.21"; patch level is ignored.
+//
+// Checkers use it to avoid suggestions that are not applicable
+// for older Go versions, see GoFeature.
+// If version is never set, the latest Go version is assumed.
+func (c *Context) SetGoVersion(version string) error {
+	v, err := parseGoVersion(version)
+	if err != nil {
+		return err
+	}
+	c.goVersion = v
+	return nil
+}
+
+// SetCheckerParam sets checker-specific parameter value.
+//
+// Parameters are read by checkers during their creation,
+// so they should be set before NewChecker call.
+// Returns an error if there is no checker with the specified name,
+// if checker has no such parameter or if value is not valid for it.
+func (c *Context) SetCheckerParam(checker, name, value string) error {
+	proto, ok := checkerPrototypes[checker]
+	if !ok {
+		return fmt.Errorf("%s: checker not found", checker)
+	}
+	p := proto.rule.findParam(name)
+	if p == nil {
+		return fmt.Errorf("%s.%s: param not found", checker, name)
+	}
+	if err := p.validate(value); err != nil {
+		return fmt.Errorf("%s.%s: %v", checker, name, err)
+	}
+	if c.checkerParams == nil {
+		c.checkerParams = make(map[string]map[string]string)
+	}
+	if c.checkerParams[checker] == nil {
+		c.checkerParams[checker] = make(map[string]string)
+	}
+	c.checkerParams[checker][name] = value
+	return nil
+}
+
+// SetMinConfidence makes checkers skip warnings that have
+// confidence level lower than conf.
+//
+// By default, all warnings are reported.
+func (c *Context) SetMinConfidence(conf Confidence) {
+	c.minConfidence = conf
+}
+
+// SetMinSeverity makes checkers skip warnings that have
+// severity level lower than sev.
+// Severity overrides are applied before the filtering.
+//
+// By default, all warnings are reported.
+func (c *Context) SetMinSeverity(sev Severity) {
+	c.minSeverity = sev
+}
+
+// SetSeverityOverride makes all warnings of the specified checker
+// have sev severity level.
+//
+// Returns an error if there is no checker with the specified name.
+func (c *Context) SetSeverityOverride(checker string, sev Severity) error {
+	if _, ok := checkerPrototypes[checker]; !ok {
+		return fmt.Errorf("%s: checker not found", checker)
+	}
+	if c.severityOverrides == nil {
+		c.severityOverrides = make(map[string]Severity)
+	}
+	c.severityOverrides[checker] = sev
+	return nil
+}
+
+// SetConfidenceOverride makes all warnings of the specified checker
+// have conf confidence level.
+// Overridden confidence is used for SetMinConfidence filtering.
+//
+// Returns an error if there is no checker with the specified name.
+func (c *Context) SetConfidenceOverride(checker string, conf Confidence) error {
+	if _, ok := checkerPrototypes[checker]; !ok {
+		return fmt.Errorf("%s: checker not found", checker)
+	}
+	if c.confidenceOverrides == nil {
+		c.confidenceOverrides = make(map[string]Confidence)
+	}
+	c.confidenceOverrides[checker] = conf
+	return nil
+}
+
 // SetFileInfo sets file-related metadata.
 //
 // Must be called for every source code file being checked.
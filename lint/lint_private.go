@@ -3,7 +3,12 @@ package lint
 import (
 	"fmt"
 	"go/ast"
+	"go/token"
+	"go/types"
+	"path"
 	"reflect"
+	"strconv"
+	"strings"
 
 	"github.com/go-critic/go-critic/lint/internal/astwalk"
 	"github.com/go-toolsmith/astfmt"
@@ -18,6 +23,10 @@ var checkerPrototypes = map[string]checkerProto{}
 type checkerProto struct {
 	rule *Rule
 
+	// doc is a documentation of checkers that are not
+	// documented by "//!" comments, like user-defined ones.
+	doc *CheckerDoc
+
 	// clone performs prototype copy and returns it as *Checker.
 	clone func(context) *Checker
 }
@@ -34,12 +43,32 @@ type abstractChecker interface {
 	Init()
 }
 
+// packageVisitor is implemented by checkers of PackageScope rules.
+//
+// VisitPackage is called with all package files that are checked.
+// Context file is not set during the call, as nodes of any file can be reported.
+type packageVisitor interface {
+	VisitPackage(files []*ast.File)
+}
+
+// programVisitor is implemented by checkers of ProgramScope rules.
+//
+// VisitProgram is called with all packages passed to Checker.CheckProgram.
+// Context package info may describe any package, pkgs types info should be used instead.
+type programVisitor interface {
+	VisitProgram(pkgs []*Package)
+}
+
 type checkerAttribute int
 
 const (
 	attrExperimental checkerAttribute = iota
 	attrSyntaxOnly
 	attrVeryOpinionated
+	attrPerformance
+	attrSharedState
+	attrSkipTests
+	attrEnvDependent
 )
 
 // context is checker-local context copy.
@@ -47,55 +76,283 @@ const (
 type context struct {
 	*Context
 
+	// checkerName is a name of the checker that owns this context.
+	checkerName string
+
+	// file is a currently checked file.
+	file *ast.File
+
+	// fileSuppressions are suppression comments of the file.
+	fileSuppressions *suppressionSet
+
+	// commentMap and directives are built for the file on demand,
+	// see CommentMap and Directives.
+	commentMap ast.CommentMap
+	directives []Directive
+
 	// printer used to format warning text.
 	printer *astfmt.Printer
 
 	warnings []Warning
+
+	// stats are checker stats, nil if they are not collected.
+	stats *CheckerStats
 }
 
-// Warn adds a Warning to checker output.
+// GoVersionAtLeast reports whether checked code targets
+// Go version that is not older than major.minor.
+func (ctx *context) GoVersionAtLeast(major, minor int) bool {
+	return ctx.goVersion.atLeast(goVersion{major: major, minor: minor})
+}
+
+// Param returns checker parameter value that was set by
+// Context.SetCheckerParam or its declared default value.
+//
+// Panics if checker does not declare the parameter.
+func (ctx *context) Param(name string) string {
+	if v, ok := ctx.checkerParams[ctx.checkerName][name]; ok {
+		return v
+	}
+	p := checkerPrototypes[ctx.checkerName].rule.findParam(name)
+	if p == nil {
+		panic(fmt.Sprintf("%s: undeclared param %q", ctx.checkerName, name))
+	}
+	return p.Default
+}
+
+// BoolParam is like Param, but for ParamBool parameters.
+func (ctx *context) BoolParam(name string) bool {
+	// Values are validated by SetCheckerParam.
+	v, _ := strconv.ParseBool(ctx.Param(name))
+	return v
+}
+
+// IntParam is like Param, but for ParamInt parameters.
+func (ctx *context) IntParam(name string) int {
+	// Values are validated by SetCheckerParam.
+	v, _ := strconv.Atoi(ctx.Param(name))
+	return v
+}
+
+// Warn adds a Warning with high confidence to checker output.
 func (ctx *context) Warn(node ast.Node, format string, args ...interface{}) {
-	ctx.warnings = append(ctx.warnings, Warning{
-		Text: ctx.printer.Sprintf(format, args...),
-		Node: node,
+	ctx.WarnWithConfidence(ConfidenceHigh, node, format, args...)
+}
+
+// WarnWithConfidence adds a Warning with specified confidence to checker output.
+func (ctx *context) WarnWithConfidence(conf Confidence, node ast.Node, format string, args ...interface{}) {
+	ctx.addWarning(Warning{
+		Text:       ctx.printer.Sprintf(format, args...),
+		Node:       node,
+		Confidence: conf,
 	})
 }
 
-// addChecker adds checker c to global checkers prototype table.
-// Checker must be a pointer to zero value of concrete checker.
+// WarnWithSeverity adds a Warning with specified severity to checker output.
+// Unlike other Warn methods that use SeverityWarning, it can
+// be used to report advisory or critical issues.
+func (ctx *context) WarnWithSeverity(sev Severity, node ast.Node, format string, args ...interface{}) {
+	ctx.addWarning(Warning{
+		Text:       ctx.printer.Sprintf(format, args...),
+		Node:       node,
+		Severity:   sev,
+		Confidence: ConfidenceHigh,
+	})
+}
+
+// WarnWithLevels adds a Warning with specified severity and confidence
+// to checker output.
+func (ctx *context) WarnWithLevels(sev Severity, conf Confidence, node ast.Node, format string, args ...interface{}) {
+	ctx.addWarning(Warning{
+		Text:       ctx.printer.Sprintf(format, args...),
+		Node:       node,
+		Severity:   sev,
+		Confidence: conf,
+	})
+}
+
+// WarnWithFix adds a Warning with high confidence and a fix to checker output.
+// Nil fix means that the issue can't be fixed automatically.
+func (ctx *context) WarnWithFix(fix []TextEdit, node ast.Node, format string, args ...interface{}) {
+	ctx.addWarning(Warning{
+		Text:       ctx.printer.Sprintf(format, args...),
+		Node:       node,
+		Confidence: ConfidenceHigh,
+		Fix:        fix,
+	})
+}
+
+// WarnWith adds w to checker output, its Text is formatted
+// according to format and args, like for other Warn methods.
+// Zero Severity and Confidence mean SeverityWarning and ConfidenceHigh.
 //
-// Attributes used to fill AttributeSet for the rule inferred from checker.
-func addChecker(c abstractChecker, attrs ...checkerAttribute) {
-	// Clone abstractChecker underlying object.
-	cloneAbstractChecker := func(c abstractChecker) abstractChecker {
-		dynType := reflect.ValueOf(c).Elem().Type()
-		return reflect.New(dynType).Interface().(abstractChecker)
+// It's used by checkers that report several kinds of issues,
+// to set warning Code along with the other Warning fields.
+func (ctx *context) WarnWith(w Warning, format string, args ...interface{}) {
+	w.Text = ctx.printer.Sprintf(format, args...)
+	if w.Confidence == 0 {
+		w.Confidence = ConfidenceHigh
 	}
+	ctx.addWarning(w)
+}
 
-	newFileWalker := func(ctx *context, c abstractChecker) astwalk.FileWalker {
-		// Infer proper AST traversing wrapper (walker).
-		switch v := c.(type) {
-		case astwalk.FuncDeclVisitor:
-			return astwalk.WalkerForFuncDecl(v)
-		case astwalk.ExprVisitor:
-			return astwalk.WalkerForExpr(v)
-		case astwalk.LocalExprVisitor:
-			return astwalk.WalkerForLocalExpr(v)
-		case astwalk.StmtListVisitor:
-			return astwalk.WalkerForStmtList(v)
-		case astwalk.StmtVisitor:
-			return astwalk.WalkerForStmt(v)
-		case astwalk.LocalDefVisitor:
-			return astwalk.WalkerForLocalDef(v, ctx.typesInfo)
-		case astwalk.TypeExprVisitor:
-			return astwalk.WalkerForTypeExpr(v, ctx.typesInfo)
-		case astwalk.LocalCommentVisitor:
-			return astwalk.WalkerForLocalComment(v)
-		default:
-			panic(fmt.Sprintf("%T does not implement known visitor interface", c))
+// addWarning adds w to checker output, unless its confidence or
+// severity is lower than Context minimal level or it's suppressed
+// by a suppression comment.
+// Severity and confidence overrides are applied before the filtering.
+func (ctx *context) addWarning(w Warning) {
+	if w.Severity == 0 {
+		w.Severity = SeverityWarning
+	}
+	if !w.Pos.IsValid() {
+		w.Pos, w.End = w.Node.Pos(), w.Node.End()
+	}
+	if sev, ok := ctx.severityOverrides[ctx.checkerName]; ok {
+		w.Severity = sev
+	}
+	if conf, ok := ctx.confidenceOverrides[ctx.checkerName]; ok {
+		w.Confidence = conf
+	}
+	if w.Confidence < ctx.minConfidence || w.Severity < ctx.minSeverity {
+		return
+	}
+	if w.GoFeature != "" && !ctx.GoFeatureAvailable(w.GoFeature) {
+		return
+	}
+	if ctx.fileSuppressions != nil {
+		line := ctx.fileSet.Position(w.Pos).Line
+		if ctx.fileSuppressions.match(ctx.checkerName, w.Code, line) {
+			return
 		}
 	}
+	ctx.warnings = append(ctx.warnings, w)
+}
+
+// replaceNode returns an edit that replaces node with replacement.
+func (ctx *context) replaceNode(node, replacement ast.Node) TextEdit {
+	return TextEdit{
+		Pos:     node.Pos(),
+		End:     node.End(),
+		NewText: ctx.printer.Sprint(replacement),
+	}
+}
 
+// hasComments reports whether there are comments inside node.
+func (ctx *context) hasComments(node ast.Node) bool {
+	for _, cg := range ctx.file.Comments {
+		if cg.Pos() >= node.Pos() && cg.End() <= node.End() {
+			return true
+		}
+	}
+	return false
+}
+
+// parenIfNeeded returns x wrapped in parenthesis if it's not a primary
+// expression, so it can replace an operand of selector, index or call.
+func parenIfNeeded(x ast.Expr) ast.Expr {
+	switch x.(type) {
+	case *ast.Ident, *ast.BasicLit, *ast.CompositeLit, *ast.FuncLit,
+		*ast.ParenExpr, *ast.SelectorExpr, *ast.IndexExpr, *ast.IndexListExpr,
+		*ast.SliceExpr, *ast.TypeAssertExpr, *ast.CallExpr:
+		return x
+	default:
+		return &ast.ParenExpr{X: x}
+	}
+}
+
+// importEdit returns an edit that adds pkgPath import to the current file.
+// Package is expected to be referenced by its default name at pos.
+//
+// Returns nil edit if package is already imported.
+// Returns false if package default name refers to something else at pos.
+func (ctx *context) importEdit(pkgPath string, pos token.Pos) (*TextEdit, bool) {
+	name := path.Base(pkgPath)
+	if ctx.pkg != nil {
+		if scope := ctx.pkg.Scope().Innermost(pos); scope != nil {
+			if _, obj := scope.LookupParent(name, pos); obj != nil {
+				pkgName, ok := obj.(*types.PkgName)
+				return nil, ok && pkgName.Imported().Path() == pkgPath
+			}
+		}
+	}
+	for _, spec := range ctx.file.Imports {
+		if spec.Path.Value == strconv.Quote(pkgPath) {
+			// Imported, but not by its default name.
+			return nil, false
+		}
+	}
+
+	spec := strconv.Quote(pkgPath)
+	for _, decl := range ctx.file.Decls {
+		decl, ok := decl.(*ast.GenDecl)
+		if !ok || decl.Tok != token.IMPORT {
+			continue
+		}
+		if decl.Lparen.IsValid() {
+			at := decl.Lparen + 1
+			return &TextEdit{Pos: at, End: at, NewText: "\n\t" + spec}, true
+		}
+		return &TextEdit{Pos: decl.End(), End: decl.End(), NewText: "\nimport " + spec}, true
+	}
+	at := ctx.file.Name.End()
+	return &TextEdit{Pos: at, End: at, NewText: "\n\nimport " + spec}, true
+}
+
+// unusedImport returns pkgPath import spec of the current file
+// if the package is referenced only inside node.
+// Such import becomes unused once node is replaced.
+//
+// Returns nil if the package is used outside of node
+// or if it's not imported by a regular import spec.
+func (ctx *context) unusedImport(pkgPath string, node ast.Node) *ast.ImportSpec {
+	var spec *ast.ImportSpec
+	for _, s := range ctx.file.Imports {
+		if s.Path.Value == strconv.Quote(pkgPath) {
+			spec = s
+		}
+	}
+	if spec == nil || (spec.Name != nil && (spec.Name.Name == "_" || spec.Name.Name == ".")) {
+		return nil
+	}
+	for id, obj := range ctx.typesInfo.Uses {
+		pkgName, ok := obj.(*types.PkgName)
+		if !ok || pkgName.Imported().Path() != pkgPath {
+			continue
+		}
+		if id.Pos() < ctx.file.Pos() || id.Pos() > ctx.file.End() {
+			continue // Used in other file
+		}
+		if id.Pos() < node.Pos() || id.End() > node.End() {
+			return nil
+		}
+	}
+	return spec
+}
+
+// deleteImportEdit returns an edit that removes spec from the current file.
+// Import declaration is removed if spec is its only import.
+func (ctx *context) deleteImportEdit(spec *ast.ImportSpec) TextEdit {
+	tf := ctx.fileSet.File(spec.Pos())
+	var from, to token.Pos = spec.Pos(), spec.End()
+	for _, decl := range ctx.file.Decls {
+		decl, ok := decl.(*ast.GenDecl)
+		if ok && decl.Tok == token.IMPORT && !decl.Lparen.IsValid() && decl.Specs[0] == spec {
+			from, to = decl.Pos(), decl.End()
+		}
+	}
+	// Remove whole lines, so there are no blank lines left.
+	from = tf.LineStart(tf.Line(from))
+	if line := tf.Line(to); line < tf.LineCount() {
+		to = tf.LineStart(line + 1)
+	}
+	return TextEdit{Pos: from, End: to}
+}
+
+// addChecker adds checker c to global checkers prototype table.
+// Checker must be a pointer to zero value of concrete checker.
+//
+// Attributes used to fill AttributeSet for the rule inferred from checker.
+func addChecker(c abstractChecker, attrs ...checkerAttribute) {
 	var rule Rule
 	typeName := reflect.ValueOf(c).Type().String()
 	rule.name = typeName[len("*lint.") : len(typeName)-len("Checker")]
@@ -108,22 +365,149 @@ func addChecker(c abstractChecker, attrs ...checkerAttribute) {
 			rule.SyntaxOnly = true
 		case attrVeryOpinionated:
 			rule.VeryOpinionated = true
+		case attrPerformance:
+			rule.Performance = true
+		case attrSharedState:
+			rule.SharedState = true
+		case attrSkipTests:
+			rule.SkipTests = true
+		case attrEnvDependent:
+			rule.EnvDependent = true
 		default:
 			panic(fmt.Sprintf("unexpected checkerAttribute"))
 		}
 	}
+	if _, ok := c.(packageVisitor); ok {
+		rule.PackageScope = true
+	}
+	if _, ok := c.(programVisitor); ok {
+		rule.ProgramScope = true
+	}
+	if c, ok := c.(paramsDeclarer); ok {
+		rule.Params = c.Params()
+		if err := rule.validateParams(); err != nil {
+			panic(err.Error())
+		}
+	}
+	if c, ok := c.(goFeaturesDeclarer); ok {
+		rule.GoFeatures = c.GoFeatures()
+		if err := validateGoFeatures(rule.GoFeatures); err != nil {
+			panic(fmt.Sprintf("%s: %v", rule.name, err))
+		}
+	}
+
+	// Clone abstractChecker underlying object.
+	dynType := reflect.ValueOf(c).Elem().Type()
+	registerChecker(&rule, nil, func() abstractChecker {
+		return reflect.New(dynType).Interface().(abstractChecker)
+	})
+}
 
-	proto := checkerProto{rule: &rule}
+// registerChecker adds rule checker prototype to the global table.
+// newChecker returns a new checker instance that is not bound
+// to any context yet.
+func registerChecker(rule *Rule, doc *CheckerDoc, newChecker func() abstractChecker) {
+	proto := checkerProto{rule: rule, doc: doc}
 	proto.clone = func(ctx context) *Checker {
-		c := cloneAbstractChecker(c)
+		c := newChecker()
+		ctx.checkerName = proto.rule.name
 		clone := &Checker{
 			Rule: proto.rule,
 			ctx:  ctx,
 		}
-		clone.walker = newFileWalker(&clone.ctx, c)
+		if v, ok := c.(packageVisitor); ok {
+			clone.pkgVisitor = v
+		} else if v, ok := c.(programVisitor); ok {
+			clone.progVisitor = v
+		} else {
+			clone.walker = newFileWalker(&clone.ctx, c)
+		}
 		c.BindContext(&clone.ctx)
 		c.Init()
 		return clone
 	}
 	checkerPrototypes[rule.name] = proto
 }
+
+// validateParams checks that declared params defaults are valid.
+func (r *Rule) validateParams() error {
+	for _, p := range r.Params {
+		if err := p.validate(p.Default); err != nil {
+			return fmt.Errorf("%s.%s: invalid default: %v", r.name, p.Name, err)
+		}
+	}
+	return nil
+}
+
+// newFileWalker infers proper AST traversing wrapper (walker)
+// from the visitor interface v implements.
+func newFileWalker(ctx *context, v interface{}) astwalk.FileWalker {
+	if ctx.stats != nil {
+		v = ctx.stats.instrument(v)
+	}
+	switch v := v.(type) {
+	case astwalk.FuncDeclVisitor:
+		return astwalk.WalkerForFuncDecl(v)
+	case astwalk.ExprVisitor:
+		return astwalk.WalkerForExpr(v)
+	case astwalk.LocalExprVisitor:
+		return astwalk.WalkerForLocalExpr(v)
+	case astwalk.StmtListVisitor:
+		return astwalk.WalkerForStmtList(v)
+	case astwalk.StmtVisitor:
+		return astwalk.WalkerForStmt(v)
+	case astwalk.LocalDefVisitor:
+		return astwalk.WalkerForLocalDef(v, ctx.typesInfo)
+	case astwalk.TypeExprVisitor:
+		return astwalk.WalkerForTypeExpr(v, ctx.typesInfo)
+	case astwalk.LocalCommentVisitor:
+		return astwalk.WalkerForLocalComment(v)
+	case astwalk.CommentVisitor:
+		return astwalk.WalkerForComment(v)
+	case astwalk.DocCommentVisitor:
+		return astwalk.WalkerForDocComment(v)
+	case astwalk.DeclVisitor:
+		return astwalk.WalkerForDecl(v)
+	default:
+		panic(fmt.Sprintf("%T does not implement known visitor interface", v))
+	}
+}
+
+// goVersion is a parsed Go language version.
+// Zero value is a special "latest" version.
+type goVersion struct {
+	major int
+	minor int
+}
+
+// parseGoVersion parses "1.21", "go1.21" and "go1.21.3" version forms.
+func parseGoVersion(s string) (goVersion, error) {
+	parts := strings.Split(strings.TrimPrefix(s, "go"), ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		return goVersion{}, fmt.Errorf("invalid Go version %q", s)
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil || major < 1 {
+		return goVersion{}, fmt.Errorf("invalid Go version %q", s)
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil || minor < 0 {
+		return goVersion{}, fmt.Errorf("invalid Go version %q", s)
+	}
+	return goVersion{major: major, minor: minor}, nil
+}
+
+func (v goVersion) isLatest() bool {
+	return v == goVersion{}
+}
+
+// atLeast reports whether v is the same or newer than other.
+func (v goVersion) atLeast(other goVersion) bool {
+	if v.isLatest() {
+		return true
+	}
+	if v.major != other.major {
+		return v.major > other.major
+	}
+	return v.minor >= other.minor
+}
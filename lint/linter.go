@@ -0,0 +1,300 @@
+package lint
+
+import (
+	"bytes"
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Diagnostics is a set of results that Linter reports for a file.
+type Diagnostics struct {
+	// Filename is an absolute name of the checked file.
+	Filename string
+
+	// Results replace all results that were previously reported for the file.
+	// Empty if file has no issues.
+	Results []Result
+}
+
+// Linter is a long-lived linter for editors and language servers.
+//
+// Unlike Runner, that checks already loaded packages once,
+// Linter keeps file contents that differ from the disk (overlays)
+// and re-checks the package of the file every time it's changed.
+// Files which contents and dependencies stay the same are not
+// re-checked, their previous results are reused.
+//
+// Package is a set of files of the same directory with the same
+// package clause, including _test.go files, that match the build context.
+//
+// Linter is safe for concurrent use.
+type Linter struct {
+	// Importer imports dependencies of the checked packages.
+	// Overlays are not visible to it.
+	// If nil, dependencies are imported from source code.
+	Importer types.Importer
+
+	// Publish is called with diagnostics for every file of the
+	// re-checked package. Nothing is published for packages that
+	// have parse or type errors, so previous diagnostics stay.
+	// Calls are made before SetFile or CloseFile return,
+	// so Publish must not call Linter methods.
+	Publish func(d Diagnostics)
+
+	// Workers is a maximum number of checkers that run concurrently.
+	// If not positive, runtime.GOMAXPROCS(0) is used.
+	Workers int
+
+	mu sync.Mutex
+
+	// ctx holds settings of the contexts that are created for every check,
+	// so memory used by the parsed files is released after the check.
+	ctx   *Context
+	rules []*Rule
+	cache *Cache
+
+	// importer is Importer or the source importer, if it's nil.
+	importer types.Importer
+
+	// overlays maps absolute file names to their unsaved contents.
+	overlays map[string][]byte
+
+	// results maps absolute file names to their last published results.
+	results map[string][]Result
+}
+
+// NewLinter returns a linter that runs checkers of rules with ctx settings.
+// Context settings must not be changed after the call.
+//
+// Files are parsed into a new token.FileSet for every check,
+// ctx file set is not used.
+func NewLinter(ctx *Context, rules []*Rule) *Linter {
+	return &Linter{
+		ctx:      ctx,
+		rules:    append([]*Rule(nil), rules...),
+		cache:    NewCache(),
+		overlays: make(map[string][]byte),
+		results:  make(map[string][]Result),
+	}
+}
+
+// SetFile sets filename contents to src, overriding the file on disk,
+// and re-checks its package.
+//
+// Returns an error if package can't be parsed or type-checked,
+// diagnostics are not published for such packages.
+func (l *Linter) SetFile(filename string, src []byte) error {
+	filename, err := filepath.Abs(filename)
+	if err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.overlays[filename] = append([]byte(nil), src...)
+	return l.check(filename)
+}
+
+// CloseFile removes filename overlay and re-checks its package
+// using the file contents from disk.
+// If file doesn't exist on disk, empty diagnostics are published for it.
+func (l *Linter) CloseFile(filename string) error {
+	filename, err := filepath.Abs(filename)
+	if err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.overlays, filename)
+	if _, err := os.Stat(filename); os.IsNotExist(err) {
+		delete(l.results, filename)
+		l.publish(Diagnostics{Filename: filename})
+		return nil
+	}
+	return l.check(filename)
+}
+
+// Results returns the last results published for filename.
+func (l *Linter) Results(filename string) []Result {
+	filename, err := filepath.Abs(filename)
+	if err != nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.results[filename]
+}
+
+// check re-checks the package of filename and publishes its diagnostics.
+func (l *Linter) check(filename string) error {
+	ctx := l.ctx.withFileSet(token.NewFileSet())
+	files, srcs, err := l.parsePackage(ctx.fileSet, filename)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		// File is excluded by build constraints.
+		delete(l.results, filename)
+		l.publish(Diagnostics{Filename: filename})
+		return nil
+	}
+
+	info := &types.Info{
+		Types:      make(map[ast.Expr]types.TypeAndValue),
+		Defs:       make(map[*ast.Ident]types.Object),
+		Uses:       make(map[*ast.Ident]types.Object),
+		Implicits:  make(map[ast.Node]types.Object),
+		Selections: make(map[*ast.SelectorExpr]*types.Selection),
+		Scopes:     make(map[ast.Node]*types.Scope),
+	}
+	if l.importer == nil {
+		l.importer = l.Importer
+		if l.importer == nil {
+			// Imported packages are cached by the importer,
+			// so their files use a separate long-lived file set.
+			l.importer = importer.ForCompiler(token.NewFileSet(), "source", nil)
+		}
+	}
+	conf := types.Config{Importer: l.importer, Sizes: ctx.sizesInfo}
+	pkg, err := conf.Check(files[0].Name.Name, ctx.fileSet, files, info)
+	if err != nil {
+		return err
+	}
+
+	ctx.SetPackageInfo(info, pkg)
+	checkers := make([]*Checker, len(l.rules))
+	for i, rule := range l.rules {
+		checkers[i] = NewChecker(rule, ctx)
+	}
+	runner := Runner{
+		Workers: l.Workers,
+		Cache:   l.cache,
+		Source:  func(f *ast.File) []byte { return srcs[f] },
+	}
+	results, err := runner.Run(checkers, files)
+	if err != nil {
+		return err
+	}
+	byFile := make(map[*ast.File][]Result)
+	for _, r := range results {
+		byFile[r.File] = append(byFile[r.File], r)
+	}
+	for _, f := range files {
+		name := ctx.fileSet.Position(f.Pos()).Filename
+		l.results[name] = byFile[f]
+		l.publish(Diagnostics{Filename: name, Results: byFile[f]})
+	}
+	return nil
+}
+
+// parsePackage parses files of the filename package.
+// Overlays take precedence over the files on disk.
+// The filename file goes first.
+//
+// Returns no files if filename is excluded by build constraints.
+func (l *Linter) parsePackage(fset *token.FileSet, filename string) ([]*ast.File, map[*ast.File][]byte, error) {
+	names, err := l.packageFiles(filepath.Dir(filename))
+	if err != nil {
+		return nil, nil, err
+	}
+	i := sort.SearchStrings(names, filename)
+	if i == len(names) || names[i] != filename {
+		return nil, nil, nil
+	}
+	names = append([]string{filename}, append(names[:i:i], names[i+1:]...)...)
+
+	var pkgName string
+	var files []*ast.File
+	srcs := make(map[*ast.File][]byte)
+	for _, name := range names {
+		src, err := l.readFile(name)
+		if err != nil {
+			return nil, nil, err
+		}
+		f, err := parser.ParseFile(fset, name, src, parser.ParseComments)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(files) == 0 {
+			pkgName = f.Name.Name
+		}
+		if f.Name.Name != pkgName {
+			continue
+		}
+		files = append(files, f)
+		srcs[f] = src
+	}
+	return files, srcs, nil
+}
+
+// packageFiles returns sorted names of Go files of dir,
+// including overlays, that match the default build context.
+func (l *Linter) packageFiles(dir string) ([]string, error) {
+	seen := make(map[string]bool)
+	var names []string
+	add := func(name string) {
+		if !seen[name] && strings.HasSuffix(name, ".go") {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	list, err := ioutil.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, info := range list {
+		if !info.IsDir() {
+			add(filepath.Join(dir, info.Name()))
+		}
+	}
+	for name := range l.overlays {
+		if filepath.Dir(name) == dir {
+			add(name)
+		}
+	}
+	sort.Strings(names)
+
+	ctxt := build.Default
+	ctxt.OpenFile = func(path string) (io.ReadCloser, error) {
+		src, err := l.readFile(path)
+		if err != nil {
+			return nil, err
+		}
+		return ioutil.NopCloser(bytes.NewReader(src)), nil
+	}
+	matched := names[:0]
+	for _, name := range names {
+		ok, err := ctxt.MatchFile(dir, filepath.Base(name))
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			matched = append(matched, name)
+		}
+	}
+	return matched, nil
+}
+
+// readFile returns filename overlay or its contents on disk.
+func (l *Linter) readFile(filename string) ([]byte, error) {
+	if src, ok := l.overlays[filename]; ok {
+		return src, nil
+	}
+	return ioutil.ReadFile(filename)
+}
+
+func (l *Linter) publish(d Diagnostics) {
+	if l.Publish != nil {
+		l.Publish(d)
+	}
+}
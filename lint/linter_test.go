@@ -0,0 +1,100 @@
+package lint
+
+import (
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLinter(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gocritic-linter")
+	if err != nil {
+		t.Fatalf("create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	a := filepath.Join(dir, "a.go")
+	b := filepath.Join(dir, "b.go")
+	c := filepath.Join(dir, "c.go")
+	writeFile := func(filename, src string) {
+		if err := ioutil.WriteFile(filename, []byte(src), 0644); err != nil {
+			t.Fatalf("write file: %v", err)
+		}
+	}
+	writeFile(a, "package p\n\nfunc f(x int) bool { return !!(x == 1) }\n")
+	writeFile(b, "package p\n\nvar _ = f\n")
+	writeFile(filepath.Join(dir, "x_test.go"), "package p_test\n\nvar _ = !!true\n")
+	writeFile(filepath.Join(dir, "ignored.go"), "//go:build ignore\n\npackage p\n\nvar _ = !!true\n")
+
+	ctx := NewContext(token.NewFileSet(), sizes)
+	l := NewLinter(ctx, []*Rule{findRule("boolExprSimplify")})
+	published := make(map[string]int)
+	l.Publish = func(d Diagnostics) {
+		published[filepath.Base(d.Filename)] = len(d.Results)
+	}
+	expectPublished := func(want map[string]int) {
+		t.Helper()
+		if !reflect.DeepEqual(published, want) {
+			t.Errorf("published: have %v, want %v", published, want)
+		}
+		published = make(map[string]int)
+	}
+
+	if err := l.SetFile(b, []byte("package p\n\nvar _ = f\n")); err != nil {
+		t.Fatalf("set b.go: %v", err)
+	}
+	expectPublished(map[string]int{"a.go": 1, "b.go": 0})
+
+	// Package API is the same, so a.go results are reused.
+	hits, _ := l.cache.Stats()
+	if err := l.SetFile(b, []byte("package p\n\nfunc init() { _ = !!f(1) }\n")); err != nil {
+		t.Fatalf("update b.go: %v", err)
+	}
+	expectPublished(map[string]int{"a.go": 1, "b.go": 1})
+	if newHits, _ := l.cache.Stats(); newHits != hits+1 {
+		t.Errorf("cache hits: have %d, want %d", newHits, hits+1)
+	}
+	if results := l.Results(b); len(results) != 1 || results[0].Code != "doubleNegation" {
+		t.Errorf("b.go results: %+v", results)
+	}
+
+	if err := l.SetFile(b, []byte("package p\n\nvar _ = undefined\n")); err == nil {
+		t.Errorf("expected type error for b.go")
+	}
+	expectPublished(map[string]int{})
+
+	if err := l.CloseFile(b); err != nil {
+		t.Fatalf("close b.go: %v", err)
+	}
+	expectPublished(map[string]int{"a.go": 1, "b.go": 0})
+
+	// Overlays may have no file on disk.
+	if err := l.SetFile(c, []byte("package p\n\nvar _ = !!f(2)\n")); err != nil {
+		t.Fatalf("set c.go: %v", err)
+	}
+	expectPublished(map[string]int{"a.go": 1, "b.go": 0, "c.go": 1})
+	if err := l.CloseFile(c); err != nil {
+		t.Fatalf("close c.go: %v", err)
+	}
+	expectPublished(map[string]int{"c.go": 0})
+
+	if err := l.SetFile(filepath.Join(dir, "x_test.go"), []byte("package p_test\n\nvar _ = !!true\n")); err != nil {
+		t.Fatalf("set x_test.go: %v", err)
+	}
+	expectPublished(map[string]int{"x_test.go": 1})
+	if err := l.SetFile(filepath.Join(dir, "ignored.go"), []byte("//go:build ignore\n\npackage p\n\nvar _ = !!true\n")); err != nil {
+		t.Fatalf("set ignored.go: %v", err)
+	}
+	expectPublished(map[string]int{"ignored.go": 0})
+
+	// Every check uses its own file set and suppressions.
+	if ctx.fileSet.Base() != token.NewFileSet().Base() {
+		t.Errorf("files were added to the linter context file set")
+	}
+	if len(ctx.suppressions) != 0 {
+		t.Errorf("suppressions were collected in the linter context")
+	}
+}
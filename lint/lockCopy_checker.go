@@ -0,0 +1,211 @@
+package lint
+
+//! Detects copies of values that contain sync package locks.
+//
+// Reports value receivers and params, assignments, function
+// arguments and range value copies of values whose type contains
+// sync.Mutex, sync.RWMutex, sync.WaitGroup, sync.Once or sync.Cond,
+// directly or inside nested struct fields and arrays.
+// Copied lock is not shared with the original value, so it doesn't
+// protect anything.
+//
+// @Before:
+// func (c Counter) Inc() {
+// 	c.mu.Lock()
+// 	c.n++
+// 	c.mu.Unlock()
+// }
+//
+// @After:
+// func (c *Counter) Inc() {
+// 	c.mu.Lock()
+// 	c.n++
+// 	c.mu.Unlock()
+// }
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+func init() {
+	addChecker(&lockCopyChecker{}, attrExperimental)
+}
+
+type lockCopyChecker struct {
+	checkerBase
+
+	// locks maps already inspected types to the lock types they contain.
+	// Empty string means that type contains no locks.
+	locks map[types.Type]string
+}
+
+func (c *lockCopyChecker) Init() {
+	c.locks = make(map[types.Type]string)
+}
+
+func (c *lockCopyChecker) VisitFuncDecl(decl *ast.FuncDecl) {
+	if decl.Recv != nil {
+		for _, field := range decl.Recv.List {
+			if lock := c.lockOf(c.ctx.typesInfo.TypeOf(field.Type)); lock != "" {
+				c.warnRecv(field.Type, lock)
+			}
+		}
+	}
+	c.checkParams(decl.Type)
+	if decl.Body == nil {
+		return
+	}
+
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			c.checkParams(n.Type)
+		case *ast.AssignStmt:
+			if len(n.Lhs) == len(n.Rhs) {
+				for i, rhs := range n.Rhs {
+					c.checkCopy(n.Lhs[i], rhs)
+				}
+			}
+		case *ast.ValueSpec:
+			if len(n.Names) == len(n.Values) {
+				for i, value := range n.Values {
+					c.checkCopy(n.Names[i], value)
+				}
+			}
+		case *ast.CallExpr:
+			c.checkArgs(n)
+		case *ast.RangeStmt:
+			c.checkRange(n)
+		}
+		return true
+	})
+}
+
+func (c *lockCopyChecker) checkParams(typ *ast.FuncType) {
+	for _, field := range typ.Params.List {
+		if lock := c.lockOf(c.ctx.typesInfo.TypeOf(field.Type)); lock != "" {
+			c.warnParam(field.Type, lock)
+		}
+	}
+}
+
+func (c *lockCopyChecker) checkCopy(dst, src ast.Expr) {
+	if id, ok := dst.(*ast.Ident); ok && id.Name == "_" {
+		return
+	}
+	if !c.isVarExpr(src) {
+		return
+	}
+	if lock := c.lockOf(c.ctx.typesInfo.TypeOf(src)); lock != "" {
+		c.warnAssign(src, dst, lock)
+	}
+}
+
+func (c *lockCopyChecker) checkArgs(call *ast.CallExpr) {
+	if tv := c.ctx.typesInfo.Types[call.Fun]; tv.IsType() || tv.IsBuiltin() {
+		return
+	}
+	for _, arg := range call.Args {
+		if !c.isVarExpr(arg) {
+			continue
+		}
+		if lock := c.lockOf(c.ctx.typesInfo.TypeOf(arg)); lock != "" {
+			c.warnArg(arg, lock)
+		}
+	}
+}
+
+func (c *lockCopyChecker) checkRange(rng *ast.RangeStmt) {
+	if rng.Value == nil {
+		return
+	}
+	if id, ok := rng.Value.(*ast.Ident); ok && id.Name == "_" {
+		return
+	}
+	if lock := c.lockOf(c.ctx.typesInfo.TypeOf(rng.Value)); lock != "" {
+		c.warnRange(rng.Value, lock)
+	}
+}
+
+// isVarExpr reports whether x refers to an existing variable,
+// so using it as a value makes a copy.
+// Composite literals and function call results are new values.
+func (c *lockCopyChecker) isVarExpr(x ast.Expr) bool {
+	switch x := x.(type) {
+	case *ast.ParenExpr:
+		return c.isVarExpr(x.X)
+	case *ast.Ident:
+		_, ok := c.ctx.typesInfo.ObjectOf(x).(*types.Var)
+		return ok
+	case *ast.SelectorExpr:
+		_, ok := c.ctx.typesInfo.ObjectOf(x.Sel).(*types.Var)
+		return ok
+	case *ast.IndexExpr:
+		return true
+	case *ast.StarExpr:
+		return true
+	default:
+		return false
+	}
+}
+
+// lockOf returns a name of the lock type that typ contains,
+// or empty string if there is no such lock.
+func (c *lockCopyChecker) lockOf(typ types.Type) string {
+	if typ == nil {
+		return ""
+	}
+	if lock, ok := c.locks[typ]; ok {
+		return lock
+	}
+	c.locks[typ] = "" // Guard against recursive types
+	lock := c.findLock(typ)
+	c.locks[typ] = lock
+	return lock
+}
+
+func (c *lockCopyChecker) findLock(typ types.Type) string {
+	if named, ok := typ.(*types.Named); ok {
+		obj := named.Obj()
+		if obj.Pkg() != nil && obj.Pkg().Path() == "sync" {
+			switch obj.Name() {
+			case "Mutex", "RWMutex", "WaitGroup", "Once", "Cond":
+				return "sync." + obj.Name()
+			}
+		}
+	}
+	switch typ := typ.Underlying().(type) {
+	case *types.Struct:
+		for i := 0; i < typ.NumFields(); i++ {
+			if lock := c.lockOf(typ.Field(i).Type()); lock != "" {
+				return lock
+			}
+		}
+	case *types.Array:
+		if typ.Len() != 0 {
+			return c.lockOf(typ.Elem())
+		}
+	}
+	return ""
+}
+
+func (c *lockCopyChecker) warnRecv(cause ast.Expr, lock string) {
+	c.ctx.WarnWith(Warning{Node: cause, Code: "receiver"}, "receiver copies a value containing %s; consider pointer receiver *%s", lock, cause)
+}
+
+func (c *lockCopyChecker) warnParam(cause ast.Expr, lock string) {
+	c.ctx.WarnWith(Warning{Node: cause, Code: "param"}, "param copies a value containing %s; consider passing *%s", lock, cause)
+}
+
+func (c *lockCopyChecker) warnAssign(cause, dst ast.Expr, lock string) {
+	c.ctx.WarnWith(Warning{Node: cause, Code: "assign"}, "assignment to %s copies a value containing %s", dst, lock)
+}
+
+func (c *lockCopyChecker) warnArg(cause ast.Expr, lock string) {
+	c.ctx.WarnWith(Warning{Node: cause, Code: "callArg"}, "call argument %s copies a value containing %s; consider passing &%s", cause, lock, cause)
+}
+
+func (c *lockCopyChecker) warnRange(cause ast.Expr, lock string) {
+	c.ctx.WarnWith(Warning{Node: cause, Code: "rangeVar"}, "range var %s copies a value containing %s; iterate over indexes instead", cause, lock)
+}
@@ -0,0 +1,266 @@
+package lint
+
+//! Detects loops that check slice membership and can use slices.Contains.
+//
+// Only reported for Go 1.21 and newer, where slices package is available.
+// Fix is suggested if the flag is initialized right before the loop
+// or if the loop is followed by `return false`.
+//
+// @Before:
+// found := false
+// for _, v := range list {
+// 	if v == target {
+// 		found = true
+// 		break
+// 	}
+// }
+//
+// @After:
+// found := slices.Contains(list, target)
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+func init() {
+	addChecker(&manualContainsChecker{}, attrExperimental)
+}
+
+type manualContainsChecker struct {
+	checkerBase
+}
+
+func (c *manualContainsChecker) GoFeatures() []GoFeature {
+	return []GoFeature{GoFeatureSlices}
+}
+
+func (c *manualContainsChecker) VisitStmtList(list []ast.Stmt) {
+	for i, stmt := range list {
+		loop, ok := stmt.(*ast.RangeStmt)
+		if !ok {
+			continue
+		}
+		target, body := c.matchLoop(loop)
+		if body == nil {
+			continue
+		}
+		var prev, next ast.Stmt
+		if i > 0 {
+			prev = list[i-1]
+		}
+		if i+1 < len(list) {
+			next = list[i+1]
+		}
+
+		switch {
+		case len(body) == 2:
+			// found = true; break
+			found := c.matchFlagSet(body)
+			if found == nil {
+				continue
+			}
+			c.warn(loop, c.flagFix(loop, target, found, prev))
+		case len(body) == 1:
+			// return true
+			if !c.isReturn(body[0], "true") {
+				continue
+			}
+			c.warn(loop, c.returnFix(loop, target, next))
+		}
+	}
+}
+
+// matchLoop matches `for _, v := range s { if v == target { body } }`.
+// Returns target and body, or nil body if loop is not matched.
+func (c *manualContainsChecker) matchLoop(loop *ast.RangeStmt) (ast.Expr, []ast.Stmt) {
+	if loop.Tok != token.DEFINE || len(loop.Body.List) != 1 {
+		return nil, nil
+	}
+	if key, ok := loop.Key.(*ast.Ident); !ok || key.Name != "_" {
+		return nil, nil
+	}
+	elem, ok := loop.Value.(*ast.Ident)
+	if !ok {
+		return nil, nil
+	}
+	ifstmt, ok := loop.Body.List[0].(*ast.IfStmt)
+	if !ok || ifstmt.Init != nil || ifstmt.Else != nil {
+		return nil, nil
+	}
+	cmp, ok := ifstmt.Cond.(*ast.BinaryExpr)
+	if !ok || cmp.Op != token.EQL {
+		return nil, nil
+	}
+	var target ast.Expr
+	switch {
+	case c.isIdent(cmp.X, elem):
+		target = cmp.Y
+	case c.isIdent(cmp.Y, elem):
+		target = cmp.X
+	default:
+		return nil, nil
+	}
+	if !c.isLoopInvariant(target, elem) || !c.isComparableSlice(loop.X, target) {
+		return nil, nil
+	}
+	return target, ifstmt.Body.List
+}
+
+// isComparableSlice reports whether slices.Contains can be
+// used to look for target inside x.
+func (c *manualContainsChecker) isComparableSlice(x, target ast.Expr) bool {
+	typ := c.ctx.typesInfo.TypeOf(x)
+	targetType := c.ctx.typesInfo.TypeOf(target)
+	if typ == nil || targetType == nil {
+		return false
+	}
+	slice, ok := typ.Underlying().(*types.Slice)
+	if !ok {
+		return false
+	}
+	return types.Comparable(slice.Elem()) && types.AssignableTo(targetType, slice.Elem())
+}
+
+// isLoopInvariant reports whether target is a simple expression
+// that can be evaluated only once, before the loop.
+func (c *manualContainsChecker) isLoopInvariant(target ast.Expr, elem *ast.Ident) bool {
+	switch x := target.(type) {
+	case *ast.BasicLit:
+		return true
+	case *ast.Ident:
+		return !c.isIdent(x, elem)
+	case *ast.SelectorExpr:
+		return c.isLoopInvariant(x.X, elem)
+	default:
+		return false
+	}
+}
+
+// matchFlagSet matches `found = true; break` statements.
+// Returns found variable or nil if statements are not matched.
+func (c *manualContainsChecker) matchFlagSet(body []ast.Stmt) *ast.Ident {
+	assign, ok := body[0].(*ast.AssignStmt)
+	if !ok || assign.Tok != token.ASSIGN || len(assign.Lhs) != 1 || !c.isTrue(assign.Rhs[0]) {
+		return nil
+	}
+	found, ok := assign.Lhs[0].(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	br, ok := body[1].(*ast.BranchStmt)
+	if !ok || br.Tok != token.BREAK || br.Label != nil {
+		return nil
+	}
+	return found
+}
+
+// flagFix returns a fix for the flag-setting loop.
+// Fix is only possible when loop is preceded by the flag initialization.
+func (c *manualContainsChecker) flagFix(loop *ast.RangeStmt, target ast.Expr, found *ast.Ident, prev ast.Stmt) []TextEdit {
+	tok := token.ILLEGAL
+	switch prev := prev.(type) {
+	case *ast.AssignStmt:
+		if len(prev.Lhs) == 1 && len(prev.Rhs) == 1 && c.isIdent(prev.Lhs[0], found) && c.isFalse(prev.Rhs[0]) {
+			tok = prev.Tok
+		}
+	case *ast.DeclStmt:
+		decl := prev.Decl.(*ast.GenDecl)
+		if decl.Tok != token.VAR || len(decl.Specs) != 1 {
+			break
+		}
+		spec := decl.Specs[0].(*ast.ValueSpec)
+		if len(spec.Names) != 1 || spec.Names[0].Name != found.Name {
+			break
+		}
+		switch {
+		case len(spec.Values) == 0 && c.isBool(spec.Names[0]):
+			tok = token.DEFINE
+		case len(spec.Values) == 1 && c.isFalse(spec.Values[0]):
+			tok = token.DEFINE
+		}
+	}
+	if tok == token.ILLEGAL {
+		return nil
+	}
+
+	replacement := found.Name + " " + tok.String() + " " + c.containsCall(loop, target)
+	return c.fix(prev, loop, replacement)
+}
+
+// returnFix returns a fix for the loop that returns true.
+// Fix is only possible when loop is followed by `return false`.
+func (c *manualContainsChecker) returnFix(loop *ast.RangeStmt, target ast.Expr, next ast.Stmt) []TextEdit {
+	if !c.isReturn(next, "false") {
+		return nil
+	}
+	return c.fix(loop, next, "return "+c.containsCall(loop, target))
+}
+
+// fix returns edits that replace [from, to] statements with replacement
+// and add slices package import if required.
+func (c *manualContainsChecker) fix(from, to ast.Stmt, replacement string) []TextEdit {
+	importEdit, ok := c.ctx.importEdit("slices", from.Pos())
+	if !ok {
+		return nil
+	}
+	var edits []TextEdit
+	if importEdit != nil {
+		edits = append(edits, *importEdit)
+	}
+	return append(edits, TextEdit{
+		Pos:     from.Pos(),
+		End:     to.End(),
+		NewText: replacement,
+	})
+}
+
+// containsCall returns slices.Contains call source text.
+func (c *manualContainsChecker) containsCall(loop *ast.RangeStmt, target ast.Expr) string {
+	return c.ctx.printer.Sprintf("slices.Contains(%s, %s)", loop.X, target)
+}
+
+// isReturn reports whether stmt returns a single value bool constant.
+func (c *manualContainsChecker) isReturn(stmt ast.Stmt, value string) bool {
+	ret, ok := stmt.(*ast.ReturnStmt)
+	return ok && len(ret.Results) == 1 && c.isBoolConst(ret.Results[0], value)
+}
+
+func (c *manualContainsChecker) isTrue(x ast.Expr) bool {
+	return c.isBoolConst(x, "true")
+}
+
+func (c *manualContainsChecker) isFalse(x ast.Expr) bool {
+	return c.isBoolConst(x, "false")
+}
+
+// isBoolConst reports whether x is a predeclared name bool constant.
+func (c *manualContainsChecker) isBoolConst(x ast.Expr, name string) bool {
+	id, ok := x.(*ast.Ident)
+	if !ok || id.Name != name {
+		return false
+	}
+	obj, ok := c.ctx.typesInfo.ObjectOf(id).(*types.Const)
+	return ok && obj.Pkg() == nil
+}
+
+func (c *manualContainsChecker) isBool(x ast.Expr) bool {
+	typ, ok := c.ctx.typesInfo.TypeOf(x).(*types.Basic)
+	return ok && typ.Kind() == types.Bool
+}
+
+// isIdent reports whether x is an identifier that refers
+// to the same object as id.
+func (c *manualContainsChecker) isIdent(x ast.Expr, id *ast.Ident) bool {
+	x2, ok := x.(*ast.Ident)
+	if !ok || x2.Name != id.Name {
+		return false
+	}
+	obj := c.ctx.typesInfo.ObjectOf(id)
+	return obj == nil || obj == c.ctx.typesInfo.ObjectOf(x2)
+}
+
+func (c *manualContainsChecker) warn(cause ast.Node, fix []TextEdit) {
+	c.ctx.WarnWithFix(fix, cause, "this membership loop can use slices.Contains")
+}
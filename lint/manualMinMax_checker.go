@@ -0,0 +1,149 @@
+package lint
+
+//! Detects if-else statements that can be replaced with min/max builtin calls.
+//
+// Only reported for Go 1.21 and newer, where min and max builtins are available.
+// Float operands are reported with low confidence and without a fix,
+// as min and max propagate NaN values, unlike the comparison.
+//
+// @Before:
+// if a < b {
+// 	m = a
+// } else {
+// 	m = b
+// }
+//
+// @After:
+// m = min(a, b)
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/go-toolsmith/astequal"
+)
+
+func init() {
+	addChecker(&manualMinMaxChecker{}, attrExperimental)
+}
+
+type manualMinMaxChecker struct {
+	checkerBase
+}
+
+func (c *manualMinMaxChecker) GoFeatures() []GoFeature {
+	return []GoFeature{GoFeatureMinMax}
+}
+
+func (c *manualMinMaxChecker) VisitStmt(stmt ast.Stmt) {
+	ifstmt, ok := stmt.(*ast.IfStmt)
+	if !ok || ifstmt.Init != nil {
+		return
+	}
+	cond, ok := ifstmt.Cond.(*ast.BinaryExpr)
+	if !ok {
+		return
+	}
+	elseBody, ok := ifstmt.Else.(*ast.BlockStmt)
+	if !ok {
+		return
+	}
+	assign1 := c.singleAssign(ifstmt.Body)
+	assign2 := c.singleAssign(elseBody)
+	if assign1 == nil || assign2 == nil || !astequal.Expr(assign1.Lhs[0], assign2.Lhs[0]) {
+		return
+	}
+
+	// "Less" is true when then-branch assigns a value
+	// that is less than the one that is assigned in else-branch.
+	var less bool
+	switch cond.Op {
+	case token.LSS, token.LEQ:
+		less = true
+	case token.GTR, token.GEQ:
+		less = false
+	default:
+		return
+	}
+	x, y := assign1.Rhs[0], assign2.Rhs[0]
+	switch {
+	case astequal.Expr(x, cond.X) && astequal.Expr(y, cond.Y):
+		// Then-branch assigns LHS operand.
+	case astequal.Expr(x, cond.Y) && astequal.Expr(y, cond.X):
+		// Then-branch assigns RHS operand.
+		less = !less
+	default:
+		return
+	}
+
+	name := "max"
+	if less {
+		name = "min"
+	}
+	if c.isBuiltin(ifstmt, name) {
+		c.warn(ifstmt, assign1.Lhs[0], name, cond)
+	}
+}
+
+// singleAssign returns "x = y" assignment that is the only b statement.
+// Returns nil if there is no such assignment.
+func (c *manualMinMaxChecker) singleAssign(b *ast.BlockStmt) *ast.AssignStmt {
+	if len(b.List) != 1 {
+		return nil
+	}
+	assign, ok := b.List[0].(*ast.AssignStmt)
+	if !ok || assign.Tok != token.ASSIGN || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+		return nil
+	}
+	return assign
+}
+
+// isBuiltin reports whether name refers to predeclared identifier
+// at the position of the stmt.
+func (c *manualMinMaxChecker) isBuiltin(stmt ast.Stmt, name string) bool {
+	if c.ctx.pkg == nil {
+		// No types info, assume builtin is not shadowed.
+		return true
+	}
+	scope := c.ctx.pkg.Scope().Innermost(stmt.Pos())
+	if scope == nil {
+		return true
+	}
+	_, obj := scope.LookupParent(name, stmt.Pos())
+	_, ok := obj.(*types.Builtin)
+	return ok
+}
+
+// isFloat reports whether x may hold a NaN value.
+func (c *manualMinMaxChecker) isFloat(x ast.Expr) bool {
+	switch typ := c.ctx.typesInfo.TypeOf(x).(type) {
+	case *types.TypeParam:
+		return typeSetHasFloat(typ.Constraint())
+	case nil:
+		return false
+	default:
+		basic, ok := typ.Underlying().(*types.Basic)
+		return ok && basic.Info()&types.IsFloat != 0
+	}
+}
+
+func (c *manualMinMaxChecker) warn(cause *ast.IfStmt, lhs ast.Expr, name string, cond *ast.BinaryExpr) {
+	if c.isFloat(cond.X) || c.isFloat(cond.Y) {
+		c.ctx.WarnWithConfidence(ConfidenceLow, cause, "this min/max pattern can use the builtin min/max")
+		return
+	}
+	var fix []TextEdit
+	// Operands are evaluated twice by the if statement and once by the call.
+	if isSafeExpr(cond) && !c.ctx.hasComments(cause) {
+		fix = []TextEdit{c.ctx.replaceNode(cause, &ast.AssignStmt{
+			Lhs: []ast.Expr{lhs},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{&ast.CallExpr{
+				Fun:  ast.NewIdent(name),
+				Args: []ast.Expr{cond.X, cond.Y},
+			}},
+		})}
+	}
+	c.ctx.WarnWithFix(fix, cause, "this min/max pattern can use the builtin min/max")
+}
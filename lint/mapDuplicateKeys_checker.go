@@ -0,0 +1,90 @@
+package lint
+
+//! Detects duplicated keys in map literals.
+//
+// Duplicated constant keys are rejected by the compiler,
+// so only non-constant keys without side effects are reported,
+// like variables, field selectors and composite literals.
+//
+// @Before:
+// m := map[string]int{
+// 	p.name: 1,
+// 	p.name: 2,
+// }
+//
+// @After:
+// m := map[string]int{
+// 	p.name:  1,
+// 	p2.name: 2,
+// }
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/go-critic/go-critic/lint/internal/lintutil"
+)
+
+func init() {
+	addChecker(&mapDuplicateKeysChecker{}, attrExperimental)
+}
+
+type mapDuplicateKeysChecker struct {
+	checkerBase
+
+	keys lintutil.AstSet
+}
+
+func (c *mapDuplicateKeysChecker) VisitExpr(expr ast.Expr) {
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok || !c.isMap(lit) {
+		return
+	}
+	c.keys.Clear()
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok || c.ctx.typesInfo.Types[kv.Key].Value != nil || !c.isPure(kv.Key) {
+			continue
+		}
+		key := kv.Key
+		if lit, ok := key.(*ast.CompositeLit); ok {
+			// Key type can be elided, compare elements only.
+			key = &ast.CompositeLit{Elts: lit.Elts}
+		}
+		if !c.keys.Insert(key) {
+			c.warn(kv.Key)
+		}
+	}
+}
+
+func (c *mapDuplicateKeysChecker) isMap(lit *ast.CompositeLit) bool {
+	typ := c.ctx.typesInfo.TypeOf(lit)
+	if typ == nil {
+		return false
+	}
+	_, ok := typ.Underlying().(*types.Map)
+	return ok
+}
+
+// isPure reports whether x evaluation has no side effects.
+// Unlike isSafeExpr, composite literals are permitted.
+func (c *mapDuplicateKeysChecker) isPure(x ast.Expr) bool {
+	lit, ok := x.(*ast.CompositeLit)
+	if !ok {
+		return isSafeExpr(x)
+	}
+	for _, elt := range lit.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			if !c.isPure(kv.Key) || !c.isPure(kv.Value) {
+				return false
+			}
+		} else if !c.isPure(elt) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *mapDuplicateKeysChecker) warn(cause ast.Expr) {
+	c.ctx.Warn(cause, "suspicious duplicate %s key in map literal", cause)
+}
@@ -0,0 +1,162 @@
+package lint
+
+//! Detects functions that promise ordered results built from map iteration.
+//
+// Reports map keys or values appended to a slice that is
+// returned unsorted by a function named Sorted* or Ordered*.
+//
+// @Before:
+// func SortedKeys(m map[string]int) []string {
+// 	var keys []string
+// 	for k := range m {
+// 		keys = append(keys, k)
+// 	}
+// 	return keys
+// }
+//
+// @After:
+// func SortedKeys(m map[string]int) []string {
+// 	var keys []string
+// 	for k := range m {
+// 		keys = append(keys, k)
+// 	}
+// 	sort.Strings(keys)
+// 	return keys
+// }
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+)
+
+func init() {
+	addChecker(&mapOrderDependenceChecker{}, attrExperimental)
+}
+
+type mapOrderDependenceChecker struct {
+	checkerBase
+}
+
+func (c *mapOrderDependenceChecker) VisitFuncDecl(decl *ast.FuncDecl) {
+	if decl.Body == nil || !c.promisesOrder(decl.Name.Name) {
+		return
+	}
+
+	// Slices that are filled inside map range loops.
+	filled := make(map[types.Object]*ast.RangeStmt)
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.RangeStmt:
+			if c.isMap(n.X) {
+				c.collectAppends(n, filled)
+			}
+		}
+		return true
+	})
+	if len(filled) == 0 {
+		return
+	}
+
+	// Slices that are passed to any function, like sort.Strings,
+	// are considered to be sorted.
+	returned := make(map[types.Object]bool)
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.CallExpr:
+			if c.ctx.isBuiltinCall(n, "append") {
+				break
+			}
+			for _, arg := range n.Args {
+				delete(filled, c.objectOf(arg))
+			}
+		case *ast.ReturnStmt:
+			for _, result := range n.Results {
+				if obj := c.objectOf(result); obj != nil {
+					returned[obj] = true
+				}
+			}
+			if len(n.Results) == 0 && decl.Type.Results != nil {
+				for _, field := range decl.Type.Results.List {
+					for _, name := range field.Names {
+						returned[c.ctx.typesInfo.ObjectOf(name)] = true
+					}
+				}
+			}
+		}
+		return true
+	})
+
+	for obj, loop := range filled {
+		if returned[obj] {
+			c.warn(loop)
+		}
+	}
+}
+
+// promisesOrder reports whether function name implies ordered results.
+func (c *mapOrderDependenceChecker) promisesOrder(name string) bool {
+	for _, prefix := range []string{"sorted", "ordered"} {
+		if len(name) >= len(prefix) && strings.EqualFold(name[:len(prefix)], prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// collectAppends finds `out = append(out, k)` statements inside loop
+// body, where k is a loop key or value, and adds out to filled.
+func (c *mapOrderDependenceChecker) collectAppends(loop *ast.RangeStmt, filled map[types.Object]*ast.RangeStmt) {
+	isLoopVar := func(x ast.Expr) bool {
+		obj := c.objectOf(x)
+		return obj != nil && (obj == c.objectOf(loop.Key) || obj == c.objectOf(loop.Value))
+	}
+	for _, stmt := range loop.Body.List {
+		assign, ok := stmt.(*ast.AssignStmt)
+		if !ok || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+			continue
+		}
+		call, ok := assign.Rhs[0].(*ast.CallExpr)
+		if !ok || len(call.Args) < 2 || call.Ellipsis.IsValid() || !c.ctx.isBuiltinCall(call, "append") {
+			continue
+		}
+		out := c.objectOf(assign.Lhs[0])
+		if out == nil || out != c.objectOf(call.Args[0]) {
+			continue
+		}
+		for _, arg := range call.Args[1:] {
+			if isLoopVar(arg) {
+				filled[out] = loop
+				break
+			}
+		}
+	}
+}
+
+func (c *mapOrderDependenceChecker) isMap(x ast.Expr) bool {
+	typ := c.ctx.typesInfo.TypeOf(x)
+	if typ == nil {
+		return false
+	}
+	_, ok := typ.Underlying().(*types.Map)
+	return ok
+}
+
+// objectOf returns an object x identifier refers to.
+// Returns nil if x is not an identifier.
+func (c *mapOrderDependenceChecker) objectOf(x ast.Expr) types.Object {
+	id, ok := x.(*ast.Ident)
+	if !ok || id.Name == "_" {
+		return nil
+	}
+	return c.ctx.typesInfo.ObjectOf(id)
+}
+
+func (c *mapOrderDependenceChecker) warn(cause ast.Node) {
+	c.ctx.WarnWithConfidence(ConfidenceMedium, cause,
+		"map iteration order is non-deterministic; sort the keys if order matters")
+}
@@ -0,0 +1,161 @@
+package lint
+
+//! Detects exported declarations without doc comments.
+//
+// Declarations inside documented const and var groups are
+// considered documented. Methods of unexported types are not checked.
+//
+// Checker params:
+//	checkPrefix - if "true", doc comment must start with the declared name
+//
+// @Before:
+// func Parse(s string) (*Config, error)
+//
+// @After:
+// // Parse returns config described by s.
+// func Parse(s string) (*Config, error)
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+)
+
+func init() {
+	addChecker(&missingExportedDocChecker{}, attrExperimental, attrSyntaxOnly, attrSkipTests)
+}
+
+type missingExportedDocChecker struct {
+	checkerBase
+
+	checkPrefix bool
+}
+
+func (c *missingExportedDocChecker) Params() []CheckerParam {
+	return []CheckerParam{
+		{Name: "checkPrefix", Kind: ParamBool, Default: "false"},
+	}
+}
+
+func (c *missingExportedDocChecker) Init() {
+	c.checkPrefix = c.ctx.BoolParam("checkPrefix")
+}
+
+func (c *missingExportedDocChecker) VisitDecl(decl ast.Decl) {
+	switch decl := decl.(type) {
+	case *ast.FuncDecl:
+		c.checkFunc(decl)
+	case *ast.GenDecl:
+		c.checkGen(decl)
+	}
+}
+
+func (c *missingExportedDocChecker) checkFunc(decl *ast.FuncDecl) {
+	if !decl.Name.IsExported() {
+		return
+	}
+	if decl.Recv == nil {
+		c.checkDoc(decl.Name, decl.Doc, "func", decl.Name.Name)
+		return
+	}
+	if len(decl.Recv.List) == 0 {
+		return
+	}
+	recv := c.recvTypeName(decl.Recv.List[0].Type)
+	if recv == nil || !recv.IsExported() {
+		return
+	}
+	c.checkDoc(decl.Name, decl.Doc, "method", recv.Name+"."+decl.Name.Name)
+}
+
+func (c *missingExportedDocChecker) checkGen(decl *ast.GenDecl) {
+	for _, spec := range decl.Specs {
+		switch spec := spec.(type) {
+		case *ast.TypeSpec:
+			if !spec.Name.IsExported() {
+				continue
+			}
+			doc := spec.Doc
+			if doc == nil && !decl.Lparen.IsValid() {
+				doc = decl.Doc
+			}
+			c.checkDoc(spec.Name, doc, "type", spec.Name.Name)
+		case *ast.ValueSpec:
+			name := c.firstExported(spec.Names)
+			if name == nil {
+				continue
+			}
+			kind := "var"
+			if decl.Tok == token.CONST {
+				kind = "const"
+			}
+			switch {
+			case spec.Doc != nil:
+				c.checkDoc(name, spec.Doc, kind, name.Name)
+			case decl.Lparen.IsValid() && decl.Doc != nil:
+				// Group doc comment describes all group members.
+			default:
+				c.checkDoc(name, decl.Doc, kind, name.Name)
+			}
+		}
+	}
+}
+
+// checkDoc reports id declaration of the specified kind
+// if doc is missing or doesn't start with name.
+func (c *missingExportedDocChecker) checkDoc(id *ast.Ident, doc *ast.CommentGroup, kind, name string) {
+	if doc == nil {
+		c.warnMissing(id, kind, name)
+		return
+	}
+	if !c.checkPrefix {
+		return
+	}
+	text := doc.Text()
+	for _, article := range []string{"A ", "An ", "The "} {
+		if kind == "type" && strings.HasPrefix(text, article) {
+			text = text[len(article):]
+			break
+		}
+	}
+	if !strings.HasPrefix(text, id.Name+" ") && strings.TrimSpace(text) != id.Name {
+		c.warnPrefix(id, kind, name, id.Name)
+	}
+}
+
+// recvTypeName returns method receiver type name.
+func (c *missingExportedDocChecker) recvTypeName(x ast.Expr) *ast.Ident {
+	for {
+		switch y := x.(type) {
+		case *ast.StarExpr:
+			x = y.X
+		case *ast.ParenExpr:
+			x = y.X
+		case *ast.IndexExpr:
+			x = y.X
+		case *ast.IndexListExpr:
+			x = y.X
+		case *ast.Ident:
+			return y
+		default:
+			return nil
+		}
+	}
+}
+
+func (c *missingExportedDocChecker) firstExported(names []*ast.Ident) *ast.Ident {
+	for _, name := range names {
+		if name.IsExported() {
+			return name
+		}
+	}
+	return nil
+}
+
+func (c *missingExportedDocChecker) warnMissing(cause ast.Node, kind, name string) {
+	c.ctx.Warn(cause, "exported %s %s should have a doc comment", kind, name)
+}
+
+func (c *missingExportedDocChecker) warnPrefix(cause ast.Node, kind, name, prefix string) {
+	c.ctx.Warn(cause, "comment on exported %s %s should be of the form \"%s ...\"", kind, name, prefix)
+}
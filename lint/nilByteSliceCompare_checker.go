@@ -0,0 +1,162 @@
+package lint
+
+//! Detects []byte nil checks that are likely meant to be emptiness checks.
+//
+// Non-nil empty slice is not equal to nil, while
+// reflect.DeepEqual with []byte{} is false for nil slice.
+// Nil checks combined with len checks are not reported.
+//
+// @Before:
+// if b == nil {
+// 	return errEmpty
+// }
+// if reflect.DeepEqual(b, []byte{}) {
+// 	return errEmpty
+// }
+//
+// @After:
+// if len(b) == 0 {
+// 	return errEmpty
+// }
+// if len(b) == 0 {
+// 	return errEmpty
+// }
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/go-toolsmith/astequal"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+func init() {
+	addChecker(&nilByteSliceCompareChecker{}, attrExperimental)
+}
+
+type nilByteSliceCompareChecker struct {
+	checkerBase
+
+	// checked is a set of nil checks that are accompanied
+	// by len checks inside enclosing && and || chain.
+	checked map[*ast.BinaryExpr]bool
+}
+
+func (c *nilByteSliceCompareChecker) Init() {
+	c.checked = make(map[*ast.BinaryExpr]bool)
+}
+
+func (c *nilByteSliceCompareChecker) VisitLocalExpr(expr ast.Expr) {
+	switch expr := expr.(type) {
+	case *ast.CallExpr:
+		c.checkDeepEqual(expr)
+	case *ast.BinaryExpr:
+		switch expr.Op {
+		case token.LAND, token.LOR:
+			c.markChecked(expr)
+		case token.EQL, token.NEQ:
+			if c.checked[expr] {
+				delete(c.checked, expr)
+				return
+			}
+			if b := c.nilCompared(expr); b != nil {
+				c.warnNilCheck(expr, b)
+			}
+		}
+	}
+}
+
+// markChecked adds nil checks of x chain to the checked set
+// if the same chain checks the slice length.
+func (c *nilByteSliceCompareChecker) markChecked(x *ast.BinaryExpr) {
+	var cmps []*ast.BinaryExpr
+	var visit func(x ast.Expr)
+	visit = func(x ast.Expr) {
+		bin, ok := astutil.Unparen(x).(*ast.BinaryExpr)
+		if !ok {
+			return
+		}
+		switch bin.Op {
+		case token.LAND, token.LOR:
+			visit(bin.X)
+			visit(bin.Y)
+		case token.EQL, token.NEQ:
+			cmps = append(cmps, bin)
+		}
+	}
+	visit(x)
+
+	for _, cmp := range cmps {
+		b := c.nilCompared(cmp)
+		if b == nil {
+			continue
+		}
+		hasLen := findNode(x, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			return ok && len(call.Args) == 1 && c.ctx.isBuiltinCall(call, "len") &&
+				astequal.Expr(call.Args[0], b)
+		}) != nil
+		if hasLen {
+			c.checked[cmp] = true
+		}
+	}
+}
+
+// nilCompared returns []byte expression that is compared to nil by cmp.
+// Returns nil if cmp is not such comparison.
+func (c *nilByteSliceCompareChecker) nilCompared(cmp *ast.BinaryExpr) ast.Expr {
+	switch {
+	case c.isNil(cmp.Y) && c.isByteSlice(cmp.X):
+		return cmp.X
+	case c.isNil(cmp.X) && c.isByteSlice(cmp.Y):
+		return cmp.Y
+	default:
+		return nil
+	}
+}
+
+func (c *nilByteSliceCompareChecker) checkDeepEqual(call *ast.CallExpr) {
+	if len(call.Args) != 2 || c.ctx.calleeName(call) != "reflect.DeepEqual" {
+		return
+	}
+	switch {
+	case c.isEmptyLit(call.Args[1]) && c.isByteSlice(call.Args[0]):
+		c.warnDeepEqual(call, call.Args[0])
+	case c.isEmptyLit(call.Args[0]) && c.isByteSlice(call.Args[1]):
+		c.warnDeepEqual(call, call.Args[1])
+	}
+}
+
+// isEmptyLit reports whether x is a `[]byte{}` literal.
+func (c *nilByteSliceCompareChecker) isEmptyLit(x ast.Expr) bool {
+	lit, ok := x.(*ast.CompositeLit)
+	return ok && len(lit.Elts) == 0 && c.isByteSlice(lit)
+}
+
+func (c *nilByteSliceCompareChecker) isNil(x ast.Expr) bool {
+	return c.ctx.typesInfo.Types[x].IsNil()
+}
+
+func (c *nilByteSliceCompareChecker) isByteSlice(x ast.Expr) bool {
+	typ := c.ctx.typesInfo.TypeOf(x)
+	if typ == nil {
+		return false
+	}
+	slice, ok := typ.Underlying().(*types.Slice)
+	if !ok {
+		return false
+	}
+	elem, ok := slice.Elem().Underlying().(*types.Basic)
+	return ok && elem.Kind() == types.Byte
+}
+
+func (c *nilByteSliceCompareChecker) warnNilCheck(cause *ast.BinaryExpr, b ast.Expr) {
+	c.ctx.WarnWithConfidence(ConfidenceLow, cause,
+		"nil check on []byte may not mean empty; use len(%s) %s 0", b, cause.Op)
+}
+
+func (c *nilByteSliceCompareChecker) warnDeepEqual(cause *ast.CallExpr, b ast.Expr) {
+	c.ctx.WarnWithConfidence(ConfidenceLow, cause,
+		"reflect.DeepEqual with []byte{} is false for nil slice; use len(%s) == 0", b)
+}
@@ -0,0 +1,117 @@
+package lint
+
+//! Detects return statements that swallow or misuse the checked error.
+//
+// Reports `if err != nil { return nil }` bodies that don't use err,
+// so the error is silently lost, and `if err == nil { return err }`,
+// where returned value is always nil.
+//
+// @Before:
+// if err != nil {
+// 	return nil
+// }
+//
+// @After:
+// if err != nil {
+// 	return err
+// }
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+func init() {
+	addChecker(&nilValReturnChecker{}, attrExperimental)
+}
+
+type nilValReturnChecker struct {
+	checkerBase
+
+	errorType *types.Interface
+}
+
+func (c *nilValReturnChecker) Init() {
+	c.errorType = types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
+}
+
+func (c *nilValReturnChecker) VisitFuncDecl(decl *ast.FuncDecl) {
+	fn, ok := c.ctx.typesInfo.ObjectOf(decl.Name).(*types.Func)
+	if !ok {
+		return
+	}
+	c.checkBody(decl.Body, fn.Type().(*types.Signature))
+}
+
+// checkBody checks if statements inside body of function with sig signature.
+// Function literals are checked with their own signatures.
+func (c *nilValReturnChecker) checkBody(body *ast.BlockStmt, sig *types.Signature) {
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			if sig, ok := c.ctx.typesInfo.TypeOf(n).(*types.Signature); ok {
+				c.checkBody(n.Body, sig)
+			}
+			return false
+		case *ast.IfStmt:
+			c.checkIf(n, sig)
+		}
+		return true
+	})
+}
+
+func (c *nilValReturnChecker) checkIf(stmt *ast.IfStmt, sig *types.Signature) {
+	cond, ok := stmt.Cond.(*ast.BinaryExpr)
+	if !ok || (cond.Op != token.EQL && cond.Op != token.NEQ) || !c.isNil(cond.Y) {
+		return
+	}
+	id, ok := cond.X.(*ast.Ident)
+	if !ok {
+		return
+	}
+	obj := c.ctx.typesInfo.ObjectOf(id)
+	if obj == nil || !c.isError(obj.Type()) || len(stmt.Body.List) == 0 {
+		return
+	}
+
+	ret, ok := stmt.Body.List[0].(*ast.ReturnStmt)
+	if !ok || len(ret.Results) != sig.Results().Len() {
+		return
+	}
+	if cond.Op == token.EQL {
+		for _, x := range ret.Results {
+			if y, ok := x.(*ast.Ident); ok && c.ctx.typesInfo.ObjectOf(y) == obj {
+				c.warnNilReturn(y)
+			}
+		}
+		return
+	}
+	if len(stmt.Body.List) != 1 {
+		// Other statements may use or handle the error.
+		return
+	}
+	for i, x := range ret.Results {
+		if c.isNil(x) && c.isError(sig.Results().At(i).Type()) {
+			c.warnSwallowed(x, id)
+		}
+	}
+}
+
+func (c *nilValReturnChecker) isNil(x ast.Expr) bool {
+	return c.ctx.typesInfo.Types[x].IsNil()
+}
+
+// isError reports whether typ is an interface type that implements error.
+func (c *nilValReturnChecker) isError(typ types.Type) bool {
+	_, ok := typ.Underlying().(*types.Interface)
+	return ok && types.Implements(typ, c.errorType)
+}
+
+func (c *nilValReturnChecker) warnNilReturn(cause *ast.Ident) {
+	c.ctx.Warn(cause, "returned expr is always nil; replace %s with nil", cause)
+}
+
+func (c *nilValReturnChecker) warnSwallowed(cause ast.Expr, err *ast.Ident) {
+	c.ctx.Warn(cause, "%s is not nil, but nil is returned", err)
+}
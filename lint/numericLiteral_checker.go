@@ -0,0 +1,134 @@
+package lint
+
+//! Detects numeric literals that can be written in a more readable way.
+//
+// Reports mixed-case hex literals, old-style octal literals
+// and long decimal literals without digit separators.
+// Octal and separator suggestions are only made for Go 1.13 and newer,
+// where 0o prefix and _ separators are available.
+//
+// Checker params:
+//	checkHex     - if "true", mixed-case hex literals are reported
+//	checkOctal   - if "true", old-style octal literals are reported
+//	minSepDigits - decimal literals with at least that many digits need separators, 0 disables the check
+//
+// @Before:
+// const (
+// 	mask = 0xFf
+// 	perm = 0755
+// 	size = 10000000
+// )
+//
+// @After:
+// const (
+// 	mask = 0xff
+// 	perm = 0o755
+// 	size = 10_000_000
+// )
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+)
+
+func init() {
+	addChecker(&numericLiteralChecker{}, attrExperimental, attrSyntaxOnly)
+}
+
+type numericLiteralChecker struct {
+	checkerBase
+
+	checkHex     bool
+	checkOctal   bool
+	minSepDigits int
+}
+
+func (c *numericLiteralChecker) Params() []CheckerParam {
+	return []CheckerParam{
+		{Name: "checkHex", Kind: ParamBool, Default: "true"},
+		{Name: "checkOctal", Kind: ParamBool, Default: "true"},
+		{Name: "minSepDigits", Kind: ParamInt, Default: "7"},
+	}
+}
+
+func (c *numericLiteralChecker) Init() {
+	c.checkHex = c.ctx.BoolParam("checkHex")
+	c.checkOctal = c.ctx.BoolParam("checkOctal")
+	c.minSepDigits = c.ctx.IntParam("minSepDigits")
+}
+
+func (c *numericLiteralChecker) VisitExpr(expr ast.Expr) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.INT {
+		return
+	}
+	s := lit.Value
+
+	switch {
+	case strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X"):
+		if c.checkHex {
+			c.checkHexCase(lit, s[:2], s[2:])
+		}
+	case len(s) > 1 && s[0] == '0' && isDigits(s[1:]):
+		if c.checkOctal {
+			c.warnOctal(lit, "0o"+s[1:])
+		}
+	case s[0] != '0' && isDigits(s):
+		if c.minSepDigits > 0 && len(s) >= c.minSepDigits {
+			c.warnSep(lit, groupDigits(s))
+		}
+	}
+}
+
+func (c *numericLiteralChecker) checkHexCase(lit *ast.BasicLit, prefix, digits string) {
+	lower := strings.ToLower(digits)
+	upper := strings.ToUpper(digits)
+	if digits != lower && digits != upper {
+		c.warnHex(lit, prefix+lower, prefix+upper)
+	}
+}
+
+// isDigits reports whether s consists of decimal digits only.
+func isDigits(s string) bool {
+	for _, ch := range s {
+		if ch < '0' || ch > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// groupDigits inserts _ separators between groups of 3 digits.
+func groupDigits(s string) string {
+	var buf strings.Builder
+	for i, ch := range s {
+		if i != 0 && (len(s)-i)%3 == 0 {
+			buf.WriteByte('_')
+		}
+		buf.WriteRune(ch)
+	}
+	return buf.String()
+}
+
+func (c *numericLiteralChecker) warnHex(cause *ast.BasicLit, lower, upper string) {
+	c.ctx.WarnWith(Warning{Node: cause, Code: "hexCase"}, "mixed-case hex literal %s; use %s or %s", cause.Value, lower, upper)
+}
+
+func (c *numericLiteralChecker) warnOctal(cause *ast.BasicLit, suggestion string) {
+	c.ctx.WarnWith(Warning{
+		Node:      cause,
+		Code:      "octalPrefix",
+		GoFeature: GoFeatureNumberLiterals,
+		Fix:       []TextEdit{c.ctx.replaceNode(cause, &ast.BasicLit{Kind: token.INT, Value: suggestion})},
+	}, "use %s instead of old-style octal %s", suggestion, cause.Value)
+}
+
+func (c *numericLiteralChecker) warnSep(cause *ast.BasicLit, suggestion string) {
+	c.ctx.WarnWith(Warning{
+		Node:      cause,
+		Code:      "digitSeparators",
+		GoFeature: GoFeatureNumberLiterals,
+		Fix:       []TextEdit{c.ctx.replaceNode(cause, &ast.BasicLit{Kind: token.INT, Value: suggestion})},
+	}, "consider %s for readability", suggestion)
+}
@@ -2,6 +2,9 @@ package lint
 
 //! Detects if function parameters could be combined by type and suggest the way to do it.
 //
+// Checker params:
+//	skipExported - if "true", exported functions and methods of exported types are not checked
+//
 // @Before:
 // func foo(a, b int, c, d int, e, f int, g int) {}
 //
@@ -15,20 +18,53 @@ import (
 )
 
 func init() {
-	addChecker(&paramTypeCombineChecker{}, attrSyntaxOnly)
+	addChecker(&paramTypeCombineChecker{}, attrSyntaxOnly, attrSkipTests)
 }
 
 type paramTypeCombineChecker struct {
 	checkerBase
+
+	skipExported bool
+}
+
+func (c *paramTypeCombineChecker) Params() []CheckerParam {
+	return []CheckerParam{
+		{Name: "skipExported", Kind: ParamBool, Default: "false"},
+	}
+}
+
+func (c *paramTypeCombineChecker) Init() {
+	c.skipExported = c.ctx.BoolParam("skipExported")
 }
 
 func (c *paramTypeCombineChecker) VisitFuncDecl(decl *ast.FuncDecl) {
+	if c.skipExported && c.isExportedAPI(decl) {
+		// Signature changes are visible in the godoc output.
+		return
+	}
 	typ := c.optimizeFuncType(decl.Type)
 	if !astequal.Expr(typ, decl.Type) {
 		c.warn(decl.Type, typ)
 	}
 }
 
+// isExportedAPI reports whether decl is documented by godoc:
+// it's an exported function or an exported method of exported type.
+func (c *paramTypeCombineChecker) isExportedAPI(decl *ast.FuncDecl) bool {
+	if !decl.Name.IsExported() {
+		return false
+	}
+	if decl.Recv == nil || len(decl.Recv.List) == 0 {
+		return true
+	}
+	typ := decl.Recv.List[0].Type
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+	id, ok := typ.(*ast.Ident)
+	return !ok || id.IsExported()
+}
+
 func (c *paramTypeCombineChecker) optimizeFuncType(f *ast.FuncType) *ast.FuncType {
 	return &ast.FuncType{
 		Params:  c.optimizeParams(f.Params),
@@ -0,0 +1,101 @@
+package lint
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParamKind describes which values a checker parameter accepts.
+type ParamKind int
+
+// Parameter kinds.
+const (
+	// ParamString accepts any value.
+	ParamString ParamKind = iota
+
+	// ParamBool accepts values understood by strconv.ParseBool.
+	ParamBool
+
+	// ParamInt accepts values understood by strconv.Atoi.
+	ParamInt
+)
+
+// String returns parameter kind name.
+func (kind ParamKind) String() string {
+	switch kind {
+	case ParamString:
+		return "string"
+	case ParamBool:
+		return "bool"
+	case ParamInt:
+		return "int"
+	default:
+		return fmt.Sprintf("ParamKind(%d)", int(kind))
+	}
+}
+
+// CheckerParam describes a parameter that checker accepts.
+//
+// Parameters are declared by checkers during registration,
+// so they can be listed and validated before checkers are created.
+type CheckerParam struct {
+	// Name is a parameter name, unique among the checker params.
+	Name string
+
+	// Kind restricts the parameter values.
+	Kind ParamKind
+
+	// Default is a value that is used if parameter is not set.
+	Default string
+
+	// Values is a list of allowed values.
+	// If empty, any value of the parameter kind is allowed.
+	Values []string
+
+	// Doc is a short parameter description.
+	// For built-in checkers, it's only filled by CheckersInfo.
+	Doc string
+}
+
+// validate returns an error if value is not valid for p.
+func (p *CheckerParam) validate(value string) error {
+	switch p.Kind {
+	case ParamBool:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("%q is not a bool", value)
+		}
+	case ParamInt:
+		if _, err := strconv.Atoi(value); err != nil {
+			return fmt.Errorf("%q is not an int", value)
+		}
+	}
+	if len(p.Values) == 0 {
+		return nil
+	}
+	for _, v := range p.Values {
+		if v == value {
+			return nil
+		}
+	}
+	return fmt.Errorf("%q is not one of %s", value, strings.Join(p.Values, ", "))
+}
+
+// paramsDeclarer is implemented by checkers that have parameters.
+//
+// Params is called once for the registered checker prototype,
+// so it should not depend on the checker state.
+type paramsDeclarer interface {
+	Params() []CheckerParam
+}
+
+// findParam returns rule parameter with the specified name.
+// Returns nil if there is no such parameter.
+func (r *Rule) findParam(name string) *CheckerParam {
+	for i := range r.Params {
+		if r.Params[i].Name == name {
+			return &r.Params[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package lint
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// PathFilter selects files and packages to be checked by glob patterns.
+//
+// Patterns use path.Match syntax and are matched against path elements,
+// so they don't depend on the project location:
+//
+//	"*.pb.go"            - files with .pb.go suffix in any directory
+//	"vendor/"            - everything inside any vendor directory
+//	"internal/*/mocks/"  - everything inside mocks of any internal package
+//	"foo/bar"            - package foo/bar or file bar inside foo directory
+//
+// Patterns with trailing slash match directories only.
+// Other patterns match the trailing path elements.
+//
+// Drivers apply the filter while loading packages,
+// so excluded files are never parsed or type-checked.
+type PathFilter struct {
+	// Include is a list of patterns files should match to be checked.
+	// If empty, all files that are not excluded are checked.
+	Include []string
+
+	// Exclude is a list of patterns of files and packages that are not checked.
+	// Exclusion takes precedence over inclusion.
+	Exclude []string
+}
+
+// Validate returns an error if some of filter patterns are malformed.
+func (f *PathFilter) Validate() error {
+	for _, patterns := range [][]string{f.Include, f.Exclude} {
+		for _, p := range patterns {
+			if strings.Trim(p, "/") == "" {
+				return fmt.Errorf("%q: empty pattern", p)
+			}
+			if _, err := path.Match(p, ""); err != nil {
+				return fmt.Errorf("%q: %v", p, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Empty reports whether filter selects everything.
+func (f *PathFilter) Empty() bool {
+	return len(f.Include) == 0 && len(f.Exclude) == 0
+}
+
+// MatchPackage reports whether package with the specified import path
+// is not excluded. Include patterns are applied to the package files only,
+// see MatchFile.
+func (f *PathFilter) MatchPackage(pkgPath string) bool {
+	elems := strings.Split(pkgPath, "/")
+	for _, p := range f.Exclude {
+		if matchPathPattern(p, elems, true) {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchFile reports whether file with the specified name should be checked.
+func (f *PathFilter) MatchFile(filename string) bool {
+	elems := strings.Split(strings.Trim(filepath.ToSlash(filename), "/"), "/")
+	for _, p := range f.Exclude {
+		if matchPathPattern(p, elems, false) {
+			return false
+		}
+	}
+	if len(f.Include) == 0 {
+		return true
+	}
+	for _, p := range f.Include {
+		if matchPathPattern(p, elems, false) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPathPattern reports whether pattern matches path elements.
+// If isDir is false, the last element is a file name
+// that is not matched by directory patterns.
+func matchPathPattern(pattern string, elems []string, isDir bool) bool {
+	dirOnly := strings.HasSuffix(pattern, "/")
+	pelems := strings.Split(strings.Trim(pattern, "/"), "/")
+	if dirOnly {
+		// Directory pattern matches any directory elements window.
+		dirs := elems
+		if !isDir {
+			dirs = elems[:len(elems)-1]
+		}
+		for i := 0; i+len(pelems) <= len(dirs); i++ {
+			if matchElems(pelems, dirs[i:i+len(pelems)]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(pelems) > len(elems) {
+		return false
+	}
+	return matchElems(pelems, elems[len(elems)-len(pelems):])
+}
+
+// matchElems reports whether every pattern element matches corresponding elems element.
+func matchElems(pattern, elems []string) bool {
+	for i, p := range pattern {
+		if ok, _ := path.Match(p, elems[i]); !ok {
+			return false
+		}
+	}
+	return true
+}
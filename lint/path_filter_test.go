@@ -0,0 +1,85 @@
+package lint
+
+import (
+	"testing"
+)
+
+func TestPathFilterMatchFile(t *testing.T) {
+	filter := PathFilter{
+		Include: []string{"*.go"},
+		Exclude: []string{"vendor/", "third_party/", "*.pb.go", "internal/*/mocks/", "gen/x.go"},
+	}
+	tests := []struct {
+		filename string
+		want     bool
+	}{
+		{"/src/foo/bar.go", true},
+		{"foo/bar.go", true},
+		{"/src/foo/vendor/x/y.go", false},
+		{"/src/vendor.go", true},
+		{"/src/third_party/lib/a.go", false},
+		{"/src/foo/api.pb.go", false},
+		{"/src/foo/api.pb.gw.go", true},
+		{"/src/internal/db/mocks/db.go", false},
+		{"/src/internal/mocks/db.go", true},
+		{"/src/gen/x.go", false},
+		{"/src/gen/y.go", true},
+		{"/src/foo/README.md", false},
+	}
+
+	for _, test := range tests {
+		if have := filter.MatchFile(test.filename); have != test.want {
+			t.Errorf("%s: have %v, want %v", test.filename, have, test.want)
+		}
+	}
+
+	if !(&PathFilter{}).MatchFile("/src/foo/README.md") {
+		t.Errorf("empty filter should match everything")
+	}
+}
+
+func TestPathFilterMatchPackage(t *testing.T) {
+	filter := PathFilter{
+		Include: []string{"foo/"},
+		Exclude: []string{"vendor/", "example.com/*/gen", "mocks"},
+	}
+	tests := []struct {
+		pkgPath string
+		want    bool
+	}{
+		{"example.com/foo", true},
+		{"example.com/bar", true},
+		{"example.com/vendor/x", false},
+		{"example.com/foo/gen", false},
+		{"example.com/gen", true},
+		{"example.com/foo/gen/x", true},
+		{"example.com/foo/mocks", false},
+	}
+
+	for _, test := range tests {
+		if have := filter.MatchPackage(test.pkgPath); have != test.want {
+			t.Errorf("%s: have %v, want %v", test.pkgPath, have, test.want)
+		}
+	}
+}
+
+func TestPathFilterValidate(t *testing.T) {
+	tests := []struct {
+		filter PathFilter
+		err    string
+	}{
+		{PathFilter{Exclude: []string{"vendor/", "*.pb.go"}}, ""},
+		{PathFilter{Include: []string{"[a-"}}, `"[a-": syntax error in pattern`},
+		{PathFilter{Exclude: []string{"/"}}, `"/": empty pattern`},
+	}
+
+	for _, test := range tests {
+		err := test.filter.Validate()
+		if test.err == "" && err != nil {
+			t.Errorf("%v: unexpected error: %v", test.filter, err)
+		}
+		if test.err != "" && (err == nil || err.Error() != test.err) {
+			t.Errorf("%v: have error %v, want %s", test.filter, err, test.err)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package lint
+
+//! Detects time.Now() expressions that can be replaced with time package helpers.
+//
+// time.Until suggestions are only made for Go 1.8 and newer.
+//
+// @Before:
+// elapsed := time.Now().Sub(start)
+// left := deadline.Sub(time.Now())
+//
+// @After:
+// elapsed := time.Since(start)
+// left := time.Until(deadline)
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+func init() {
+	addChecker(&preferTimeHelpersChecker{}, attrExperimental)
+}
+
+type preferTimeHelpersChecker struct {
+	checkerBase
+}
+
+func (c *preferTimeHelpersChecker) VisitLocalExpr(expr ast.Expr) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 {
+		return
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+	recv, arg := sel.X, call.Args[0]
+
+	switch c.ctx.calleeName(call) {
+	case "(time.Time).Sub":
+		if now := c.nowCall(recv); now != nil {
+			c.warnFix(call, c.helperCall(now, "Since", arg), "")
+		} else if now := c.nowCall(arg); now != nil {
+			c.warnFix(call, c.helperCall(now, "Until", recv), GoFeatureTimeUntil)
+		}
+	case "(time.Time).Before":
+		if now := c.nowCall(recv); now != nil {
+			c.warn(call, c.positive(c.helperCall(now, "Until", arg)), GoFeatureTimeUntil)
+		}
+	case "(time.Time).After":
+		if now := c.nowCall(recv); now != nil {
+			c.warn(call, c.positive(c.helperCall(now, "Since", arg)), "")
+		}
+	}
+}
+
+// nowCall returns x as time.Now() call, or nil if it's something else.
+func (c *preferTimeHelpersChecker) nowCall(x ast.Expr) *ast.CallExpr {
+	call, ok := x.(*ast.CallExpr)
+	if !ok || len(call.Args) != 0 || c.ctx.calleeName(call) != "time.Now" {
+		return nil
+	}
+	return call
+}
+
+// helperCall returns time package helper call expression.
+// Package is qualified the same way as in now call.
+func (c *preferTimeHelpersChecker) helperCall(now *ast.CallExpr, name string, arg ast.Expr) *ast.CallExpr {
+	var fn ast.Expr = ast.NewIdent(name)
+	if sel, ok := now.Fun.(*ast.SelectorExpr); ok {
+		fn = &ast.SelectorExpr{X: sel.X, Sel: ast.NewIdent(name)}
+	}
+	return &ast.CallExpr{Fun: fn, Args: []ast.Expr{arg}}
+}
+
+func (c *preferTimeHelpersChecker) positive(x ast.Expr) ast.Expr {
+	return &ast.BinaryExpr{X: x, Op: token.GTR, Y: &ast.BasicLit{Kind: token.INT, Value: "0"}}
+}
+
+func (c *preferTimeHelpersChecker) warnFix(cause, suggestion ast.Expr, feature GoFeature) {
+	c.ctx.WarnWith(Warning{
+		Node:      cause,
+		Fix:       []TextEdit{c.ctx.replaceNode(cause, suggestion)},
+		GoFeature: feature,
+	}, "%s can be simplified to %s", cause, suggestion)
+}
+
+func (c *preferTimeHelpersChecker) warn(cause, suggestion ast.Expr, feature GoFeature) {
+	c.ctx.WarnWith(Warning{Node: cause, GoFeature: feature},
+		"%s can be simplified to %s", cause, suggestion)
+}
@@ -0,0 +1,146 @@
+package lint
+
+import (
+	"go/ast"
+	"go/constant"
+	"strings"
+	"unicode/utf8"
+)
+
+// printfFuncs maps printf-like functions full names to the format arg index.
+var printfFuncs = map[string]int{
+	"fmt.Errorf":           0,
+	"fmt.Printf":           0,
+	"fmt.Sprintf":          0,
+	"fmt.Fprintf":          1,
+	"fmt.Appendf":          1,
+	"log.Fatalf":           0,
+	"log.Panicf":           0,
+	"log.Printf":           0,
+	"(*log.Logger).Fatalf": 0,
+	"(*log.Logger).Panicf": 0,
+	"(*log.Logger).Printf": 0,
+}
+
+// printfCall is a printf-like function call with constant format string.
+type printfCall struct {
+	// name is a called function full name, like "fmt.Sprintf".
+	name string
+
+	// format is a format string value.
+	format string
+
+	// formatArg is a format string argument expression.
+	formatArg ast.Expr
+
+	// args are the arguments that follow the format.
+	args []ast.Expr
+
+	// verbs are format string directives, in order of appearance.
+	verbs []printfVerb
+}
+
+// printfVerb is a single formatting directive inside format string, like "%-8.2f".
+type printfVerb struct {
+	// start and end are verb byte offsets inside format string.
+	start, end int
+
+	flags string
+	width string
+	prec  string
+	verb  rune
+
+	// arg is an index of the argument formatted by this verb.
+	// It's -1 for "%%" that doesn't consume any arguments.
+	arg int
+}
+
+// simple reports whether v has no flags, width or precision.
+func (v printfVerb) simple() bool {
+	return v.flags == "" && v.width == "" && v.prec == ""
+}
+
+// printfCallOf returns call as printfCall.
+// Returns false if call is not a printf-like function call,
+// or format string can't be parsed.
+func (ctx *context) printfCallOf(call *ast.CallExpr) (printfCall, bool) {
+	name := ctx.calleeName(call)
+	i, ok := printfFuncs[name]
+	if !ok || len(call.Args) <= i || call.Ellipsis.IsValid() {
+		return printfCall{}, false
+	}
+	tv := ctx.typesInfo.Types[call.Args[i]]
+	if tv.Value == nil || tv.Value.Kind() != constant.String {
+		return printfCall{}, false
+	}
+	format := constant.StringVal(tv.Value)
+	verbs, ok := parsePrintfFormat(format)
+	if !ok {
+		return printfCall{}, false
+	}
+	return printfCall{
+		name:      name,
+		format:    format,
+		formatArg: call.Args[i],
+		args:      call.Args[i+1:],
+		verbs:     verbs,
+	}, true
+}
+
+// parsePrintfFormat returns all format directives from the format string.
+//
+// Returns false for malformed formats and formats
+// that use explicit argument indexes, like "%[1]d".
+func parsePrintfFormat(format string) ([]printfVerb, bool) {
+	var verbs []printfVerb
+	arg := 0
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		v := printfVerb{start: i}
+		i++
+
+		j := i
+		for j < len(format) && strings.IndexByte("+-# 0", format[j]) != -1 {
+			j++
+		}
+		v.flags, i = format[i:j], j
+
+		v.width, i, arg = scanPrintfNum(format, i, arg)
+		if i < len(format) && format[i] == '.' {
+			i++
+			v.prec, i, arg = scanPrintfNum(format, i, arg)
+			v.prec = "." + v.prec
+		}
+
+		if i >= len(format) || format[i] == '[' {
+			return nil, false
+		}
+		ch, size := utf8.DecodeRuneInString(format[i:])
+		v.verb = ch
+		v.end = i + size
+		if ch == '%' {
+			v.arg = -1
+		} else {
+			v.arg = arg
+			arg++
+		}
+		verbs = append(verbs, v)
+		i = v.end - 1
+	}
+	return verbs, true
+}
+
+// scanPrintfNum scans width or precision that starts at i.
+// Star consumes an argument, so the updated arg index is returned.
+func scanPrintfNum(format string, i, arg int) (num string, next, nextArg int) {
+	if i < len(format) && format[i] == '*' {
+		return "*", i + 1, arg + 1
+	}
+	j := i
+	for j < len(format) && format[j] >= '0' && format[j] <= '9' {
+		j++
+	}
+	return format[i:j], j, arg
+}
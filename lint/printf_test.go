@@ -0,0 +1,47 @@
+package lint
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParsePrintfFormat(t *testing.T) {
+	tests := []struct {
+		format string
+		want   []printfVerb
+	}{
+		{"", nil},
+		{"no verbs", nil},
+		{"%d", []printfVerb{{start: 0, end: 2, verb: 'd', arg: 0}}},
+		{"x=%-8.2f%%", []printfVerb{
+			{start: 2, end: 8, flags: "-", width: "8", prec: ".2", verb: 'f', arg: 0},
+			{start: 8, end: 10, verb: '%', arg: -1},
+		}},
+		{"%*d %s", []printfVerb{
+			{start: 0, end: 3, width: "*", verb: 'd', arg: 1},
+			{start: 4, end: 6, verb: 's', arg: 2},
+		}},
+		{"%+#v %.*q", []printfVerb{
+			{start: 0, end: 4, flags: "+#", verb: 'v', arg: 0},
+			{start: 5, end: 9, prec: ".*", verb: 'q', arg: 2},
+		}},
+		{"%ä", []printfVerb{{start: 0, end: 3, verb: 'ä', arg: 0}}},
+	}
+
+	for _, test := range tests {
+		have, ok := parsePrintfFormat(test.format)
+		if !ok {
+			t.Errorf("%q: unexpected parse failure", test.format)
+			continue
+		}
+		if !reflect.DeepEqual(have, test.want) {
+			t.Errorf("%q:\nhave: %+v\nwant: %+v", test.format, have, test.want)
+		}
+	}
+
+	for _, format := range []string{"%", "abc %-", "%[1]d", "%5.2"} {
+		if _, ok := parsePrintfFormat(format); ok {
+			t.Errorf("%q: expected parse failure", format)
+		}
+	}
+}
@@ -22,7 +22,7 @@ import (
 )
 
 func init() {
-	addChecker(&rangeExprCopyChecker{})
+	addChecker(&rangeExprCopyChecker{}, attrPerformance, attrSkipTests)
 }
 
 type rangeExprCopyChecker struct {
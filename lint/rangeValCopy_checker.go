@@ -1,8 +1,12 @@
 package lint
 
 //! Detects loops that copy big objects during each iteration.
+//
 // Suggests to use index access or take address and make use pointer instead.
 //
+// Checker params:
+//	sizeThreshold - minimal element size in bytes that is reported (48 by default)
+//
 // @Before:
 // xs := make([][1024]byte, length)
 // for _, x := range xs {
@@ -21,11 +25,23 @@ import (
 )
 
 func init() {
-	addChecker(&rangeValCopyChecker{})
+	addChecker(&rangeValCopyChecker{}, attrPerformance, attrSkipTests)
 }
 
 type rangeValCopyChecker struct {
 	checkerBase
+
+	sizeThreshold int64
+}
+
+func (c *rangeValCopyChecker) Params() []CheckerParam {
+	return []CheckerParam{
+		{Name: "sizeThreshold", Kind: ParamInt, Default: "48"},
+	}
+}
+
+func (c *rangeValCopyChecker) Init() {
+	c.sizeThreshold = int64(c.ctx.IntParam("sizeThreshold"))
 }
 
 func (c *rangeValCopyChecker) EnterFunc(fn *ast.FuncDecl) bool {
@@ -38,11 +54,10 @@ func (c *rangeValCopyChecker) VisitStmt(stmt ast.Stmt) {
 		return
 	}
 	typ := c.ctx.typesInfo.TypeOf(rng.Value)
-	if typ == nil {
+	if typ == nil || hasTypeParam(typ) {
 		return
 	}
-	const sizeThreshold = 48
-	if size := c.ctx.sizesInfo.Sizeof(typ); size >= sizeThreshold {
+	if size := c.ctx.sizesInfo.Sizeof(typ); size >= c.sizeThreshold {
 		c.warn(rng, size)
 	}
 }
@@ -0,0 +1,102 @@
+package lint
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+// rdjsonReporter writes reports in reviewdog diagnostic format (rdjson),
+// so they can be posted as pull request review comments by reviewdog,
+// including the suggested fixes.
+//
+// See https://github.com/reviewdog/reviewdog/tree/master/proto/rdf.
+type rdjsonReporter struct{}
+
+// Types below describe the subset of rdjson format that is used by rdjsonReporter.
+
+type rdjsonResult struct {
+	Source      rdjsonSource       `json:"source"`
+	Diagnostics []rdjsonDiagnostic `json:"diagnostics"`
+}
+
+type rdjsonSource struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+type rdjsonDiagnostic struct {
+	Message     string             `json:"message"`
+	Location    rdjsonLocation     `json:"location"`
+	Severity    string             `json:"severity"`
+	Code        rdjsonCode         `json:"code"`
+	Suggestions []rdjsonSuggestion `json:"suggestions,omitempty"`
+}
+
+type rdjsonLocation struct {
+	Path  string      `json:"path"`
+	Range rdjsonRange `json:"range"`
+}
+
+type rdjsonRange struct {
+	Start rdjsonPosition  `json:"start"`
+	End   *rdjsonPosition `json:"end,omitempty"`
+}
+
+type rdjsonPosition struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+type rdjsonCode struct {
+	Value string `json:"value"`
+}
+
+type rdjsonSuggestion struct {
+	Range rdjsonRange `json:"range"`
+	Text  string      `json:"text"`
+}
+
+func (rdjsonReporter) Write(w io.Writer, reports []Report) error {
+	out := rdjsonResult{
+		Source: rdjsonSource{
+			Name: "gocritic",
+			URL:  "https://github.com/go-critic/go-critic",
+		},
+		Diagnostics: []rdjsonDiagnostic{},
+	}
+	for _, r := range reports {
+		d := rdjsonDiagnostic{
+			Message: r.Text,
+			Location: rdjsonLocation{
+				Path:  relativePath(r.Pos.Filename),
+				Range: newRdjsonRange(r.Pos, r.End),
+			},
+			Severity: strings.ToUpper(r.Severity.String()),
+			Code:     rdjsonCode{Value: reportID(r)},
+		}
+		for _, edit := range r.Fixes {
+			// Suggestions can only change the reported file.
+			if edit.Pos.Filename != r.Pos.Filename {
+				continue
+			}
+			d.Suggestions = append(d.Suggestions, rdjsonSuggestion{
+				Range: newRdjsonRange(edit.Pos, edit.End),
+				Text:  edit.NewText,
+			})
+		}
+		out.Diagnostics = append(out.Diagnostics, d)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+	return enc.Encode(out)
+}
+
+func newRdjsonRange(pos, end Position) rdjsonRange {
+	rng := rdjsonRange{Start: rdjsonPosition{Line: pos.Line, Column: pos.Column}}
+	if end.Line != 0 {
+		rng.End = &rdjsonPosition{Line: end.Line, Column: end.Column}
+	}
+	return rng
+}
@@ -0,0 +1,90 @@
+package lint
+
+//! Detects regexp compilation of constant patterns inside loops.
+//
+// Reports regexp.Compile and regexp.MustCompile calls, as well as
+// regexp.Match functions, that compile the same pattern on every iteration.
+// Only loop bodies are checked, calls inside function literals are not reported.
+//
+// @Before:
+// for _, s := range lines {
+// 	re := regexp.MustCompile(`^\d+$`)
+// 	if re.MatchString(s) {
+// 		n++
+// 	}
+// }
+//
+// @After:
+// var digitsRE = regexp.MustCompile(`^\d+$`)
+//
+// for _, s := range lines {
+// 	if digitsRE.MatchString(s) {
+// 		n++
+// 	}
+// }
+
+import (
+	"go/ast"
+)
+
+func init() {
+	addChecker(&regexpCompileInLoopChecker{}, attrExperimental, attrPerformance)
+}
+
+type regexpCompileInLoopChecker struct {
+	checkerBase
+
+	// compileFuncs is a set of regexp package functions
+	// that compile their first argument.
+	compileFuncs map[string]bool
+}
+
+func (c *regexpCompileInLoopChecker) Init() {
+	c.compileFuncs = map[string]bool{
+		"regexp.Compile":          true,
+		"regexp.CompilePOSIX":     true,
+		"regexp.MustCompile":      true,
+		"regexp.MustCompilePOSIX": true,
+		"regexp.Match":            true,
+		"regexp.MatchReader":      true,
+		"regexp.MatchString":      true,
+	}
+}
+
+func (c *regexpCompileInLoopChecker) VisitStmt(stmt ast.Stmt) {
+	switch stmt := stmt.(type) {
+	case *ast.RangeStmt:
+		c.checkLoopBody(stmt.Body)
+	case *ast.ForStmt:
+		c.checkLoopBody(stmt.Body)
+	}
+}
+
+func (c *regexpCompileInLoopChecker) checkLoopBody(body *ast.BlockStmt) {
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ForStmt, *ast.RangeStmt:
+			// Nested loops are checked by their own VisitStmt call.
+			return false
+		case *ast.CallExpr:
+			c.checkCall(n)
+		}
+		return true
+	})
+}
+
+func (c *regexpCompileInLoopChecker) checkCall(call *ast.CallExpr) {
+	if len(call.Args) == 0 {
+		return
+	}
+	name := c.ctx.calleeName(call)
+	if c.compileFuncs[name] && c.ctx.typesInfo.Types[call.Args[0]].Value != nil {
+		c.warn(call, name)
+	}
+}
+
+func (c *regexpCompileInLoopChecker) warn(cause *ast.CallExpr, name string) {
+	c.ctx.Warn(cause, "%s compiles constant pattern on every iteration; consider moving it out of the loop", name)
+}
@@ -0,0 +1,88 @@
+package lint
+
+//! Detects regexp matching with patterns that have no metacharacters.
+//
+// Such patterns match a literal string, so regexp usage
+// can be replaced with much faster strings/bytes functions.
+//
+// @Before:
+// ok := regexp.MustCompile("abc").MatchString(s)
+//
+// @After:
+// ok := strings.Contains(s, "abc")
+
+import (
+	"go/ast"
+	"go/constant"
+	"regexp"
+
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+func init() {
+	addChecker(&regexpPlainLiteralChecker{}, attrExperimental, attrPerformance, attrSkipTests)
+}
+
+type regexpPlainLiteralChecker struct {
+	checkerBase
+
+	// suggestions maps regexp functions and methods to
+	// their literal string matching equivalents.
+	suggestions map[string]string
+}
+
+func (c *regexpPlainLiteralChecker) Init() {
+	c.suggestions = map[string]string{
+		"regexp.MatchString": "strings.Contains",
+		"regexp.Match":       "bytes.Contains",
+
+		"(*regexp.Regexp).MatchString": "strings.Contains",
+		"(*regexp.Regexp).Match":       "bytes.Contains",
+	}
+}
+
+func (c *regexpPlainLiteralChecker) VisitExpr(x ast.Expr) {
+	call, ok := x.(*ast.CallExpr)
+	if !ok {
+		return
+	}
+	name := c.ctx.calleeName(call)
+	suggestion := c.suggestions[name]
+	if suggestion == "" {
+		return
+	}
+
+	var pattern ast.Expr
+	switch name {
+	case "regexp.MatchString", "regexp.Match":
+		pattern = call.Args[0]
+	default:
+		// Only check methods called over immediately compiled regexp.
+		// Tracking regexp variables is much harder.
+		sel := astutil.Unparen(call.Fun).(*ast.SelectorExpr)
+		compile, ok := astutil.Unparen(sel.X).(*ast.CallExpr)
+		if !ok || c.ctx.calleeName(compile) != "regexp.MustCompile" {
+			return
+		}
+		pattern = compile.Args[0]
+	}
+
+	if c.isPlainLiteral(pattern) {
+		c.warn(call, suggestion)
+	}
+}
+
+// isPlainLiteral reports whether x is a constant non-empty
+// regexp pattern that has no metacharacters.
+func (c *regexpPlainLiteralChecker) isPlainLiteral(x ast.Expr) bool {
+	cv := c.ctx.typesInfo.Types[x].Value
+	if cv == nil || cv.Kind() != constant.String {
+		return false
+	}
+	s := constant.StringVal(cv)
+	return s != "" && regexp.QuoteMeta(s) == s
+}
+
+func (c *regexpPlainLiteralChecker) warn(cause ast.Node, suggestion string) {
+	c.ctx.Warn(cause, "regexp pattern has no metacharacters; use %s for speed", suggestion)
+}
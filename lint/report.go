@@ -0,0 +1,110 @@
+package lint
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"go/token"
+)
+
+// Report is a Warning representation that is suitable for
+// machine-readable output, like JSON.
+//
+// Unlike Warning, it's self-contained: locations are
+// resolved and don't depend on the token.FileSet.
+type Report struct {
+	Checker string `json:"checker"`
+
+	// ID is a warning identifier, see WarningID.
+	ID string `json:"id"`
+
+	// Fingerprint is a content-based warning identifier, see Fingerprint.
+	// It's set by the caller, as reports are created without the source code.
+	Fingerprint string `json:"fingerprint,omitempty"`
+
+	// Explanation is a checker documentation excerpt, see Explain.
+	// Like Fingerprint, it's set by the caller.
+	Explanation string `json:"explanation,omitempty"`
+
+	Pos        Position   `json:"pos"`
+	End        Position   `json:"end"`
+	Text       string     `json:"text"`
+	Severity   Severity   `json:"severity"`
+	Confidence Confidence `json:"confidence"`
+
+	// Fixes is a list of source code edits that fix the issue.
+	// Empty for warnings that can't be fixed automatically.
+	Fixes []ReportEdit `json:"fixes,omitempty"`
+}
+
+// ReportEdit is a TextEdit representation used by Report.
+type ReportEdit struct {
+	Pos     Position `json:"pos"`
+	End     Position `json:"end"`
+	NewText string   `json:"newText"`
+}
+
+// Position is a resolved source code location.
+type Position struct {
+	Filename string `json:"filename"`
+
+	// Offset is a byte offset inside file, starting from 0.
+	Offset int `json:"offset"`
+
+	// Line and Column start from 1.
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// NewReport returns a report for the warning w of the specified checker.
+// fset is used to resolve warning and fix locations.
+func NewReport(fset *token.FileSet, checker string, w Warning) Report {
+	r := Report{
+		Checker:    checker,
+		ID:         WarningID(checker, w.Code),
+		Pos:        newPosition(fset, w.Pos),
+		End:        newPosition(fset, w.End),
+		Text:       w.Text,
+		Severity:   w.Severity,
+		Confidence: w.Confidence,
+	}
+	for _, edit := range w.Fix {
+		r.Fixes = append(r.Fixes, ReportEdit{
+			Pos:     newPosition(fset, edit.Pos),
+			End:     newPosition(fset, edit.End),
+			NewText: edit.NewText,
+		})
+	}
+	return r
+}
+
+// reportID returns r.ID, falling back to the checker name
+// for reports that were created without NewReport.
+func reportID(r Report) string {
+	if r.ID == "" {
+		return r.Checker
+	}
+	return r.ID
+}
+
+func newPosition(fset *token.FileSet, pos token.Pos) Position {
+	p := fset.Position(pos)
+	return Position{
+		Filename: p.Filename,
+		Offset:   p.Offset,
+		Line:     p.Line,
+		Column:   p.Column,
+	}
+}
+
+// Fingerprint returns a content-based identifier of the warning w
+// of the specified checker, src is a source code of the warning file.
+//
+// It's a hash of the warning ID, text and the source lines
+// spanned by the warning, ignoring whitespace, so it
+// survives line moves and formatting changes.
+func Fingerprint(fset *token.FileSet, src []byte, checker string, w Warning) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00%s", WarningID(checker, w.Code), w.Text, linesFingerprint(fset, src, w))
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
@@ -0,0 +1,83 @@
+package lint
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ReportGrouping describes how SortReports orders reports.
+type ReportGrouping int
+
+// Report groupings.
+const (
+	// GroupByFile orders reports by file, position and checker name.
+	// This is a default grouping.
+	GroupByFile ReportGrouping = iota
+
+	// GroupByChecker orders reports by checker name first,
+	// reports of every checker are ordered by file and position.
+	GroupByChecker
+)
+
+// String returns grouping name.
+func (g ReportGrouping) String() string {
+	switch g {
+	case GroupByFile:
+		return "file"
+	case GroupByChecker:
+		return "checker"
+	default:
+		return fmt.Sprintf("ReportGrouping(%d)", int(g))
+	}
+}
+
+// ParseReportGrouping returns report grouping by its name.
+// Valid names are "file" and "checker".
+func ParseReportGrouping(s string) (ReportGrouping, error) {
+	for _, g := range []ReportGrouping{GroupByFile, GroupByChecker} {
+		if g.String() == s {
+			return g, nil
+		}
+	}
+	return 0, fmt.Errorf("%s: unknown report grouping", s)
+}
+
+// SortReports sorts reports according to the grouping g.
+//
+// Reports that have the same group and start position are ordered
+// by their end position, ID and text, so the result doesn't depend
+// on the order in which checkers and packages were run.
+func SortReports(reports []Report, g ReportGrouping) {
+	sort.SliceStable(reports, func(i, j int) bool {
+		x, y := &reports[i], &reports[j]
+		if g == GroupByChecker && x.Checker != y.Checker {
+			return x.Checker < y.Checker
+		}
+		switch {
+		case x.Pos.Filename != y.Pos.Filename:
+			return x.Pos.Filename < y.Pos.Filename
+		case x.Pos != y.Pos:
+			return x.Pos.less(y.Pos)
+		case x.Checker != y.Checker:
+			return x.Checker < y.Checker
+		case x.End != y.End:
+			return x.End.less(y.End)
+		case reportID(*x) != reportID(*y):
+			return reportID(*x) < reportID(*y)
+		default:
+			return x.Text < y.Text
+		}
+	})
+}
+
+// less reports whether p goes before other in the same file.
+func (p Position) less(other Position) bool {
+	switch {
+	case p.Line != other.Line:
+		return p.Line < other.Line
+	case p.Column != other.Column:
+		return p.Column < other.Column
+	default:
+		return p.Offset < other.Offset
+	}
+}
@@ -0,0 +1,472 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"go/token"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReportJSON(t *testing.T) {
+	rule := findRule("boolExprSimplify")
+	if rule == nil {
+		t.Fatal("boolExprSimplify rule not found")
+	}
+	pkgPath := testdataPkgPath + rule.Name()
+	prog := newProg(t, pkgPath)
+	pkgInfo := prog.Imported[pkgPath]
+	ctx := NewContext(prog.Fset, sizes)
+	ctx.SetPackageInfo(&pkgInfo.Info, pkgInfo.Pkg)
+
+	var reports []Report
+	c := NewChecker(rule, ctx)
+	for _, f := range pkgInfo.Files {
+		for _, w := range c.Check(f) {
+			reports = append(reports, NewReport(prog.Fset, rule.Name(), w))
+		}
+	}
+	if len(reports) == 0 {
+		t.Fatal("no warnings reported")
+	}
+
+	data, err := json.Marshal(reports)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var decoded []Report
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if !reflect.DeepEqual(reports, decoded) {
+		t.Errorf("reports changed after JSON round-trip:\nhave: %+v\nwant: %+v", decoded, reports)
+	}
+
+	withoutFixes := 0
+	for _, r := range decoded {
+		if r.Checker != rule.Name() || r.Severity != SeverityWarning || r.Confidence != ConfidenceHigh {
+			t.Errorf("%s:%d: unexpected report metadata: %+v", r.Pos.Filename, r.Pos.Line, r)
+		}
+		if !strings.HasPrefix(r.ID, rule.Name()+"/") {
+			t.Errorf("%s:%d: ID %q should include the simplification code", r.Pos.Filename, r.Pos.Line, r.ID)
+		}
+		// boolExprSimplify suggests the replacement,
+		// unless the expression has comments inside.
+		if len(r.Fixes) == 0 {
+			withoutFixes++
+			continue
+		}
+		if len(r.Fixes) != 1 {
+			t.Errorf("%s:%d: have %d fixes, want 1", r.Pos.Filename, r.Pos.Line, len(r.Fixes))
+			continue
+		}
+		fix := r.Fixes[0]
+		if fix.Pos != r.Pos || fix.End != r.End {
+			t.Errorf("%s:%d: fix should replace the whole warning node", r.Pos.Filename, r.Pos.Line)
+		}
+	}
+	if withoutFixes != 1 {
+		t.Errorf("have %d reports without fixes, want 1", withoutFixes)
+	}
+
+	var raw []map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if raw[0]["severity"] != "warning" || raw[0]["confidence"] != "high" {
+		t.Errorf("levels should be encoded by their names: %s", data)
+	}
+	if _, ok := raw[0]["fixes"]; !ok {
+		t.Errorf("fixes field not found: %s", data)
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	const src = "package p\n\nfunc f(x bool) bool {\n\treturn !!x\n}\n"
+	fset := token.NewFileSet()
+	warning := func(src string) ([]byte, Warning) {
+		tf := fset.AddFile("p.go", -1, len(src))
+		tf.SetLinesForContent([]byte(src))
+		pos := tf.Pos(strings.Index(src, "!!x"))
+		return []byte(src), Warning{Pos: pos, End: pos + 3, Text: "can simplify `!!x` to `x`", Code: "doubleNegation"}
+	}
+
+	src1, w := warning(src)
+	base := Fingerprint(fset, src1, "boolExprSimplify", w)
+
+	// Moved and reformatted code keeps the fingerprint.
+	src2, moved := warning("package p\n\n// Doc.\n\nfunc f(x bool) bool {\n\treturn  !!x\n}\n")
+	if have := Fingerprint(fset, src2, "boolExprSimplify", moved); have != base {
+		t.Errorf("fingerprint changed after the line move: have %s, want %s", have, base)
+	}
+
+	src3, edited := warning("package p\n\nfunc f(x bool) bool {\n\treturn !!x || x\n}\n")
+	other := []string{
+		Fingerprint(fset, src3, "boolExprSimplify", edited),
+		Fingerprint(fset, src1, "dupSubExpr", w),
+		Fingerprint(fset, src1, "boolExprSimplify", Warning{Pos: w.Pos, End: w.End, Text: w.Text}),
+	}
+	for i, fp := range other {
+		if fp == base {
+			t.Errorf("other[%d]: fingerprint is the same", i)
+		}
+	}
+}
+
+func TestSortReports(t *testing.T) {
+	pos := func(filename string, line, column int) Position {
+		return Position{Filename: filename, Line: line, Column: column}
+	}
+	reports := []Report{
+		{Checker: "unslice", Pos: pos("b.go", 1, 1), Text: "1"},
+		{Checker: "dupSubExpr", Pos: pos("a.go", 10, 2), Text: "2"},
+		{Checker: "unslice", Pos: pos("a.go", 3, 5), Text: "3"},
+		{Checker: "dupSubExpr", Pos: pos("a.go", 3, 5), Text: "4"},
+		{Checker: "dupSubExpr", Pos: pos("a.go", 3, 1), End: pos("a.go", 3, 9), Text: "5"},
+		{Checker: "dupSubExpr", Pos: pos("a.go", 3, 1), End: pos("a.go", 3, 4), Text: "6"},
+		{Checker: "dupSubExpr", ID: "dupSubExpr/b", Pos: pos("a.go", 3, 1), End: pos("a.go", 3, 4), Text: "7"},
+		{Checker: "dupSubExpr", ID: "dupSubExpr/a", Pos: pos("a.go", 3, 1), End: pos("a.go", 3, 4), Text: "8"},
+	}
+
+	tests := []struct {
+		group ReportGrouping
+		want  string
+	}{
+		{GroupByFile, "68754321"},
+		{GroupByChecker, "68754231"},
+	}
+	for _, test := range tests {
+		// Result should not depend on the initial order.
+		for _, input := range [][]Report{reports, reverseReports(reports)} {
+			sorted := append([]Report(nil), input...)
+			SortReports(sorted, test.group)
+			var have []string
+			for _, r := range sorted {
+				have = append(have, r.Text)
+			}
+			if strings.Join(have, "") != test.want {
+				t.Errorf("group by %s: have %s, want %s", test.group, strings.Join(have, ""), test.want)
+			}
+		}
+	}
+
+	for _, g := range []ReportGrouping{GroupByFile, GroupByChecker} {
+		if have, err := ParseReportGrouping(g.String()); err != nil || have != g {
+			t.Errorf("parse %s: have %s, %v", g, have, err)
+		}
+	}
+	if _, err := ParseReportGrouping("severity"); err == nil {
+		t.Errorf("expected error for unknown grouping")
+	}
+}
+
+func reverseReports(reports []Report) []Report {
+	reversed := make([]Report, len(reports))
+	for i, r := range reports {
+		reversed[len(reports)-1-i] = r
+	}
+	return reversed
+}
+
+func TestNewReporter(t *testing.T) {
+	if want := []string{"checkstyle", "github", "json", "junit", "rdjson", "sarif"}; !reflect.DeepEqual(ReportFormats(), want) {
+		t.Errorf("have %q formats, want %q", ReportFormats(), want)
+	}
+	_, err := NewReporter("xml")
+	if err == nil || err.Error() != "xml: unknown report format" {
+		t.Errorf("expected unknown format error, got %v", err)
+	}
+}
+
+func TestSarifReporter(t *testing.T) {
+	reports := []Report{
+		{
+			Checker:     "boolExprSimplify",
+			ID:          "boolExprSimplify/doubleNegation",
+			Fingerprint: "0123456789abcdef",
+			Pos:         Position{Filename: "/src/p/a.go", Offset: 20, Line: 3, Column: 6},
+			End:         Position{Filename: "/src/p/a.go", Offset: 23, Line: 3, Column: 9},
+			Text:        "can simplify `!!x` to `x`",
+			Severity:    SeverityWarning,
+			Confidence:  ConfidenceHigh,
+			Fixes: []ReportEdit{{
+				Pos:     Position{Filename: "/src/p/a.go", Offset: 20, Line: 3, Column: 6},
+				End:     Position{Filename: "/src/p/a.go", Offset: 23, Line: 3, Column: 9},
+				NewText: "x",
+			}},
+		},
+		{
+			Checker:    "emptySelect",
+			Pos:        Position{Filename: "p/b.go", Offset: 10, Line: 2, Column: 2},
+			End:        Position{Filename: "p/b.go", Offset: 19, Line: 2, Column: 11},
+			Text:       "select {} blocks forever; ensure this is intentional",
+			Severity:   SeverityInfo,
+			Confidence: ConfidenceLow,
+		},
+	}
+
+	r, err := NewReporter("sarif")
+	if err != nil {
+		t.Fatalf("new reporter: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := r.Write(&buf, reports); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("unexpected log header: %s", buf.Bytes())
+	}
+	run := log.Runs[0]
+	var ruleIDs []string
+	for _, rule := range run.Tool.Driver.Rules {
+		ruleIDs = append(ruleIDs, rule.ID)
+	}
+	if want := []string{"boolExprSimplify", "emptySelect"}; !reflect.DeepEqual(ruleIDs, want) {
+		t.Errorf("have %q rules, want %q", ruleIDs, want)
+	}
+	if run.Tool.Driver.Rules[0].ShortDescription.Text != checkerDoc(t, "boolExprSimplify").Summary {
+		t.Errorf("rule description should be taken from the checker doc")
+	}
+
+	type result struct {
+		rule       string
+		level      string
+		uri        string
+		region     sarifRegion
+		fixes      int
+		confidence string
+	}
+	var have []result
+	for _, res := range run.Results {
+		if run.Tool.Driver.Rules[res.RuleIndex].ID != res.RuleID {
+			t.Errorf("%s: rule index mismatch", res.RuleID)
+		}
+		loc := res.Locations[0].PhysicalLocation
+		have = append(have, result{
+			rule:       res.RuleID,
+			level:      res.Level,
+			uri:        loc.ArtifactLocation.URI,
+			region:     loc.Region,
+			fixes:      len(res.Fixes),
+			confidence: res.Properties.Confidence,
+		})
+	}
+	want := []result{
+		{"boolExprSimplify", "warning", "file:///src/p/a.go", sarifRegion{3, 6, 3, 9}, 1, "high"},
+		{"emptySelect", "note", "p/b.go", sarifRegion{2, 2, 2, 11}, 0, "low"},
+	}
+	if !reflect.DeepEqual(have, want) {
+		t.Errorf("results mismatch:\nhave: %+v\nwant: %+v", have, want)
+	}
+
+	if fp := run.Results[0].PartialFingerprints["gocritic/v1"]; fp != "0123456789abcdef" {
+		t.Errorf("have %q fingerprint, want the report one", fp)
+	}
+	if run.Results[0].Properties.ID != "boolExprSimplify/doubleNegation" || run.Results[1].Properties.ID != "emptySelect" {
+		t.Errorf("unexpected results IDs: %q, %q", run.Results[0].Properties.ID, run.Results[1].Properties.ID)
+	}
+	if run.Results[1].PartialFingerprints != nil {
+		t.Errorf("unexpected fingerprint for report without it")
+	}
+
+	replacement := run.Results[0].Fixes[0].ArtifactChanges[0].Replacements[0]
+	if replacement.InsertedContent.Text != "x" || replacement.DeletedRegion != (sarifRegion{3, 6, 3, 9}) {
+		t.Errorf("unexpected fix replacement: %+v", replacement)
+	}
+}
+
+func TestXMLReporters(t *testing.T) {
+	reports := []Report{
+		{
+			Checker:  "dupSubExpr",
+			Pos:      Position{Filename: "p/a.go", Line: 3, Column: 6},
+			Text:     "suspicious identical LHS and RHS for `<` operator",
+			Severity: SeverityError,
+		},
+		{
+			Checker:  "emptySelect",
+			Pos:      Position{Filename: "p/b.go", Line: 2, Column: 2},
+			Text:     "select {} blocks forever; ensure this is intentional",
+			Severity: SeverityInfo,
+		},
+		{
+			Checker:  "unslice",
+			Pos:      Position{Filename: "p/a.go", Line: 7, Column: 1},
+			Text:     "could simplify s[:] to s",
+			Severity: SeverityWarning,
+		},
+	}
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"checkstyle", `<?xml version="1.0" encoding="UTF-8"?>
+<checkstyle version="5.0">
+	<file name="p/a.go">
+		<error line="3" column="6" severity="error" message="suspicious identical LHS and RHS for ` + "`&lt;`" + ` operator" source="dupSubExpr"></error>
+		<error line="7" column="1" severity="warning" message="could simplify s[:] to s" source="unslice"></error>
+	</file>
+	<file name="p/b.go">
+		<error line="2" column="2" severity="info" message="select {} blocks forever; ensure this is intentional" source="emptySelect"></error>
+	</file>
+</checkstyle>
+`},
+		{"junit", `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+	<testsuite name="p/a.go" tests="2" failures="2">
+		<testcase name="dupSubExpr" classname="p/a.go:3:6">
+			<failure message="suspicious identical LHS and RHS for ` + "`&lt;`" + ` operator" type="error">p/a.go:3:6: dupSubExpr: suspicious identical LHS and RHS for ` + "`&lt;`" + ` operator</failure>
+		</testcase>
+		<testcase name="unslice" classname="p/a.go:7:1">
+			<failure message="could simplify s[:] to s" type="warning">p/a.go:7:1: unslice: could simplify s[:] to s</failure>
+		</testcase>
+	</testsuite>
+	<testsuite name="p/b.go" tests="1" failures="1">
+		<testcase name="emptySelect" classname="p/b.go:2:2">
+			<failure message="select {} blocks forever; ensure this is intentional" type="info">p/b.go:2:2: emptySelect: select {} blocks forever; ensure this is intentional</failure>
+		</testcase>
+	</testsuite>
+</testsuites>
+`},
+	}
+
+	for _, test := range tests {
+		r, err := NewReporter(test.format)
+		if err != nil {
+			t.Fatalf("new reporter: %v", err)
+		}
+		var buf bytes.Buffer
+		if err := r.Write(&buf, reports); err != nil {
+			t.Fatalf("%s: write: %v", test.format, err)
+		}
+		if buf.String() != test.want {
+			t.Errorf("%s output mismatch:\nhave:\n%s\nwant:\n%s", test.format, buf.String(), test.want)
+		}
+	}
+}
+
+func TestGithubReporter(t *testing.T) {
+	reports := []Report{
+		{
+			Checker:  "dupSubExpr",
+			Pos:      Position{Filename: "p/a.go", Line: 3, Column: 6},
+			End:      Position{Filename: "p/a.go", Line: 3, Column: 12},
+			Text:     "suspicious identical LHS and RHS for `<` operator",
+			Severity: SeverityError,
+		},
+		{
+			Checker:  "emptySelect",
+			Pos:      Position{Filename: "p/b,c.go", Line: 2, Column: 2},
+			Text:     "select {} blocks forever;\nensure this is 100% intentional",
+			Severity: SeverityInfo,
+		},
+		{
+			Checker:     "unslice",
+			Pos:         Position{Filename: "p/a.go", Line: 7, Column: 1},
+			End:         Position{Filename: "p/a.go", Line: 8, Column: 2},
+			Text:        "could simplify s[:] to s",
+			Severity:    SeverityWarning,
+			Explanation: "see docs",
+		},
+	}
+
+	want := "::error file=p/a.go,line=3,col=6,endLine=3,endColumn=12,title=gocritic%3A dupSubExpr::suspicious identical LHS and RHS for `<` operator\n" +
+		"::notice file=p/b%2Cc.go,line=2,col=2,title=gocritic%3A emptySelect::select {} blocks forever;%0Aensure this is 100%25 intentional\n" +
+		"::warning file=p/a.go,line=7,col=1,endLine=8,endColumn=2,title=gocritic%3A unslice::could simplify s[:] to s%0A%0Asee docs\n"
+
+	r, err := NewReporter("github")
+	if err != nil {
+		t.Fatalf("new reporter: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := r.Write(&buf, reports); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if buf.String() != want {
+		t.Errorf("output mismatch:\nhave:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestRdjsonReporter(t *testing.T) {
+	reports := []Report{
+		{
+			Checker:  "boolExprSimplify",
+			Pos:      Position{Filename: "p/a.go", Offset: 20, Line: 3, Column: 6},
+			End:      Position{Filename: "p/a.go", Offset: 23, Line: 3, Column: 9},
+			Text:     "can simplify `!!x` to `x`",
+			Severity: SeverityWarning,
+			Fixes: []ReportEdit{{
+				Pos:     Position{Filename: "p/a.go", Offset: 20, Line: 3, Column: 6},
+				End:     Position{Filename: "p/a.go", Offset: 23, Line: 3, Column: 9},
+				NewText: "x",
+			}},
+		},
+		{
+			Checker:  "emptySelect",
+			Pos:      Position{Filename: "p/b.go", Line: 2, Column: 2},
+			Text:     "select {} blocks forever; ensure this is intentional",
+			Severity: SeverityInfo,
+		},
+	}
+
+	r, err := NewReporter("rdjson")
+	if err != nil {
+		t.Fatalf("new reporter: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := r.Write(&buf, reports); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	var have rdjsonResult
+	if err := json.Unmarshal(buf.Bytes(), &have); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	want := rdjsonResult{
+		Source: rdjsonSource{Name: "gocritic", URL: "https://github.com/go-critic/go-critic"},
+		Diagnostics: []rdjsonDiagnostic{
+			{
+				Message: "can simplify `!!x` to `x`",
+				Location: rdjsonLocation{Path: "p/a.go", Range: rdjsonRange{
+					Start: rdjsonPosition{Line: 3, Column: 6},
+					End:   &rdjsonPosition{Line: 3, Column: 9},
+				}},
+				Severity: "WARNING",
+				Code:     rdjsonCode{Value: "boolExprSimplify"},
+				Suggestions: []rdjsonSuggestion{{
+					Range: rdjsonRange{
+						Start: rdjsonPosition{Line: 3, Column: 6},
+						End:   &rdjsonPosition{Line: 3, Column: 9},
+					},
+					Text: "x",
+				}},
+			},
+			{
+				Message: "select {} blocks forever; ensure this is intentional",
+				Location: rdjsonLocation{Path: "p/b.go", Range: rdjsonRange{
+					Start: rdjsonPosition{Line: 2, Column: 2},
+				}},
+				Severity: "INFO",
+				Code:     rdjsonCode{Value: "emptySelect"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(have, want) {
+		t.Errorf("output mismatch:\nhave: %+v\nwant: %+v", have, want)
+	}
+}
+
+func checkerDoc(t *testing.T, name string) CheckerDoc {
+	doc, err := ParseCheckerDoc(checkerDocs[name])
+	if err != nil {
+		t.Fatalf("%s: parse doc: %v", name, err)
+	}
+	return doc
+}
@@ -0,0 +1,57 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// Reporter writes reports in some machine-readable format.
+type Reporter interface {
+	// Write writes all reports collected during the linter run to w.
+	Write(w io.Writer, reports []Report) error
+}
+
+// reporters maps output format name to its Reporter constructor.
+var reporters = map[string]func() Reporter{
+	"checkstyle": func() Reporter { return checkstyleReporter{} },
+	"github":     func() Reporter { return githubReporter{} },
+	"json":       func() Reporter { return jsonReporter{} },
+	"junit":      func() Reporter { return junitReporter{} },
+	"rdjson":     func() Reporter { return rdjsonReporter{} },
+	"sarif":      func() Reporter { return sarifReporter{} },
+}
+
+// ReportFormats returns a list of formats supported by NewReporter.
+// Slice is sorted.
+func ReportFormats() []string {
+	formats := make([]string, 0, len(reporters))
+	for format := range reporters {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// NewReporter returns a reporter for the specified output format.
+// Valid formats can be obtained by ReportFormats call.
+func NewReporter(format string) (Reporter, error) {
+	newReporter, ok := reporters[format]
+	if !ok {
+		return nil, fmt.Errorf("%s: unknown report format", format)
+	}
+	return newReporter(), nil
+}
+
+// jsonReporter writes reports as an indented JSON array.
+type jsonReporter struct{}
+
+func (jsonReporter) Write(w io.Writer, reports []Report) error {
+	if reports == nil {
+		reports = []Report{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+	return enc.Encode(reports)
+}
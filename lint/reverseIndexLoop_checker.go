@@ -0,0 +1,226 @@
+package lint
+
+//! Detects reverse index loops that can use slices.Backward.
+//
+// Loop is only reported if its index is used to read slice
+// elements and nothing else. Only reported for Go 1.23 and newer.
+//
+// @Before:
+// for i := len(xs) - 1; i >= 0; i-- {
+// 	fmt.Println(xs[i])
+// }
+//
+// @After:
+// for _, v := range slices.Backward(xs) {
+// 	fmt.Println(v)
+// }
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/go-toolsmith/astequal"
+)
+
+func init() {
+	addChecker(&reverseIndexLoopChecker{}, attrExperimental)
+}
+
+type reverseIndexLoopChecker struct {
+	checkerBase
+}
+
+func (c *reverseIndexLoopChecker) GoFeatures() []GoFeature {
+	return []GoFeature{GoFeatureRangeOverFunc}
+}
+
+func (c *reverseIndexLoopChecker) VisitStmt(stmt ast.Stmt) {
+	loop, ok := stmt.(*ast.ForStmt)
+	if !ok {
+		return
+	}
+	i, s := c.matchHeader(loop)
+	if i == nil {
+		return
+	}
+	reads, ok := c.elemReads(loop.Body, i, s)
+	if !ok || len(reads) == 0 {
+		return
+	}
+	c.warn(loop, c.fix(loop, s, reads))
+}
+
+// matchHeader matches `for i := len(s) - 1; i >= 0; i--` loop header.
+// Returns i and s, or nil i if header is not matched.
+func (c *reverseIndexLoopChecker) matchHeader(loop *ast.ForStmt) (*ast.Ident, ast.Expr) {
+	init, ok := loop.Init.(*ast.AssignStmt)
+	if !ok || init.Tok != token.DEFINE || len(init.Lhs) != 1 || len(init.Rhs) != 1 {
+		return nil, nil
+	}
+	i, ok := init.Lhs[0].(*ast.Ident)
+	if !ok {
+		return nil, nil
+	}
+	start, ok := init.Rhs[0].(*ast.BinaryExpr)
+	if !ok || start.Op != token.SUB || !c.isIntLit(start.Y, "1") {
+		return nil, nil
+	}
+	length, ok := start.X.(*ast.CallExpr)
+	if !ok || len(length.Args) != 1 || !c.ctx.isBuiltinCall(length, "len") {
+		return nil, nil
+	}
+	s := length.Args[0]
+	if !c.isSliceVar(s) {
+		return nil, nil
+	}
+
+	cond, ok := loop.Cond.(*ast.BinaryExpr)
+	if !ok || cond.Op != token.GEQ || !c.isVar(cond.X, i) || !c.isIntLit(cond.Y, "0") {
+		return nil, nil
+	}
+	post, ok := loop.Post.(*ast.IncDecStmt)
+	if !ok || post.Tok != token.DEC || !c.isVar(post.X, i) {
+		return nil, nil
+	}
+	return i, s
+}
+
+// isSliceVar reports whether x is a slice variable or field selector.
+// Such expressions can be evaluated once, before the loop.
+func (c *reverseIndexLoopChecker) isSliceVar(x ast.Expr) bool {
+	root := x
+	for sel, ok := root.(*ast.SelectorExpr); ok; sel, ok = root.(*ast.SelectorExpr) {
+		root = sel.X
+	}
+	if _, ok := root.(*ast.Ident); !ok {
+		return false
+	}
+	typ := c.ctx.typesInfo.TypeOf(x)
+	if typ == nil {
+		return false
+	}
+	_, ok := typ.Underlying().(*types.Slice)
+	return ok
+}
+
+// elemReads returns all s[i] expressions of the loop body.
+// Returns false if i is used for anything else but reading s
+// elements or if s is re-assigned inside the loop.
+func (c *reverseIndexLoopChecker) elemReads(body *ast.BlockStmt, i *ast.Ident, s ast.Expr) ([]*ast.IndexExpr, bool) {
+	var reads []*ast.IndexExpr
+	indexes := make(map[*ast.Ident]bool)
+	isElem := func(x ast.Expr) bool {
+		index, ok := x.(*ast.IndexExpr)
+		return ok && c.isVar(index.Index, i) && astequal.Expr(index.X, s)
+	}
+	// isModified reports whether x is the slice itself
+	// or contains its element, like `s[i].field`.
+	isModified := func(x ast.Expr) bool {
+		return astequal.Expr(x, s) || findNode(x, func(n ast.Node) bool {
+			x, ok := n.(ast.Expr)
+			return ok && isElem(x)
+		}) != nil
+	}
+
+	ok := true
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range n.Lhs {
+				if isModified(lhs) {
+					ok = false
+				}
+			}
+		case *ast.IncDecStmt:
+			if isModified(n.X) {
+				ok = false
+			}
+		case *ast.UnaryExpr:
+			if n.Op == token.AND && isModified(n.X) {
+				ok = false
+			}
+		case *ast.SelectorExpr:
+			if isElem(n.X) && c.hasPointerRecv(n) {
+				ok = false
+			}
+		case *ast.IndexExpr:
+			if isElem(n) {
+				reads = append(reads, n)
+				indexes[n.Index.(*ast.Ident)] = true
+			}
+		case *ast.Ident:
+			if !indexes[n] && c.isVar(n, i) {
+				ok = false
+			}
+		}
+		return ok
+	})
+	return reads, ok
+}
+
+// hasPointerRecv reports whether sel is a pointer receiver method
+// that is called for addressable value.
+func (c *reverseIndexLoopChecker) hasPointerRecv(sel *ast.SelectorExpr) bool {
+	selection := c.ctx.typesInfo.Selections[sel]
+	if selection == nil || selection.Kind() != types.MethodVal {
+		return false
+	}
+	sig := selection.Obj().Type().(*types.Signature)
+	_, isPtrRecv := sig.Recv().Type().(*types.Pointer)
+	_, isPtrValue := selection.Recv().(*types.Pointer)
+	return isPtrRecv && !isPtrValue
+}
+
+// fix returns edits that replace the loop header with slices.Backward range
+// and replace every element read with the range value.
+// Returns nil if the value name would shadow something used in the loop body.
+func (c *reverseIndexLoopChecker) fix(loop *ast.ForStmt, s ast.Expr, reads []*ast.IndexExpr) []TextEdit {
+	const name = "v"
+	shadows := findNode(loop.Body, func(n ast.Node) bool {
+		id, ok := n.(*ast.Ident)
+		return ok && id.Name == name
+	}) != nil
+	if shadows {
+		return nil
+	}
+	importEdit, ok := c.ctx.importEdit("slices", loop.Pos())
+	if !ok {
+		return nil
+	}
+
+	var edits []TextEdit
+	if importEdit != nil {
+		edits = append(edits, *importEdit)
+	}
+	edits = append(edits, TextEdit{
+		Pos:     loop.Init.Pos(),
+		End:     loop.Post.End(),
+		NewText: c.ctx.printer.Sprintf("_, %s := range slices.Backward(%s)", name, s),
+	})
+	for _, read := range reads {
+		edits = append(edits, TextEdit{Pos: read.Pos(), End: read.End(), NewText: name})
+	}
+	return edits
+}
+
+func (c *reverseIndexLoopChecker) isIntLit(x ast.Expr, value string) bool {
+	lit, ok := x.(*ast.BasicLit)
+	return ok && lit.Kind == token.INT && lit.Value == value
+}
+
+// isVar reports whether x is an identifier that refers
+// to the same object as id.
+func (c *reverseIndexLoopChecker) isVar(x ast.Expr, id *ast.Ident) bool {
+	x2, ok := x.(*ast.Ident)
+	if !ok || x2.Name != id.Name {
+		return false
+	}
+	obj := c.ctx.typesInfo.ObjectOf(id)
+	return obj == nil || obj == c.ctx.typesInfo.ObjectOf(x2)
+}
+
+func (c *reverseIndexLoopChecker) warn(cause ast.Node, fix []TextEdit) {
+	c.ctx.WarnWith(Warning{Node: cause, Confidence: ConfidenceLow, Fix: fix},
+		"reverse index loop can use slices.Backward")
+}
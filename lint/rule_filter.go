@@ -0,0 +1,180 @@
+package lint
+
+import (
+	"fmt"
+)
+
+// Rule tags that can be used to address rule groups.
+const (
+	// TagStable is a tag for every rule that is not experimental.
+	TagStable = "stable"
+
+	// TagExperimental is a tag for rules with Experimental attribute.
+	TagExperimental = "experimental"
+
+	// TagOpinionated is a tag for rules with VeryOpinionated attribute.
+	TagOpinionated = "opinionated"
+
+	// TagSyntaxOnly is a tag for rules with SyntaxOnly attribute.
+	TagSyntaxOnly = "syntax-only"
+
+	// TagPerformance is a tag for rules with Performance attribute.
+	TagPerformance = "performance"
+)
+
+// TagList returns a list of all known rule tags.
+func TagList() []string {
+	return []string{
+		TagStable,
+		TagExperimental,
+		TagOpinionated,
+		TagSyntaxOnly,
+		TagPerformance,
+	}
+}
+
+// Tags returns a list of tags associated with r.
+// Tags are inferred from rule attributes.
+func (r *Rule) Tags() []string {
+	var tags []string
+	if r.Experimental {
+		tags = append(tags, TagExperimental)
+	} else {
+		tags = append(tags, TagStable)
+	}
+	if r.VeryOpinionated {
+		tags = append(tags, TagOpinionated)
+	}
+	if r.SyntaxOnly {
+		tags = append(tags, TagSyntaxOnly)
+	}
+	if r.Performance {
+		tags = append(tags, TagPerformance)
+	}
+	return tags
+}
+
+// HasTag reports whether r is associated with a given tag.
+func (r *Rule) HasTag(tag string) bool {
+	for _, t := range r.Tags() {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// RuleFilter describes which rules should be selected by SelectRules.
+//
+// Filter is applied in this order:
+//  1. Default rules set is collected, unless DisableAll is set.
+//  2. Rules from Enable and EnableTags are added.
+//  3. Rules from Disable and DisableTags are removed.
+//
+// So explicit disabling always wins over enabling.
+type RuleFilter struct {
+	// DisableAll makes the filter start from an empty rules set.
+	//
+	// If false, all stable non-opinionated rules are selected by default.
+	DisableAll bool
+
+	// WithExperimental adds experimental rules to the default set.
+	WithExperimental bool
+
+	// WithOpinionated adds very opinionated rules to the default set.
+	WithOpinionated bool
+
+	// Enable is a list of rule names to be selected.
+	Enable []string
+
+	// EnableTags is a list of tags which rules should be selected.
+	EnableTags []string
+
+	// Disable is a list of rule names to be excluded.
+	Disable []string
+
+	// DisableTags is a list of tags which rules should be excluded.
+	DisableTags []string
+}
+
+// SelectRules returns rules that match filter.
+// Slice is sorted by rule names.
+//
+// Returns an error if filter references unknown rules or tags.
+func SelectRules(filter RuleFilter) ([]*Rule, error) {
+	rules := RuleList()
+
+	byName := make(map[string]*Rule, len(rules))
+	for _, rule := range rules {
+		byName[rule.Name()] = rule
+	}
+	for _, names := range [][]string{filter.Enable, filter.Disable} {
+		for _, name := range names {
+			if byName[name] == nil {
+				return nil, fmt.Errorf("%s: checker not found", name)
+			}
+		}
+	}
+	for _, tags := range [][]string{filter.EnableTags, filter.DisableTags} {
+		for _, tag := range tags {
+			if !isKnownTag(tag) {
+				return nil, fmt.Errorf("%s: unknown tag", tag)
+			}
+		}
+	}
+
+	selected := make(map[string]bool, len(rules))
+	if !filter.DisableAll {
+		for _, rule := range rules {
+			if rule.Experimental && !filter.WithExperimental {
+				continue
+			}
+			if rule.VeryOpinionated && !filter.WithOpinionated {
+				continue
+			}
+			selected[rule.Name()] = true
+		}
+	}
+	for _, name := range filter.Enable {
+		selected[name] = true
+	}
+	for _, rule := range rules {
+		if matchAnyTag(rule, filter.EnableTags) {
+			selected[rule.Name()] = true
+		}
+	}
+	for _, name := range filter.Disable {
+		delete(selected, name)
+	}
+	for _, rule := range rules {
+		if matchAnyTag(rule, filter.DisableTags) {
+			delete(selected, rule.Name())
+		}
+	}
+
+	result := rules[:0]
+	for _, rule := range rules {
+		if selected[rule.Name()] {
+			result = append(result, rule)
+		}
+	}
+	return result, nil
+}
+
+func isKnownTag(tag string) bool {
+	for _, t := range TagList() {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func matchAnyTag(rule *Rule, tags []string) bool {
+	for _, tag := range tags {
+		if rule.HasTag(tag) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,157 @@
+package lint
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSelectRules(t *testing.T) {
+	var stable, experimental []string
+	for _, rule := range RuleList() {
+		if rule.Experimental {
+			experimental = append(experimental, rule.Name())
+		} else {
+			stable = append(stable, rule.Name())
+		}
+	}
+
+	tests := []struct {
+		name   string
+		filter RuleFilter
+		want   []string
+	}{
+		{
+			name:   "enable names",
+			filter: RuleFilter{DisableAll: true, Enable: []string{"dupSubExpr", "boolExprSimplify"}},
+			want:   []string{"boolExprSimplify", "dupSubExpr"},
+		},
+		{
+			name: "disable wins",
+			filter: RuleFilter{
+				DisableAll: true,
+				Enable:     []string{"dupSubExpr", "boolExprSimplify"},
+				Disable:    []string{"dupSubExpr"},
+			},
+			want: []string{"boolExprSimplify"},
+		},
+		{
+			name:   "enable tags",
+			filter: RuleFilter{DisableAll: true, EnableTags: []string{TagStable}},
+			want:   stable,
+		},
+		{
+			name: "disable tags wins",
+			filter: RuleFilter{
+				DisableAll:  true,
+				Enable:      []string{"dupSubExpr", "rangeValCopy"},
+				DisableTags: []string{TagExperimental},
+			},
+			want: []string{"rangeValCopy"},
+		},
+		{
+			name:   "tags combined",
+			filter: RuleFilter{EnableTags: []string{TagExperimental}, WithOpinionated: true},
+			want:   ruleNames(RuleList()),
+		},
+		{
+			name:   "disable all",
+			filter: RuleFilter{DisableAll: true},
+			want:   nil,
+		},
+		{
+			name:   "disable everything",
+			filter: RuleFilter{DisableTags: []string{TagStable, TagExperimental}},
+			want:   nil,
+		},
+	}
+
+	for _, test := range tests {
+		rules, err := SelectRules(test.filter)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if have := ruleNames(rules); !reflect.DeepEqual(have, test.want) {
+			t.Errorf("%s:\nhave: %v\nwant: %v", test.name, have, test.want)
+		}
+	}
+
+	for _, rule := range RuleList() {
+		if rule.HasTag(TagStable) == rule.HasTag(TagExperimental) {
+			t.Errorf("%s: must be either stable or experimental", rule.Name())
+		}
+	}
+	if len(stable)+len(experimental) != len(RuleList()) {
+		t.Errorf("stable and experimental tags do not cover all rules")
+	}
+}
+
+func TestSelectRulesErrors(t *testing.T) {
+	tests := []struct {
+		filter RuleFilter
+		err    string
+	}{
+		{RuleFilter{Enable: []string{"noSuchChecker"}}, "noSuchChecker: checker not found"},
+		{RuleFilter{Disable: []string{"dupSubExpr", "x"}}, "x: checker not found"},
+		{RuleFilter{EnableTags: []string{"fast"}}, "fast: unknown tag"},
+		{RuleFilter{DisableTags: []string{""}}, ": unknown tag"},
+	}
+
+	for _, test := range tests {
+		_, err := SelectRules(test.filter)
+		if err == nil {
+			t.Errorf("%+v: expected error", test.filter)
+			continue
+		}
+		if err.Error() != test.err {
+			t.Errorf("%+v:\nhave error: %v\nwant error: %v", test.filter, err, test.err)
+		}
+	}
+}
+
+func TestDisabledRuleWarnings(t *testing.T) {
+	// Run selected checkers over the input that
+	// definitely triggers disabled checker.
+	pkgPath := testdataPkgPath + "dupSubExpr"
+	prog := newProg(t, pkgPath)
+	pkgInfo := prog.Imported[pkgPath]
+	ctx := NewContext(prog.Fset, sizes)
+	ctx.SetPackageInfo(&pkgInfo.Info, pkgInfo.Pkg)
+
+	countWarnings := func(filter RuleFilter) map[string]int {
+		rules, err := SelectRules(filter)
+		if err != nil {
+			t.Fatalf("select rules: %v", err)
+		}
+		counts := make(map[string]int)
+		for _, rule := range rules {
+			c := NewChecker(rule, ctx)
+			for _, f := range pkgInfo.Files {
+				counts[rule.Name()] += len(c.Check(f))
+			}
+		}
+		return counts
+	}
+
+	enabled := countWarnings(RuleFilter{DisableAll: true, Enable: []string{"dupSubExpr"}})
+	if enabled["dupSubExpr"] == 0 {
+		t.Fatalf("expected enabled dupSubExpr to produce warnings")
+	}
+
+	disabled := countWarnings(RuleFilter{
+		EnableTags: []string{TagExperimental},
+		Enable:     []string{"dupSubExpr"},
+		Disable:    []string{"dupSubExpr"},
+	})
+	if n := disabled["dupSubExpr"]; n != 0 {
+		t.Errorf("disabled dupSubExpr produced %d warnings", n)
+	}
+}
+
+func ruleNames(rules []*Rule) []string {
+	var names []string
+	for _, rule := range rules {
+		names = append(names, rule.Name())
+	}
+	return names
+}
@@ -0,0 +1,218 @@
+package lint
+
+import (
+	"fmt"
+	"go/ast"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// Runner runs checkers over the files of a package using a pool of workers.
+//
+// Checkers are split into groups, one group per worker. Every group
+// processes files sequentially, in the given order, while different
+// groups run concurrently. Checkers of the same group share a single
+// file traversal, see CheckFile.
+//
+// Checkers of rules with SharedState attribute form a separate
+// group that is run after all other groups.
+//
+// Checkers of PackageScope rules are run by Checker.CheckPackage
+// over all files at once, their results are never cached.
+// Checkers of ProgramScope rules are not run, see Checker.CheckProgram.
+type Runner struct {
+	// Workers is a maximum number of checker groups that run concurrently.
+	// If not positive, runtime.GOMAXPROCS(0) is used.
+	Workers int
+
+	// Cache is used to skip checkers which results for the file
+	// are known from the previous runs. Optional.
+	Cache *Cache
+
+	// Source returns a source code f was parsed from.
+	// Only used and required if Cache is set.
+	Source func(f *ast.File) []byte
+}
+
+// Result is a warning reported by a checker that is run by Runner.
+type Result struct {
+	Warning
+
+	// Checker is a checker that reported the warning.
+	Checker *Checker
+
+	// File is a file the warning was reported for.
+	File *ast.File
+}
+
+// Run runs every checker over every file and returns all reported warnings.
+//
+// Results are sorted by file (in files order), warning position,
+// rule name and text, so they don't depend on the scheduling.
+//
+// Checkers signal unexpected errors with panic(error).
+// Such errors are returned, other panics are propagated to the caller
+// with the failed checker rule name prepended.
+func (r *Runner) Run(checkers []*Checker, files []*ast.File) ([]Result, error) {
+	workers := r.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	var groups [][]*Checker
+	var sequential []*Checker
+	n := 0 // Number of concurrent checkers
+	for _, c := range checkers {
+		if c.Rule.SharedState {
+			sequential = append(sequential, c)
+			continue
+		}
+		// Distribute checkers round-robin.
+		if n < workers {
+			groups = append(groups, nil)
+		}
+		groups[n%workers] = append(groups[n%workers], c)
+		n++
+	}
+
+	var sink resultSink
+	var wg sync.WaitGroup
+	for _, group := range groups {
+		wg.Add(1)
+		go func(group []*Checker) {
+			defer wg.Done()
+			r.runGroup(&sink, group, files)
+		}(group)
+	}
+	wg.Wait()
+	if len(sequential) != 0 {
+		r.runGroup(&sink, sequential, files)
+	}
+
+	if sink.panicValue != nil {
+		if err, ok := sink.panicValue.(error); ok {
+			return nil, fmt.Errorf("%s: %v", sink.panicRule, err)
+		}
+		if sink.panicRule != nil {
+			panic(checkerPanic{rule: sink.panicRule, value: sink.panicValue})
+		}
+		panic(sink.panicValue)
+	}
+
+	fileIndex := make(map[*ast.File]int, len(files))
+	for i, f := range files {
+		fileIndex[f] = i
+	}
+	results := sink.results
+	sort.SliceStable(results, func(i, j int) bool {
+		x, y := results[i], results[j]
+		switch {
+		case x.File != y.File:
+			return fileIndex[x.File] < fileIndex[y.File]
+		case x.Pos != y.Pos:
+			return x.Pos < y.Pos
+		case x.Checker.Rule.Name() != y.Checker.Rule.Name():
+			return x.Checker.Rule.Name() < y.Checker.Rule.Name()
+		default:
+			return x.Text < y.Text
+		}
+	})
+	return results, nil
+}
+
+// runGroup runs checkers over files and adds their warnings to sink.
+// Panic stops the group and is recorded in sink.
+func (r *Runner) runGroup(sink *resultSink, checkers []*Checker, files []*ast.File) {
+	defer func() {
+		v := recover()
+		if v == nil {
+			return
+		}
+		if p, ok := v.(checkerPanic); ok {
+			sink.setPanic(p.rule, p.value)
+		} else {
+			sink.setPanic(nil, v)
+		}
+	}()
+
+	var fileCheckers []*Checker
+	for _, c := range checkers {
+		if c.Rule.ProgramScope {
+			continue
+		}
+		if c.Rule.PackageScope {
+			for i, warnings := range checkPackage(c, files) {
+				sink.add(c, files[i], warnings)
+			}
+			continue
+		}
+		fileCheckers = append(fileCheckers, c)
+	}
+
+	var misses []*Checker
+	var keys []string
+	for _, f := range files {
+		misses, keys = misses[:0], keys[:0]
+		for _, c := range fileCheckers {
+			if r.Cache == nil {
+				misses = append(misses, c)
+				continue
+			}
+			warnings, key, ok := r.Cache.lookup(c, f, r.Source(f))
+			if ok {
+				sink.add(c, f, warnings)
+				continue
+			}
+			misses = append(misses, c)
+			keys = append(keys, key)
+		}
+		if len(misses) == 0 {
+			continue
+		}
+		for i, warnings := range CheckFile(misses, f) {
+			if r.Cache != nil {
+				r.Cache.store(misses[i], f, keys[i], warnings)
+			}
+			sink.add(misses[i], f, warnings)
+		}
+	}
+}
+
+// checkPackage runs c.CheckPackage, wrapping panics
+// into checkerPanic, like CheckFile does.
+func checkPackage(c *Checker, files []*ast.File) [][]Warning {
+	defer func() {
+		if r := recover(); r != nil {
+			panic(checkerPanic{rule: c.Rule, value: r})
+		}
+	}()
+	return c.CheckPackage(files)
+}
+
+// resultSink collects results of the concurrently running checkers.
+type resultSink struct {
+	mu sync.Mutex
+
+	results []Result
+
+	// panicValue and panicRule describe the first checker panic.
+	panicValue interface{}
+	panicRule  *Rule
+}
+
+func (s *resultSink) add(c *Checker, f *ast.File, warnings []Warning) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, w := range warnings {
+		s.results = append(s.results, Result{Warning: w, Checker: c, File: f})
+	}
+}
+
+func (s *resultSink) setPanic(rule *Rule, v interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.panicValue == nil {
+		s.panicValue = v
+		s.panicRule = rule
+	}
+}
@@ -0,0 +1,199 @@
+package lint
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/loader"
+)
+
+func TestRunner(t *testing.T) {
+	names := []string{"boolExprSimplify", "dupSubExpr", "unslice", "typeUnparen"}
+	for _, name := range names {
+		rule := findRule(name)
+		if rule == nil {
+			t.Fatalf("%s rule not found", name)
+		}
+	}
+	pkgPath := testdataPkgPath + "dupSubExpr"
+	prog := newProg(t, pkgPath)
+	pkgInfo := prog.Imported[pkgPath]
+
+	run := func(r *Runner) []string {
+		ctx := NewContext(prog.Fset, sizes)
+		ctx.SetPackageInfo(&pkgInfo.Info, pkgInfo.Pkg)
+		var checkers []*Checker
+		for _, name := range names {
+			checkers = append(checkers, NewChecker(findRule(name), ctx))
+		}
+		results, err := r.Run(checkers, pkgInfo.Files)
+		if err != nil {
+			t.Fatalf("run: %v", err)
+		}
+		var have []string
+		for _, r := range results {
+			have = append(have, prog.Fset.Position(r.Node.Pos()).String()+": "+r.Checker.Rule.Name()+": "+r.Text)
+		}
+		return have
+	}
+
+	want := run(&Runner{Workers: 1})
+	if len(want) == 0 {
+		t.Fatal("no warnings reported")
+	}
+	for i := 0; i < 5; i++ {
+		if have := run(&Runner{Workers: 4}); !reflect.DeepEqual(have, want) {
+			t.Fatalf("results depend on workers count:\nhave: %q\nwant: %q", have, want)
+		}
+	}
+
+	// Shared state checkers report the same results.
+	rule := findRule("dupSubExpr")
+	rule.SharedState = true
+	have := run(&Runner{})
+	rule.SharedState = false
+	if !reflect.DeepEqual(have, want) {
+		t.Errorf("shared state checker results mismatch:\nhave: %q\nwant: %q", have, want)
+	}
+}
+
+func TestRunnerPackageScope(t *testing.T) {
+	rule := findRule("dupFunc")
+	if !rule.PackageScope {
+		t.Fatalf("dupFunc is not a package scope checker")
+	}
+	pkgPath := testdataPkgPath + "dupFunc"
+	prog := newProg(t, pkgPath)
+	pkgInfo := prog.Imported[pkgPath]
+	ctx := NewContext(prog.Fset, sizes)
+	ctx.SetPackageInfo(&pkgInfo.Info, pkgInfo.Pkg)
+
+	// Duplicates from different files are reported when all
+	// package files are checked together.
+	isCrossFile := func(text string) bool {
+		return strings.Contains(text, "sumAll") && strings.Contains(text, "sumInts")
+	}
+	c := NewChecker(rule, ctx)
+	results, err := (&Runner{}).Run([]*Checker{c}, pkgInfo.Files)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	found := false
+	for _, r := range results {
+		found = found || isCrossFile(r.Text)
+	}
+	if !found {
+		t.Errorf("cross-file duplicate is not reported by Runner")
+	}
+
+	found = false
+	for i, warnings := range c.CheckPackage(pkgInfo.Files) {
+		for _, w := range warnings {
+			if prog.Fset.File(w.Pos) != prog.Fset.File(pkgInfo.Files[i].Pos()) {
+				t.Errorf("%s: warning is reported for the wrong file", w.Text)
+			}
+			found = found || isCrossFile(w.Text)
+		}
+	}
+	if !found {
+		t.Errorf("cross-file duplicate is not reported by CheckPackage")
+	}
+}
+
+func TestRunnerError(t *testing.T) {
+	pkgPath := testdataPkgPath + "unslice"
+	prog := newProg(t, pkgPath)
+	pkgInfo := prog.Imported[pkgPath]
+	ctx := NewContext(prog.Fset, sizes)
+	ctx.SetPackageInfo(&pkgInfo.Info, pkgInfo.Pkg)
+
+	checkers := []*Checker{
+		NewChecker(findRule("dupSubExpr"), ctx),
+		{
+			Rule:   findRule("unslice"),
+			ctx:    context{Context: ctx},
+			walker: panicWalker{value: errors.New("unexpected node")},
+		},
+	}
+	for _, workers := range []int{1, 2} {
+		r := Runner{Workers: workers}
+		_, err := r.Run(checkers, pkgInfo.Files)
+		if err == nil || err.Error() != "unslice: unexpected node" {
+			t.Errorf("workers=%d: expected checker error, got %v", workers, err)
+		}
+	}
+}
+
+func TestRunnerPanic(t *testing.T) {
+	pkgPath := testdataPkgPath + "unslice"
+	prog := newProg(t, pkgPath)
+	pkgInfo := prog.Imported[pkgPath]
+	ctx := NewContext(prog.Fset, sizes)
+	ctx.SetPackageInfo(&pkgInfo.Info, pkgInfo.Pkg)
+
+	checkers := []*Checker{
+		{
+			Rule:   findRule("unslice"),
+			ctx:    context{Context: ctx},
+			walker: panicWalker{value: "unexpected node"},
+		},
+	}
+	defer func() {
+		v := recover()
+		if s := fmt.Sprint(v); s != "unslice: unexpected node" {
+			t.Errorf("expected checker panic, got %q", s)
+		}
+	}()
+	r := Runner{Workers: 1}
+	r.Run(checkers, pkgInfo.Files)
+}
+
+// checkSharedTraversal checks that CheckFile gives the same
+// results as running every checker separately.
+func checkSharedTraversal(t *testing.T, prog *loader.Program, pkgPath string) {
+	pkgInfo := prog.Imported[pkgPath]
+	newCheckers := func() []*Checker {
+		ctx := NewContext(prog.Fset, sizes)
+		ctx.SetPackageInfo(&pkgInfo.Info, pkgInfo.Pkg)
+		checkers := make([]*Checker, len(ruleList))
+		for i, rule := range ruleList {
+			checkers[i] = NewChecker(rule, ctx)
+		}
+		return checkers
+	}
+	format := func(c *Checker, warnings []Warning) []string {
+		var lines []string
+		for _, w := range warnings {
+			pos := prog.Fset.Position(w.Node.Pos())
+			lines = append(lines, pos.String()+": "+c.Rule.Name()+": "+w.Text)
+		}
+		// Some checkers report warnings in map iteration order.
+		sort.Strings(lines)
+		return lines
+	}
+
+	separate := newCheckers()
+	shared := newCheckers()
+	for _, f := range pkgInfo.Files {
+		results := CheckFile(shared, f)
+		for i, c := range separate {
+			want := format(c, c.Check(f))
+			have := format(shared[i], results[i])
+			if !reflect.DeepEqual(have, want) {
+				t.Errorf("shared traversal: %s results mismatch:\nhave: %q\nwant: %q",
+					c.Rule.Name(), have, want)
+			}
+		}
+	}
+}
+
+type panicWalker struct {
+	value interface{}
+}
+
+func (w panicWalker) WalkFile(*ast.File) { panic(w.value) }
@@ -0,0 +1,274 @@
+package lint
+
+import (
+	"encoding/json"
+	"io"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// sarifReporter writes reports as SARIF 2.1.0 log.
+//
+// Rule metadata is taken from the checker documentation.
+// Only rules that have at least one result are described.
+//
+// See https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html.
+type sarifReporter struct{}
+
+// Types below describe the subset of SARIF format that is used by sarifReporter.
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	FullDescription      *sarifMessage      `json:"fullDescription,omitempty"`
+	Help                 sarifHelp          `json:"help"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+	Properties           sarifProperties    `json:"properties"`
+}
+
+type sarifHelp struct {
+	Text     string `json:"text"`
+	Markdown string `json:"markdown"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifProperties struct {
+	Tags       []string `json:"tags,omitempty"`
+	Confidence string   `json:"confidence,omitempty"`
+	ID         string   `json:"id,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID     string          `json:"ruleId"`
+	RuleIndex  int             `json:"ruleIndex"`
+	Level      string          `json:"level"`
+	Message    sarifMessage    `json:"message"`
+	Locations  []sarifLocation `json:"locations"`
+	Fixes      []sarifFix      `json:"fixes,omitempty"`
+	Properties sarifProperties `json:"properties"`
+
+	PartialFingerprints map[string]string `json:"partialFingerprints,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+	EndLine     int `json:"endLine"`
+	EndColumn   int `json:"endColumn"`
+}
+
+type sarifFix struct {
+	Description     sarifMessage          `json:"description"`
+	ArtifactChanges []sarifArtifactChange `json:"artifactChanges"`
+}
+
+type sarifArtifactChange struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Replacements     []sarifReplacement    `json:"replacements"`
+}
+
+type sarifReplacement struct {
+	DeletedRegion   sarifRegion  `json:"deletedRegion"`
+	InsertedContent sarifMessage `json:"insertedContent"`
+}
+
+func (sarifReporter) Write(w io.Writer, reports []Report) error {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "gocritic",
+			InformationURI: "https://github.com/go-critic/go-critic",
+			Rules:          []sarifRule{},
+		}},
+		Results: []sarifResult{},
+	}
+
+	ruleIndex := make(map[string]int)
+	for _, r := range reports {
+		ruleIndex[r.Checker] = 0
+	}
+	for _, info := range CheckersInfo() {
+		if _, ok := ruleIndex[info.Name]; !ok {
+			continue
+		}
+		ruleIndex[info.Name] = len(run.Tool.Driver.Rules)
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, newSarifRule(info))
+	}
+
+	for _, r := range reports {
+		result := sarifResult{
+			RuleID:    r.Checker,
+			RuleIndex: ruleIndex[r.Checker],
+			Level:     sarifLevel(r.Severity),
+			Message:   sarifMessage{Text: r.Text},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: sarifURI(r.Pos.Filename)},
+					Region:           newSarifRegion(r.Pos, r.End),
+				},
+			}},
+			Properties: sarifProperties{Confidence: r.Confidence.String(), ID: reportID(r)},
+		}
+		if len(r.Fixes) != 0 {
+			result.Fixes = []sarifFix{newSarifFix(r)}
+		}
+		if r.Fingerprint != "" {
+			result.PartialFingerprints = map[string]string{"gocritic/v1": r.Fingerprint}
+		}
+		run.Results = append(run.Results, result)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+	return enc.Encode(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	})
+}
+
+func newSarifRule(info CheckerInfo) sarifRule {
+	rule := sarifRule{
+		ID:                   info.Name,
+		ShortDescription:     sarifMessage{Text: info.Summary},
+		DefaultConfiguration: sarifConfiguration{Level: sarifLevel(SeverityWarning)},
+		Properties:           sarifProperties{Tags: info.Tags},
+	}
+	if info.Details != "" {
+		rule.FullDescription = &sarifMessage{Text: info.Details}
+	}
+
+	var text, markdown []string
+	text = append(text, info.Summary)
+	markdown = append(markdown, info.Summary)
+	if info.Details != "" {
+		text = append(text, info.Details)
+		markdown = append(markdown, info.Details)
+	}
+	text = append(text, "Before:\n"+info.Before, "After:\n"+info.After)
+	markdown = append(markdown,
+		"Before:\n```go\n"+info.Before+"\n```",
+		"After:\n```go\n"+info.After+"\n```")
+	if info.Note != "" {
+		text = append(text, info.Note)
+		markdown = append(markdown, info.Note)
+	}
+	rule.Help = sarifHelp{
+		Text:     strings.Join(text, "\n\n"),
+		Markdown: strings.Join(markdown, "\n\n"),
+	}
+	return rule
+}
+
+func newSarifFix(r Report) sarifFix {
+	fix := sarifFix{Description: sarifMessage{Text: "Apply " + r.Checker + " suggestion"}}
+
+	// Edits are grouped by file, keeping the first appearance order.
+	changes := make(map[string]int)
+	for _, edit := range r.Fixes {
+		i, ok := changes[edit.Pos.Filename]
+		if !ok {
+			i = len(fix.ArtifactChanges)
+			changes[edit.Pos.Filename] = i
+			fix.ArtifactChanges = append(fix.ArtifactChanges, sarifArtifactChange{
+				ArtifactLocation: sarifArtifactLocation{URI: sarifURI(edit.Pos.Filename)},
+			})
+		}
+		fix.ArtifactChanges[i].Replacements = append(fix.ArtifactChanges[i].Replacements, sarifReplacement{
+			DeletedRegion:   newSarifRegion(edit.Pos, edit.End),
+			InsertedContent: sarifMessage{Text: edit.NewText},
+		})
+	}
+	for _, change := range fix.ArtifactChanges {
+		// SARIF consumers expect replacements to be ordered.
+		sort.SliceStable(change.Replacements, func(i, j int) bool {
+			return change.Replacements[i].DeletedRegion.less(change.Replacements[j].DeletedRegion)
+		})
+	}
+	return fix
+}
+
+func newSarifRegion(pos, end Position) sarifRegion {
+	return sarifRegion{
+		StartLine:   pos.Line,
+		StartColumn: pos.Column,
+		EndLine:     end.Line,
+		EndColumn:   end.Column,
+	}
+}
+
+func (r sarifRegion) less(other sarifRegion) bool {
+	if r.StartLine != other.StartLine {
+		return r.StartLine < other.StartLine
+	}
+	return r.StartColumn < other.StartColumn
+}
+
+// sarifLevel maps severity to SARIF result level.
+func sarifLevel(sev Severity) string {
+	switch sev {
+	case SeverityInfo:
+		return "note"
+	case SeverityError:
+		return "error"
+	default:
+		return "warning"
+	}
+}
+
+// sarifURI returns artifact URI for the filename.
+// Absolute paths are converted to file URIs.
+func sarifURI(filename string) string {
+	filename = filepath.ToSlash(filename)
+	if !strings.HasPrefix(filename, "/") {
+		if filepath.IsAbs(filepath.FromSlash(filename)) {
+			// Windows volume path, like C:/dir/file.go.
+			filename = "/" + filename
+		} else {
+			return (&url.URL{Path: filename}).String()
+		}
+	}
+	return (&url.URL{Scheme: "file", Path: filename}).String()
+}
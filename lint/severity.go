@@ -0,0 +1,64 @@
+package lint
+
+import (
+	"fmt"
+)
+
+// Severity describes how important the reported issue is.
+//
+// Greater values mean more severe issues.
+type Severity int
+
+// Severity levels.
+const (
+	// SeverityInfo is used for advisory warnings that
+	// should not fail the build.
+	SeverityInfo Severity = iota + 1
+
+	// SeverityWarning is used for regular issues.
+	// This is a default severity level.
+	SeverityWarning
+
+	// SeverityError is used for issues that must be fixed.
+	SeverityError
+)
+
+// String returns severity level name.
+func (sev Severity) String() string {
+	switch sev {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	default:
+		return fmt.Sprintf("Severity(%d)", int(sev))
+	}
+}
+
+// ParseSeverity returns severity level by its name.
+// Valid names are "info", "warning" and "error".
+func ParseSeverity(s string) (Severity, error) {
+	for _, sev := range []Severity{SeverityInfo, SeverityWarning, SeverityError} {
+		if sev.String() == s {
+			return sev, nil
+		}
+	}
+	return 0, fmt.Errorf("%s: unknown severity level", s)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (sev Severity) MarshalText() ([]byte, error) {
+	return []byte(sev.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (sev *Severity) UnmarshalText(text []byte) error {
+	v, err := ParseSeverity(string(text))
+	if err != nil {
+		return err
+	}
+	*sev = v
+	return nil
+}
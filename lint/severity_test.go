@@ -0,0 +1,132 @@
+package lint
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseSeverity(t *testing.T) {
+	for _, sev := range []Severity{SeverityInfo, SeverityWarning, SeverityError} {
+		have, err := ParseSeverity(sev.String())
+		if err != nil {
+			t.Errorf("parse %s: unexpected error: %v", sev, err)
+			continue
+		}
+		if have != sev {
+			t.Errorf("parse %s: have %s", sev, have)
+		}
+	}
+	if _, err := ParseSeverity("fatal"); err == nil {
+		t.Errorf("expected error for unknown severity level")
+	}
+}
+
+func TestLevelOverrides(t *testing.T) {
+	rule := findRule("dupSubExpr")
+	if rule == nil {
+		t.Fatal("dupSubExpr rule not found")
+	}
+	pkgPath := testdataPkgPath + rule.Name()
+	prog := newProg(t, pkgPath)
+	pkgInfo := prog.Imported[pkgPath]
+
+	check := func(setup func(ctx *Context)) []Warning {
+		ctx := NewContext(prog.Fset, sizes)
+		ctx.SetPackageInfo(&pkgInfo.Info, pkgInfo.Pkg)
+		setup(ctx)
+		var warnings []Warning
+		c := NewChecker(rule, ctx)
+		for _, f := range pkgInfo.Files {
+			warnings = append(warnings, c.Check(f)...)
+		}
+		return warnings
+	}
+
+	// NaN checks are reported as info, other warnings have default severity.
+	defaultSeverity := func(w Warning) Severity {
+		if strings.Contains(w.Text, "NaN check") {
+			return SeverityInfo
+		}
+		return SeverityWarning
+	}
+
+	all := check(func(ctx *Context) {})
+	if len(all) == 0 {
+		t.Fatal("no warnings reported")
+	}
+	infos := 0
+	for _, w := range all {
+		if w.Severity != defaultSeverity(w) {
+			t.Errorf("%s: have %s severity by default", prog.Fset.Position(w.Node.Pos()), w.Severity)
+		}
+		if w.Severity == SeverityInfo {
+			infos++
+		}
+	}
+	if infos == 0 || infos == len(all) {
+		t.Fatalf("testdata should contain both info and warning severity warnings")
+	}
+
+	overridden := check(func(ctx *Context) {
+		if err := ctx.SetSeverityOverride(rule.Name(), SeverityError); err != nil {
+			t.Fatalf("set severity override: %v", err)
+		}
+		if err := ctx.SetConfidenceOverride(rule.Name(), ConfidenceLow); err != nil {
+			t.Fatalf("set confidence override: %v", err)
+		}
+	})
+	if len(overridden) != len(all) {
+		t.Errorf("overrides changed warnings count: have %d, want %d", len(overridden), len(all))
+	}
+	for _, w := range overridden {
+		if w.Severity != SeverityError || w.Confidence != ConfidenceLow {
+			t.Errorf("%s: have %s severity and %s confidence, want error and low",
+				prog.Fset.Position(w.Node.Pos()), w.Severity, w.Confidence)
+		}
+	}
+
+	// Overridden confidence is used for filtering.
+	filtered := check(func(ctx *Context) {
+		ctx.SetMinConfidence(ConfidenceMedium)
+		if err := ctx.SetConfidenceOverride(rule.Name(), ConfidenceLow); err != nil {
+			t.Fatalf("set confidence override: %v", err)
+		}
+	})
+	if len(filtered) != 0 {
+		t.Errorf("have %d warnings, want all of them filtered", len(filtered))
+	}
+
+	// Overridden severity is used for filtering too.
+	if len(check(func(ctx *Context) { ctx.SetMinSeverity(SeverityWarning) })) != len(all)-infos {
+		t.Errorf("only warnings should pass the warning severity threshold")
+	}
+	filtered = check(func(ctx *Context) {
+		ctx.SetMinSeverity(SeverityWarning)
+		if err := ctx.SetSeverityOverride(rule.Name(), SeverityInfo); err != nil {
+			t.Fatalf("set severity override: %v", err)
+		}
+	})
+	if len(filtered) != 0 {
+		t.Errorf("have %d warnings, want all info warnings filtered", len(filtered))
+	}
+
+	// Overrides of other checkers have no effect.
+	other := check(func(ctx *Context) {
+		if err := ctx.SetSeverityOverride("appendAssign", SeverityInfo); err != nil {
+			t.Fatalf("set severity override: %v", err)
+		}
+	})
+	for _, w := range other {
+		if w.Severity != defaultSeverity(w) {
+			t.Errorf("%s: have %s severity, want %s", prog.Fset.Position(w.Node.Pos()), w.Severity, defaultSeverity(w))
+		}
+	}
+
+	ctx := NewContext(prog.Fset, sizes)
+	if err := ctx.SetSeverityOverride("noSuchChecker", SeverityError); err == nil {
+		t.Errorf("expected error for unknown checker severity override")
+	}
+	if err := ctx.SetConfidenceOverride("noSuchChecker", ConfidenceHigh); err == nil {
+		t.Errorf("expected error for unknown checker confidence override")
+	}
+}
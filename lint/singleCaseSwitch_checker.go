@@ -2,6 +2,10 @@ package lint
 
 //! Detects switch statements that could be better written as if statements.
 //
+// Type switches get a type assertion suggestion.
+// Switches with a break statement inside the case body are not reported,
+// since break would refer to the enclosing loop after the rewrite.
+//
 // @Before:
 // switch x := x.(type) {
 // case int:
@@ -35,20 +39,54 @@ func (c *singleCaseSwitchChecker) VisitStmt(stmt ast.Stmt) {
 }
 
 func (c *singleCaseSwitchChecker) checkSwitchStmt(stmt ast.Stmt, body *ast.BlockStmt) {
-	if len(body.List) == 1 {
-		if body.List[0].(*ast.CaseClause).List == nil {
-			// default case.
-			c.warnDefault(stmt)
-		} else if len(body.List[0].(*ast.CaseClause).List) == 1 {
-			c.warn(stmt)
-		}
+	if len(body.List) != 1 {
+		return
+	}
+	cc := body.List[0].(*ast.CaseClause)
+	if caseHasBreak(cc) {
+		return
+	}
+	switch {
+	case cc.List == nil:
+		// default case.
+		c.warnDefault(stmt)
+	case len(cc.List) != 1:
+		return
+	case c.isTypeSwitch(stmt) && !c.isNilCase(cc):
+		c.warnTypeSwitch(stmt.(*ast.TypeSwitchStmt), cc.List[0])
+	default:
+		c.warn(stmt)
 	}
 }
 
+func (c *singleCaseSwitchChecker) isTypeSwitch(stmt ast.Stmt) bool {
+	_, ok := stmt.(*ast.TypeSwitchStmt)
+	return ok
+}
+
+func (c *singleCaseSwitchChecker) isNilCase(cc *ast.CaseClause) bool {
+	id, ok := cc.List[0].(*ast.Ident)
+	return ok && id.Name == "nil"
+}
+
 func (c *singleCaseSwitchChecker) warn(stmt ast.Stmt) {
-	c.ctx.Warn(stmt, "should rewrite switch statement to if statement")
+	c.ctx.WarnWith(Warning{Node: stmt, Code: "ifStmt"}, "should rewrite switch statement to if statement")
+}
+
+func (c *singleCaseSwitchChecker) warnTypeSwitch(stmt *ast.TypeSwitchStmt, typ ast.Expr) {
+	name := "_"
+	var assert *ast.TypeAssertExpr
+	switch x := stmt.Assign.(type) {
+	case *ast.AssignStmt:
+		name = x.Lhs[0].(*ast.Ident).Name
+		assert = x.Rhs[0].(*ast.TypeAssertExpr)
+	case *ast.ExprStmt:
+		assert = x.X.(*ast.TypeAssertExpr)
+	}
+	c.ctx.WarnWith(Warning{Node: stmt, Code: "typeAssertion"}, "should rewrite switch statement to `if %s, ok := %s.(%s); ok` statement",
+		name, assert.X, typ)
 }
 
 func (c *singleCaseSwitchChecker) warnDefault(stmt ast.Stmt) {
-	c.ctx.Warn(stmt, "found switch with default case only")
+	c.ctx.WarnWith(Warning{Node: stmt, Code: "defaultOnly"}, "found switch with default case only")
 }
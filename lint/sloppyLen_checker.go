@@ -0,0 +1,86 @@
+package lint
+
+//! Detects len() comparisons that are always true, always false or can be simplified.
+//
+// Length can't be negative, so `len(x) >= 0` is always true,
+// `len(x) < 0` is always false and `len(x) <= 0` means `len(x) == 0`.
+//
+// @Before:
+// if len(arr) <= 0 {
+// 	return
+// }
+//
+// @After:
+// if len(arr) == 0 {
+// 	return
+// }
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+func init() {
+	addChecker(&sloppyLenChecker{}, attrExperimental)
+}
+
+type sloppyLenChecker struct {
+	checkerBase
+}
+
+func (c *sloppyLenChecker) VisitLocalExpr(expr ast.Expr) {
+	bin, ok := expr.(*ast.BinaryExpr)
+	if !ok {
+		return
+	}
+
+	// Normalize `0 op len(x)` to the `len(x) op 0` form.
+	op := bin.Op
+	lenCall, zero := bin.X, bin.Y
+	if c.isLenCall(bin.Y) {
+		lenCall, zero, op = bin.Y, bin.X, swappedCmpOp(op)
+	} else if !c.isLenCall(bin.X) {
+		return
+	}
+	if !c.ctx.isConstZero(zero) {
+		return
+	}
+
+	if result, ok := c.ctx.foldCmp(lenCall, op, zero); ok {
+		c.warnConst(bin, result)
+	} else if op == token.LEQ {
+		c.warnEqual(bin, lenCall, zero)
+	}
+}
+
+// isLenCall reports whether x is a builtin len call with
+// a slice, map, string or chan argument.
+func (c *sloppyLenChecker) isLenCall(x ast.Expr) bool {
+	call, ok := x.(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 || !c.ctx.isBuiltinCall(call, "len") {
+		return false
+	}
+	typ := c.ctx.typesInfo.TypeOf(call.Args[0])
+	if typ == nil {
+		return false
+	}
+	switch typ := typ.Underlying().(type) {
+	case *types.Slice, *types.Map, *types.Chan:
+		return true
+	case *types.Basic:
+		return typ.Info()&types.IsString != 0
+	default:
+		return false
+	}
+}
+
+func (c *sloppyLenChecker) warnConst(cause *ast.BinaryExpr, result bool) {
+	c.ctx.Warn(cause, "`%s` is always %v", cause, result)
+}
+
+func (c *sloppyLenChecker) warnEqual(cause *ast.BinaryExpr, lenCall, zero ast.Expr) {
+	suggestion := &ast.BinaryExpr{X: lenCall, Op: token.EQL, Y: zero}
+	fix := []TextEdit{c.ctx.replaceNode(cause, suggestion)}
+	c.ctx.WarnWithFix(fix, cause, "`%s` can be `%s`", cause, suggestion)
+}
@@ -0,0 +1,84 @@
+package lint
+
+//! Detects fmt.Sprintf("%d", n) calls with int argument that can use strconv.Itoa.
+//
+// strconv.Itoa avoids fmt formatting and reflection overhead.
+//
+// @Before:
+// s := fmt.Sprintf("%d", n)
+//
+// @After:
+// s := strconv.Itoa(n)
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+)
+
+func init() {
+	addChecker(&sprintfIntChecker{}, attrExperimental, attrPerformance, attrSkipTests)
+}
+
+type sprintfIntChecker struct {
+	checkerBase
+}
+
+func (c *sprintfIntChecker) VisitExpr(expr ast.Expr) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || len(call.Args) != 2 || call.Ellipsis.IsValid() || c.ctx.calleeName(call) != "fmt.Sprintf" {
+		return
+	}
+	format, ok := call.Args[0].(*ast.BasicLit)
+	if !ok || format.Kind != token.STRING {
+		return
+	}
+	if s, err := strconv.Unquote(format.Value); err != nil || s != "%d" {
+		return
+	}
+	arg := call.Args[1]
+	if !types.Identical(c.ctx.typesInfo.TypeOf(arg), types.Typ[types.Int]) {
+		return
+	}
+	c.warn(call, arg)
+}
+
+// fix returns edits that replace call with strconv.Itoa call.
+//
+// If fmt is not used anywhere else, its import is removed or,
+// if possible, turned into the strconv import.
+// Import that is used only by several fixed calls is removed by ApplyFixes.
+func (c *sprintfIntChecker) fix(call *ast.CallExpr, arg ast.Expr) []TextEdit {
+	importEdit, ok := c.ctx.importEdit("strconv", call.Pos())
+	if !ok {
+		return nil
+	}
+	edits := []TextEdit{{
+		Pos:     call.Pos(),
+		End:     call.End(),
+		NewText: c.ctx.printer.Sprintf("strconv.Itoa(%s)", arg),
+	}}
+	unused := c.ctx.unusedImport("fmt", call)
+	switch {
+	case unused != nil && unused.Name != nil && importEdit != nil:
+		// Adding strconv import near the removed import is error-prone.
+		return nil
+	case unused != nil && importEdit != nil:
+		edits = append(edits, TextEdit{
+			Pos:     unused.Path.Pos(),
+			End:     unused.Path.End(),
+			NewText: strconv.Quote("strconv"),
+		})
+	case unused != nil:
+		edits = append(edits, c.ctx.deleteImportEdit(unused))
+	case importEdit != nil:
+		edits = append(edits, *importEdit)
+	}
+	return edits
+}
+
+func (c *sprintfIntChecker) warn(cause *ast.CallExpr, arg ast.Expr) {
+	c.ctx.WarnWithFix(c.fix(cause, arg), cause,
+		`fmt.Sprintf("%%d", %s) can be strconv.Itoa(%s) for better performance`, arg, arg)
+}
@@ -0,0 +1,117 @@
+package lint
+
+//! Detects "%s" formatting directives wrapped in quotes and redundant fmt.Sprintf("%s", s) calls.
+//
+// %q verb quotes and escapes the value, so it's better
+// than manual quoting that produces broken output for
+// strings that contain quotes or control characters.
+//
+// @Before:
+// fmt.Printf("unknown name \"%s\"\n", name)
+// s := fmt.Sprintf("%s", name)
+//
+// @After:
+// fmt.Printf("unknown name %q\n", name)
+// s := name
+//
+// @Note:
+// Values of named string types are converted to string.
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	addChecker(&sprintfQuotedStringChecker{}, attrExperimental)
+}
+
+type sprintfQuotedStringChecker struct {
+	checkerBase
+}
+
+func (c *sprintfQuotedStringChecker) VisitExpr(expr ast.Expr) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return
+	}
+	p, ok := c.ctx.printfCallOf(call)
+	if !ok {
+		return
+	}
+
+	if p.name == "fmt.Sprintf" && p.format == "%s" && len(p.args) == 1 {
+		arg := p.args[0]
+		if types.Identical(c.ctx.typesInfo.TypeOf(arg).Underlying(), types.Typ[types.String]) &&
+			!c.hasMethods(c.ctx.typesInfo.TypeOf(arg)) {
+			c.warnRedundant(call, arg)
+			return
+		}
+	}
+
+	var quoted []printfVerb
+	for _, v := range p.verbs {
+		if v.verb == 's' && v.simple() && v.start > 0 && v.end < len(p.format) &&
+			p.format[v.start-1] == '"' && p.format[v.end] == '"' {
+			quoted = append(quoted, v)
+		}
+	}
+	if len(quoted) != 0 {
+		c.warnQuoted(p.formatArg, c.quotedFix(p, quoted))
+	}
+}
+
+// hasMethods reports whether typ has any methods,
+// as String or Error method can change %s formatting.
+func (c *sprintfQuotedStringChecker) hasMethods(typ types.Type) bool {
+	return types.NewMethodSet(typ).Len() != 0
+}
+
+// quotedFix returns an edit that replaces quoted %s verbs with %q.
+// Returns nil if format is not a string literal.
+func (c *sprintfQuotedStringChecker) quotedFix(p printfCall, quoted []printfVerb) []TextEdit {
+	lit, ok := p.formatArg.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return nil
+	}
+	var buf strings.Builder
+	prev := 0
+	for _, v := range quoted {
+		buf.WriteString(p.format[prev : v.start-1])
+		buf.WriteString("%q")
+		prev = v.end + 1
+	}
+	buf.WriteString(p.format[prev:])
+	format := buf.String()
+
+	value := strconv.Quote(format)
+	if strings.HasPrefix(lit.Value, "`") {
+		value = "`" + format + "`"
+	}
+	return []TextEdit{{Pos: lit.Pos(), End: lit.End(), NewText: value}}
+}
+
+func (c *sprintfQuotedStringChecker) warnQuoted(cause ast.Node, fix []TextEdit) {
+	c.ctx.WarnWith(Warning{Node: cause, Code: "quotedVerb", Fix: fix},
+		`use %%q instead of "%%s" for quoted strings`)
+}
+
+func (c *sprintfQuotedStringChecker) warnRedundant(cause *ast.CallExpr, arg ast.Expr) {
+	repl := parenIfNeeded(arg)
+	if !types.Identical(c.ctx.typesInfo.TypeOf(arg), types.Typ[types.String]) {
+		// Sprintf result is a plain string, while arg has a named type.
+		repl = &ast.CallExpr{Fun: ast.NewIdent("string"), Args: []ast.Expr{arg}}
+	}
+	fix := []TextEdit{c.ctx.replaceNode(cause, repl)}
+	if spec := c.ctx.unusedImport("fmt", cause); spec != nil {
+		fix = append(fix, c.ctx.deleteImportEdit(spec))
+	}
+	c.ctx.WarnWith(Warning{
+		Node: cause,
+		Code: "redundantSprintf",
+		Fix:  fix,
+	}, "%s is already a string; fmt.Sprintf call is redundant", arg)
+}
@@ -0,0 +1,244 @@
+package lint
+
+import (
+	"fmt"
+	"go/ast"
+	"io"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/go-critic/go-critic/lint/internal/astwalk"
+)
+
+// CheckerStats describes the work done by the checker.
+// Collected only if Context.SetCollectStats was called.
+type CheckerStats struct {
+	// Checker is a checker rule name.
+	Checker string
+
+	// Files is a number of checked files.
+	Files int
+
+	// Visits is a number of checker visitor calls.
+	// Usually, visitor is called once per AST node it's interested in.
+	Visits int
+
+	// Warnings is a number of reported warnings.
+	Warnings int
+
+	// Duration is a total wall time spent in visitor calls.
+	Duration time.Duration
+}
+
+// SetCollectStats makes checkers that are created after the
+// call collect CheckerStats, which are returned by Checker.Stats.
+//
+// Collecting stats makes checkers slightly slower.
+func (c *Context) SetCollectStats(collect bool) {
+	c.collectStats = collect
+}
+
+// Stats returns checker stats accumulated over all Check
+// and CheckFile calls. Results restored from Cache are not counted.
+//
+// Stats are zero if checker doesn't collect them,
+// see Context.SetCollectStats.
+func (c *Checker) Stats() CheckerStats {
+	if c.ctx.stats == nil {
+		return CheckerStats{Checker: c.Rule.Name()}
+	}
+	return *c.ctx.stats
+}
+
+// WriteStats writes stats as a table to w.
+// Rows are sorted by duration, the slowest checkers go first.
+func WriteStats(w io.Writer, stats []CheckerStats) error {
+	stats = append([]CheckerStats(nil), stats...)
+	sort.SliceStable(stats, func(i, j int) bool {
+		if stats[i].Duration != stats[j].Duration {
+			return stats[i].Duration > stats[j].Duration
+		}
+		return stats[i].Checker < stats[j].Checker
+	})
+
+	total := CheckerStats{Checker: "total"}
+	for _, s := range stats {
+		total.Visits += s.Visits
+		total.Warnings += s.Warnings
+		total.Duration += s.Duration
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(tw, "checker\ttime\ttime%%\tfiles\tvisits\twarnings\n")
+	for _, s := range append(stats, total) {
+		share := 0.0
+		if total.Duration != 0 {
+			share = 100 * float64(s.Duration) / float64(total.Duration)
+		}
+		files := fmt.Sprint(s.Files)
+		if s.Checker == total.Checker {
+			files = ""
+		}
+		fmt.Fprintf(tw, "%s\t%v\t%.1f\t%s\t%d\t%d\n",
+			s.Checker, s.Duration.Round(time.Microsecond), share, files, s.Visits, s.Warnings)
+	}
+	return tw.Flush()
+}
+
+// endFile updates checker stats after f was checked.
+func (c *Checker) endFile() {
+	if s := c.ctx.stats; s != nil {
+		s.Files++
+		s.Warnings += len(c.ctx.warnings)
+	}
+}
+
+// visit records a visitor call that started at the specified time.
+func (s *CheckerStats) visit(start time.Time) {
+	s.Visits++
+	s.Duration += time.Since(start)
+}
+
+// instrument returns a visitor that updates s on every v visit.
+// Returned visitor implements the same visitor interface
+// that is used by newFileWalker for v.
+func (s *CheckerStats) instrument(v interface{}) interface{} {
+	switch v := v.(type) {
+	case astwalk.FuncDeclVisitor:
+		return funcDeclStatsVisitor{v, s}
+	case astwalk.ExprVisitor:
+		return exprStatsVisitor{v, s}
+	case astwalk.LocalExprVisitor:
+		return localExprStatsVisitor{v, s}
+	case astwalk.StmtListVisitor:
+		return stmtListStatsVisitor{v, s}
+	case astwalk.StmtVisitor:
+		return stmtStatsVisitor{v, s}
+	case astwalk.LocalDefVisitor:
+		return localDefStatsVisitor{v, s}
+	case astwalk.TypeExprVisitor:
+		return typeExprStatsVisitor{v, s}
+	case astwalk.LocalCommentVisitor:
+		return localCommentStatsVisitor{v, s}
+	case astwalk.CommentVisitor:
+		return commentStatsVisitor{v, s}
+	case astwalk.DocCommentVisitor:
+		return docCommentStatsVisitor{v, s}
+	case astwalk.DeclVisitor:
+		return declStatsVisitor{v, s}
+	default:
+		// Let newFileWalker report the error.
+		return v
+	}
+}
+
+type funcDeclStatsVisitor struct {
+	astwalk.FuncDeclVisitor
+	s *CheckerStats
+}
+
+func (v funcDeclStatsVisitor) VisitFuncDecl(decl *ast.FuncDecl) {
+	defer v.s.visit(time.Now())
+	v.FuncDeclVisitor.VisitFuncDecl(decl)
+}
+
+type exprStatsVisitor struct {
+	astwalk.ExprVisitor
+	s *CheckerStats
+}
+
+func (v exprStatsVisitor) VisitExpr(x ast.Expr) {
+	defer v.s.visit(time.Now())
+	v.ExprVisitor.VisitExpr(x)
+}
+
+type localExprStatsVisitor struct {
+	astwalk.LocalExprVisitor
+	s *CheckerStats
+}
+
+func (v localExprStatsVisitor) VisitLocalExpr(x ast.Expr) {
+	defer v.s.visit(time.Now())
+	v.LocalExprVisitor.VisitLocalExpr(x)
+}
+
+type stmtListStatsVisitor struct {
+	astwalk.StmtListVisitor
+	s *CheckerStats
+}
+
+func (v stmtListStatsVisitor) VisitStmtList(list []ast.Stmt) {
+	defer v.s.visit(time.Now())
+	v.StmtListVisitor.VisitStmtList(list)
+}
+
+type stmtStatsVisitor struct {
+	astwalk.StmtVisitor
+	s *CheckerStats
+}
+
+func (v stmtStatsVisitor) VisitStmt(stmt ast.Stmt) {
+	defer v.s.visit(time.Now())
+	v.StmtVisitor.VisitStmt(stmt)
+}
+
+type localDefStatsVisitor struct {
+	astwalk.LocalDefVisitor
+	s *CheckerStats
+}
+
+func (v localDefStatsVisitor) VisitLocalDef(name astwalk.Name, x ast.Expr) {
+	defer v.s.visit(time.Now())
+	v.LocalDefVisitor.VisitLocalDef(name, x)
+}
+
+type typeExprStatsVisitor struct {
+	astwalk.TypeExprVisitor
+	s *CheckerStats
+}
+
+func (v typeExprStatsVisitor) VisitTypeExpr(x ast.Expr) {
+	defer v.s.visit(time.Now())
+	v.TypeExprVisitor.VisitTypeExpr(x)
+}
+
+type localCommentStatsVisitor struct {
+	astwalk.LocalCommentVisitor
+	s *CheckerStats
+}
+
+func (v localCommentStatsVisitor) VisitLocalComment(cg *ast.CommentGroup) {
+	defer v.s.visit(time.Now())
+	v.LocalCommentVisitor.VisitLocalComment(cg)
+}
+
+type commentStatsVisitor struct {
+	astwalk.CommentVisitor
+	s *CheckerStats
+}
+
+func (v commentStatsVisitor) VisitComment(cg *ast.CommentGroup) {
+	defer v.s.visit(time.Now())
+	v.CommentVisitor.VisitComment(cg)
+}
+
+type docCommentStatsVisitor struct {
+	astwalk.DocCommentVisitor
+	s *CheckerStats
+}
+
+func (v docCommentStatsVisitor) VisitDocComment(cg *ast.CommentGroup) {
+	defer v.s.visit(time.Now())
+	v.DocCommentVisitor.VisitDocComment(cg)
+}
+
+type declStatsVisitor struct {
+	astwalk.DeclVisitor
+	s *CheckerStats
+}
+
+func (v declStatsVisitor) VisitDecl(decl ast.Decl) {
+	defer v.s.visit(time.Now())
+	v.DeclVisitor.VisitDecl(decl)
+}
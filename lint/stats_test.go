@@ -0,0 +1,78 @@
+package lint
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestCheckerStats(t *testing.T) {
+	pkgPath := testdataPkgPath + "dupSubExpr"
+	prog := newProg(t, pkgPath)
+	pkgInfo := prog.Imported[pkgPath]
+	ctx := NewContext(prog.Fset, sizes)
+	ctx.SetPackageInfo(&pkgInfo.Info, pkgInfo.Pkg)
+
+	names := []string{"dupSubExpr", "typeUnparen", "unusedParam"}
+	var checkers []*Checker
+	for _, name := range names {
+		checkers = append(checkers, NewChecker(findRule(name), ctx))
+	}
+	ctx.SetCollectStats(true)
+	warnings := make(map[string]int)
+	for _, name := range names {
+		c := NewChecker(findRule(name), ctx)
+		for _, f := range pkgInfo.Files {
+			warnings[name] += len(c.Check(f))
+		}
+		checkers = append(checkers, c)
+	}
+	// Shared traversal is counted the same way.
+	for _, f := range pkgInfo.Files {
+		CheckFile(checkers[len(names):], f)
+	}
+
+	for i, c := range checkers {
+		stats := c.Stats()
+		if stats.Checker != c.Rule.Name() {
+			t.Errorf("%s: have %q checker name", c.Rule, stats.Checker)
+		}
+		if i < len(names) {
+			if stats != (CheckerStats{Checker: c.Rule.Name()}) {
+				t.Errorf("%s: have %+v stats, want zero", c.Rule, stats)
+			}
+			continue
+		}
+		if want := 2 * len(pkgInfo.Files); stats.Files != want {
+			t.Errorf("%s: have %d files, want %d", c.Rule, stats.Files, want)
+		}
+		if want := 2 * warnings[c.Rule.Name()]; stats.Warnings != want {
+			t.Errorf("%s: have %d warnings, want %d", c.Rule, stats.Warnings, want)
+		}
+		if stats.Visits == 0 || stats.Visits%2 != 0 {
+			t.Errorf("%s: have %d visits, want even non-zero number", c.Rule, stats.Visits)
+		}
+	}
+}
+
+func TestWriteStats(t *testing.T) {
+	stats := []CheckerStats{
+		{Checker: "unslice", Files: 2, Visits: 100, Warnings: 1, Duration: 250 * time.Microsecond},
+		{Checker: "dupSubExpr", Files: 2, Visits: 300, Warnings: 0, Duration: 750 * time.Microsecond},
+	}
+	var buf bytes.Buffer
+	if err := WriteStats(&buf, stats); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	want := `checker     time   time%  files  visits  warnings
+dupSubExpr  750µs  75.0   2      300     0
+unslice     250µs  25.0   2      100     1
+total       1ms    100.0         400     1
+`
+	if buf.String() != want {
+		t.Errorf("stats table mismatch:\nhave:\n%s\nwant:\n%s", buf.String(), want)
+	}
+	if stats[0].Checker != "unslice" {
+		t.Errorf("stats slice was modified")
+	}
+}
@@ -0,0 +1,181 @@
+package lint
+
+//! Detects redundant conversions between string and []byte.
+//
+// Reports conversion round-trips like `string([]byte(s))`,
+// `copy(b, []byte(s))`, as copy accepts string source,
+// and `w.Write([]byte(fmt.Sprintf(...)))` that can use fmt.Fprintf.
+//
+// @Before:
+// copy(b, []byte(s))
+// w.Write([]byte(fmt.Sprintf("%d items", n)))
+//
+// @After:
+// copy(b, s)
+// fmt.Fprintf(w, "%d items", n)
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+func init() {
+	addChecker(&stringXbytesChecker{}, attrExperimental, attrPerformance)
+}
+
+type stringXbytesChecker struct {
+	checkerBase
+
+	// fprintFuncs maps fmt printing functions
+	// that return string to their io.Writer versions.
+	fprintFuncs map[string]string
+
+	// writerType is an io.Writer interface type.
+	writerType *types.Interface
+}
+
+func (c *stringXbytesChecker) Init() {
+	c.fprintFuncs = map[string]string{
+		"fmt.Sprint":   "Fprint",
+		"fmt.Sprintf":  "Fprintf",
+		"fmt.Sprintln": "Fprintln",
+	}
+
+	params := types.NewTuple(types.NewVar(token.NoPos, nil, "p", types.NewSlice(types.Typ[types.Byte])))
+	results := types.NewTuple(
+		types.NewVar(token.NoPos, nil, "n", types.Typ[types.Int]),
+		types.NewVar(token.NoPos, nil, "err", types.Universe.Lookup("error").Type()))
+	write := types.NewFunc(token.NoPos, nil, "Write", types.NewSignatureType(nil, nil, nil, params, results, false))
+	c.writerType = types.NewInterfaceType([]*types.Func{write}, nil).Complete()
+}
+
+func (c *stringXbytesChecker) VisitExpr(expr ast.Expr) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || len(call.Args) == 0 {
+		return
+	}
+	switch {
+	case c.ctx.isBuiltinCall(call, "copy"):
+		c.checkCopy(call)
+	case c.isConversion(call):
+		c.checkRoundTrip(call)
+	case c.isWriteMethod(call):
+		c.checkWrite(call)
+	}
+}
+
+// checkRoundTrip reports `T(U(x))` conversions where x has T type
+// and one of T and U is a string while the other is a []byte.
+func (c *stringXbytesChecker) checkRoundTrip(outer *ast.CallExpr) {
+	inner, ok := astutil.Unparen(outer.Args[0]).(*ast.CallExpr)
+	if !ok || !c.isConversion(inner) {
+		return
+	}
+	x := inner.Args[0]
+	outerType := c.ctx.typesInfo.TypeOf(outer)
+	innerType := c.ctx.typesInfo.TypeOf(inner)
+	if !types.Identical(outerType, c.ctx.typesInfo.TypeOf(x)) {
+		return
+	}
+	switch {
+	case c.isString(outerType) && c.isBytes(innerType):
+		// Strings are immutable, so x can be used as is.
+		// Conversion may be an operand, like in `string([]byte(*p))[0]`.
+		fix := []TextEdit{c.ctx.replaceNode(outer, parenIfNeeded(x))}
+		c.ctx.WarnWith(Warning{Node: outer, Code: "roundTrip", Fix: fix},
+			"redundant conversions; %s can be simplified to %s", outer, x)
+	case c.isBytes(outerType) && c.isString(innerType):
+		c.ctx.WarnWith(Warning{Node: outer, Code: "doubleCopy"}, "%s copies %s twice; use bytes.Clone(%s) or %s itself", outer, x, x, x)
+	}
+}
+
+// checkCopy reports `copy(b, []byte(s))` calls.
+func (c *stringXbytesChecker) checkCopy(call *ast.CallExpr) {
+	if len(call.Args) != 2 {
+		return
+	}
+	conv, ok := astutil.Unparen(call.Args[1]).(*ast.CallExpr)
+	if !ok || !c.isConversion(conv) || !c.isBytes(c.ctx.typesInfo.TypeOf(conv)) {
+		return
+	}
+	s := conv.Args[0]
+	if !c.isString(c.ctx.typesInfo.TypeOf(s)) {
+		return
+	}
+	fix := []TextEdit{c.ctx.replaceNode(call.Args[1], s)}
+	c.ctx.WarnWith(Warning{Node: call, Code: "copySource", Fix: fix},
+		"can simplify `%s` to `%s`; copy accepts string source", call.Args[1], s)
+}
+
+// checkWrite reports `w.Write([]byte(fmt.Sprintf(...)))` calls.
+func (c *stringXbytesChecker) checkWrite(call *ast.CallExpr) {
+	conv, ok := astutil.Unparen(call.Args[0]).(*ast.CallExpr)
+	if !ok || !c.isConversion(conv) || !c.isBytes(c.ctx.typesInfo.TypeOf(conv)) {
+		return
+	}
+	sprint, ok := astutil.Unparen(conv.Args[0]).(*ast.CallExpr)
+	if !ok {
+		return
+	}
+	fprint, ok := c.fprintFuncs[c.ctx.calleeName(sprint)]
+	if !ok {
+		return
+	}
+	fmtSel, ok := astutil.Unparen(sprint.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+	w := call.Fun.(*ast.SelectorExpr).X
+	switch typ := c.ctx.typesInfo.TypeOf(w); {
+	case types.Implements(typ, c.writerType):
+		// Can be passed as is.
+	case types.Implements(types.NewPointer(typ), c.writerType):
+		// Write has a pointer receiver, so w is addressable,
+		// as it's implicitly taken by the w.Write call.
+		w = &ast.UnaryExpr{Op: token.AND, X: w}
+	default:
+		return
+	}
+	suggestion := &ast.CallExpr{
+		Fun:      &ast.SelectorExpr{X: fmtSel.X, Sel: ast.NewIdent(fprint)},
+		Args:     append([]ast.Expr{w}, sprint.Args...),
+		Ellipsis: sprint.Ellipsis,
+	}
+	fix := []TextEdit{c.ctx.replaceNode(call, suggestion)}
+	c.ctx.WarnWith(Warning{Node: call, Code: "writeSprintf", Fix: fix}, "%s can be %s", call, suggestion)
+}
+
+// isConversion reports whether call is a single argument type conversion.
+func (c *stringXbytesChecker) isConversion(call *ast.CallExpr) bool {
+	return len(call.Args) == 1 && c.ctx.typesInfo.Types[call.Fun].IsType()
+}
+
+// isWriteMethod reports whether call is an io.Writer-like Write method call.
+func (c *stringXbytesChecker) isWriteMethod(call *ast.CallExpr) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Write" || len(call.Args) != 1 {
+		return false
+	}
+	fn, ok := c.ctx.typesInfo.ObjectOf(sel.Sel).(*types.Func)
+	if !ok {
+		return false
+	}
+	sig := fn.Type().(*types.Signature)
+	if sig.Recv() == nil || sig.Params().Len() != 1 || sig.Results().Len() != 2 {
+		return false
+	}
+	return types.Identical(sig.Params().At(0).Type(), types.NewSlice(types.Typ[types.Byte])) &&
+		types.Identical(sig.Results().At(0).Type(), types.Typ[types.Int]) &&
+		types.Identical(sig.Results().At(1).Type(), types.Universe.Lookup("error").Type())
+}
+
+func (c *stringXbytesChecker) isString(typ types.Type) bool {
+	return types.Identical(typ, types.Typ[types.String])
+}
+
+func (c *stringXbytesChecker) isBytes(typ types.Type) bool {
+	return types.Identical(typ, types.NewSlice(types.Typ[types.Byte]))
+}
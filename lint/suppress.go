@@ -0,0 +1,257 @@
+package lint
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+	"sync"
+)
+
+// Suppression comments silence warnings without disabling checkers:
+//
+//	//nolint:gocritic              - line-level, compatible with golangci-lint
+//	//gocritic:ignore name1,name2  - line-level, for specified checkers
+//	//gocritic:disable name1       - block-level, until the matching
+//	//gocritic:enable name1          comment or the end of file
+//
+// Checker names list can be omitted to suppress all checkers.
+// Warning IDs, like boolExprSimplify/doubleNegation, can be used
+// instead of names to suppress only the specific kind of warnings.
+// Explanation can follow the directive after "//", like
+// `//gocritic:ignore dupSubExpr // intentional`.
+// Line-level comment that is placed after code suppresses warnings
+// on the same line. Otherwise, it suppresses warnings of the
+// next statement or declaration, whole its lines range.
+
+// suppression is a parsed suppression comment.
+type suppression struct {
+	comment *ast.Comment
+
+	// checkers is a set of suppressed checkers.
+	// Nil set means all checkers.
+	checkers map[string]bool
+
+	// fromLine and toLine is a suppressed lines range (inclusive).
+	fromLine int
+	toLine   int
+
+	used bool
+}
+
+// suppressionSet is a list of suppressions of a single file.
+//
+// It's shared by checkers, so it's guarded by mutex.
+type suppressionSet struct {
+	mu sync.Mutex
+
+	list []*suppression
+
+	// ran is a set of checkers that checked the file.
+	ran map[string]bool
+}
+
+// match reports whether warning of checker with the specified code at line is suppressed.
+func (set *suppressionSet) match(checker, code string, line int) bool {
+	set.mu.Lock()
+	defer set.mu.Unlock()
+	id := WarningID(checker, code)
+	matched := false
+	for _, s := range set.list {
+		if s.fromLine <= line && line <= s.toLine && (s.checkers == nil || s.checkers[checker] || s.checkers[id]) {
+			s.used = true
+			matched = true
+		}
+	}
+	return matched
+}
+
+func (set *suppressionSet) markRan(checker string) {
+	set.mu.Lock()
+	set.ran[checker] = true
+	set.mu.Unlock()
+}
+
+// unused returns comments of suppressions that didn't match any warning.
+// Suppressions of checkers that didn't check the file are not reported.
+func (set *suppressionSet) unused() []*ast.Comment {
+	set.mu.Lock()
+	defer set.mu.Unlock()
+	var comments []*ast.Comment
+	for _, s := range set.list {
+		if s.used {
+			continue
+		}
+		ran := len(set.ran) != 0
+		for name := range s.checkers {
+			// Warning IDs are checked by their checker names.
+			if i := strings.IndexByte(name, '/'); i != -1 {
+				name = name[:i]
+			}
+			ran = ran && set.ran[name]
+		}
+		if ran {
+			comments = append(comments, s.comment)
+		}
+	}
+	return comments
+}
+
+// suppressionsOf returns suppressions of f, parsing them
+// during the first call for the file.
+func (c *Context) suppressionsOf(f *ast.File) *suppressionSet {
+	c.suppressionsMu.Lock()
+	defer c.suppressionsMu.Unlock()
+	set, ok := c.suppressions[f]
+	if !ok {
+		if c.suppressions == nil {
+			c.suppressions = make(map[*ast.File]*suppressionSet)
+		}
+		set = parseSuppressions(c.fileSet, f)
+		c.suppressions[f] = set
+	}
+	return set
+}
+
+// UnusedSuppressions returns suppression comments of f that
+// didn't silence any warning during the f checks.
+//
+// Suppressions that mention checkers that were not run for f
+// are not returned, as well as suppressions of files that were
+// not checked at all.
+// Results are incomplete if some of f checks results were cached.
+func (c *Context) UnusedSuppressions(f *ast.File) []*ast.Comment {
+	return c.suppressionsOf(f).unused()
+}
+
+func parseSuppressions(fset *token.FileSet, f *ast.File) *suppressionSet {
+	set := &suppressionSet{ran: make(map[string]bool)}
+	if fset == nil {
+		return set
+	}
+	tf := fset.File(f.Pos())
+	lastLine := tf.Line(f.End())
+
+	// For every line: the first visited (outermost) node that starts
+	// on it and the first code position, including nodes ends.
+	lineNode := make(map[int]ast.Node)
+	lineCode := make(map[int]token.Pos)
+	addCode := func(pos token.Pos) {
+		line := tf.Line(pos)
+		if code, ok := lineCode[line]; !ok || pos < code {
+			lineCode[line] = pos
+		}
+	}
+	ast.Inspect(f, func(n ast.Node) bool {
+		switch n.(type) {
+		case nil:
+			return false
+		case *ast.CommentGroup, *ast.Comment:
+			return false
+		}
+		line := tf.Line(n.Pos())
+		if _, ok := lineNode[line]; !ok {
+			lineNode[line] = n
+		}
+		addCode(n.Pos())
+		addCode(n.End() - 1)
+		return true
+	})
+
+	var open []*suppression
+	for _, cg := range f.Comments {
+		for _, comment := range cg.List {
+			kind, checkers, ok := parseSuppressionComment(comment.Text)
+			if !ok {
+				continue
+			}
+			line := tf.Line(comment.Pos())
+			s := &suppression{comment: comment, checkers: checkers}
+			switch kind {
+			case "ignore":
+				if code, ok := lineCode[line]; ok && code < comment.Pos() {
+					s.fromLine, s.toLine = line, line
+					break
+				}
+				for next := line + 1; next <= lastLine; next++ {
+					if n := lineNode[next]; n != nil {
+						s.fromLine, s.toLine = next, tf.Line(n.End())
+						break
+					}
+				}
+				if s.fromLine == 0 {
+					continue // Nothing to suppress
+				}
+			case "disable":
+				s.fromLine, s.toLine = line, lastLine
+				open = append(open, s)
+			case "enable":
+				for _, o := range open {
+					if o.toLine == lastLine && (checkers == nil || sameCheckers(o.checkers, checkers)) {
+						o.toLine = line
+					}
+				}
+				continue
+			}
+			set.list = append(set.list, s)
+		}
+	}
+	return set
+}
+
+// parseSuppressionComment returns suppression kind and checkers set
+// of the comment with text. Returns false for other comments.
+//
+// Kind is one of "ignore", "disable" or "enable".
+func parseSuppressionComment(text string) (string, map[string]bool, bool) {
+	if !strings.HasPrefix(text, "//") {
+		return "", nil, false
+	}
+	fields := strings.Fields(text[len("//"):])
+	if len(fields) == 0 {
+		return "", nil, false
+	}
+	directive := fields[0]
+
+	parseNames := func(list string) map[string]bool {
+		if list == "" {
+			return nil
+		}
+		names := make(map[string]bool)
+		for _, name := range strings.Split(list, ",") {
+			names[name] = true
+		}
+		return names
+	}
+	switch {
+	case directive == "nolint":
+		return "ignore", nil, true
+	case strings.HasPrefix(directive, "nolint:"):
+		if parseNames(strings.TrimPrefix(directive, "nolint:"))["gocritic"] {
+			return "ignore", nil, true
+		}
+		return "", nil, false
+	}
+	for _, kind := range []string{"ignore", "disable", "enable"} {
+		if directive != "gocritic:"+kind {
+			continue
+		}
+		// Checkers list is optional, explanation can follow after "//".
+		if len(fields) > 1 && !strings.HasPrefix(fields[1], "//") {
+			return kind, parseNames(fields[1]), true
+		}
+		return kind, nil, true
+	}
+	return "", nil, false
+}
+
+func sameCheckers(x, y map[string]bool) bool {
+	if len(x) != len(y) {
+		return false
+	}
+	for name := range x {
+		if !y[name] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,167 @@
+package lint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestParseSuppressionComment(t *testing.T) {
+	tests := []struct {
+		text     string
+		kind     string
+		checkers []string
+	}{
+		{"//nolint", "ignore", nil},
+		{"//nolint:gocritic", "ignore", nil},
+		{"//nolint:errcheck,gocritic // reason", "ignore", nil},
+		{"//gocritic:ignore", "ignore", nil},
+		{"//gocritic:ignore dupSubExpr,appendAssign", "ignore", []string{"appendAssign", "dupSubExpr"}},
+		{"//gocritic:ignore // reason", "ignore", nil},
+		{"//gocritic:disable dupSubExpr // reason", "disable", []string{"dupSubExpr"}},
+		{"//gocritic:enable", "enable", nil},
+
+		{"//nolint:errcheck", "", nil},
+		{"// Just a comment", "", nil},
+		{"//gocritic:unknown", "", nil},
+		{"/* gocritic:ignore */", "", nil},
+	}
+
+	for _, test := range tests {
+		kind, checkers, ok := parseSuppressionComment(test.text)
+		if ok != (test.kind != "") || kind != test.kind {
+			t.Errorf("parse %q: have kind %q (%v), want %q", test.text, kind, ok, test.kind)
+			continue
+		}
+		var names []string
+		for name := range checkers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		if !reflect.DeepEqual(names, test.checkers) {
+			t.Errorf("parse %q: have checkers %q, want %q", test.text, names, test.checkers)
+		}
+	}
+}
+
+func TestSuppressions(t *testing.T) {
+	const src = `package p
+
+func f(a, b int) {
+	_ = a == a //nolint:gocritic
+	_ = b == b //gocritic:ignore dupSubExpr
+	_ = a != a // reported
+
+	//gocritic:ignore
+	_ = a <
+		a
+
+	//gocritic:ignore appendAssign
+	_ = a > a // reported
+
+	//gocritic:disable dupSubExpr
+	_ = b < b
+	_ = b > b
+	//gocritic:enable dupSubExpr
+
+	_ = b >= b // reported
+
+	//nolint:gocritic // unused: there is nothing to suppress
+	_ = a + b
+}
+
+//gocritic:disable
+func g(a int) bool { return a == a }
+`
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	info := &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+		Defs:  make(map[*ast.Ident]types.Object),
+		Uses:  make(map[*ast.Ident]types.Object),
+	}
+	pkg, err := (&types.Config{Importer: importer.Default()}).Check("p", fset, []*ast.File{f}, info)
+	if err != nil {
+		t.Fatalf("typecheck: %v", err)
+	}
+	ctx := NewContext(fset, sizes)
+	ctx.SetPackageInfo(info, pkg)
+
+	var have []int
+	for _, w := range NewChecker(findRule("dupSubExpr"), ctx).Check(f) {
+		have = append(have, fset.Position(w.Node.Pos()).Line)
+	}
+	var want []int
+	for i, line := range strings.Split(src, "\n") {
+		if strings.HasSuffix(line, "// reported") {
+			want = append(want, i+1)
+		}
+	}
+	if !reflect.DeepEqual(have, want) {
+		t.Errorf("warnings lines:\nhave: %v\nwant: %v", have, want)
+	}
+
+	var unused []string
+	for _, c := range ctx.UnusedSuppressions(f) {
+		unused = append(unused, c.Text)
+	}
+	wantUnused := []string{"//nolint:gocritic // unused: there is nothing to suppress"}
+	if !reflect.DeepEqual(unused, wantUnused) {
+		t.Errorf("unused suppressions:\nhave: %q\nwant: %q", unused, wantUnused)
+	}
+}
+
+func TestSuppressionsByID(t *testing.T) {
+	const src = `package p
+
+func f(x bool, i, j int) {
+	_ = !!x //gocritic:ignore boolExprSimplify/doubleNegation
+	_ = !(i < j) //gocritic:ignore boolExprSimplify/doubleNegation // reported
+	_ = x == true //gocritic:ignore boolExprSimplify
+}
+`
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	info := &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+		Defs:  make(map[*ast.Ident]types.Object),
+		Uses:  make(map[*ast.Ident]types.Object),
+	}
+	pkg, err := (&types.Config{Importer: importer.Default()}).Check("p", fset, []*ast.File{f}, info)
+	if err != nil {
+		t.Fatalf("typecheck: %v", err)
+	}
+	ctx := NewContext(fset, sizes)
+	ctx.SetPackageInfo(info, pkg)
+
+	var have []string
+	for _, w := range NewChecker(findRule("boolExprSimplify"), ctx).Check(f) {
+		have = append(have, fmt.Sprintf("%d: %s", fset.Position(w.Pos).Line, WarningID("boolExprSimplify", w.Code)))
+	}
+	want := []string{"5: boolExprSimplify/invertComparison"}
+	if !reflect.DeepEqual(have, want) {
+		t.Errorf("warnings:\nhave: %q\nwant: %q", have, want)
+	}
+
+	var unused []string
+	for _, c := range ctx.UnusedSuppressions(f) {
+		unused = append(unused, c.Text)
+	}
+	wantUnused := []string{"//gocritic:ignore boolExprSimplify/doubleNegation // reported"}
+	if !reflect.DeepEqual(unused, wantUnused) {
+		t.Errorf("unused suppressions:\nhave: %q\nwant: %q", unused, wantUnused)
+	}
+}
@@ -0,0 +1,91 @@
+package lint
+
+//! Detects redundant fallthrough and break statements in switch cases.
+//
+// Reports cases that contain only fallthrough, so they can be merged with
+// the next case, fallthrough into an empty case and cases that contain
+// only unlabeled break, which is a no-op in Go.
+//
+// @Before:
+// switch kind {
+// case 'a':
+// 	fallthrough
+// case 'b':
+// 	letter()
+// case 'c':
+// 	break
+// }
+//
+// @After:
+// switch kind {
+// case 'a', 'b':
+// 	letter()
+// case 'c':
+// }
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+func init() {
+	addChecker(&switchHygieneChecker{}, attrExperimental, attrSyntaxOnly)
+}
+
+type switchHygieneChecker struct {
+	checkerBase
+}
+
+func (c *switchHygieneChecker) VisitStmt(stmt ast.Stmt) {
+	switch stmt := stmt.(type) {
+	case *ast.SwitchStmt:
+		c.checkFallthrough(stmt.Body)
+		c.checkBreak(stmt.Body)
+	case *ast.TypeSwitchStmt:
+		// Type switches can't have fallthrough.
+		c.checkBreak(stmt.Body)
+	}
+}
+
+func (c *switchHygieneChecker) checkFallthrough(body *ast.BlockStmt) {
+	for i := 0; i < len(body.List)-1; i++ {
+		cc := body.List[i].(*ast.CaseClause)
+		next := body.List[i+1].(*ast.CaseClause)
+		if !endsWithFallthrough(cc.Body) {
+			continue
+		}
+		switch {
+		case len(cc.Body) == 1:
+			if cc.List != nil && next.List != nil {
+				c.warnEmptyFallthrough(cc)
+			}
+		case len(next.Body) == 0:
+			c.warnFallthroughIntoEmpty(cc.Body[len(cc.Body)-1])
+		}
+	}
+}
+
+func (c *switchHygieneChecker) checkBreak(body *ast.BlockStmt) {
+	for _, x := range body.List {
+		cc := x.(*ast.CaseClause)
+		if len(cc.Body) != 1 {
+			continue
+		}
+		branch, ok := cc.Body[0].(*ast.BranchStmt)
+		if ok && branch.Tok == token.BREAK && branch.Label == nil {
+			c.warnBreak(branch)
+		}
+	}
+}
+
+func (c *switchHygieneChecker) warnEmptyFallthrough(cause ast.Node) {
+	c.ctx.WarnWith(Warning{Node: cause, Code: "emptyFallthrough"}, "replace empty case containing only fallthrough with expression list")
+}
+
+func (c *switchHygieneChecker) warnFallthroughIntoEmpty(cause ast.Node) {
+	c.ctx.WarnWith(Warning{Node: cause, Code: "fallthroughIntoEmpty"}, "fallthrough into an empty case is redundant")
+}
+
+func (c *switchHygieneChecker) warnBreak(cause ast.Node) {
+	c.ctx.WarnWith(Warning{Node: cause, Code: "redundantBreak"}, "case body contains only a redundant break")
+}
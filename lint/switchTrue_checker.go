@@ -14,7 +14,13 @@ package lint
 // 	// ...
 // }
 
-import "go/ast"
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/ast/astutil"
+)
 
 func init() {
 	addChecker(&switchTrueChecker{}, attrSyntaxOnly)
@@ -33,10 +39,33 @@ func (c *switchTrueChecker) VisitStmt(stmt ast.Stmt) {
 }
 
 func (c *switchTrueChecker) warn(cause *ast.SwitchStmt) {
+	var fix []TextEdit
+	if cause.Init == nil {
+		// Remove the tag along with the preceding space.
+		fix = []TextEdit{{Pos: cause.Switch + token.Pos(len("switch")), End: cause.Tag.End()}}
+	} else {
+		fix = []TextEdit{{Pos: cause.Init.End(), End: cause.Tag.End(), NewText: ";"}}
+	}
+	if c.isShadowedTrue(cause.Tag) {
+		// Tag is not a constant, so removing it changes the switch.
+		fix = nil
+	}
 	if cause.Init == nil {
-		c.ctx.Warn(cause, "replace 'switch true {}' with 'switch {}'")
+		c.ctx.WarnWithFix(fix, cause, "replace 'switch true {}' with 'switch {}'")
 	} else {
-		c.ctx.Warn(cause, "replace 'switch %s; true {}' with 'switch %s; {}'",
+		c.ctx.WarnWithFix(fix, cause, "replace 'switch %s; true {}' with 'switch %s; {}'",
 			cause.Init, cause.Init)
 	}
 }
+
+// isShadowedTrue reports whether tag refers to something
+// other than the predeclared true constant.
+// Tags without types info are considered not shadowed.
+func (c *switchTrueChecker) isShadowedTrue(tag ast.Expr) bool {
+	id, ok := astutil.Unparen(tag).(*ast.Ident)
+	if !ok {
+		return false
+	}
+	obj := c.ctx.typesInfo.ObjectOf(id)
+	return obj != nil && obj != types.Universe.Lookup("true")
+}
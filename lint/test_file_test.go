@@ -0,0 +1,51 @@
+package lint
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+func TestSkipTests(t *testing.T) {
+	const src = `package p
+
+func f(a int, b int) {}
+`
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, filename := range []string{"p.go", "p_test.go"} {
+		f, err := parser.ParseFile(fset, filename, src, 0)
+		if err != nil {
+			t.Fatalf("parse: %v", err)
+		}
+		files = append(files, f)
+	}
+	ctx := NewContext(fset, sizes)
+
+	// paramTypeCombine skips tests, dupSubExpr does not.
+	rules := []*Rule{findRule("paramTypeCombine"), findRule("dupSubExpr")}
+	if !rules[0].SkipTests || rules[1].SkipTests {
+		t.Fatalf("unexpected SkipTests attributes")
+	}
+	for _, f := range files {
+		isTest := IsTestFile(fset, f)
+		checkers := []*Checker{NewChecker(rules[0], ctx), NewChecker(rules[1], ctx)}
+		warnings := CheckFile(checkers, f)
+		if have, want := len(warnings), 2; have != want {
+			t.Fatalf("have %d warning lists, want %d", have, want)
+		}
+		for i, c := range checkers {
+			n := len(c.Check(f))
+			if n != len(warnings[i]) {
+				t.Errorf("%s: Check and CheckFile results differ", c.Rule)
+			}
+			if c.Rule.SkipTests && isTest && n != 0 {
+				t.Errorf("%s: have %d warnings for test file", c.Rule, n)
+			}
+		}
+		if !isTest && len(warnings[0]) == 0 {
+			t.Errorf("%s: no warnings for non-test file", rules[0])
+		}
+	}
+}
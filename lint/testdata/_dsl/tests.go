@@ -0,0 +1,55 @@
+package checker_test
+
+import (
+	"bytes"
+	format "fmt"
+	"strings"
+)
+
+func sprintfString(s string, b []byte, n int) {
+	/// fmt.Sprintf call can be replaced with s
+	_ = format.Sprintf("%s", s)
+
+	_ = format.Sprintf("%s", b)
+	_ = format.Sprintf("%d", n)
+	_ = format.Sprintf("%s", s, n)
+}
+
+func stringsIndex(s, sub string) {
+	/// strings.Index(s, sub) >= 0 can be simplified to strings.Contains(s, sub)
+	_ = strings.Index(s, sub) >= 0
+	/// strings.Index(s, "x") >= 0 can be simplified to strings.Contains(s, "x")
+	_ = strings.Index(s, "x") >= 0
+
+	_ = strings.Index(s, sub) > 0
+	_ = strings.Index(s, sub) >= 1
+}
+
+func selfAssign(xs []int, i int) {
+	/// xs[i] - xs[i] is always 0
+	_ = xs[i] - xs[i]
+	/// i - i is always 0
+	_ = i - i
+
+	_ = xs[i] - xs[i+1]
+	var ch chan int
+	_ = <-ch - <-ch
+}
+
+func bufferWrite(buf *bytes.Buffer, parts []string) {
+	/// buf.Write([]byte(parts[0])) should be buf.WriteString(parts[0])
+	buf.Write([]byte(parts[0]))
+
+	var w struct{ b bytes.Buffer }
+	w.b.Write([]byte("x"))
+}
+
+func appendArgs(xs []int) {
+	/// append arguments: [1, 2, 3]
+	_ = append(xs, 1, 2, 3)
+	/// append arguments: []
+	_ = append(xs)
+
+	// Pattern has no ellipsis.
+	_ = append(xs, xs...)
+}
@@ -0,0 +1,40 @@
+package checker_test
+
+import (
+	"math"
+	"runtime"
+)
+
+const debug = false
+
+const maxItems = 10
+
+func maybeTrue(x int, u uint, b byte, i8 int8, f float64) {
+	_ = x < 0
+	_ = u > 0
+	_ = u == 0
+	_ = b < 255
+	_ = b >= 255
+	_ = i8 > -128
+	_ = i8 == -128
+	_ = f < 0
+	_ = x > math.MaxInt32
+	_ = u <= math.MaxUint32
+}
+
+func namedConstants(n int) {
+	_ = debug == true
+	_ = runtime.GOOS == "windows"
+	_ = maxItems > 5
+	_ = n < maxItems
+}
+
+func shadowedLen(xs []int) {
+	len := func(xs []int) int { return -1 }
+	_ = len(xs) >= 0
+}
+
+func nonConstants(x, y uint) {
+	_ = x < y
+	_ = x+1 > 0
+}
@@ -0,0 +1,46 @@
+package checker_test
+
+func unsignedCompare(x int, u uint, u32 uint32) {
+	/// `uint(x) < 0` is always false
+	_ = uint(x) < 0
+	/// `u >= 0` is always true
+	_ = u >= 0
+	/// `0 > u32` is always false
+	if 0 > u32 {
+	}
+	/// `u < 0` is always false
+	_ = (u < 0)
+}
+
+func outOfRange(b byte, i8 int8, u16 uint16) {
+	/// `b > 255` is always false
+	_ = b > 255
+	/// `b <= 255` is always true
+	_ = b <= 255
+	/// `i8 < -128` is always false
+	_ = i8 < -128
+	/// `i8 >= -128` is always true
+	_ = i8 >= -128
+	/// `u16 <= 65535` is always true
+	for u16 <= 65535 {
+	}
+}
+
+func lenCompare(s []int, cond bool) {
+	/// `len(s) >= 0` is always true
+	if len(s) >= 0 && cond {
+	}
+	/// `cap(s) < 0` is always false
+	_ = cond || cap(s) < 0
+	/// `len(s) == -1` is always false
+	_ = len(s) == -1
+}
+
+func literals() {
+	/// `1 < 2` is always true
+	_ = 1 < 2
+	/// `"a" == "b"` is always false
+	_ = "a" == "b"
+	/// `-1 >= (0)` is always false
+	_ = -1 >= (0)
+}
@@ -82,3 +82,50 @@ func appendNotInAssignment() {
 		_ = v3
 	}
 }
+
+func shadowedAppend() {
+	var xs, ys []int
+
+	append := func(xs []int, vals ...int) []int { return xs }
+
+	// Not a builtin append, so discarding result is OK.
+	append(xs, 1)
+	(append(xs, 2))
+
+	// Not a builtin append, so any assignment is OK.
+	xs = append(ys, 1)
+	ys = append(xs, 1)
+}
+
+func inPlaceGrowth() {
+	var buf []byte
+	var b byte
+
+	buf = append(buf, b)
+	buf = append(buf, buf...)
+	buf = append(buf[:0], b)
+}
+
+type buffer struct {
+	data []byte
+	ints []int
+}
+
+func aliasedAppends(b *buffer, xs []int) {
+	{
+		xs2 := xs
+		xs = append(xs2, 1)
+		xs2 = append(xs, 1)
+	}
+
+	data := b.data[:0]
+	data = append(data, 'a')
+	b.data = append(data, 'b')
+
+	var ints = b.ints
+	b.ints = append(ints[:len(ints)-1], 1)
+
+	var tmp []int
+	tmp = xs[1:]
+	xs = append(tmp, 2)
+}
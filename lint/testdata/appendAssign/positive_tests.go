@@ -1,5 +1,7 @@
 package checker_tests
 
+var xs2 []int
+
 func suspeciousAppends() {
 	var xs []int
 	var ys []int
@@ -28,10 +30,15 @@ func suspeciousAppends() {
 	xsMap["10"] = append(xsMap["100"], 1, 2)
 
 	{
-		xs2 := xs
+		xs2 := ys
 		/// append result not assigned to the same slice
 		xs = append(xs2, 1)
 		/// append result not assigned to the same slice
 		xs2 = append(xs, 1)
 	}
 }
+
+func aliasInOtherFunc(xs []int) {
+	/// append result not assigned to the same slice
+	xs = append(xs2, 1)
+}
@@ -0,0 +1,31 @@
+package checker_test
+
+import (
+	"bytes"
+	"strings"
+)
+
+const prefix = "http://"
+
+const repeatedSpaces = "                "
+
+func goodArgs(url, filename string, b []byte, r rune) {
+	_ = strings.HasPrefix(url, "http://")
+	_ = strings.HasSuffix(filename, ".go")
+	_ = strings.Contains(url, prefix)
+	_ = strings.TrimPrefix(url, prefix)
+	_ = bytes.HasPrefix(b, []byte("#"))
+
+	// Both are constants or both are variables.
+	_ = strings.HasPrefix("http://", "http")
+	_ = strings.Contains(url, filename)
+
+	// Named constants may be a haystack.
+	_ = strings.HasPrefix(repeatedSpaces, url)
+	_ = strings.TrimPrefix(prefix, url)
+
+	// Set membership checks are not reported.
+	_ = strings.ContainsRune("+-", r)
+	_ = strings.ContainsAny("+-", url)
+	_ = strings.IndexByte("0123456789", url[0])
+}
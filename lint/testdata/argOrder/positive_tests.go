@@ -0,0 +1,32 @@
+package checker_test
+
+import (
+	"bytes"
+	"strings"
+)
+
+func swappedArgs(url, filename string, b []byte) {
+	/// strings.HasPrefix arguments order looks reversed
+	_ = strings.HasPrefix("http://", url)
+
+	/// strings.HasSuffix arguments order looks reversed
+	_ = strings.HasSuffix(".go", filename)
+
+	/// strings.Contains arguments order looks reversed
+	_ = strings.Contains("substr", url)
+
+	/// strings.TrimPrefix arguments order looks reversed
+	_ = strings.TrimPrefix("http://", url)
+
+	/// strings.TrimSuffix arguments order looks reversed
+	_ = strings.TrimSuffix(".go", filename)
+
+	/// strings.Split arguments order looks reversed
+	_ = strings.Split(",", url)
+
+	/// bytes.HasPrefix arguments order looks reversed
+	_ = bytes.HasPrefix([]byte("#"), b)
+
+	/// bytes.Contains arguments order looks reversed
+	_ = bytes.Contains([]byte("x"), b)
+}
@@ -0,0 +1,29 @@
+package checker_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+)
+
+func goodCalls(s string, b []byte, xs []int, elems []string, n int) {
+	_ = strings.Replace(s, "a", "b", -1)
+	_ = strings.Replace(s, "a", "b", 1)
+	_ = strings.Replace(s, "a", "b", n)
+	_ = strings.SplitN(s, ",", 2)
+	_ = bytes.Replace(b, nil, nil, -1)
+
+	xs = append(xs, 1)
+	xs = append(xs, xs...)
+	_ = xs
+
+	_ = filepath.Join(s, "a")
+	_ = filepath.Join(elems...)
+
+	_ = strings.TrimRight(s, " \t\n")
+	_ = strings.TrimRight(s, "/")
+	_ = strings.TrimLeft(s, "0123456789")
+	_ = strings.TrimRight(s, "x")
+	_ = bytes.TrimRight(b, ".,;")
+	_ = strings.TrimSuffix(s, ".go")
+}
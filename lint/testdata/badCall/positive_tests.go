@@ -0,0 +1,39 @@
+package checker_test
+
+import (
+	"bytes"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+func zeroN(s string, b []byte) {
+	/// suspicious arg 0, probably meant -1
+	_ = strings.Replace(s, "a", "b", 0)
+	/// suspicious arg 0, probably meant -1
+	_ = bytes.Replace(b, []byte("a"), []byte("b"), 0)
+	/// suspicious arg 0, probably meant -1
+	_ = strings.SplitN(s, ",", 0)
+}
+
+func singleAppend(xs []int) {
+	/// no-op append call, probably missing arguments
+	xs = append(xs)
+	_ = xs
+}
+
+func emptyJoinElem(dir string) {
+	/// empty string element is ignored by filepath.Join
+	_ = filepath.Join(dir, "")
+	/// empty string element is ignored by path.Join
+	_ = path.Join("", dir)
+}
+
+func suffixCutsets(filename string, b []byte) {
+	/// cutset ".go" looks like a word; consider strings.TrimSuffix
+	_ = strings.TrimRight(filename, ".go")
+	/// cutset "http://" looks like a word; consider strings.TrimPrefix
+	_ = strings.TrimLeft(filename, "http://")
+	/// cutset "--" looks like a word; consider bytes.TrimPrefix
+	_ = bytes.TrimLeft(b, "--")
+}
@@ -0,0 +1,35 @@
+//go:build go1.1
+// +build go1.1
+
+package checker_test
+
+//go:generate stringer -type=Kind2
+type Kind2 int
+
+//go:noinline
+func noinline2() {}
+
+// This is the go:generate directive explained in a comment.
+func doc() {}
+
+// go:generated is not a known directive, so it's an ordinary comment.
+func doc2() {}
+
+//lint:ignore SA1000 tool directives are not checked
+func other() {}
+
+//nolint:errcheck
+func nolint() {}
+
+//go:unknowndirective is not close to any known directive
+func unknown() {}
+
+//export exported
+func exported() {}
+
+//line negative_tests.go:40
+func line() {}
+
+func g() {
+	_ = 0 //go:genrate is not at the line start
+}
@@ -0,0 +1,27 @@
+package checker_test
+
+/// //go:genrate is not a known directive, did you mean //go:generate?
+//go:genrate stringer -type=Kind
+type Kind int
+
+/// //go:noinlin is not a known directive, did you mean //go:noinline?
+//go:noinlin
+func noinline() {}
+
+/// remove space after // to make go:generate a directive
+// go:generate stringer -type=Color
+type Color int
+
+/// remove space after // to make go:embed a directive
+// go:embed testdata
+var files string
+
+/// //go:linknme is not a known directive, did you mean //go:linkname?
+//go:linknme runtimeNano runtime.nanotime
+func runtimeNano() int64
+
+/// build constraint after the package clause is ignored
+//go:build linux
+
+/// build constraint after the package clause is ignored
+// +build linux
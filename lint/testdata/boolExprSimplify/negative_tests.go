@@ -16,3 +16,44 @@ func otherBinOps() {
 	_ = true && false
 	_ = true || false
 }
+
+func parensOnly() {
+	var x, y bool
+
+	// Parenthesis alone are not reported.
+	_ = (x) == (y)
+	_ = (x) && !(y)
+}
+
+func deMorganNoInvertibleOperands() {
+	var a, b, c bool
+	var i, j int
+
+	// Rewrite would only add negations.
+	_ = !(a && b)
+	_ = !(a || b || c)
+	_ = !((a || b) && c)
+
+	// Nested chains of the other operator are not inspected.
+	_ = !(a || b && i > j)
+}
+
+func boolConstOK() {
+	var a bool
+	var i int
+
+	_ = a == a
+	_ = a != a
+	_ = i == 1
+	_ = !a && true
+}
+
+func floatComparisonsOK() {
+	var a bool
+	var f, g float64
+
+	// Ordered comparisons are all false for NaN.
+	_ = !(f < g)
+	_ = !(f >= 1.5)
+	_ = !(a && f > g)
+}
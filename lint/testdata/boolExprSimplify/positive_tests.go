@@ -25,14 +25,14 @@ func doubleNegation() {
 }
 
 func negatedEquals() {
-	/// can simplify `!(x) == !(y)` to `(x) == (y)`
+	/// can simplify `!(x) == !(y)` to `x == y`
 	_ = !(x) == !(y)
 
-	/// can simplify `!x == !x == !x` to `x == x == !x`
+	/// can simplify `!x == !x` to `x == x`
 	_ = !x == !x == !x
 
 	// TODO: should probably simplify other 2 expressions as well.
-	/// can simplify `!x == !y == !x == !y` to `x == y == !x == !y`
+	/// can simplify `!x == !y` to `x == y`
 	_ = !x == !y == !x == !y
 }
 
@@ -93,3 +93,113 @@ func invertComparison() {
 		_ = !(!((x + y) >= (z - x)))
 	}
 }
+
+func atomParens() {
+	var a, b, c bool
+	var v struct{ f bool }
+
+	/// can simplify `!(v.f) == !(a)` to `v.f == a`
+	_ = !(v.f) == !(a)
+
+	/// can simplify `!!(a)` to `a`
+	_ = !!(a) && (b)
+
+	/// can simplify `!!(a || b)` to `a || b`
+	_ = !!(a || b) && c
+
+	/// can simplify `!!(a || b)` to `a || b`
+	_ = c && !!(a || b)
+
+	/// can simplify `!((a || b) == (c))` to `(a || b) != c`
+	_ = !((a || b) == (c))
+}
+
+func deMorgan() {
+	var a, b, c bool
+	var i, j int
+
+	/// can simplify `!(a == b && c)` to `a != b || !c`
+	_ = !(a == b && c)
+
+	/// can simplify `!(!a && b)` to `a || !b`
+	_ = !(!a && b)
+
+	/// can simplify `!(i < j || !c)` to `i >= j && c`
+	_ = !(i < j || !c)
+
+	/// can simplify `!(a && b && i != j)` to `!a || !b || i == j`
+	_ = !(a && b && i != j)
+
+	/// can simplify `!((a || b) && i <= j)` to `!(a || b) || i > j`
+	_ = !((a || b) && i <= j)
+
+	/// can simplify `!(a != b || c)` to `a == b && !c`
+	_ = !(a != b || c) && c
+
+	/// can simplify `!(!a || !b)` to `a && b`
+	_ = !(!a || !b)
+
+	/// can simplify `!(!a && !b && !c)` to `a || b || c`
+	_ = !(!a && !b && !c)
+}
+
+func floatComparisons() {
+	var a bool
+	var f, g float64
+
+	/// can simplify `!(f == g)` to `f != g`
+	_ = !(f == g)
+
+	/// can simplify `!(!a || f < g)` to `a && !(f < g)`
+	_ = !(!a || f < g)
+
+	/// can simplify `!(f > g) == false` to `f > g`
+	_ = !(f > g) == false
+}
+
+func boolConstCompare() {
+	var a, b bool
+	var i, j int
+	var v struct{ f bool }
+
+	/// can simplify `a == true` to `a`
+	_ = a == true
+
+	/// can simplify `a != false` to `a`
+	_ = a != false
+
+	/// can simplify `a == false` to `!a`
+	_ = a == false
+
+	/// can simplify `a != true` to `!a`
+	_ = a != true
+
+	/// can simplify `true == v.f` to `v.f`
+	_ = true == v.f
+
+	/// can simplify `(a && b) == false` to `!(a && b)`
+	_ = (a && b) == false
+
+	/// can simplify `(i < j) == false` to `i >= j`
+	_ = (i < j) == false
+
+	/// can simplify `!a == false` to `a`
+	_ = !a == false
+
+	/// can simplify `!(v.f) == !(true)` to `v.f`
+	_ = !(v.f) == !(true)
+}
+
+func funcLitBody() {
+	var a, b bool
+
+	_ = func() bool {
+		// Only the return value is reported.
+		/// can simplify `!(a == b)` to `a != b`
+		return !(a == b)
+	}
+
+	// Comments would be lost, so there is no fix.
+	/// can simplify `!(a == b)` to `a != b`
+	_ = !(a /* a */ == b)
+}
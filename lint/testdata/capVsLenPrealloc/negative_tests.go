@@ -0,0 +1,71 @@
+package checker_test
+
+func withCap(src []int) []int {
+	dst := make([]int, 0, len(src))
+	for _, x := range src {
+		dst = append(dst, x)
+	}
+	return dst
+}
+
+func indexAssign(src []int) []int {
+	dst := make([]int, len(src))
+	for i, x := range src {
+		dst[i] = x
+	}
+	return dst
+}
+
+func otherSlice(src []int) []int {
+	dst := make([]int, len(src))
+	var other []int
+	for _, x := range src {
+		other = append(other, x)
+	}
+	copy(dst, other)
+	return dst
+}
+
+func notImmediately(src []int) []int {
+	dst := make([]int, len(src))
+	dst = dst[:0]
+	for _, x := range src {
+		dst = append(dst, x)
+	}
+	return dst
+}
+
+func constLength(src []int) []int {
+	dst := make([]int, 10)
+	for _, x := range src {
+		dst = append(dst, x)
+	}
+	return dst
+}
+
+func mapMake(src []string) map[string]int {
+	m := make(map[string]int, len(src))
+	for i, s := range src {
+		m[s] = i
+	}
+	return m
+}
+
+func funcLit(src []int) []int {
+	dst := make([]int, len(src))
+	for range src {
+		_ = func() {
+			dst = append(dst, 1)
+		}
+	}
+	return dst
+}
+
+func shadowedAppend(src []int) []int {
+	append := func(xs []int, x int) []int { xs[0] = x; return xs }
+	dst := make([]int, len(src))
+	for _, x := range src {
+		dst = append(dst, x)
+	}
+	return dst
+}
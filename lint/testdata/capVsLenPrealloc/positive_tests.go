@@ -0,0 +1,43 @@
+package checker_test
+
+func rangeLoop(src []int) []int {
+	/// make([]T, len(x)) then append produces len(x) extra zero elements; use cap
+	dst := make([]int, len(src))
+	for _, x := range src {
+		dst = append(dst, x*2)
+	}
+	return dst
+}
+
+func forLoop(src []string) []string {
+	var dst []string
+	/// make([]T, len(x)) then append produces len(x) extra zero elements; use cap
+	dst = make([]string, len(src))
+	for i := 0; i < len(src); i++ {
+		if src[i] != "" {
+			dst = append(dst, src[i])
+		}
+	}
+	return dst
+}
+
+func varDecl(m map[string]int) []string {
+	/// make([]T, len(x)) then append produces len(x) extra zero elements; use cap
+	var keys = make([]string, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+type sliceHolder struct {
+	xs []int
+}
+
+func fieldSlice(h *sliceHolder, src []int) {
+	/// make([]T, len(x)) then append produces len(x) extra zero elements; use cap
+	h.xs = make([]int, len(src))
+	for _, x := range src {
+		h.xs = append(h.xs, x, x)
+	}
+}
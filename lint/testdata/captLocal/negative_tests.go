@@ -40,3 +40,11 @@ func noWarnings() {
 		)
 	}
 }
+
+// Package-level declarations are not checked.
+
+var GlobalVar int
+
+const GlobalConst = 1
+
+func (ExportedType) unnamedRecv(int) {}
@@ -53,3 +53,13 @@ func localBody() {
 		)
 	}
 }
+
+func funcLit() {
+	/// `X' should not be capitalized
+	/// `Y' should not be capitalized
+	_ = func(X int) (Y int) {
+		/// `Z' should not be capitalized
+		Z := X
+		return Z
+	}
+}
@@ -32,3 +32,28 @@ func permittedComments() {
 
 	// <-ch
 }
+
+// docWithExample shows an example in its doc comment,
+// which is not reported:
+//
+//	docWithExample(10)
+func docWithExample(n int) {}
+
+// fmt.Println(docWithExample)
+func docIsCode() {}
+
+// Package-level prose is fine too.
+
+// type legacyID string
+
+// func is not a code here
+
+type withFieldDocs struct {
+	// x = append(x, 1)
+	x []int
+}
+
+func shortCode() {
+	// x := 10
+	// _ = x
+}
@@ -16,3 +16,27 @@ func multiLineCode() {
 	// if !strings.HasPrefix(l, "// ") {
 	// }
 }
+
+func multiStmtCode() {
+	/// may want to remove commented-out code
+	// x := 10
+	// y := x * 2
+	// _ = y
+}
+
+/// may want to remove commented-out code
+// func oldHelper(x int) int {
+// 	return x * 2
+// }
+
+/// may want to remove commented-out code
+// var defaultTimeout = 10
+
+/// may want to remove commented-out code
+
+/*
+const (
+	modeA = iota
+	modeB
+)
+*/
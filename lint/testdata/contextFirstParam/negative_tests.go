@@ -0,0 +1,37 @@
+package checker_test
+
+import (
+	"context"
+	"testing"
+)
+
+func g1(ctx context.Context, x int) {}
+
+func g2(ctx, other context.Context) {}
+
+func g3() {}
+
+func g4(context.Context, int, context.Context) {}
+
+func (s *service) goodMethod(ctx context.Context, name string) {}
+
+func testHelper(t *testing.T, ctx context.Context) {}
+
+func benchHelper(b *testing.B, ctx context.Context) {}
+
+func tbHelper(tb testing.TB, ctx context.Context) {}
+
+type goodHandler interface {
+	Handle(ctx context.Context, req string) error
+}
+
+type notStored struct {
+	newCtx func() context.Context
+	cancel context.CancelFunc
+}
+
+type myContext interface {
+	Value(key interface{}) interface{}
+}
+
+func notContext(x int, ctx myContext) {}
@@ -0,0 +1,39 @@
+package checker_test
+
+import (
+	"context"
+)
+
+/// context.Context should be the first param
+func f1(x int, ctx context.Context) {}
+
+/// context.Context should be the first param
+func f2(a, b string, ctx context.Context, x int) {}
+
+/// context.Context should be the first param
+func f3(string, context.Context) {}
+
+type service struct {
+	/// don't store context.Context in a struct field, pass it as a param instead
+	ctx context.Context
+
+	name string
+}
+
+/// context.Context should be the first param
+func (s *service) method(name string, ctx context.Context) error { return nil }
+
+type handler interface {
+	/// context.Context should be the first param
+	Handle(req string, ctx context.Context) error
+}
+
+func funcLits() {
+	/// context.Context should be the first param
+	_ = func(x int, ctx context.Context) {}
+
+	_ = struct {
+		/// don't store context.Context in a struct field, pass it as a param instead
+		context.Context
+	}{}
+}
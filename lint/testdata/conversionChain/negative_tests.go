@@ -0,0 +1,44 @@
+package checker_test
+
+type myInt int
+
+func legitimate(x int64, u uint64, i int, y int32, f float64) {
+	// Narrowing that is not widened back to the original type.
+	_ = int(int32(x))
+	_ = int16(int32(x))
+
+	// Widening chains keep the value.
+	_ = int64(int32(y))
+	_ = int64(int(y))
+
+	// Unsigned narrowing is a masking idiom.
+	_ = uint64(uint32(u))
+
+	// Sign conversions.
+	_ = int64(uint32(x))
+	_ = uint64(int32(u))
+
+	// Named types.
+	_ = int(myInt(i))
+	_ = myInt(int(i))
+
+	// Constants and non-integer types.
+	_ = int64(int32(10))
+	_ = float64(float32(f))
+	_ = int64(int32(f))
+
+	// Single conversions.
+	_ = int64(x)
+	_ = int32(x)
+}
+
+func rangeChecks(x int64, y int32) bool {
+	// Checks that the value fits into the narrowed type.
+	if int64(rune(x)) == x {
+		return true
+	}
+	if y != (int32(int8(y))) {
+		return false
+	}
+	return int64(int32(x)) != (x)
+}
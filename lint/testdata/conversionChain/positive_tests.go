@@ -0,0 +1,30 @@
+package checker_test
+
+func lossy(x int64, y int32, z int) {
+	/// conversion narrows then widens, losing data
+	_ = int64(int32(x))
+	/// conversion narrows then widens, losing data
+	_ = int64(int8(x))
+	/// conversion narrows then widens, losing data
+	_ = int32(int16(y))
+	/// conversion narrows then widens, losing data
+	_ = int(int32(z))
+}
+
+func comparedChains(x, v int64) bool {
+	/// conversion narrows then widens, losing data
+	if int64(int32(x)) == v {
+		return true
+	}
+	/// conversion narrows then widens, losing data
+	return int64(int32(x)) < x
+}
+
+func redundant(x int32, y uint) {
+	/// redundant conversion chain; int(int(x)) can be simplified to int(x)
+	_ = int(int(x))
+	/// redundant conversion chain; uint64(uint64(y)) can be simplified to uint64(y)
+	_ = uint64(uint64(y))
+	/// redundant conversion chain; int64((int64(x))) can be simplified to int64(x)
+	_ = int64((int64(x)))
+}
@@ -20,3 +20,14 @@ func g() {
 		}()
 	}
 }
+
+func deferInFuncLit(names []string) {
+	for _, name := range names {
+		fn := func() {
+			if name != "" {
+				defer println(name)
+			}
+		}
+		fn()
+	}
+}
@@ -20,3 +20,29 @@ func f() {
 		defer f()
 	}
 }
+
+type file struct{}
+
+func (*file) Close() error { return nil }
+
+func openFile(name string) (*file, error) { return &file{}, nil }
+
+func nestedBlocks(names []string) {
+	for _, name := range names {
+		f, err := openFile(name)
+		if err == nil {
+			/// defer will be executed only at the end of the func's scope
+			defer f.Close()
+		}
+	}
+
+	for _, name := range names {
+		for i := 0; i < 2; i++ {
+			switch i {
+			case 0:
+				/// defer will be executed only at the end of the func's scope
+				defer println(name)
+			}
+		}
+	}
+}
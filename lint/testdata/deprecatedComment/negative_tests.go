@@ -0,0 +1,34 @@
+package checker_test
+
+// Deprecated: use bar instead.
+func g1() {}
+
+// g2 does stuff.
+//
+// Deprecated: use bar instead.
+func g2() {}
+
+// g3 returns all deprecated
+// deprecated names, even the ones that are
+// deprecated in newer versions.
+func g3() {}
+
+// g4 reports whether symbol is
+// Deprecatedness is not a word.
+func g4() {}
+
+type fields struct {
+	// Deprecated: use y instead.
+	x int
+
+	y int
+}
+
+func localComments() {
+	// deprecated: local comments are not docs.
+}
+
+/*
+deprecated: block comments are not checked.
+*/
+func g5() {}
@@ -0,0 +1,58 @@
+package checker_test
+
+/// use `Deprecated: ` (note the casing) instead of `deprecated: `
+// deprecated: use bar instead.
+func f1() {}
+
+/// use `Deprecated: ` (note the casing) instead of `DEPRECATED: `
+// DEPRECATED: use bar instead.
+func f2() {}
+
+/// the proper format is `Deprecated: <text>`
+// Deprecated. Use bar instead.
+func f3() {}
+
+/// the proper format is `Deprecated: <text>`
+// Deprecated, use bar instead.
+func f4() {}
+
+/// the proper format is `Deprecated: <text>`
+// Deprecated use bar instead.
+func f5() {}
+
+/// the proper format is `Deprecated: <text>`
+// Deprecated:use bar instead.
+func f6() {}
+
+/// `Deprecated: ` notices should be in a dedicated paragraph, separated from the rest
+// f7 does stuff.
+// Deprecated: use bar instead.
+func f7() {}
+
+/// `Deprecated: ` notices should be in a dedicated paragraph, separated from the rest
+// f8 does stuff. Deprecated: use bar instead.
+func f8() {}
+
+type withFields struct {
+	/// use `Deprecated: ` (note the casing) instead of `deprecated: `
+	// deprecated: use y instead.
+	x int
+
+	y int
+}
+
+const (
+	/// the proper format is `Deprecated: <text>`
+	// Deprecated - use c2 instead.
+	c1 = 1
+
+	c2 = 2
+)
+
+type withMethods interface {
+	/// the proper format is `Deprecated: <text>`
+	// DEPRECATED. Use M2 instead.
+	M1()
+
+	M2()
+}
@@ -0,0 +1,38 @@
+package checker_test
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"strings"
+)
+
+func differentArgs(xs, ys []int, s, x, y string, b []byte, v, w interface{}, f1, f2 float64) {
+	copy(xs, ys)
+	copy(xs, xs[1:])
+	_ = strings.Replace(s, x, y, -1)
+	_ = strings.Replace(x, x, y, -1)
+	_ = strings.ReplaceAll(s, s, x)
+	_ = bytes.Equal(b, b[1:])
+	_ = reflect.DeepEqual(v, w)
+	_ = math.Max(f1, f2)
+}
+
+func impureArgs(next func() []int, rand func() float64) {
+	copy(next(), next())
+	_ = math.Max(rand(), rand())
+}
+
+func notTabled(s string) {
+	_ = strings.Contains(s, s)
+	_ = strings.Repeat(s, 2) + strings.Repeat(s, 2)
+}
+
+type copier struct{}
+
+func (copier) copy(dst, src []int) {}
+
+func localCopy(xs []int) {
+	var c copier
+	c.copy(xs, xs)
+}
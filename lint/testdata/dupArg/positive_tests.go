@@ -0,0 +1,45 @@
+package checker_test
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"strings"
+)
+
+func dupCopy(xs []struct{ ys []int }) {
+	/// suspicious duplicated `xs` argument in `copy` call
+	copy(xs, xs)
+	/// suspicious duplicated `xs[0].ys` argument in `copy` call
+	copy(xs[0].ys, xs[0].ys)
+}
+
+func dupStrings(s, x string) {
+	/// suspicious duplicated `x` argument in `strings.Replace` call
+	_ = strings.Replace(s, x, x, -1)
+	/// suspicious duplicated `"a"` argument in `strings.ReplaceAll` call
+	_ = strings.ReplaceAll(s, "a", "a")
+	/// suspicious duplicated `s` argument in `strings.EqualFold` call
+	_ = strings.EqualFold(s, s)
+	/// suspicious duplicated `s` argument in `strings.Compare` call
+	_ = strings.Compare(s, s)
+}
+
+func dupBytes(b, x []byte) {
+	/// suspicious duplicated `x` argument in `bytes.Replace` call
+	_ = bytes.Replace(b, x, x, -1)
+	/// suspicious duplicated `b` argument in `bytes.Equal` call
+	_ = bytes.Equal(b, b)
+}
+
+func dupDeepEqual(v interface{}) {
+	/// suspicious duplicated `v` argument in `reflect.DeepEqual` call
+	_ = reflect.DeepEqual(v, v)
+}
+
+func dupMath(x, y float64) {
+	/// suspicious duplicated `x` argument in `math.Max` call
+	_ = math.Max(x, x)
+	/// suspicious duplicated `x + y` argument in `math.Min` call
+	_ = math.Min(x+y, x+y)
+}
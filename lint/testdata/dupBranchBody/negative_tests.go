@@ -28,3 +28,44 @@ func ifBranches(cond1, cond2 bool) {
 		println(x)
 	}
 }
+
+func switchCases(x int, v interface{}) {
+	switch x {
+	case 1:
+		println(1)
+	case 2:
+		println(2)
+	default:
+		println(3)
+	}
+
+	switch x {
+	case 1:
+	case 2:
+	case 3:
+		println(3)
+	}
+
+	switch x {
+	case 1:
+		println(1)
+		fallthrough
+	case 2:
+		println(1)
+	case 3:
+		println(3)
+		fallthrough
+	case 4:
+		println(3)
+		fallthrough
+	default:
+		println(3)
+	}
+
+	switch v := v.(type) {
+	case int:
+		println(v)
+	case string:
+		println(v)
+	}
+}
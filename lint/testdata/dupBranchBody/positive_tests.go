@@ -17,3 +17,28 @@ func duplicatedIfBranches(cond1, cond2 bool) {
 		println(1)
 	}
 }
+
+func duplicatedSwitchCases(x int) {
+	switch x {
+	case 1:
+		println(1)
+	case 2:
+		println(2)
+		/// switch case body duplicates one of the preceding cases
+	case 3:
+		println(1)
+		/// switch case body duplicates one of the preceding cases
+	default:
+		println(2)
+	}
+
+	switch {
+	case x > 10:
+		x++
+		println(x)
+		/// switch case body duplicates one of the preceding cases
+	case x < 0:
+		x++
+		println(x)
+	}
+}
@@ -49,3 +49,14 @@ func goodStructSwitch() {
 	default:
 	}
 }
+
+type namedInt int
+
+func goodTypeSwitch(v interface{}) {
+	switch v.(type) {
+	case int, namedInt:
+	case []int, [2]int, map[int]int:
+	case nil:
+	default:
+	}
+}
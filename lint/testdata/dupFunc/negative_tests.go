@@ -0,0 +1,74 @@
+package checker_test
+
+import (
+	"strings"
+)
+
+func getterA() int { return 1 }
+
+func getterB() int { return 1 }
+
+func trimAll(xs []string) []string {
+	var res []string
+	for _, x := range xs {
+		res = append(res, strings.TrimSpace(x))
+	}
+	return res
+}
+
+func lowerAll(xs []string) []string {
+	var res []string
+	for _, x := range xs {
+		res = append(res, strings.ToLower(x))
+	}
+	return res
+}
+
+func countPositive(xs []int) int {
+	n := 0
+	for _, x := range xs {
+		if x > 0 {
+			n++
+		}
+	}
+	return n
+}
+
+func countNegative(xs []int) int {
+	n := 0
+	for _, x := range xs {
+		if x < 0 {
+			n++
+		}
+	}
+	return n
+}
+
+func firstKeys(m map[string]int) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys[:1]
+}
+
+func lastKeys(m map[string]int) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys[1:]
+}
+
+// sumAll duplicates sumInts from positive_tests.go, but files are checked
+// separately by the checker tests.
+func sumAll(xs []int) int {
+	total := 0
+	for _, x := range xs {
+		total += x
+	}
+	if total < 0 {
+		return 0
+	}
+	return total
+}
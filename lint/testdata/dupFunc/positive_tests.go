@@ -0,0 +1,65 @@
+package checker_test
+
+import (
+	"strings"
+)
+
+func sumInts(xs []int) int {
+	total := 0
+	for _, x := range xs {
+		total += x
+	}
+	if total < 0 {
+		return 0
+	}
+	return total
+}
+
+/// sumWeights body is identical to sumInts body; consider reusing it
+func sumWeights(weights []int) int {
+	sum := 0
+	for _, w := range weights {
+		sum += w
+	}
+	if sum < 0 {
+		return 0
+	}
+	return sum
+}
+
+/// sumFloats body is nearly identical to sumInts body; consider extracting common code
+func sumFloats(xs []float64) float64 {
+	total := 0.0
+	for _, x := range xs {
+		total += x
+	}
+	if total < 0 {
+		return 1
+	}
+	return total
+}
+
+type fooList struct {
+	items []string
+}
+
+type barList struct {
+	items []string
+}
+
+func (l *fooList) join() string {
+	var parts []string
+	for _, item := range l.items {
+		parts = append(parts, strings.TrimSpace(item))
+	}
+	return strings.Join(parts, ",")
+}
+
+/// barList.join body is identical to fooList.join body; consider reusing it
+func (l barList) join() string {
+	var parts []string
+	for _, item := range l.items {
+		parts = append(parts, strings.TrimSpace(item))
+	}
+	return strings.Join(parts, ",")
+}
@@ -3,14 +3,18 @@ package checker_test
 func floatBinOps() {
 	var x float64
 
-	_ = x == x &&
-		x != x &&
-		x <= x &&
+	_ = x <= x ||
 		x >= x
 	_ = x / x
 	_ = x - x
+
+	// Not a NaN check.
+	_ = x != -x
+	_ = f(x) != f(x)
 }
 
+func f(x float64) float64 { return x }
+
 func noBinOpDuplicates() {
 	var p point
 	var xs [2]int
@@ -38,3 +42,27 @@ func uncheckedOps(x int) {
 	_ = x + x
 	_ = x * x
 }
+
+func distinctChainOperands(a, b, c, d int, x, y bool, p *point) {
+	_ = a == b && c == d
+	_ = a == b || b == a
+	_ = x || y || !x
+	_ = x && (y || x)
+	_ = x || y && x
+}
+
+func (p *point) ok() bool { return p.x != 0 }
+
+func sideEffectsInChain(p *point, x bool, ch chan bool) {
+	_ = p.ok() && x && p.ok()
+	_ = <-ch || x || <-ch
+}
+
+type number interface {
+	~int | ~float32
+}
+
+func genericFloatBinOps[T number, C interface{ ~complex128 }](x T, c C) {
+	_ = x - x
+	_ = c / c
+}
@@ -1,5 +1,7 @@
 package checker_test
 
+import "cmp"
+
 type point struct{ x, y int }
 
 func lhsRhsDuplicates() {
@@ -32,3 +34,47 @@ func lhsRhsDuplicates() {
 	if (1+p.x+3) >= (1+p.x+3) && p.y^p.y != 0 {
 	}
 }
+
+func dupChainOperands(x, y, z bool, err error, p *point) {
+	/// suspicious identical LHS and RHS for `||` operator
+	_ = x || y || x
+
+	/// suspicious identical LHS and RHS for `&&` operator
+	_ = err == nil && err == nil
+
+	/// suspicious identical LHS and RHS for `||` operator
+	_ = p != nil || z || (p != nil)
+
+	/// suspicious identical LHS and RHS for `&&` operator
+	_ = x && (y && z) && y
+
+	/// suspicious identical LHS and RHS for `||` operator
+	_ = x && y || z || x && y
+
+	/// suspicious identical LHS and RHS for `&&` operator
+	_ = z || (x && y && x)
+}
+
+func nanChecks() {
+	var x float64
+	var p struct{ f float32 }
+
+	/// `x != x` looks like a NaN check, consider `math.IsNaN(x)`
+	_ = x != x
+
+	/// `p.f == p.f` looks like a NaN check, consider `!math.IsNaN(p.f)`
+	_ = p.f == p.f
+
+	/// `(x) != (x)` looks like a NaN check, consider `math.IsNaN(x)`
+	_ = (x) != (x)
+}
+
+func genericNaNChecks[T cmp.Ordered](x T) {
+	/// `x != x` looks like a NaN check, consider `math.IsNaN(x)`
+	_ = x != x
+}
+
+func genericInts[T ~int | ~int64](x T) {
+	/// suspicious identical LHS and RHS for `-` operator
+	_ = x - x
+}
@@ -0,0 +1,15 @@
+package main
+
+func selectWithCases(done, quit chan struct{}) {
+	select {
+	case <-done:
+	}
+	select {
+	case <-done:
+	case <-quit:
+	default:
+	}
+	select {
+	default:
+	}
+}
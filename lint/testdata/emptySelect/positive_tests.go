@@ -0,0 +1,25 @@
+package main
+
+func main() {
+	go serve()
+	/// select {} blocks forever; ensure this is intentional
+	select {}
+}
+
+func serve() {
+	go func() {
+		/// select {} blocks forever; ensure this is intentional
+		select {}
+	}()
+}
+
+type worker struct{}
+
+func (w *worker) wait(done chan struct{}) {
+	if done == nil {
+		/// select {} blocks forever; ensure this is intentional
+		select {
+		}
+	}
+	<-done
+}
@@ -0,0 +1,8 @@
+package checker_test
+
+import "errors"
+
+func received(ch chan error) string {
+	/// errors.Join with a single error is redundant
+	return errors.Join(<-ch).Error()
+}
@@ -0,0 +1,36 @@
+package checker_test
+
+import (
+	"errors"
+)
+
+func multipleArgs(err1, err2 error) error {
+	return errors.Join(err1, err2)
+}
+
+func variadicArgs(errs []error) error {
+	return errors.Join(errs...)
+}
+
+type joiner struct{}
+
+func (joiner) Join(errs ...error) error { return nil }
+
+func otherJoin(err error) error {
+	var errors joiner
+	return errors.Join(err)
+}
+
+func pair() (error, error) { return nil, nil }
+
+func multiValueArg() error {
+	return errors.Join(pair())
+}
+
+type myError struct{}
+
+func (*myError) Error() string { return "" }
+
+func concreteErrorArg(err *myError) error {
+	return errors.Join(err)
+}
@@ -0,0 +1,20 @@
+package checker_test
+
+import (
+	"errors"
+)
+
+func singleArg(err error) error {
+	/// errors.Join with a single error is redundant
+	return errors.Join(err)
+}
+
+func singleArgCall(f func() error) error {
+	/// errors.Join with a single error is redundant
+	return errors.Join(f())
+}
+
+func noArgs() error {
+	/// errors.Join with no arguments returns nil
+	return errors.Join()
+}
@@ -50,3 +50,16 @@ func unrelated() {
 		}
 	}
 }
+
+func goodAssignments(xs []int, i, j int) {
+	xs[i], xs[j] = xs[j], xs[i]
+	i, j = j, i
+	xs[0] = len(xs)
+	v, ok := xs[0], mayMutateSlice(nil)
+	var a, b = xs[0], xs[1]
+	_, _, _, _ = v, ok, a, b
+
+	var k int
+	i, k = xs[i], xs[j]
+	_ = k
+}
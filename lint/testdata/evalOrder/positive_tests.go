@@ -75,3 +75,43 @@ func multiReturn() {
 		}
 	}
 }
+
+func assignments() {
+	_ = func(xs []int) {
+		var ok bool
+		var v int
+		/// potential dependency on evaluation order (low)
+		ok, v = mayMutateSlice(&xs), xs[0]
+		_, _ = ok, v
+	}
+
+	_ = func(xs []int) {
+		/// potential dependency on evaluation order (low)
+		ok, v := mayMutateSlice(&xs), xs[0]
+		_, _ = ok, v
+	}
+
+	_ = func(xs []int) {
+		var ok bool
+		/// potential dependency on evaluation order (low)
+		xs[0], ok = 1, mayMutateSlice(&xs)
+		_ = ok
+	}
+
+	_ = func(xs []int) {
+		/// potential dependency on evaluation order (low)
+		var ok, v = mayMutateSlice(&xs), xs[0]
+		_, _ = ok, v
+	}
+}
+
+func assignedOperands(xs []int, i int, p *struct{ x int }) {
+	/// xs[i] uses i value before the assignment
+	xs[i], i = 1, 2
+
+	/// xs[i+1] uses i value before the assignment
+	i, xs[i+1] = 1, 2
+
+	/// p.x uses p value before the assignment
+	p, p.x = nil, 10
+}
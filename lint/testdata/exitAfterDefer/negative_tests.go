@@ -0,0 +1,38 @@
+package checker_test
+
+import (
+	"log"
+	"os"
+)
+
+func exitNoDefer() {
+	log.Fatal("fatal")
+	os.Exit(1)
+}
+
+func exitBeforeDefer(filename string) {
+	if filename == "" {
+		log.Fatalf("empty filename")
+	}
+	defer os.Remove(filename)
+	log.Printf("ok")
+}
+
+func deferInOuterFunc(filename string) {
+	defer os.Remove(filename)
+	_ = func() {
+		os.Exit(1)
+	}
+}
+
+func deferInFuncLit() {
+	_ = func() {
+		defer println("done")
+	}
+	os.Exit(0)
+}
+
+func panicAfterDefer() {
+	defer println("done")
+	log.Panic("panic runs defers")
+}
@@ -0,0 +1,33 @@
+package checker_test
+
+import (
+	"log"
+	"os"
+)
+
+func exitWithDefer(filename string, bad bool) {
+	defer os.Remove(filename)
+	if bad {
+		/// log.Fatalf will exit, and `defer os.Remove(filename)` will not run
+		log.Fatalf("something bad happened")
+	}
+	/// os.Exit will exit, and `defer os.Remove(filename)` will not run
+	os.Exit(1)
+}
+
+func exitWithFuncLitDefer(l *log.Logger) {
+	defer func() {
+		println("cleanup")
+	}()
+	/// l.Fatal will exit, and `defer func(){...}(...)` will not run
+	l.Fatal("fatal")
+}
+
+func exitInFuncLit() {
+	_ = func() {
+		f, _ := os.Open("x")
+		defer f.Close()
+		/// log.Fatalln will exit, and `defer f.Close()` will not run
+		log.Fatalln("fatal")
+	}
+}
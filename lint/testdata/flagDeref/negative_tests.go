@@ -32,3 +32,12 @@ func varFunctionsUsage() {
 	var u64 uint64
 	flag.Uint64Var(&u64, "u64", 0, "")
 }
+
+type fakeFlags struct{}
+
+func (fakeFlags) Bool(name string, value bool, usage string) *bool { return &value }
+
+func notFlagPackage() {
+	var flag fakeFlags
+	_ = *flag.Bool("b", false, "")
+}
@@ -35,3 +35,11 @@ func shouldWarn() {
 	/// immediate deref in *flag.Uint64("u64", 0, "") is most likely an error; consider using flag.Uint64Var
 	_ = *flag.Uint64("u64", 0, "")
 }
+
+func flagSetMethods(fs *flag.FlagSet) {
+	/// immediate deref in *fs.Bool("b", false, "") is most likely an error; consider using fs.BoolVar
+	_ = *fs.Bool("b", false, "")
+
+	/// immediate deref in *flag.CommandLine.String("s", "", "") is most likely an error; consider using flag.CommandLine.StringVar
+	_ = *flag.CommandLine.String("s", "", "")
+}
@@ -0,0 +1,8 @@
+package checker_test
+
+import fl "flag"
+
+func renamedImport() {
+	/// immediate deref in *fl.Int("i", 0, "") is most likely an error; consider using fl.IntVar
+	_ = *fl.Int("i", 0, "")
+}
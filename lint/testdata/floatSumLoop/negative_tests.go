@@ -0,0 +1,60 @@
+package checker_test
+
+func intSum(xs []int) int {
+	sum := 0
+	for _, x := range xs {
+		sum += x
+	}
+	return sum
+}
+
+func float32Sum(xs []float32) float32 {
+	var sum float32
+	for _, x := range xs {
+		sum += x
+	}
+	return sum
+}
+
+func fixedArraySum(xs [4]float64) float64 {
+	var sum float64
+	for _, x := range xs {
+		sum += x
+	}
+	return sum
+}
+
+func localAccumulator(xs []float64) {
+	for _, x := range xs {
+		var sum float64
+		sum += x
+		_ = sum
+	}
+}
+
+func notAccumulation(xs []float64) float64 {
+	var sum, prod float64
+	prod = 1
+	for _, x := range xs {
+		sum = x + sum
+		sum -= x
+		prod *= x
+	}
+	return sum + prod
+}
+
+func closureInLoop(xs []float64) {
+	var sum float64
+	for _, x := range xs {
+		f := func() { sum += x }
+		_ = f
+	}
+}
+
+func forLoop(xs []float64) float64 {
+	var sum float64
+	for i := 0; i < len(xs); i++ {
+		sum += xs[i]
+	}
+	return sum
+}
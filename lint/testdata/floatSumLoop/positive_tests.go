@@ -0,0 +1,43 @@
+package checker_test
+
+type measurement float64
+
+func sumSlice(xs []float64) float64 {
+	var sum float64
+	for _, x := range xs {
+		/// naive float64 summation in a loop can accumulate error; consider Kahan summation for large inputs
+		sum += x
+	}
+	return sum
+}
+
+func sumMap(m map[string]float64) float64 {
+	sum := 0.0
+	for _, x := range m {
+		/// naive float64 summation in a loop can accumulate error; consider Kahan summation for large inputs
+		sum = sum + x
+	}
+	return sum
+}
+
+func sumChan(ch chan measurement) measurement {
+	var total measurement
+	for x := range ch {
+		if x > 0 {
+			/// naive float64 summation in a loop can accumulate error; consider Kahan summation for large inputs
+			total += x
+		}
+	}
+	return total
+}
+
+func sumNested(xss [][]float64) float64 {
+	var sum float64
+	for _, xs := range xss {
+		for _, x := range xs {
+			/// naive float64 summation in a loop can accumulate error; consider Kahan summation for large inputs
+			sum += x
+		}
+	}
+	return sum
+}
@@ -0,0 +1,28 @@
+package checker_test
+
+//go:generate go version
+
+//go:generate go run ./gen -flag
+//go:generate go run -tags=gen ./gen
+//go:generate go run cmd/gofmt -l .
+//go:generate go run golang.org/x/tools/cmd/stringer@v0.1.0 -type=Kind
+//go:generate go run "./gen"
+//go:generate go run -tags gen -mod=mod ./gen
+//go:generate go run -v --ldflags -s ./gen
+//go:generate go run -C gen .
+//go:generate go run ./gen/$GOARCH
+//go:generate go run ${GOPACKAGE}_gen.go
+
+//go:generate -command gen go run ./gen
+//go:generate gen -type=Kind
+
+//go:generate $GOROOT/bin/go version
+
+// go:generate non-existing-tool (not a directive due to the space)
+
+type Kind int // go:generate non-existing-tool (not a directive)
+
+func f() {
+	// Indented comments are not directives.
+	//go:generate non-existing-tool
+}
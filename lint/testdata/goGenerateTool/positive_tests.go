@@ -0,0 +1,19 @@
+package checker_test
+
+/// go:generate references tool "non-existing-gocritic-tool" which was not found
+//go:generate non-existing-gocritic-tool -type=Kind
+
+/// go:generate references tool "./gen.sh" which was not found
+//go:generate ./gen.sh
+
+/// go:generate references tool "./nogen" which was not found
+//go:generate go run ./nogen
+
+/// go:generate references tool "./nogen" which was not found
+//go:generate go run -tags gen -x ./nogen
+
+/// go:generate references tool "non-existing-gocritic-gen.go" which was not found
+//go:generate go run non-existing-gocritic-gen.go
+
+/// go:generate references tool "gen" which was not found
+//go:generate gen
@@ -1,5 +1,7 @@
 package checker_test
 
+import "fmt"
+
 func noParams() {}
 
 func outputParams() bigStruct {
@@ -17,3 +19,24 @@ func bigStructPtr2(x, y *bigStruct) {}
 func mixedBigObjectsPtr(x *bigStruct, y *[20][]int) {}
 
 func (x *bigStruct) bigRecvPtr(y *[2]bigStruct) {}
+
+type bigSizer interface {
+	Size(x bigStruct) int
+}
+
+// OK: required by fmt.Stringer.
+func (x bigStruct) String() string { return x.x1 }
+
+// OK: required by error.
+func (x bigStruct) Error() string { return x.x2 }
+
+// OK: required by bigSizer, including params.
+func (x *bigStruct) Size(y bigStruct) int { return len(y.x1) }
+
+var (
+	_ fmt.Stringer = bigStruct{}
+	_ bigSizer     = &bigStruct{}
+)
+
+// OK: size of T is unknown.
+func genericParam[T any](x [1024]T, y struct{ v T }) {}
@@ -25,3 +25,11 @@ func mixedBigObjects(x bigStruct, y [20][]int) {}
 /// x is heavy (80 bytes); consider passing it by pointer
 /// y is heavy (160 bytes); consider passing it by pointer
 func (x bigStruct) bigRecv(y [2]bigStruct) {}
+
+type bigStringer struct {
+	bigStruct
+}
+
+/// x is heavy (80 bytes); consider passing it by pointer
+/// y is heavy (80 bytes); consider passing it by pointer
+func (x bigStringer) String(y bigStruct) string { return x.x1 }
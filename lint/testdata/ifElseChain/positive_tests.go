@@ -55,3 +55,33 @@ func describeInt(x int) string {
 		return "positive"
 	}
 }
+
+func describeKind(kind string, p *struct{ kind string }) int {
+	/// should rewrite if-else to `switch kind` statement
+	if kind == "a" {
+		return 1
+	} else if kind == "b" {
+		return 2
+	} else {
+		return 3
+	}
+
+	/// should rewrite if-else to `switch p.kind` statement
+	if p.kind == "a" {
+		return 1
+	} else if p.kind == "b" || p.kind == "c" {
+		return 2
+	} else if p.kind == "d" {
+		return 3
+	}
+
+	/// should rewrite if-else to switch statement
+	if p.kind == "a" {
+		return 1
+	} else if kind == "b" {
+		return 2
+	} else if p.kind == "c" {
+		return 3
+	}
+	return 0
+}
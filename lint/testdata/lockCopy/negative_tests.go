@@ -0,0 +1,48 @@
+package checker_test
+
+import (
+	"sync"
+)
+
+type safeCounter struct {
+	mu *sync.Mutex
+	n  int
+}
+
+func (c safeCounter) Value() int { return c.n }
+
+func (c *counter) Inc() { c.n++ }
+
+func takePtr(c *counter) {}
+
+func noCopies(c *counter, cs []counter, ps []*counter, empty [0]sync.Mutex) {
+	// New values are not copies.
+	x := counter{}
+	y := newCounter()
+	_ = x
+	_ = y
+
+	// Pointers and blank assignments.
+	p := c
+	_ = p
+	_ = *c
+	takePtr(&cs[0])
+
+	for i := range cs {
+		cs[i].n++
+	}
+	for _, _ = range cs {
+	}
+	for _, p := range ps {
+		_ = p
+	}
+
+	// Zero-length array has no locks.
+	e := empty
+	_ = e
+
+	// Builtins don't copy.
+	_ = len(cs)
+}
+
+func newCounter() counter { return counter{} }
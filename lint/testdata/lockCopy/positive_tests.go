@@ -0,0 +1,55 @@
+package checker_test
+
+import (
+	"sync"
+)
+
+type counter struct {
+	mu sync.Mutex
+	n  int
+}
+
+type nested struct {
+	c counter
+}
+
+type withArray struct {
+	groups [2]sync.WaitGroup
+}
+
+/// receiver copies a value containing sync.Mutex; consider pointer receiver *counter
+func (c counter) Value() int { return c.n }
+
+/// param copies a value containing sync.Mutex; consider passing *nested
+func takeNested(x nested) {}
+
+/// param copies a value containing sync.WaitGroup; consider passing *withArray
+func takeArray(withArray) {}
+
+func useCounter(c *counter, cs []counter, m map[string]nested) {
+	/// assignment to x copies a value containing sync.Mutex
+	x := *c
+
+	var y counter
+	/// assignment to y copies a value containing sync.Mutex
+	y = x
+
+	/// assignment to z copies a value containing sync.Mutex
+	var z = cs[0]
+
+	/// call argument m["a"] copies a value containing sync.Mutex; consider passing &m["a"]
+	takeNested(m["a"])
+
+	/// assignment to inner copies a value containing sync.Mutex
+	inner := m["b"].c
+
+	/// range var v copies a value containing sync.Mutex; iterate over indexes instead
+	for _, v := range cs {
+		_ = v
+	}
+
+	/// param copies a value containing sync.RWMutex; consider passing *sync.RWMutex
+	_ = func(mu sync.RWMutex) {}
+
+	_, _, _ = y, z, inner
+}
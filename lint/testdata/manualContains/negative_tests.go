@@ -0,0 +1,76 @@
+package checker_test
+
+func differentElem(list []string, target string) bool {
+	for _, v := range list {
+		if v+"x" == target {
+			return true
+		}
+	}
+	return false
+}
+
+func usesIndex(list []string, target string) bool {
+	for i, v := range list {
+		if v == target && i != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func moreStatements(list []string, target string) bool {
+	found := false
+	for _, v := range list {
+		if v == target {
+			println(v)
+			found = true
+			break
+		}
+	}
+	return found
+}
+
+func callTarget(list []string, f func() string) bool {
+	for _, v := range list {
+		if v == f() {
+			return true
+		}
+	}
+	return false
+}
+
+func arrayRange(arr [4]int, target int) bool {
+	for _, v := range arr {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
+
+func returnFalse(list []string, target string) bool {
+	for _, v := range list {
+		if v == target {
+			return false
+		}
+	}
+	return true
+}
+
+func mapRange(m map[string]string, target string) bool {
+	for _, v := range m {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
+
+func notEqual(list []string, target string) bool {
+	for _, v := range list {
+		if v != target {
+			return true
+		}
+	}
+	return false
+}
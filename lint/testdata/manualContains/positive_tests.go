@@ -0,0 +1,52 @@
+package checker_test
+
+import (
+	"fmt"
+)
+
+func flagDefine(list []string, target string) bool {
+	found := false
+	/// this membership loop can use slices.Contains
+	for _, v := range list {
+		if v == target {
+			found = true
+			break
+		}
+	}
+	return found
+}
+
+func flagVar(list []int) {
+	var found bool
+	/// this membership loop can use slices.Contains
+	for _, v := range list {
+		if 10 == v {
+			found = true
+			break
+		}
+	}
+	fmt.Println(found)
+}
+
+type point struct{ x, y int }
+
+func returnTrue(xs []int, p *point) bool {
+	/// this membership loop can use slices.Contains
+	for _, v := range xs {
+		if v == p.x {
+			return true
+		}
+	}
+	return false
+}
+
+func withoutInit(list []string, target string) (found bool) {
+	/// this membership loop can use slices.Contains
+	for _, v := range list {
+		if v == target {
+			found = true
+			break
+		}
+	}
+	return found
+}
@@ -0,0 +1,92 @@
+package checker_test
+
+func notMinMax(a, b, c int) {
+	var m, n int
+
+	// Assigns unrelated value.
+	if a < b {
+		m = a
+	} else {
+		m = c
+	}
+
+	// Assigns to different variables.
+	if a < b {
+		m = a
+	} else {
+		n = b
+	}
+
+	// Same value in both branches.
+	if a < b {
+		m = a
+	} else {
+		m = a
+	}
+
+	// Not an ordering comparison.
+	if a == b {
+		m = a
+	} else {
+		m = b
+	}
+
+	// Has init statement.
+	if v := a; v < b {
+		m = v
+	} else {
+		m = b
+	}
+
+	// Not a simple assignment.
+	if a < b {
+		m += a
+	} else {
+		m += b
+	}
+
+	// Extra statements.
+	if a < b {
+		m = a
+		n = b
+	} else {
+		m = b
+	}
+
+	// Else-if chain.
+	if a < b {
+		m = a
+	} else if b < c {
+		m = b
+	}
+
+	// No else.
+	m = b
+	if a < b {
+		m = a
+	}
+
+	_, _ = m, n
+}
+
+func shadowedBuiltins(a, b int) {
+	min := func(x, y int) int { return x }
+	max := func(x, y int) int { return y }
+	_, _ = min, max
+
+	var m int
+
+	if a < b {
+		m = a
+	} else {
+		m = b
+	}
+
+	if a > b {
+		m = a
+	} else {
+		m = b
+	}
+
+	_ = m
+}
@@ -0,0 +1,97 @@
+package checker_test
+
+import "cmp"
+
+func minPatterns(a, b int) {
+	var m int
+
+	/// this min/max pattern can use the builtin min/max
+	if a < b {
+		m = a
+	} else {
+		m = b
+	}
+
+	/// this min/max pattern can use the builtin min/max
+	if a <= b {
+		m = a
+	} else {
+		m = b
+	}
+
+	/// this min/max pattern can use the builtin min/max
+	if a > b {
+		m = b
+	} else {
+		m = a
+	}
+
+	_ = m
+}
+
+func maxPatterns(a, b float64) {
+	var xs [2]float64
+
+	/// this min/max pattern can use the builtin min/max
+	if a > b {
+		xs[0] = a
+	} else {
+		xs[0] = b
+	}
+
+	/// this min/max pattern can use the builtin min/max
+	if a < b {
+		xs[1] = b
+	} else {
+		xs[1] = a
+	}
+
+	/// this min/max pattern can use the builtin min/max
+	if a >= b {
+		xs[1] = a
+	} else {
+		xs[1] = b
+	}
+}
+
+func stringOperands(s1, s2 string) string {
+	var longest string
+	/// this min/max pattern can use the builtin min/max
+	if s1 > s2 {
+		longest = s1
+	} else {
+		longest = s2
+	}
+	return longest
+}
+
+func noFix(a, b int, f func() int) int {
+	var m int
+
+	/// this min/max pattern can use the builtin min/max
+	if f() < b {
+		m = f()
+	} else {
+		m = b
+	}
+
+	/// this min/max pattern can use the builtin min/max
+	if a < b {
+		// Keep the smallest.
+		m = a
+	} else {
+		m = b
+	}
+	return m
+}
+
+func genericOperands[T cmp.Ordered](a, b T) T {
+	var m T
+	/// this min/max pattern can use the builtin min/max
+	if a < b {
+		m = a
+	} else {
+		m = b
+	}
+	return m
+}
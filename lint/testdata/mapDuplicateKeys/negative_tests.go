@@ -0,0 +1,31 @@
+package checker_test
+
+type keyMap map[string]int
+
+func uniqueKeys(p, p2 person, k string, next func() string) {
+	_ = map[string]int{
+		p.name:  1,
+		p2.name: 2,
+		k:       3,
+	}
+
+	_ = map[string]int{
+		next(): 1,
+		next(): 2,
+	}
+
+	_ = keyMap{
+		k + "a": 1,
+		k + "b": 2,
+	}
+
+	_ = map[point]int{
+		{1, 2}: 1,
+		{2, 1}: 2,
+	}
+
+	_ = []string{
+		1: k,
+		2: k,
+	}
+}
@@ -0,0 +1,30 @@
+package checker_test
+
+type point struct{ x, y int }
+
+type person struct{ name string }
+
+func duplicatedKeys(p, p2 person, k string, i int) {
+	_ = map[string]int{
+		p.name:  1,
+		p2.name: 2,
+		/// suspicious duplicate p.name key in map literal
+		p.name: 3,
+	}
+
+	_ = map[string]bool{
+		k: true,
+		/// suspicious duplicate k key in map literal
+		k: false,
+	}
+
+	_ = map[point]string{
+		{1, 2}: "a",
+		{2, 1}: "b",
+		/// suspicious duplicate {1, 2} key in map literal
+		{1, 2}: "c",
+		{x: i}: "d",
+		/// suspicious duplicate point{x: i} key in map literal
+		point{x: i}: "e",
+	}
+}
@@ -0,0 +1,33 @@
+package checker_test
+
+func SortedKeys(m map[string]int) []string {
+	var keys []string
+	/// map iteration order is non-deterministic; sort the keys if order matters
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func orderedValues(m map[int]string) []string {
+	values := make([]string, 0, len(m))
+	/// map iteration order is non-deterministic; sort the keys if order matters
+	for _, v := range m {
+		if v != "" {
+			continue
+		}
+		values = append(values, v)
+	}
+	return values
+}
+
+type registry map[string]bool
+
+func (r registry) SortedNames() (names []string) {
+	/// map iteration order is non-deterministic; sort the keys if order matters
+	for name, enabled := range r {
+		names = append(names, name)
+		_ = enabled
+	}
+	return
+}
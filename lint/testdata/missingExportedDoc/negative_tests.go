@@ -0,0 +1,37 @@
+package checker_test
+
+// Documented is documented.
+func Documented() {}
+
+// Wrong prefix is accepted unless checkPrefix param is set.
+func Prefixed() {}
+
+func unexported() {}
+
+type internal struct{}
+
+func (internal) Exported() {}
+
+// Buffer is documented.
+type Buffer struct{}
+
+// Len is documented.
+func (b *Buffer) Len() int { return 0 }
+
+// Levels.
+const (
+	LevelLow = iota
+	LevelHigh
+)
+
+var (
+	// Enabled is documented.
+	Enabled bool
+
+	unexportedVar bool
+)
+
+var _ = unexportedVar
+
+// A Point is documented.
+type Point struct{}
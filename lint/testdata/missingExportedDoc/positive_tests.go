@@ -0,0 +1,47 @@
+package checker_test
+
+/// exported func Parse should have a doc comment
+
+func Parse(s string) (*Config, error) { return nil, nil }
+
+/// exported type Config should have a doc comment
+
+type Config struct{}
+
+/// exported method Config.Validate should have a doc comment
+
+func (c *Config) Validate() error { return nil }
+
+type (
+	// Option is documented.
+	Option func(*Config)
+
+	/// exported type Mode should have a doc comment
+
+	Mode int
+)
+
+/// exported const DefaultMode should have a doc comment
+
+const DefaultMode Mode = 0
+
+/// exported var ErrInvalid should have a doc comment
+
+var ErrInvalid error
+
+var (
+	/// exported var Verbose should have a doc comment
+
+	Verbose bool
+
+	/// exported var Debug should have a doc comment
+
+	debugFlag, Debug bool
+)
+
+// List is documented.
+type List[T any] []T
+
+/// exported method List.Len should have a doc comment
+
+func (l List[T]) Len() int { return len(l) }
@@ -0,0 +1,33 @@
+package checker_test
+
+import (
+	"bytes"
+	"reflect"
+)
+
+func lenChecks(b []byte) bool {
+	if b == nil || len(b) == 0 {
+		return true
+	}
+	if b != nil && len(b) > 10 {
+		return false
+	}
+	return len(b) == 0
+}
+
+func otherSlices(xs []int, s []string, p *int, err error) bool {
+	return xs == nil || s == nil || p == nil || err == nil
+}
+
+func otherComparisons(a, b []byte) bool {
+	if reflect.DeepEqual(a, b) {
+		return true
+	}
+	if reflect.DeepEqual(a, []byte{1}) {
+		return true
+	}
+	if reflect.DeepEqual(a, []int{}) {
+		return true
+	}
+	return bytes.Equal(a, nil)
+}
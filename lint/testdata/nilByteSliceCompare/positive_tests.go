@@ -0,0 +1,40 @@
+package checker_test
+
+import (
+	"errors"
+	"reflect"
+)
+
+var errEmpty = errors.New("empty")
+
+func nilChecks(b []byte, data []uint8) error {
+	/// nil check on []byte may not mean empty; use len(b) == 0
+	if b == nil {
+		return errEmpty
+	}
+	/// nil check on []byte may not mean empty; use len(data) != 0
+	if nil != data {
+		return nil
+	}
+	return errEmpty
+}
+
+type payload []byte
+
+type message struct {
+	body payload
+}
+
+func fieldCheck(m *message, ok bool) bool {
+	/// nil check on []byte may not mean empty; use len(m.body) == 0
+	return ok && m.body == nil
+}
+
+func deepEqual(b []byte) bool {
+	/// reflect.DeepEqual with []byte{} is false for nil slice; use len(b) == 0
+	if reflect.DeepEqual(b, []byte{}) {
+		return true
+	}
+	/// reflect.DeepEqual with []byte{} is false for nil slice; use len(b) == 0
+	return reflect.DeepEqual([]byte{}, b)
+}
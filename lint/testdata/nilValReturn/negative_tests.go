@@ -0,0 +1,57 @@
+package checker_test
+
+import (
+	"fmt"
+	"log"
+)
+
+func returnsErr() error {
+	if err := do(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func wrapsErr() (int, error) {
+	if err := do(); err != nil {
+		return 0, fmt.Errorf("do: %v", err)
+	}
+	return 1, nil
+}
+
+func handledErr() error {
+	if err := do(); err != nil {
+		log.Printf("ignored: %v", err)
+		return nil
+	}
+	return nil
+}
+
+func notErrorResult() *int {
+	if err := do(); err != nil {
+		return nil
+	}
+	return new(int)
+}
+
+func nilCheckedValue(p *int) *int {
+	if p == nil {
+		return p
+	}
+	return nil
+}
+
+func reassigned() error {
+	err := do()
+	if err == nil {
+		err = do()
+		return err
+	}
+	return nil
+}
+
+func noResults() {
+	if err := do(); err != nil {
+		return
+	}
+}
@@ -0,0 +1,43 @@
+package checker_test
+
+import (
+	"errors"
+)
+
+func do() error { return errors.New("failed") }
+
+func swallowed() error {
+	err := do()
+	if err != nil {
+		/// err is not nil, but nil is returned
+		return nil
+	}
+	return nil
+}
+
+func swallowedMultiple() (int, error) {
+	if err := do(); err != nil {
+		/// err is not nil, but nil is returned
+		return 0, nil
+	}
+	return 1, nil
+}
+
+func swallowedInFuncLit() {
+	_ = func() error {
+		if err := do(); err != nil {
+			/// err is not nil, but nil is returned
+			return nil
+		}
+		return nil
+	}
+}
+
+func alwaysNil() (int, error) {
+	err := do()
+	if err == nil {
+		/// returned expr is always nil; replace err with nil
+		return 0, err
+	}
+	return 1, err
+}
@@ -0,0 +1,15 @@
+package checker_test
+
+const (
+	lowerHex  = 0xff
+	upperHex  = 0XFF
+	digitsHex = 0x1234567890
+	newOctal  = 0o755
+	binary    = 0b1010
+	zeroValue = 0
+	small     = 999999
+	separated = 1_000_000
+	withSep   = 0x_dead_beef
+	float     = 1000000.5
+	floatZero = 0755.5
+)
@@ -0,0 +1,29 @@
+package checker_test
+
+const (
+	/// mixed-case hex literal 0xFf; use 0xff or 0xFF
+	mask1 = 0xFf
+
+	/// mixed-case hex literal 0XabCD; use 0Xabcd or 0XABCD
+	mask2 = 0XabCD
+
+	/// use 0o755 instead of old-style octal 0755
+	perm1 = 0755
+
+	/// use 0o0 instead of old-style octal 00
+	zero = 00
+
+	/// consider 1_000_000 for readability
+	million = 1000000
+
+	/// consider 12_345_678_901 for readability
+	big = 12345678901
+)
+
+func numbers() {
+	/// use 0o644 instead of old-style octal 0644
+	_ = 0644
+
+	/// mixed-case hex literal 0xdeadBEEF; use 0xdeadbeef or 0xDEADBEEF
+	_ = 0xdeadBEEF
+}
@@ -41,3 +41,16 @@ func withBlank1(_, _ int, _ int, _ int32) {}
 
 /// func() (_, _ int, _ int, _ int32) could be replaced with func() (_, _, _ int, _ int32)
 func withBlank2() (_, _ int, _ int, _ int32) { return }
+
+/// func(a int, b int) could be replaced with func(a, b int)
+func Exported(a int, b int) {}
+
+type Exported2 struct{}
+
+/// func(a int, b int) could be replaced with func(a, b int)
+func (*Exported2) Method(a int, b int) {}
+
+type unexported struct{}
+
+/// func(a int, b int) could be replaced with func(a, b int)
+func (unexported) Method(a int, b int) {}
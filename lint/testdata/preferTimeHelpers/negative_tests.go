@@ -0,0 +1,21 @@
+package checker_test
+
+import (
+	"time"
+)
+
+type fakeTime struct{}
+
+func (fakeTime) Now() time.Time { return time.Time{} }
+
+func goodTimeHelpers(start, end time.Time, clock fakeTime) {
+	_ = time.Since(start)
+	_ = time.Until(end)
+	_ = end.Sub(start)
+	_ = time.Now().Add(time.Second)
+	_ = end.Before(start)
+
+	// Not a time.Now call.
+	_ = clock.Now().Sub(start)
+	_ = end.Sub(clock.Now())
+}
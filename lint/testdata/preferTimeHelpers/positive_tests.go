@@ -0,0 +1,23 @@
+package checker_test
+
+import (
+	"time"
+)
+
+func timeHelpers(start, deadline time.Time, timeouts []time.Time) {
+	/// time.Now().Sub(start) can be simplified to time.Since(start)
+	_ = time.Now().Sub(start)
+
+	/// deadline.Sub(time.Now()) can be simplified to time.Until(deadline)
+	_ = deadline.Sub(time.Now())
+
+	/// time.Now().Sub(timeouts[0]) can be simplified to time.Since(timeouts[0])
+	_ = time.Now().Sub(timeouts[0])
+
+	/// time.Now().Before(deadline) can be simplified to time.Until(deadline) > 0
+	if time.Now().Before(deadline) {
+	}
+
+	/// time.Now().After(deadline) can be simplified to time.Since(deadline) > 0
+	_ = time.Now().After(deadline)
+}
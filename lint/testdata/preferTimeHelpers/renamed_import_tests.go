@@ -0,0 +1,10 @@
+package checker_test
+
+import (
+	stdtime "time"
+)
+
+func renamedTime(start stdtime.Time) {
+	/// stdtime.Now().Sub(start) can be simplified to stdtime.Since(start)
+	_ = stdtime.Now().Sub(start)
+}
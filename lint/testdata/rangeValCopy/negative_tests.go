@@ -39,3 +39,33 @@ func bigPointers(xs []*bigObject) int32 {
 	}
 	return v
 }
+
+func smallValues(xs [][32]byte) byte {
+	// OK: values are smaller than the default threshold.
+	v := byte(0)
+	for _, x := range xs {
+		v += x[0]
+	}
+	return v
+}
+
+func genericValues[T any](xs [][64]T) int {
+	// OK: size of T is unknown.
+	n := 0
+	for _, x := range xs {
+		_ = x
+		n++
+	}
+	return n
+}
+
+func genericLocalType[T any](xs []T) int {
+	// OK: size of local type depends on T.
+	type pair struct{ a, b [8]T }
+	n := 0
+	for _, p := range make([]pair, len(xs)) {
+		_ = p
+		n++
+	}
+	return n
+}
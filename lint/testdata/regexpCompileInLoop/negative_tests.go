@@ -0,0 +1,52 @@
+package checker_test
+
+import (
+	"regexp"
+)
+
+var digitsRE = regexp.MustCompile(`^\d+$`)
+
+func compileOutsideLoop(lines []string) int {
+	re := regexp.MustCompile(`^\d+$`)
+	n := 0
+	for _, s := range lines {
+		if re.MatchString(s) || digitsRE.MatchString(s) {
+			n++
+		}
+	}
+	return n
+}
+
+func dynamicPatterns(patterns []string, s string) int {
+	n := 0
+	for _, p := range patterns {
+		re := regexp.MustCompile(p)
+		if re.MatchString(s) {
+			n++
+		}
+		if ok, _ := regexp.MatchString(p+"$", s); ok {
+			n++
+		}
+	}
+	return n
+}
+
+func compileInFuncLit(lines []string) {
+	for range lines {
+		init := func() *regexp.Regexp {
+			return regexp.MustCompile(`a`)
+		}
+		_ = init
+	}
+}
+
+func loopInit() {
+	for re := regexp.MustCompile(`a`); re != nil; re = nil {
+	}
+}
+
+func quoteInLoop(xs []string) {
+	for _, x := range xs {
+		_ = regexp.QuoteMeta("a.b") + x
+	}
+}
@@ -0,0 +1,59 @@
+package checker_test
+
+import (
+	"regexp"
+	"strings"
+)
+
+const digitsPattern = `^\d+$`
+
+func compileInLoop(lines []string) int {
+	n := 0
+	for _, s := range lines {
+		/// regexp.MustCompile compiles constant pattern on every iteration; consider moving it out of the loop
+		re := regexp.MustCompile(`^\d+$`)
+		if re.MatchString(s) {
+			n++
+		}
+	}
+	for i := 0; i < len(lines); i++ {
+		/// regexp.Compile compiles constant pattern on every iteration; consider moving it out of the loop
+		re, err := regexp.Compile(digitsPattern)
+		if err == nil && re.MatchString(lines[i]) {
+			n++
+		}
+	}
+	return n
+}
+
+func matchInLoop(lines []string) int {
+	n := 0
+	for _, s := range lines {
+		if s != "" {
+			/// regexp.MatchString compiles constant pattern on every iteration; consider moving it out of the loop
+			if ok, _ := regexp.MatchString(`a+b`, s); ok {
+				n++
+			}
+		}
+	}
+	for _, s := range lines {
+		/// regexp.Match compiles constant pattern on every iteration; consider moving it out of the loop
+		if ok, _ := regexp.Match("x"+digitsPattern, []byte(s)); ok {
+			n++
+		}
+		/// regexp.MatchReader compiles constant pattern on every iteration; consider moving it out of the loop
+		if ok, _ := regexp.MatchReader(`a`, strings.NewReader(s)); ok {
+			n++
+		}
+	}
+	return n
+}
+
+func nestedLoops(lines [][]string) {
+	for _, xs := range lines {
+		for _, x := range xs {
+			/// regexp.MustCompilePOSIX compiles constant pattern on every iteration; consider moving it out of the loop
+			_ = regexp.MustCompilePOSIX(`a|b`).MatchString(x)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package checker_test
+
+import (
+	"regexp"
+)
+
+var re = regexp.MustCompile("abc")
+
+func metaPatterns(s string, b []byte, pattern string) {
+	_ = regexp.MustCompile("a.c").MatchString(s)
+	_ = regexp.MustCompile("a*").MatchString(s)
+	_ = regexp.MustCompile("a+").MatchString(s)
+	_ = regexp.MustCompile("a?").MatchString(s)
+	_ = regexp.MustCompile("[abc]").MatchString(s)
+	_ = regexp.MustCompile("(abc)").MatchString(s)
+	_ = regexp.MustCompile(`a\d`).MatchString(s)
+	_ = regexp.MustCompile("^abc").MatchString(s)
+	_ = regexp.MustCompile("abc$").MatchString(s)
+	_ = regexp.MustCompile("a|b").MatchString(s)
+	_ = regexp.MustCompile("a{2}").MatchString(s)
+	_, _ = regexp.MatchString("a.c", s)
+}
+
+func nonConstPatterns(s string, pattern string) {
+	_ = regexp.MustCompile(pattern).MatchString(s)
+	_, _ = regexp.MatchString(pattern, s)
+}
+
+func emptyPattern(s string) {
+	_ = regexp.MustCompile("").MatchString(s)
+}
+
+func otherMethods(s string, b []byte) {
+	// Not tracking variables.
+	_ = re.MatchString(s)
+
+	// Methods without direct strings equivalent.
+	_ = regexp.MustCompile("abc").ReplaceAllString(s, "x")
+	_ = regexp.MustCompile("abc").FindAllString(s, -1)
+
+	// Return a match or its location, not a bool or an index.
+	_ = regexp.MustCompile("abc").FindString(s)
+	_ = regexp.MustCompile("abc").FindStringIndex(s)
+	_ = regexp.MustCompile("abc").FindIndex(b)
+}
+
+type fakeRegexp struct{}
+
+func (fakeRegexp) MatchString(s string) bool { return false }
+
+func MustCompile(s string) fakeRegexp { return fakeRegexp{} }
+
+func notRegexpPackage(s string) {
+	_ = MustCompile("abc").MatchString(s)
+}
@@ -0,0 +1,30 @@
+package checker_test
+
+import (
+	"regexp"
+)
+
+const plainPattern = "hello"
+
+func plainPatterns(s string, b []byte) {
+	/// regexp pattern has no metacharacters; use strings.Contains for speed
+	_ = regexp.MustCompile("abc").MatchString(s)
+
+	/// regexp pattern has no metacharacters; use strings.Contains for speed
+	_ = regexp.MustCompile(`foo bar`).MatchString(s)
+
+	/// regexp pattern has no metacharacters; use strings.Contains for speed
+	_ = regexp.MustCompile(plainPattern).MatchString(s)
+
+	/// regexp pattern has no metacharacters; use bytes.Contains for speed
+	_ = regexp.MustCompile("abc").Match(b)
+
+	/// regexp pattern has no metacharacters; use bytes.Contains for speed
+	_ = (regexp.MustCompile("abc")).Match(b)
+
+	/// regexp pattern has no metacharacters; use strings.Contains for speed
+	_, _ = regexp.MatchString("x-y_z", s)
+
+	/// regexp pattern has no metacharacters; use bytes.Contains for speed
+	_, _ = regexp.Match("abc", b)
+}
@@ -0,0 +1,92 @@
+package checker_test
+
+import (
+	"fmt"
+)
+
+func indexUsed(xs []int) {
+	for i := len(xs) - 1; i >= 0; i-- {
+		fmt.Println(i, xs[i])
+	}
+}
+
+func otherSliceIndexed(xs, ys []int) {
+	for i := len(xs) - 1; i >= 0; i-- {
+		fmt.Println(xs[i], ys[i])
+	}
+}
+
+func elemAssigned(xs []int) {
+	for i := len(xs) - 1; i >= 0; i-- {
+		xs[i] = 0
+	}
+	for i := len(xs) - 1; i >= 0; i-- {
+		xs[i]++
+	}
+}
+
+func fieldAssigned(points []point) {
+	for i := len(points) - 1; i >= 0; i-- {
+		points[i].x = 0
+	}
+}
+
+func (p *point) reset() { p.x, p.y = 0, 0 }
+
+func pointerMethod(points []point) {
+	for i := len(points) - 1; i >= 0; i-- {
+		points[i].reset()
+	}
+}
+
+func addressTaken(xs []int) []*int {
+	var ptrs []*int
+	for i := len(xs) - 1; i >= 0; i-- {
+		ptrs = append(ptrs, &xs[i])
+	}
+	return ptrs
+}
+
+func sliceModified(xs []int) {
+	for i := len(xs) - 1; i >= 0; i-- {
+		if xs[i] == 0 {
+			xs = xs[:len(xs)-1]
+		}
+	}
+}
+
+func indexModified(xs []int) {
+	for i := len(xs) - 1; i >= 0; i-- {
+		fmt.Println(xs[i])
+		i--
+	}
+}
+
+func notReverseLoops(xs []int, s string, arr [4]int) {
+	for i := len(xs) - 2; i >= 0; i-- {
+		fmt.Println(xs[i])
+	}
+	for i := len(xs) - 1; i > 0; i-- {
+		fmt.Println(xs[i])
+	}
+	for i := len(xs) - 1; i >= 0; i -= 2 {
+		fmt.Println(xs[i])
+	}
+	for i := len(s) - 1; i >= 0; i-- {
+		fmt.Println(s[i])
+	}
+	for i := len(arr) - 1; i >= 0; i-- {
+		fmt.Println(arr[i])
+	}
+	for i := len(getInts()) - 1; i >= 0; i-- {
+		fmt.Println(getInts()[i])
+	}
+}
+
+func getInts() []int { return nil }
+
+func unusedIndex(xs []int) {
+	for i := len(xs) - 1; i >= 0; i-- {
+		fmt.Println("x")
+	}
+}
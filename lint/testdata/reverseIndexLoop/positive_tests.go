@@ -0,0 +1,47 @@
+package checker_test
+
+import (
+	"fmt"
+)
+
+func printBackward(xs []string) {
+	/// reverse index loop can use slices.Backward
+	for i := len(xs) - 1; i >= 0; i-- {
+		fmt.Println(xs[i])
+	}
+}
+
+type stack struct {
+	items []int
+}
+
+func (s *stack) sum() int {
+	total := 0
+	/// reverse index loop can use slices.Backward
+	for i := len(s.items) - 1; i >= 0; i-- {
+		total += s.items[i] * s.items[i]
+	}
+	return total
+}
+
+type point struct{ x, y int }
+
+func (p point) String() string { return fmt.Sprint(p.x, p.y) }
+
+func lastPositive(points []point) (point, bool) {
+	/// reverse index loop can use slices.Backward
+	for i := len(points) - 1; i >= 0; i-- {
+		if points[i].x > 0 {
+			return points[i], true
+		}
+		fmt.Println(points[i].String())
+	}
+	return point{}, false
+}
+
+func shadowedName(xs []int, v int) {
+	/// reverse index loop can use slices.Backward
+	for i := len(xs) - 1; i >= 0; i-- {
+		fmt.Println(xs[i] + v)
+	}
+}
@@ -29,3 +29,22 @@ func caseWithTwoValues(x int) {
 	case 1, 2:
 	}
 }
+
+func caseWithBreak(xs []int, vs []interface{}) {
+	for _, x := range xs {
+		switch x {
+		case 1:
+			if x > 0 {
+				break
+			}
+			println(x)
+		}
+	}
+
+	for _, v := range vs {
+		switch v.(type) {
+		default:
+			break
+		}
+	}
+}
@@ -1,10 +1,11 @@
 package checker_test
 
-// TODO: add tests for "no warnings" cases.
-// TODO: add tests for expression switch statement.
+import (
+	"fmt"
+)
 
 func intValue(x interface{}) int {
-	/// should rewrite switch statement to if statement
+	/// should rewrite switch statement to `if x, ok := x.(int); ok` statement
 	switch x := x.(type) {
 	case int:
 		return x
@@ -25,3 +26,37 @@ func switchWithOneCase(x int) {
 	case 1:
 	}
 }
+
+func switchTrueCond(x int) {
+	/// should rewrite switch statement to if statement
+	switch {
+	case x > 0:
+		println(x)
+	}
+}
+
+func nestedBreak(xs []int) {
+	for _, x := range xs {
+		/// should rewrite switch statement to if statement
+		switch x {
+		case 1:
+			for {
+				break
+			}
+		}
+	}
+}
+
+func typeSwitchNoVar(x interface{}) {
+	/// should rewrite switch statement to `if _, ok := x.(fmt.Stringer); ok` statement
+	switch x.(type) {
+	case fmt.Stringer:
+		println("stringer")
+	}
+
+	/// should rewrite switch statement to if statement
+	switch x.(type) {
+	case nil:
+		println("nil")
+	}
+}
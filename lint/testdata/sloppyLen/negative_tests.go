@@ -0,0 +1,29 @@
+package checker_test
+
+func goodLenChecks(xs []int, s string) {
+	_ = len(xs) == 0
+	_ = len(xs) != 0
+	_ = len(xs) > 0
+	_ = 0 < len(s)
+	_ = len(xs) >= 1
+	_ = len(xs) <= 1
+	_ = len(xs) >= len(s)
+}
+
+func notLenCalls(n int, arr [4]int, p *[4]int) {
+	_ = n >= 0
+	_ = n < 0
+	_ = n <= 0
+	_ = cap(arr) >= 0
+
+	// Arrays lengths are constants.
+	_ = len(arr) >= 0
+	_ = len(p) < 0
+}
+
+func shadowedLen(xs []int) {
+	len := func(xs []int) int { return -1 }
+	_ = len(xs) >= 0
+	_ = len(xs) < 0
+	_ = len(xs) <= 0
+}
@@ -0,0 +1,32 @@
+package checker_test
+
+type namedSlice []int
+
+func alwaysTrue(xs []int, m map[string]int, s string, ch chan int, ns namedSlice) {
+	/// `len(xs) >= 0` is always true
+	_ = len(xs) >= 0
+	/// `0 <= len(m)` is always true
+	_ = 0 <= len(m)
+	/// `len(s) >= 0` is always true
+	_ = len(s) >= 0
+	/// `len(ns) >= 0` is always true
+	_ = len(ns) >= 0
+	/// `len(ch) >= 0.0` is always true
+	_ = len(ch) >= 0.0
+}
+
+func alwaysFalse(xs []int, s string) {
+	/// `len(xs) < 0` is always false
+	_ = len(xs) < 0
+	/// `0 > len(s)` is always false
+	if 0 > len(s) {
+	}
+}
+
+func lessOrEqual(xs []int, s string) {
+	/// `len(xs) <= 0` can be `len(xs) == 0`
+	if len(xs) <= 0 {
+	}
+	/// `0 >= len(s)` can be `len(s) == 0`
+	_ = 0 >= len(s)
+}
@@ -0,0 +1,11 @@
+package checker_test
+
+import (
+	"fmt"
+	"strconv"
+)
+
+func withStrconv(n int, b bool) string {
+	/// fmt.Sprintf("%d", n) can be strconv.Itoa(n) for better performance
+	return fmt.Sprintf("%d", n) + strconv.FormatBool(b)
+}
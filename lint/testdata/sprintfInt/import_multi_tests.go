@@ -0,0 +1,11 @@
+package checker_test
+
+import "fmt"
+
+func severalFmtUses(x, y int) string {
+	/// fmt.Sprintf("%d", x) can be strconv.Itoa(x) for better performance
+	a := fmt.Sprintf("%d", x)
+	/// fmt.Sprintf("%d", y) can be strconv.Itoa(y) for better performance
+	b := fmt.Sprintf("%d", y)
+	return a + b
+}
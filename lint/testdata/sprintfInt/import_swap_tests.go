@@ -0,0 +1,8 @@
+package checker_test
+
+import "fmt"
+
+func onlyFmtUse(xs []int) string {
+	/// fmt.Sprintf("%d", len(xs)) can be strconv.Itoa(len(xs)) for better performance
+	return fmt.Sprintf("%d", len(xs))
+}
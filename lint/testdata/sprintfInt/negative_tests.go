@@ -0,0 +1,24 @@
+package checker_test
+
+import (
+	"fmt"
+)
+
+type myInt int
+
+func otherTypes(i8 int8, i64 int64, u uint, m myInt) {
+	_ = fmt.Sprintf("%d", i8)
+	_ = fmt.Sprintf("%d", i64)
+	_ = fmt.Sprintf("%d", u)
+	_ = fmt.Sprintf("%d", m)
+}
+
+func otherFormats(n int, format string, args []interface{}) {
+	_ = fmt.Sprintf("%v", n)
+	_ = fmt.Sprintf("%5d", n)
+	_ = fmt.Sprintf("n=%d", n)
+	_ = fmt.Sprintf("%d%d", n, n)
+	_ = fmt.Sprintf(format, n)
+	_ = fmt.Sprintf("%d", args...)
+	_ = fmt.Sprint(n)
+}
@@ -0,0 +1,25 @@
+package checker_test
+
+import (
+	"fmt"
+)
+
+func itoa(n int) string {
+	/// fmt.Sprintf("%d", n) can be strconv.Itoa(n) for better performance
+	return fmt.Sprintf("%d", n)
+}
+
+type counter struct{ n int }
+
+func (c *counter) String() string {
+	/// fmt.Sprintf("%d", c.n + 1) can be strconv.Itoa(c.n + 1) for better performance
+	s := fmt.Sprintf(`%d`, c.n+1)
+	fmt.Println(s)
+	/// fmt.Sprintf("%d", len(s)) can be strconv.Itoa(len(s)) for better performance
+	return fmt.Sprintf("%d", len(s))
+}
+
+func untypedConst() string {
+	/// fmt.Sprintf("%d", 10) can be strconv.Itoa(10) for better performance
+	return fmt.Sprintf("%d", 10)
+}
@@ -0,0 +1,8 @@
+package checker_test
+
+import "fmt"
+
+func onlyFmtUse(s string) string {
+	/// s is already a string; fmt.Sprintf call is redundant
+	return fmt.Sprintf("%s", s)
+}
@@ -0,0 +1,32 @@
+package checker_test
+
+import (
+	"fmt"
+)
+
+type stringer string
+
+func (s stringer) String() string { return "<" + string(s) + ">" }
+
+func goodFormats(s string, st stringer, b []byte, format string) {
+	fmt.Printf("name %q\n", s)
+	fmt.Printf("name '%s'\n", s)
+	_ = fmt.Sprintf("\"%10s\"", s)
+	_ = fmt.Sprintf("\"%s", s)
+	_ = fmt.Sprintf("%s\"", s)
+	_ = fmt.Sprintf("\"%[1]s\"", s)
+	_ = fmt.Sprintf("\"%d\"", 10)
+	_ = fmt.Sprintf("100%%\"%d\"", 10)
+	_ = fmt.Sprintf(format, s)
+	_ = fmt.Sprint("\"%s\"", s)
+
+	// Not a string or has String method.
+	_ = fmt.Sprintf("%s", b)
+	_ = fmt.Sprintf("%s", st)
+	_ = fmt.Sprintf("%s", 10)
+
+	// Not a plain %s or not fmt.Sprintf.
+	_ = fmt.Sprintf("%s!", s)
+	_ = fmt.Sprintf("%5s", s)
+	fmt.Printf("%s", s)
+}
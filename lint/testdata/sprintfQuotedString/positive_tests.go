@@ -0,0 +1,44 @@
+package checker_test
+
+import (
+	"fmt"
+	"log"
+	"os"
+)
+
+type name string
+
+func quoted(s string, n name, l *log.Logger) {
+	/// use %q instead of "%s" for quoted strings
+	fmt.Printf("unknown name \"%s\"\n", s)
+
+	/// use %q instead of "%s" for quoted strings
+	_ = fmt.Sprintf(`"%s" is not "%s"`, s, s)
+
+	/// use %q instead of "%s" for quoted strings
+	_ = fmt.Errorf("bad value \"%s\" at %d", s, 10)
+
+	/// use %q instead of "%s" for quoted strings
+	fmt.Fprintf(os.Stderr, "\"%s\"", s)
+
+	/// use %q instead of "%s" for quoted strings
+	log.Printf("file \"%s\" not found", s)
+
+	/// use %q instead of "%s" for quoted strings
+	l.Printf("file \"%s\" not found", s)
+
+	const format = "key \"%s\""
+	/// use %q instead of "%s" for quoted strings
+	_ = fmt.Sprintf(format, s)
+}
+
+func redundant(s string, n name) {
+	/// s is already a string; fmt.Sprintf call is redundant
+	_ = fmt.Sprintf("%s", s)
+
+	/// n is already a string; fmt.Sprintf call is redundant
+	s = fmt.Sprintf("%s", n)
+
+	/// s + "x" is already a string; fmt.Sprintf call is redundant
+	_ = fmt.Sprintf("%s", s+"x") + "y"
+}
@@ -0,0 +1,40 @@
+package checker_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+type myString string
+
+type myBytes []byte
+
+type rawWriter struct{}
+
+func (rawWriter) Write(s string) {}
+
+func goodConversions(s string, b []byte, ms myString, mb myBytes, r []rune) {
+	_ = []byte(s)
+	_ = string(b)
+	_ = string(myBytes(b))
+	_ = myString([]byte(s))
+	_ = string([]byte(ms))
+	_ = []byte(string(mb))
+	_ = string([]rune(s))
+	_ = []byte(string(r))
+}
+
+func goodCopy(dst []byte, src []byte, s string, ms myString) {
+	copy(dst, src)
+	copy(dst, s)
+	copy(dst, []byte(ms))
+	copy([]rune(s), []rune(s))
+}
+
+func goodWrite(w io.Writer, buf *bytes.Buffer, rw rawWriter, s string, n int) {
+	w.Write([]byte(s))
+	buf.Write([]byte(fmt.Errorf("n=%d", n).Error()))
+	rw.Write(fmt.Sprintf("%d", n))
+	fmt.Fprintf(w, "%d", n)
+}
@@ -0,0 +1,47 @@
+package checker_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+)
+
+func roundTrips(s string, b []byte, ps *string) {
+	/// redundant conversions; string([]byte(s)) can be simplified to s
+	_ = string([]byte(s))
+	/// redundant conversions; string(([]byte(s + "x"))) can be simplified to s + "x"
+	_ = string(([]byte(s + "x")))
+	/// redundant conversions; string([]byte(*ps)) can be simplified to *ps
+	_ = string([]byte(*ps))[0]
+	/// []byte(string(b)) copies b twice; use bytes.Clone(b) or b itself
+	_ = []byte(string(b))
+}
+
+func copyString(dst []byte, s string) {
+	/// can simplify `[]byte(s)` to `s`; copy accepts string source
+	copy(dst, []byte(s))
+	/// can simplify `[]byte("abc")` to `"abc"`; copy accepts string source
+	_ = copy(dst[1:], []byte("abc"))
+}
+
+func writeSprintf(w io.Writer, buf *bytes.Buffer, n int, args []interface{}) {
+	/// w.Write([]byte(fmt.Sprintf("%d items", n))) can be fmt.Fprintf(w, "%d items", n)
+	w.Write([]byte(fmt.Sprintf("%d items", n)))
+	/// buf.Write([]byte(fmt.Sprint(n))) can be fmt.Fprint(buf, n)
+	buf.Write([]byte(fmt.Sprint(n)))
+	/// os.Stdout.Write([]byte(fmt.Sprintln(args...))) can be fmt.Fprintln(os.Stdout, args...)
+	_, _ = os.Stdout.Write([]byte(fmt.Sprintln(args...)))
+
+	var local bytes.Buffer
+	/// local.Write([]byte(fmt.Sprintf("%d", n))) can be fmt.Fprintf(&local, "%d", n)
+	local.Write([]byte(fmt.Sprintf("%d", n)))
+
+	var vw valueWriter
+	/// vw.Write([]byte(fmt.Sprint(n))) can be fmt.Fprint(vw, n)
+	vw.Write([]byte(fmt.Sprint(n)))
+}
+
+type valueWriter struct{}
+
+func (valueWriter) Write(p []byte) (int, error) { return len(p), nil }
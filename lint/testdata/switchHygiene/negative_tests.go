@@ -0,0 +1,36 @@
+package checker_test
+
+func goodSwitches(x int, xs []int) {
+	switch x {
+	case 1, 2:
+		println("1 or 2")
+	case 3:
+		println("3")
+		fallthrough
+	case 4:
+		println("3 or 4")
+	}
+
+	// Merging with default case would need a different rewrite.
+	switch x {
+	case 1:
+		fallthrough
+	default:
+		println(x)
+	}
+
+	switch x {
+	case 1:
+		println(x)
+		break
+	case 2:
+	}
+
+outer:
+	for range xs {
+		switch x {
+		case 1:
+			break outer
+		}
+	}
+}
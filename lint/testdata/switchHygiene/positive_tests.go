@@ -0,0 +1,41 @@
+package checker_test
+
+func emptyFallthrough(x int) {
+	switch x {
+	/// replace empty case containing only fallthrough with expression list
+	case 1:
+		fallthrough
+	case 2:
+		println("1 or 2")
+	}
+}
+
+func fallthroughIntoEmpty(x int) {
+	switch x {
+	case 1:
+		println("1")
+		/// fallthrough into an empty case is redundant
+		fallthrough
+	case 2:
+	case 3:
+		println("3")
+	}
+}
+
+func breakOnly(x int, v interface{}) {
+	switch x {
+	case 1:
+		/// case body contains only a redundant break
+		break
+	default:
+		println(x)
+	}
+
+	switch v.(type) {
+	case int:
+		/// case body contains only a redundant break
+		break
+	case string:
+		println(v)
+	}
+}
@@ -0,0 +1,22 @@
+package checker_test
+
+func notTruncated(x32 int32, x64 int64, i16 int16, u32 uint32, i int, s []int) {
+	// Widening conversions.
+	_ = int64(x32) < x64
+	_ = int32(i16) == x32
+
+	// Same width, only signedness is changed.
+	_ = int32(u32) < x32
+
+	// Constants.
+	_ = uint8(u32) == 0xff
+	_ = int16(x32) < 10
+	_ = x32 == int32(100)
+
+	// Not a comparison.
+	_ = int16(x32) + i16
+
+	// Arch-dependent sizes, int can be 32 bits wide.
+	_ = int32(i) < x32
+	_ = int(x64) < len(s)
+}
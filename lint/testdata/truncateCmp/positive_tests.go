@@ -0,0 +1,24 @@
+package checker_test
+
+type myInt64 int64
+
+func truncated(x32 int32, x64 int64, u32 uint32, i16 int16, u8 uint8, i32 int32, m myInt64, s []int, n uint16) {
+	/// truncation in comparison 32->16 bit; cast the other operand to int32 instead
+	_ = int16(x32) < i16
+
+	/// truncation in comparison 64->32 bit; cast the other operand to int64 instead
+	_ = i32 == int32(x64)
+
+	/// truncation in comparison 32->8 bit; cast the other operand to uint32 instead
+	_ = uint8(u32) >= u8
+
+	/// truncation in comparison 64->16 bit; cast the other operand to myInt64 instead
+	_ = int16(m) != i16
+
+	/// truncation in comparison 32->16 bit; cast the other operand to int instead
+	_ = uint16(len(s)) == n
+
+	/// truncation in comparison 64->32 bit; cast the other operand to int64 instead
+	if int32(x64) > i32 {
+	}
+}
@@ -102,3 +102,29 @@ func f5(x, y, z interface{}) int {
 	}
 	return 0
 }
+
+type byteAlias = byte
+
+func f6(v interface{}) int {
+	/// case 0 can benefit from type switch with assignment
+	/// case 1 can benefit from type switch with assignment
+	/// case 3 can benefit from type switch with assignment
+	switch v.(type) {
+	case byteAlias:
+		return int(v.(uint8))
+	case int32:
+		return int(v.(rune))
+	case []byte:
+		{
+			v := interface{}(1)
+			_ = v.([]byte)
+		}
+		return 0
+	case string:
+		if len(v.(string)) == 0 {
+			return 0
+		}
+		return 1
+	}
+	return 0
+}
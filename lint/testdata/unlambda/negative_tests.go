@@ -0,0 +1,45 @@
+package checker_test
+
+import (
+	"strings"
+)
+
+type text string
+
+func toText(s string) text { return text(s) }
+
+func notForwarding(xs []string, s *sorter, other []*sorter) {
+	// Different arguments order.
+	_ = func(prefix, s string) string { return strings.TrimPrefix(s, prefix) }
+	_ = func(s, prefix string) bool { return strings.HasPrefix(prefix, s) }
+
+	// Extra arguments or computations.
+	_ = func(s string) []string { return strings.Split(s, ",") }
+	_ = func(s string) bool { return !isEmpty(s) }
+	_ = func(s string) bool {
+		println(s)
+		return isEmpty(s)
+	}
+
+	// Different signatures.
+	_ = func(s string) interface{} { return toText(s) }
+	_ = func(parts []string) string { return join(",", parts...) }
+	_ = func(sep string, parts ...string) string { return join(sep, parts[1:]...) }
+
+	// Not a function: conversions and builtins.
+	_ = func(s string) text { return text(s) }
+	_ = func(s string) int { return len(s) }
+
+	// Receiver with side effects.
+	_ = func() { other[0].run() }
+	_ = func() { newSorter().run() }
+
+	// Function variable may change.
+	f := isEmpty
+	_ = func(s string) bool { return f(s) }
+
+	// Results are not forwarded.
+	_ = func(s string) { isEmpty(s) }
+}
+
+func newSorter() *sorter { return &sorter{} }
@@ -0,0 +1,38 @@
+package checker_test
+
+import (
+	"sort"
+	"strings"
+)
+
+type sorter struct{ xs []int }
+
+func (s *sorter) less(i, j int) bool { return s.xs[i] < s.xs[j] }
+
+func (s *sorter) run() {}
+
+func isEmpty(s string) bool { return s == "" }
+
+func join(sep string, parts ...string) string { return strings.Join(parts, sep) }
+
+func filter(xs []string, pred func(string) bool) {}
+
+func funcs(xs []string, s *sorter) {
+	/// replace `func(x string) bool { return isEmpty(x) }` with `isEmpty`
+	filter(xs, func(x string) bool { return isEmpty(x) })
+
+	/// replace `func(s string) string { return strings.ToUpper(s) }` with `strings.ToUpper`
+	_ = func(s string) string { return strings.ToUpper(s) }
+
+	/// replace `func(sep string, parts ...string) string { return join(sep, parts...) }` with `join`
+	_ = func(sep string, parts ...string) string { return join(sep, parts...) }
+
+	/// replace `func(s *sorter) { (*sorter).run(s) }` with `(*sorter).run`
+	_ = func(s *sorter) { (*sorter).run(s) }
+
+	/// replace `func(i, j int) bool { return s.less(i, j) }` with `s.less`
+	sort.Slice(s.xs, func(i, j int) bool { return s.less(i, j) })
+
+	/// replace `func() { s.run() }` with `s.run`
+	_ = func() { s.run() }
+}
@@ -40,3 +40,15 @@ func slicing() {
 		_ = xs["0"][0][:10]
 	}
 }
+
+type namedArray [4]int
+
+var globalArray [4]int
+
+var globalArrayView = globalArray[:]
+
+func sliceArrays(p *[4]int, arr namedArray) {
+	_ = p[:]
+	_ = arr[:]
+	copy(p[:], arr[:])
+}
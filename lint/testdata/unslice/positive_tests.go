@@ -41,3 +41,19 @@ func dullSlicing() {
 		_ = xs["0"][0][:]
 	}
 }
+
+type namedBytes []byte
+
+type namedString string
+
+/// could simplify globalBytes[:] to globalBytes
+var globalView = globalBytes[:]
+
+var globalBytes []byte
+
+func namedTypes(b namedBytes, s namedString) {
+	/// could simplify b[:] to b
+	_ = b[:]
+	/// could simplify s[:] to s
+	_ = s[:]
+}
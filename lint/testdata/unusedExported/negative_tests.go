@@ -0,0 +1,31 @@
+package checker_test
+
+// Identifiers below are used by the external test package.
+
+func Min(x, y int) int {
+	if x < y {
+		return x
+	}
+	return y
+}
+
+type Size struct {
+	Width, Height int
+}
+
+const Unit = 1
+
+var Zero Size
+
+// Methods are not reported.
+func (s Size) Area() int { return s.Width * s.Height }
+
+// Unexported identifiers are not reported.
+func min3(x, y, z int) int { return Min(Min(x, y), z) }
+
+type sizes []Size
+
+var (
+	_ = min3
+	_ sizes
+)
@@ -0,0 +1,34 @@
+package checker_test
+
+/// Max is not used outside of checker_test package; consider unexporting it
+func Max(x, y int) int {
+	if x > y {
+		return x
+	}
+	return y
+}
+
+/// Point is not used outside of checker_test package; consider unexporting it
+type Point struct {
+	X, Y int
+}
+
+const (
+	/// DefaultLimit is not used outside of checker_test package; consider unexporting it
+	DefaultLimit = 10
+
+	/// MaxLimit is not used outside of checker_test package; consider unexporting it
+	MaxLimit = 100
+)
+
+/// ErrLimit is not used outside of checker_test package; consider unexporting it
+var ErrLimit = limitError("limit exceeded")
+
+type limitError string
+
+func (e limitError) Error() string { return string(e) }
+
+func usedInside() int {
+	p := Point{X: DefaultLimit, Y: MaxLimit}
+	return Max(p.X, p.Y)
+}
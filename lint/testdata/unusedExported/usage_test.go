@@ -0,0 +1,10 @@
+package checker_test_test
+
+import (
+	checker "github.com/go-critic/go-critic/lint/testdata/unusedExported"
+)
+
+func ExportedInTests() checker.Size {
+	_ = checker.Min(checker.Unit, 2)
+	return checker.Zero
+}
@@ -0,0 +1,26 @@
+package checker_test
+
+func goodIndexChecks(xs []int, m map[string]int, arr *[4]int, s string) {
+	_ = len(xs) != 0 && xs[0] != 0
+	_ = xs != nil && len(xs) > 0 && xs[0] != 0
+	_ = xs != nil || xs[0] != 0
+	_ = xs == nil && len(xs) > 0
+
+	// Nil check is enough for maps and array pointers.
+	_ = m != nil && m["k"] != 0
+	_ = arr != nil && arr[0] != 0
+
+	ys := xs
+	_ = xs != nil && ys[0] != 0
+}
+
+func goodEmptyChecks(xs []int) {
+	_ = xs == nil || len(xs) == 0
+	_ = xs != nil && len(xs) > 0
+	_ = xs == nil || len(xs) > 1
+}
+
+func unsafeOperands(f func() []int) {
+	_ = f() != nil && f()[0] != 0
+	_ = f() == nil || len(f()) != 0
+}
@@ -0,0 +1,27 @@
+package checker_test
+
+type node struct {
+	children []*node
+	names    []string
+}
+
+func nilCheckedIndex(xs []int, n *node, ok bool) {
+	/// suspicious `xs[0]`; nil check may not be enough, check for len
+	_ = xs != nil && xs[0] != 0
+
+	/// suspicious `n.children[0]`; nil check may not be enough, check for len
+	if n.children != nil && n.children[0] != nil {
+	}
+
+	/// suspicious `xs[len(xs)-1]`; nil check may not be enough, check for len
+	_ = ok && nil != xs && (xs[len(xs)-1] > 0 || ok)
+}
+
+func nilOrNonEmpty(xs []int, n *node) {
+	/// suspicious `xs == nil || len(xs) > 0`; it's true for nil and non-empty xs
+	_ = xs == nil || len(xs) > 0
+
+	/// suspicious `n.names == nil || 0 != len(n.names)`; it's true for nil and non-empty n.names
+	if n.names == nil || 0 != len(n.names) {
+	}
+}
@@ -0,0 +1,118 @@
+package lint
+
+//! Detects potential truncation issues when comparing ints of different sizes.
+//
+// Reports comparisons where one operand is converted to a narrower
+// integer type to match the other operand type.
+// Comparison result may change after the truncation,
+// so it's better to convert the other operand to the wider type.
+//
+// Checker params:
+//	skipArchDependent - if "true", int, uint and uintptr sizes are assumed to be
+//	  the least favorable for the truncation (32 bits for the source type
+//	  and 64 bits for the result type), so reports don't depend on the target arch
+//
+// @Before:
+// func f(x int32, y int16) bool {
+// 	return int16(x) < y
+// }
+//
+// @After:
+// func f(x int32, y int16) bool {
+// 	return x < int32(y)
+// }
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+func init() {
+	addChecker(&truncateCmpChecker{}, attrExperimental)
+}
+
+type truncateCmpChecker struct {
+	checkerBase
+
+	skipArchDependent bool
+}
+
+func (c *truncateCmpChecker) Params() []CheckerParam {
+	return []CheckerParam{
+		{Name: "skipArchDependent", Kind: ParamBool, Default: "true"},
+	}
+}
+
+func (c *truncateCmpChecker) Init() {
+	c.skipArchDependent = c.ctx.BoolParam("skipArchDependent")
+}
+
+func (c *truncateCmpChecker) VisitLocalExpr(expr ast.Expr) {
+	cmp, ok := expr.(*ast.BinaryExpr)
+	if !ok {
+		return
+	}
+	switch cmp.Op {
+	case token.LSS, token.GTR, token.LEQ, token.GEQ, token.EQL, token.NEQ:
+		c.checkCmp(cmp.X, cmp.Y)
+		c.checkCmp(cmp.Y, cmp.X)
+	}
+}
+
+func (c *truncateCmpChecker) checkCmp(conv, other ast.Expr) {
+	call, ok := conv.(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 {
+		return
+	}
+	if tv := c.ctx.typesInfo.Types[call.Fun]; !tv.IsType() {
+		return
+	}
+	if c.ctx.isConst(other) {
+		// Comparisons against constants are usually intentional,
+		// like uint8(x) == 0xff that checks the lowest byte.
+		return
+	}
+
+	dst, ok := c.ctx.typesInfo.TypeOf(call).Underlying().(*types.Basic)
+	if !ok || dst.Info()&types.IsInteger == 0 {
+		return
+	}
+	x := call.Args[0]
+	if c.ctx.isConst(x) {
+		return
+	}
+	src, ok := c.ctx.typesInfo.TypeOf(x).Underlying().(*types.Basic)
+	if !ok || src.Info()&types.IsInteger == 0 {
+		return
+	}
+
+	srcSize := c.typeSize(src, 4)
+	dstSize := c.typeSize(dst, 8)
+	if srcSize > dstSize {
+		c.warn(call, srcSize*8, dstSize*8, c.ctx.typesInfo.TypeOf(x))
+	}
+}
+
+// typeSize returns typ size in bytes.
+// If skipArchDependent is set, archSize is used for int, uint and uintptr.
+func (c *truncateCmpChecker) typeSize(typ *types.Basic, archSize int64) int64 {
+	switch typ.Kind() {
+	case types.Int, types.Uint, types.Uintptr:
+		if c.skipArchDependent {
+			return archSize
+		}
+	}
+	return c.ctx.sizesInfo.Sizeof(typ)
+}
+
+func (c *truncateCmpChecker) warn(cause *ast.CallExpr, srcBits, dstBits int64, srcType types.Type) {
+	typeName := types.TypeString(srcType, func(p *types.Package) string {
+		if p == nil || p == c.ctx.pkg {
+			return ""
+		}
+		return p.Name()
+	})
+	c.ctx.Warn(cause, "truncation in comparison %d->%d bit; cast the other operand to %s instead",
+		srcBits, dstBits, typeName)
+}
@@ -2,6 +2,9 @@ package lint
 
 //! Detects type switches that can benefit from type guard clause with variable.
 //
+// Case types and asserted types are compared by identity,
+// so assertions to type aliases are reported as well.
+//
 // @Before:
 // switch v.(type) {
 // case int:
@@ -24,6 +27,7 @@ package lint
 
 import (
 	"go/ast"
+	"go/types"
 
 	"github.com/go-toolsmith/astequal"
 	"github.com/go-toolsmith/astp"
@@ -62,20 +66,36 @@ func (c *typeSwitchVarChecker) checkTypeSwitch(root *ast.TypeSwitchStmt) {
 		if len(clause.List) != 1 {
 			continue
 		}
-		// Create artificial node just for matching.
-		assert1 := ast.TypeAssertExpr{X: expr, Type: clause.List[0]}
-		for _, stmt := range clause.Body {
-			assert2 := findNode(stmt, func(x ast.Node) bool {
-				return astequal.Node(&assert1, x)
-			})
-			if object == c.ctx.typesInfo.ObjectOf(identOf(assert2)) {
-				c.warn(root, i)
-				break
-			}
+		typ := c.ctx.typesInfo.TypeOf(clause.List[0])
+		if typ == nil {
+			continue
+		}
+		if c.hasAssert(clause.Body, expr, object, typ) {
+			c.warn(root, i)
 		}
 	}
 }
 
+// hasAssert reports whether body contains x.(T) type assertion
+// where x refers to the object and T is identical to typ.
+// Types are compared by identity, so aliases are matched too.
+func (c *typeSwitchVarChecker) hasAssert(body []ast.Stmt, x ast.Expr, object types.Object, typ types.Type) bool {
+	found := false
+	for _, stmt := range body {
+		ast.Inspect(stmt, func(n ast.Node) bool {
+			assert, ok := n.(*ast.TypeAssertExpr)
+			if !ok || assert.Type == nil || found {
+				return !found
+			}
+			found = astequal.Expr(assert.X, x) &&
+				object == c.ctx.typesInfo.ObjectOf(identOf(assert.X)) &&
+				types.Identical(typ, c.ctx.typesInfo.TypeOf(assert.Type))
+			return !found
+		})
+	}
+	return found
+}
+
 func (c *typeSwitchVarChecker) warn(node ast.Node, caseIndex int) {
 	c.ctx.Warn(node, "case %d can benefit from type switch with assignment", caseIndex)
 }
@@ -82,5 +82,6 @@ func (c *typeUnparenChecker) unparenExpr(x ast.Expr) ast.Expr {
 
 func (c *typeUnparenChecker) warn(cause, noParens ast.Expr) {
 	c.cause = cause
-	c.ctx.Warn(cause, "could simplify %s to %s", c.cause, noParens)
+	fix := []TextEdit{c.ctx.replaceNode(cause, noParens)}
+	c.ctx.WarnWithFix(fix, cause, "could simplify %s to %s", c.cause, noParens)
 }
@@ -18,7 +18,7 @@ import (
 )
 
 func init() {
-	addChecker(&unexportedCallChecker{}, attrVeryOpinionated, attrExperimental)
+	addChecker(&unexportedCallChecker{}, attrVeryOpinionated, attrExperimental, attrSkipTests)
 }
 
 type unexportedCallChecker struct {
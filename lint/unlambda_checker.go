@@ -0,0 +1,129 @@
+package lint
+
+//! Detects function literals that can be simplified.
+//
+// Reports literals that only forward their params to another
+// function with identical signature, so the function itself can be used.
+// Method values are reported with medium confidence, since
+// the receiver is evaluated once instead of on every call.
+//
+// @Before:
+// f := func(x int) int { return fn(x) }
+//
+// @After:
+// f := fn
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+func init() {
+	addChecker(&unlambdaChecker{}, attrExperimental)
+}
+
+type unlambdaChecker struct {
+	checkerBase
+}
+
+func (c *unlambdaChecker) VisitExpr(expr ast.Expr) {
+	lit, ok := expr.(*ast.FuncLit)
+	if !ok || len(lit.Body.List) != 1 {
+		return
+	}
+	call := c.forwardedCall(lit)
+	if call == nil {
+		return
+	}
+
+	litType, ok := c.ctx.typesInfo.TypeOf(lit).(*types.Signature)
+	if !ok {
+		return
+	}
+	fnType, ok := c.ctx.typesInfo.TypeOf(call.Fun).(*types.Signature)
+	if !ok || !types.Identical(litType, fnType) || call.Ellipsis.IsValid() != litType.Variadic() {
+		return
+	}
+	if !c.forwardsParams(lit, call) {
+		return
+	}
+
+	switch fn := call.Fun.(type) {
+	case *ast.Ident:
+		if _, ok := c.ctx.typesInfo.ObjectOf(fn).(*types.Func); ok {
+			c.warn(lit, fn, ConfidenceHigh)
+		}
+	case *ast.SelectorExpr:
+		sel := c.ctx.typesInfo.Selections[fn]
+		switch {
+		case sel == nil:
+			// Qualified identifier, like strings.ToUpper.
+			c.warn(lit, fn, ConfidenceHigh)
+		case sel.Kind() == types.MethodExpr:
+			c.warn(lit, fn, ConfidenceHigh)
+		case sel.Kind() == types.MethodVal && c.isSimpleRecv(fn.X):
+			c.warn(lit, fn, ConfidenceMedium)
+		}
+	}
+}
+
+// forwardedCall returns the call that is the only action performed by lit.
+func (c *unlambdaChecker) forwardedCall(lit *ast.FuncLit) *ast.CallExpr {
+	var x ast.Expr
+	switch stmt := lit.Body.List[0].(type) {
+	case *ast.ReturnStmt:
+		if len(stmt.Results) != 1 {
+			return nil
+		}
+		x = stmt.Results[0]
+	case *ast.ExprStmt:
+		if lit.Type.Results != nil && len(lit.Type.Results.List) != 0 {
+			return nil
+		}
+		x = stmt.X
+	default:
+		return nil
+	}
+	call, _ := x.(*ast.CallExpr)
+	return call
+}
+
+// forwardsParams reports whether call arguments are lit
+// params passed in the same order.
+func (c *unlambdaChecker) forwardsParams(lit *ast.FuncLit, call *ast.CallExpr) bool {
+	var params []*ast.Ident
+	for _, field := range lit.Type.Params.List {
+		if len(field.Names) == 0 {
+			return len(call.Args) == 0 && lit.Type.Params.NumFields() == 0
+		}
+		params = append(params, field.Names...)
+	}
+	if len(params) != len(call.Args) {
+		return false
+	}
+	for i, arg := range call.Args {
+		id, ok := arg.(*ast.Ident)
+		if !ok || params[i].Name == "_" || c.ctx.typesInfo.ObjectOf(id) != c.ctx.typesInfo.ObjectOf(params[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// isSimpleRecv reports whether method value receiver is a plain
+// variable or package-level value, so its evaluation has no side effects.
+func (c *unlambdaChecker) isSimpleRecv(x ast.Expr) bool {
+	switch x := x.(type) {
+	case *ast.Ident:
+		_, ok := c.ctx.typesInfo.ObjectOf(x).(*types.Var)
+		return ok
+	case *ast.SelectorExpr:
+		return c.ctx.typesInfo.Selections[x] == nil && c.isSimpleRecv(x.Sel)
+	default:
+		return false
+	}
+}
+
+func (c *unlambdaChecker) warn(cause *ast.FuncLit, suggestion ast.Expr, conf Confidence) {
+	c.ctx.WarnWithConfidence(conf, cause, "replace `%s` with `%s`", cause, suggestion)
+}
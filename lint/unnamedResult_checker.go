@@ -15,7 +15,7 @@ import (
 )
 
 func init() {
-	addChecker(&unnamedResultChecker{}, attrExperimental)
+	addChecker(&unnamedResultChecker{}, attrExperimental, attrSkipTests)
 }
 
 type unnamedResultChecker struct {
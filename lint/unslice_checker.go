@@ -2,6 +2,9 @@ package lint
 
 //! Detects slice expressions that can be simplified to sliced expression itself.
 //
+// Slicing arrays and pointers to arrays is not reported,
+// since it converts them to slices.
+//
 // @Before:
 // f(s[:])               // s is string
 // copy(b[:], values...) // b is []byte
@@ -23,21 +26,31 @@ type unsliceChecker struct {
 	checkerBase
 }
 
-func (c *unsliceChecker) VisitLocalExpr(expr ast.Expr) {
+func (c *unsliceChecker) VisitExpr(expr ast.Expr) {
 	if expr, ok := expr.(*ast.SliceExpr); ok {
 		// No need to worry about 3-index slicing,
 		// because it's only permitted if expr.High is not nil.
 		if expr.Low != nil || expr.High != nil {
 			return
 		}
-		switch c.ctx.typesInfo.TypeOf(expr.X).(type) {
-		case *types.Slice, *types.Basic:
-			// Basic kind catches strings, Slice cathes everything else.
+		typ := c.ctx.typesInfo.TypeOf(expr.X)
+		if typ == nil {
+			return
+		}
+		// Slicing yields a value of the same type for both
+		// slices and strings, including the named ones.
+		switch typ := typ.Underlying().(type) {
+		case *types.Slice:
 			c.warn(expr)
+		case *types.Basic:
+			if typ.Info()&types.IsString != 0 {
+				c.warn(expr)
+			}
 		}
 	}
 }
 
 func (c *unsliceChecker) warn(expr *ast.SliceExpr) {
-	c.ctx.Warn(expr, "could simplify %s to %s", expr, expr.X)
+	fix := []TextEdit{c.ctx.replaceNode(expr, expr.X)}
+	c.ctx.WarnWithFix(fix, expr, "could simplify %s to %s", expr, expr.X)
 }
@@ -0,0 +1,111 @@
+package lint
+
+//! Detects exported identifiers that are not used outside of their package.
+//
+// Package-level funcs, types, vars and consts are reported.
+// Methods and struct fields are not, as they can be required
+// by interfaces or used by reflection.
+// Only analyzed packages are searched for external uses,
+// so the checker is run only for the whole program analysis,
+// see -wholeProgram flag.
+//
+// @Before:
+// // Max is only used inside that package.
+// func Max(x, y int) int {
+// 	if x > y {
+// 		return x
+// 	}
+// 	return y
+// }
+//
+// @After:
+// func max(x, y int) int {
+// 	if x > y {
+// 		return x
+// 	}
+// 	return y
+// }
+//
+// @Note:
+// Identifiers of libraries are intended to be used by other modules,
+// so the checker is mostly useful for the applications code.
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+func init() {
+	addChecker(&unusedExportedChecker{}, attrExperimental)
+}
+
+type unusedExportedChecker struct {
+	checkerBase
+}
+
+func (c *unusedExportedChecker) VisitProgram(pkgs []*Package) {
+	// used are objects that are referenced outside of their package.
+	used := make(map[types.Object]bool)
+	for _, pkg := range pkgs {
+		for _, obj := range pkg.Info.Uses {
+			if obj.Pkg() != nil && obj.Pkg() != pkg.Pkg {
+				used[obj] = true
+			}
+		}
+	}
+
+	for _, pkg := range pkgs {
+		if pkg.Pkg.Name() == "main" {
+			continue
+		}
+		for _, f := range pkg.Files {
+			if IsTestFile(c.ctx.fileSet, f) {
+				continue
+			}
+			for _, decl := range f.Decls {
+				for _, id := range c.exportedNames(decl) {
+					obj := pkg.Info.Defs[id]
+					if obj != nil && !used[obj] {
+						c.warn(id, pkg.Pkg)
+					}
+				}
+			}
+		}
+	}
+}
+
+// exportedNames returns exported package-level identifiers defined by decl.
+func (c *unusedExportedChecker) exportedNames(decl ast.Decl) []*ast.Ident {
+	var names []*ast.Ident
+	switch decl := decl.(type) {
+	case *ast.FuncDecl:
+		if decl.Recv == nil && decl.Name.IsExported() {
+			names = append(names, decl.Name)
+		}
+	case *ast.GenDecl:
+		if decl.Tok == token.IMPORT {
+			break
+		}
+		for _, spec := range decl.Specs {
+			switch spec := spec.(type) {
+			case *ast.TypeSpec:
+				if spec.Name.IsExported() {
+					names = append(names, spec.Name)
+				}
+			case *ast.ValueSpec:
+				for _, id := range spec.Names {
+					if id.IsExported() {
+						names = append(names, id)
+					}
+				}
+			}
+		}
+	}
+	return names
+}
+
+func (c *unusedExportedChecker) warn(id *ast.Ident, pkg *types.Package) {
+	c.ctx.Warn(id, "%s is not used outside of %s package; consider unexporting it",
+		id.Name, pkg.Name())
+}
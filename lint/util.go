@@ -2,6 +2,7 @@ package lint
 
 import (
 	"go/ast"
+	"go/token"
 	"go/types"
 	"strings"
 
@@ -22,6 +23,46 @@ func (ctx *context) IsUnitTestFuncDecl(fn *ast.FuncDecl) bool {
 	return false
 }
 
+// calleeName returns called function or method full name,
+// as reported by types.Func FullName method.
+// Examples: "regexp.MustCompile", "(*regexp.Regexp).MatchString".
+//
+// For calls that are not function or method calls, returns empty string.
+// Unlike qualifiedName, resolves names by using types info.
+func (ctx *context) calleeName(call *ast.CallExpr) string {
+	var id *ast.Ident
+	switch fn := astutil.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		id = fn
+	case *ast.SelectorExpr:
+		id = fn.Sel
+	default:
+		return ""
+	}
+	fn, ok := ctx.typesInfo.ObjectOf(id).(*types.Func)
+	if !ok {
+		return ""
+	}
+	return fn.FullName()
+}
+
+// isBuiltinCall reports whether call is a call to the name builtin.
+// Calls to locally re-defined functions with the same name are not reported.
+//
+// If there is no types info, builtin is assumed not to be shadowed.
+func (ctx *context) isBuiltinCall(call *ast.CallExpr, name string) bool {
+	fn, ok := astutil.Unparen(call.Fun).(*ast.Ident)
+	if !ok || fn.Name != name {
+		return false
+	}
+	obj := ctx.typesInfo.ObjectOf(fn)
+	if obj == nil {
+		return true
+	}
+	_, ok = obj.(*types.Builtin)
+	return ok
+}
+
 // qualifiedName returns called expr fully-quallified name.
 //
 // It works for simple identifiers like f => "f" and identifiers
@@ -43,6 +84,34 @@ func qualifiedName(x ast.Expr) string {
 	}
 }
 
+// isSafeExpr reports whether expr evaluation has no side effects,
+// so its duplicates are guaranteed to produce the same value.
+func isSafeExpr(expr ast.Expr) bool {
+	// This list switch is not comprehensive and uses
+	// whitelist to be on the conservative side.
+	// Can be extended as needed.
+	//
+	// Note that it is not very strict "safe" as
+	// index expressions are permitted even though they
+	// may cause panics.
+	switch expr := expr.(type) {
+	case *ast.BinaryExpr:
+		return isSafeExpr(expr.X) && isSafeExpr(expr.Y)
+	case *ast.UnaryExpr:
+		return expr.Op != token.ARROW && isSafeExpr(expr.X)
+	case *ast.BasicLit, *ast.Ident:
+		return true
+	case *ast.IndexExpr:
+		return isSafeExpr(expr.X) && isSafeExpr(expr.Index)
+	case *ast.SelectorExpr:
+		return isSafeExpr(expr.X)
+	case *ast.ParenExpr:
+		return isSafeExpr(expr.X)
+	default:
+		return false
+	}
+}
+
 // findNode applies pred for root and all it's childs until it returns true.
 // Matched node is returned.
 // If none of the nodes matched predicate, nil is returned.
@@ -92,3 +161,53 @@ func typeIsPointer(typ types.Type) bool {
 	_, ok := typ.(*types.Pointer)
 	return ok
 }
+
+// hasTypeParam reports whether typ size depends on a type parameter.
+// types.Sizes can't compute the size of such types.
+func hasTypeParam(typ types.Type) bool {
+	if _, ok := typ.(*types.TypeParam); ok {
+		return true
+	}
+	// Types declared inside generic functions may refer to their type params.
+	switch typ := typ.Underlying().(type) {
+	case *types.Array:
+		return hasTypeParam(typ.Elem())
+	case *types.Struct:
+		for i := 0; i < typ.NumFields(); i++ {
+			if hasTypeParam(typ.Field(i).Type()) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// endsWithFallthrough reports whether list ends with fallthrough statement.
+func endsWithFallthrough(list []ast.Stmt) bool {
+	if len(list) == 0 {
+		return false
+	}
+	branch, ok := list[len(list)-1].(*ast.BranchStmt)
+	return ok && branch.Tok == token.FALLTHROUGH
+}
+
+// caseHasBreak reports whether cc contains unlabeled break
+// statement that refers to the enclosing switch statement.
+func caseHasBreak(cc *ast.CaseClause) bool {
+	found := false
+	for _, stmt := range cc.Body {
+		ast.Inspect(stmt, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.BranchStmt:
+				if n.Tok == token.BREAK && n.Label == nil {
+					found = true
+				}
+			case *ast.ForStmt, *ast.RangeStmt, *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt, *ast.FuncLit:
+				// Breaks inside these refer to the nested statements.
+				return false
+			}
+			return !found
+		})
+	}
+	return found
+}
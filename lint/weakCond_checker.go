@@ -0,0 +1,182 @@
+package lint
+
+//! Detects conditions that are unsafe due to not being exhaustive.
+//
+// Nil check doesn't protect from indexing an empty slice,
+// and `s == nil || len(s) != 0` is true for every slice except empty non-nil ones.
+//
+// @Before:
+// xs != nil && xs[0] != nil
+//
+// @After:
+// len(xs) != 0 && xs[0] != nil
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/go-toolsmith/astequal"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+func init() {
+	addChecker(&weakCondChecker{}, attrExperimental)
+}
+
+type weakCondChecker struct {
+	checkerBase
+
+	// chainParts is a set of nested && and || chain nodes
+	// that were already checked as a part of the enclosing chain.
+	chainParts map[*ast.BinaryExpr]bool
+}
+
+func (c *weakCondChecker) Init() {
+	c.chainParts = make(map[*ast.BinaryExpr]bool)
+}
+
+func (c *weakCondChecker) VisitLocalExpr(expr ast.Expr) {
+	bin, ok := expr.(*ast.BinaryExpr)
+	if !ok || (bin.Op != token.LAND && bin.Op != token.LOR) {
+		return
+	}
+	if c.chainParts[bin] {
+		delete(c.chainParts, bin)
+		return
+	}
+	operands := c.chainOperands(bin, bin.Op, nil)
+	delete(c.chainParts, bin)
+	if bin.Op == token.LAND {
+		c.checkAnd(bin, operands)
+	} else {
+		c.checkOr(bin, operands)
+	}
+}
+
+// checkAnd reports `xs != nil && xs[i]` chains that have
+// no length check between the nil check and the index expression.
+func (c *weakCondChecker) checkAnd(cause *ast.BinaryExpr, operands []ast.Expr) {
+	for i, x := range operands {
+		xs := c.nilCompared(x, token.NEQ)
+		if xs == nil {
+			continue
+		}
+		for _, y := range operands[i+1:] {
+			// Index expressions like xs[len(xs)-1] are not
+			// protected by their own len calls.
+			if index := c.findIndex(y, xs); index != nil {
+				c.warnIndex(cause, index)
+				return
+			}
+			if c.hasLen(y, xs) {
+				break
+			}
+		}
+	}
+}
+
+// checkOr reports `xs == nil || len(xs) != 0` chains.
+func (c *weakCondChecker) checkOr(cause *ast.BinaryExpr, operands []ast.Expr) {
+	for i, x := range operands {
+		xs := c.nilCompared(x, token.EQL)
+		if xs == nil {
+			continue
+		}
+		for _, y := range operands[i+1:] {
+			if c.isNonEmptyCheck(y, xs) {
+				c.warnNonEmpty(cause, xs)
+				return
+			}
+		}
+	}
+}
+
+// chainOperands appends operands of the x chain of op operators to dst.
+func (c *weakCondChecker) chainOperands(x ast.Expr, op token.Token, dst []ast.Expr) []ast.Expr {
+	x = astutil.Unparen(x)
+	if bin, ok := x.(*ast.BinaryExpr); ok && bin.Op == op {
+		c.chainParts[bin] = true
+		dst = c.chainOperands(bin.X, op, dst)
+		return c.chainOperands(bin.Y, op, dst)
+	}
+	return append(dst, x)
+}
+
+// nilCompared returns slice expression that is compared to nil with op.
+// Returns nil if x is not such comparison.
+func (c *weakCondChecker) nilCompared(x ast.Expr, op token.Token) ast.Expr {
+	cmp, ok := x.(*ast.BinaryExpr)
+	if !ok || cmp.Op != op {
+		return nil
+	}
+	var xs ast.Expr
+	switch {
+	case c.isNil(cmp.Y):
+		xs = cmp.X
+	case c.isNil(cmp.X):
+		xs = cmp.Y
+	default:
+		return nil
+	}
+	if !isSafeExpr(xs) || !c.isSlice(xs) {
+		return nil
+	}
+	return xs
+}
+
+// findIndex returns the first index expression over xs inside x.
+func (c *weakCondChecker) findIndex(x, xs ast.Expr) ast.Node {
+	return findNode(x, func(n ast.Node) bool {
+		index, ok := n.(*ast.IndexExpr)
+		return ok && astequal.Expr(index.X, xs)
+	})
+}
+
+// hasLen reports whether x contains len(xs) call.
+func (c *weakCondChecker) hasLen(x, xs ast.Expr) bool {
+	return findNode(x, func(n ast.Node) bool { return c.isLenOf(n, xs) }) != nil
+}
+
+// isNonEmptyCheck reports whether x is a `len(xs) != 0` or `len(xs) > 0` comparison.
+func (c *weakCondChecker) isNonEmptyCheck(x, xs ast.Expr) bool {
+	cmp, ok := x.(*ast.BinaryExpr)
+	if !ok {
+		return false
+	}
+	switch {
+	case c.isLenOf(cmp.X, xs) && c.ctx.isConstZero(cmp.Y):
+		return cmp.Op == token.NEQ || cmp.Op == token.GTR
+	case c.ctx.isConstZero(cmp.X) && c.isLenOf(cmp.Y, xs):
+		return cmp.Op == token.NEQ || cmp.Op == token.LSS
+	default:
+		return false
+	}
+}
+
+func (c *weakCondChecker) isLenOf(n ast.Node, xs ast.Expr) bool {
+	call, ok := n.(*ast.CallExpr)
+	return ok && len(call.Args) == 1 && c.ctx.isBuiltinCall(call, "len") &&
+		astequal.Expr(call.Args[0], xs)
+}
+
+func (c *weakCondChecker) isNil(x ast.Expr) bool {
+	return c.ctx.typesInfo.Types[x].IsNil()
+}
+
+func (c *weakCondChecker) isSlice(x ast.Expr) bool {
+	typ := c.ctx.typesInfo.TypeOf(x)
+	if typ == nil {
+		return false
+	}
+	_, ok := typ.Underlying().(*types.Slice)
+	return ok
+}
+
+func (c *weakCondChecker) warnIndex(cause *ast.BinaryExpr, index ast.Node) {
+	c.ctx.Warn(cause, "suspicious `%s`; nil check may not be enough, check for len", index)
+}
+
+func (c *weakCondChecker) warnNonEmpty(cause *ast.BinaryExpr, xs ast.Expr) {
+	c.ctx.Warn(cause, "suspicious `%s`; it's true for nil and non-empty %s", cause, xs)
+}